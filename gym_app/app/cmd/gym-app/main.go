@@ -41,7 +41,7 @@ func main() {
 
 	log.Info("starting application")
 
-	application := app.New(ctx, log, cfg)
+	application := app.New(ctx, log, *cfg)
 
 	application.Cron.Start(ctx)
 
@@ -50,6 +50,13 @@ func main() {
 
 	go application.HTTPSrv.Run()
 
+	if application.Bot != nil {
+		go application.Bot.Run(ctx)
+		defer application.Bot.Stop()
+
+		log.Info("telegram bot started")
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
@@ -57,8 +64,14 @@ func main() {
 
 	log.Info("stopping application", slog.String("signal", sign.String()))
 
+	// Открытые потоки /live не дают серверу остановиться, закрываем их первыми
+	application.Live.Close()
+
 	application.HTTPSrv.Stop(ctx)
 
+	// Дожидаемся асинхронных подписчиков (отправка уведомлений)
+	application.Events.Wait()
+
 	log.Info("application stopped")
 }
 
@@ -5,27 +5,47 @@ import (
 
 	"log/slog"
 
+	"github.com/Muaz717/gym_app/app/internal/app/bot"
 	"github.com/Muaz717/gym_app/app/internal/app/http"
 	"github.com/Muaz717/gym_app/app/internal/clients/sso/grpc"
+	"github.com/Muaz717/gym_app/app/internal/clients/telegram"
 	"github.com/Muaz717/gym_app/app/internal/config"
 	"github.com/Muaz717/gym_app/app/internal/cron"
+	"github.com/Muaz717/gym_app/app/internal/events"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/lib/notify"
+	"github.com/Muaz717/gym_app/app/internal/lib/webhook"
 
+	"github.com/Muaz717/gym_app/app/internal/services/access"
 	"github.com/Muaz717/gym_app/app/internal/services/auth"
+	"github.com/Muaz717/gym_app/app/internal/services/document"
+	"github.com/Muaz717/gym_app/app/internal/services/export"
+	"github.com/Muaz717/gym_app/app/internal/services/importer"
+	"github.com/Muaz717/gym_app/app/internal/services/live"
+	"github.com/Muaz717/gym_app/app/internal/services/member"
+	"github.com/Muaz717/gym_app/app/internal/services/notification"
 	"github.com/Muaz717/gym_app/app/internal/services/person"
 	"github.com/Muaz717/gym_app/app/internal/services/person_sub"
 	"github.com/Muaz717/gym_app/app/internal/services/single_visit"
+	"github.com/Muaz717/gym_app/app/internal/services/single_visit_tariff"
+	"github.com/Muaz717/gym_app/app/internal/services/staff"
 	"github.com/Muaz717/gym_app/app/internal/services/statistics"
 	"github.com/Muaz717/gym_app/app/internal/services/sub_freeze"
 	"github.com/Muaz717/gym_app/app/internal/services/subscription"
+	"github.com/Muaz717/gym_app/app/internal/services/visit"
+	"github.com/Muaz717/gym_app/app/internal/services/webhook"
 
 	"github.com/Muaz717/gym_app/app/internal/storage/postgres"
 	"github.com/Muaz717/gym_app/app/internal/storage/redis"
+	"github.com/Muaz717/gym_app/app/internal/subscribers"
 )
 
 type App struct {
 	HTTPSrv *httpApp.HttpApp
 	Cron    *cron.CronJobs
+	Bot     *botApp.BotApp // nil, если бот выключен
+	Events  *events.Bus
+	Live    *liveService.LiveService
 }
 
 func New(ctx context.Context, log *slog.Logger, cfg config.Config) *App {
@@ -50,29 +70,77 @@ func New(ctx context.Context, log *slog.Logger, cfg config.Config) *App {
 		cfg.Clients.SSO.Port,
 		cfg.Clients.SSO.Timeout,
 		cfg.Clients.SSO.RetriesCount,
+		cfg.Clients.SSO.AppSecret,
 	)
 	if err != nil {
 		log.Error("failed to init sso client", sl.Error(err))
 		panic(err)
 	}
 
+	// --- Init Event Bus ---
+	bus := events.NewBus(log)
+
 	// --- Init Services ---
-	personSrv := personService.New(log, storage, cache, cache)
+	personSrv := personService.New(log, storage, cache, bus)
 	subscriptionSrv := subscriptionService.New(log, storage)
-	personSubSrv := personSubService.New(log, storage, cache, storage, cache)
+	personSubSrv := personSubService.New(log, storage, cache, bus)
 	authSrv := authService.New(log, ssoClient, cfg.AppID)
+	tokenVerifier := authService.NewVerifier(log, ssoClient, cfg.AppID, cfg.Clients.SSO.RevocationCheckInterval)
 	statSrv := statistics.New(log, storage, cache)
-	freezeSrv := subFreezeService.New(log, storage, cache)
-	singleVisitSrv := singleVisitService.New(log, storage, cache)
+	freezeSrv := subFreezeService.New(log, storage, cache, bus)
+	singleVisitSrv := singleVisitService.New(log, storage, storage, cache, bus)
+	singleVisitTariffSrv := singleVisitTariffService.New(log, storage)
+	visitSrv := visitService.New(log, storage, storage, bus)
+	exportSrv := exportService.New(log, storage)
+	importSrv := importService.New(log, storage, bus)
+	accessSrv := accessService.New(log, storage, bus, cfg.AccessControl)
+	staffSrv := staffService.New(log, ssoClient)
+
+	documentSrv, err := documentService.New(log, storage, storage, cfg.Documents)
+	if err != nil {
+		log.Error("failed to init document service", sl.Error(err))
+		panic(err)
+	}
+
+	notificationSrv, err := notificationService.New(log, storage, notificationChannels(cfg.Notifications), cfg.Notifications, cfg.Documents.Organization)
+	if err != nil {
+		log.Error("failed to init notification service", sl.Error(err))
+		panic(err)
+	}
+
+	// --- Init Event Subscribers ---
+	subscribers.NewCacheInvalidator(log, cache).Register(bus)
+	subscribers.NewAudit(log).Register(bus)
+	if cfg.Notifications.Enabled {
+		subscribers.NewNotifications(log, notificationSrv).Register(bus)
+	}
+
+	liveSrv := liveService.New(log, statSrv)
+	liveSrv.Register(bus)
+
+	memberSrv := memberService.New(log, storage, personSubSrv, freezeSrv, authSrv)
+	webhookSrv := webhookService.New(log, storage, webhook.NewSender(cfg.Webhooks.Timeout), cfg.Webhooks)
+
+	// --- Init Telegram Bot ---
+	var bot *botApp.BotApp
+	if cfg.Bot.Enabled {
+		if cfg.Notifications.Telegram.Token == "" {
+			log.Warn("telegram bot is enabled but token is empty, bot is not started")
+		} else {
+			client := telegram.New(cfg.Notifications.Telegram.BaseURL, cfg.Notifications.Telegram.Token)
+			bot = botApp.New(log, client, memberSrv, cfg.Bot.PollTimeout)
+		}
+	}
 
 	// --- Init Cron ---
-	cronJobs := cron.New(personSubSrv)
+	cronJobs := cron.New(log, personSubSrv, notificationSrv, cfg.Notifications, webhookSrv, cfg.Webhooks)
 
 	// --- Init HTTP App ---
 	httpSrv := httpApp.New(
 		log,
 		cfg,
 		ssoClient,
+		tokenVerifier,
 		authSrv,
 		personSrv,
 		subscriptionSrv,
@@ -80,10 +148,44 @@ func New(ctx context.Context, log *slog.Logger, cfg config.Config) *App {
 		statSrv,
 		freezeSrv,
 		singleVisitSrv,
+		singleVisitTariffSrv,
+		visitSrv,
+		exportSrv,
+		importSrv,
+		documentSrv,
+		notificationSrv,
+		memberSrv,
+		memberSrv,
+		webhookSrv,
+		liveSrv,
+		accessSrv,
+		accessSrv,
+		staffSrv,
+		cache,
 	)
 
 	return &App{
 		HTTPSrv: httpSrv,
 		Cron:    cronJobs,
+		Bot:     bot,
+		Events:  bus,
+		Live:    liveSrv,
 	}
 }
+
+// notificationChannels включает каналы, для которых заполнены настройки
+func notificationChannels(cfg config.Notifications) []notify.Channel {
+	var channels []notify.Channel
+
+	if cfg.SMS.URL != "" {
+		channels = append(channels, notify.NewSMS(cfg.SMS.URL, cfg.SMS.APIKey, cfg.SMS.Sender, cfg.SMS.Timeout))
+	}
+	if cfg.Email.Host != "" {
+		channels = append(channels, notify.NewEmail(cfg.Email.Host, cfg.Email.Port, cfg.Email.Username, cfg.Email.Password, cfg.Email.From))
+	}
+	if cfg.Telegram.Token != "" {
+		channels = append(channels, notify.NewTelegram(cfg.Telegram.BaseURL, cfg.Telegram.Token, cfg.Telegram.Timeout))
+	}
+
+	return channels
+}
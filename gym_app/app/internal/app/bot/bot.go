@@ -0,0 +1,255 @@
+package botApp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/clients/telegram"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	memberService "github.com/Muaz717/gym_app/app/internal/services/member"
+)
+
+const (
+	buttonStatus  = "Мой абонемент"
+	buttonFreezes = "Заморозки"
+	buttonShare   = "Поделиться номером"
+
+	// retryDelay — пауза после ошибки long polling, чтобы не долбить API
+	retryDelay = 5 * time.Second
+)
+
+type MemberService interface {
+	LinkTelegram(ctx context.Context, chatID int64, phone string) ([]models.Person, error)
+	UnlinkTelegram(ctx context.Context, chatID int64) error
+	TelegramSubscriptions(ctx context.Context, chatID int64) ([]dto.MemberSubscription, error)
+}
+
+type BotApp struct {
+	log           *slog.Logger
+	client        *telegram.Client
+	memberService MemberService
+	pollTimeout   time.Duration
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+func New(
+	log *slog.Logger,
+	client *telegram.Client,
+	memberService MemberService,
+	pollTimeout time.Duration,
+) *BotApp {
+	return &BotApp{
+		log:           log,
+		client:        client,
+		memberService: memberService,
+		pollTimeout:   pollTimeout,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Run получает сообщения через long polling до вызова Stop
+func (b *BotApp) Run(ctx context.Context) {
+	const op = "botApp.Run"
+
+	log := b.log.With(slog.String("op", op))
+	log.Info("telegram bot is starting")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer close(b.done)
+
+	go func() {
+		select {
+		case <-b.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var offset int64
+	for {
+		updates, err := b.client.GetUpdates(ctx, offset, b.pollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error("failed to get updates", sl.Error(err))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			if update.Message != nil {
+				b.handleMessage(ctx, update.Message)
+			}
+		}
+	}
+}
+
+// Stop прерывает ожидание обновлений и дожидается завершения Run. Вызывать только после запуска Run.
+func (b *BotApp) Stop() {
+	close(b.stop)
+	<-b.done
+}
+
+func (b *BotApp) handleMessage(ctx context.Context, msg *telegram.Message) {
+	const op = "botApp.handleMessage"
+
+	log := b.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+
+	// Личные данные показываем только в личном чате с ботом
+	if msg.Chat.Type != "private" {
+		return
+	}
+
+	var reply string
+	var markup any = mainKeyboard()
+
+	switch {
+	case msg.Contact != nil:
+		reply, markup = b.link(ctx, msg)
+	case msg.Text == "/start" || msg.Text == "/help":
+		reply = "Здравствуйте! Я покажу срок действия вашего абонемента и заморозки.\n" +
+			"Чтобы начать, нажмите «" + buttonShare + "» — я найду вас по номеру телефона.\n\n" +
+			"/status — абонемент\n/freezes — история заморозок\n/unlink — отвязать номер"
+		markup = shareKeyboard()
+	case msg.Text == "/status" || msg.Text == buttonStatus:
+		reply = b.status(ctx, msg.Chat.ID, false)
+	case msg.Text == "/freezes" || msg.Text == buttonFreezes:
+		reply = b.status(ctx, msg.Chat.ID, true)
+	case msg.Text == "/unlink":
+		if err := b.memberService.UnlinkTelegram(ctx, msg.Chat.ID); err != nil {
+			reply = "Не получилось отвязать номер, попробуйте позже."
+		} else {
+			reply = "Номер отвязан. Чтобы привязать снова, нажмите «" + buttonShare + "»."
+			markup = shareKeyboard()
+		}
+	default:
+		reply = "Не понимаю команду. Нажмите /help, чтобы увидеть список."
+	}
+
+	if err := b.client.SendMessage(ctx, msg.Chat.ID, reply, markup); err != nil {
+		log.Error("failed to send reply", sl.Error(err))
+	}
+}
+
+// link привязывает чат по контакту. Принимаем только собственный номер отправителя,
+// иначе любой смог бы посмотреть чужой абонемент, переслав контакт.
+func (b *BotApp) link(ctx context.Context, msg *telegram.Message) (string, any) {
+	if msg.From == nil || msg.Contact.UserID != msg.From.ID {
+		return "Пожалуйста, отправьте свой номер кнопкой «" + buttonShare + "».", shareKeyboard()
+	}
+
+	people, err := b.memberService.LinkTelegram(ctx, msg.Chat.ID, msg.Contact.PhoneNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, memberService.ErrMemberNotFound), errors.Is(err, memberService.ErrInvalidPhone):
+			return "Не нашли клиента с этим номером. Проверьте, что на ресепшене указан тот же телефон.", shareKeyboard()
+		default:
+			return "Что-то пошло не так, попробуйте позже.", shareKeyboard()
+		}
+	}
+
+	names := make([]string, 0, len(people))
+	for _, p := range people {
+		names = append(names, p.Name)
+	}
+
+	return "Готово! Номер привязан: " + strings.Join(names, ", ") + ".\n" +
+		"Сюда же будут приходить напоминания об окончании абонемента.", mainKeyboard()
+}
+
+func (b *BotApp) status(ctx context.Context, chatID int64, withFreezes bool) string {
+	subs, err := b.memberService.TelegramSubscriptions(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, memberService.ErrNotLinked) {
+			return "Сначала привяжите номер: нажмите /start."
+		}
+		return "Не получилось получить данные, попробуйте позже."
+	}
+	if len(subs) == 0 {
+		return "У вас пока нет абонементов."
+	}
+
+	blocks := make([]string, 0, len(subs))
+	for _, s := range subs {
+		blocks = append(blocks, formatSubscription(s, withFreezes, len(subs) > 1))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func formatSubscription(s dto.MemberSubscription, withFreezes, withName bool) string {
+	var b strings.Builder
+
+	sub := s.Subscription
+	if withName {
+		b.WriteString(s.PersonName + "\n")
+	}
+	fmt.Fprintf(&b, "Абонемент «%s» № %s\n", sub.SubscriptionTitle, sub.Number)
+	fmt.Fprintf(&b, "Действует: %s — %s\n", sub.StartDate.Format("02.01.2006"), sub.EndDate.Format("02.01.2006"))
+
+	switch {
+	case s.Frozen:
+		b.WriteString("Статус: заморожен\n")
+	case s.DaysLeft > 0:
+		fmt.Fprintf(&b, "Осталось дней: %d\n", s.DaysLeft)
+	case sub.EndDate.Before(time.Now().Truncate(24 * time.Hour)):
+		b.WriteString("Статус: закончился\n")
+	default:
+		b.WriteString("Последний день — сегодня\n")
+	}
+
+	if sub.FreezeDays > 0 {
+		fmt.Fprintf(&b, "Дней заморозки осталось: %d из %d", s.FreezeDaysLeft, sub.FreezeDays)
+	} else {
+		b.WriteString("Заморозка по этому абонементу не предусмотрена")
+	}
+
+	if withFreezes {
+		if len(s.Freezes) == 0 {
+			b.WriteString("\nЗаморозок не было")
+		}
+		for _, f := range s.Freezes {
+			if f.FreezeEnd.IsZero() {
+				fmt.Fprintf(&b, "\n• с %s — по настоящее время", f.FreezeStart.Format("02.01.2006"))
+				continue
+			}
+			fmt.Fprintf(&b, "\n• %s — %s (%d дн.)", f.FreezeStart.Format("02.01.2006"), f.FreezeEnd.Format("02.01.2006"), f.DaysUsed)
+		}
+	}
+
+	return b.String()
+}
+
+func mainKeyboard() telegram.ReplyKeyboardMarkup {
+	return telegram.ReplyKeyboardMarkup{
+		Keyboard:       [][]telegram.KeyboardButton{{{Text: buttonStatus}, {Text: buttonFreezes}}},
+		ResizeKeyboard: true,
+	}
+}
+
+func shareKeyboard() telegram.ReplyKeyboardMarkup {
+	return telegram.ReplyKeyboardMarkup{
+		Keyboard:        [][]telegram.KeyboardButton{{{Text: buttonShare, RequestContact: true}}},
+		ResizeKeyboard:  true,
+		OneTimeKeyboard: true,
+	}
+}
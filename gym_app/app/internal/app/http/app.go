@@ -11,15 +11,29 @@ import (
 
 	"github.com/Muaz717/gym_app/app/internal/clients/sso/grpc"
 	"github.com/Muaz717/gym_app/app/internal/config"
+	accessHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/access"
 	authHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/auth"
+	documentHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/document"
+	exportHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/export"
+	importHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/importer"
+	liveHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/live"
+	memberHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/member"
+	notificationHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/notification"
 	personHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/person"
 	personSubHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/person_sub"
 	singleVisitHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/single_visit"
+	singleVisitTariffHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/single_visit_tariff"
+	staffHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/staff"
 	statHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/statistics"
 	subFreezeHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/sub_freeze"
 	subscriptionHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/subscription"
+	visitHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/visit"
+	webhookHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/webhook"
 	authMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/auth"
+	deviceMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/device"
 	loggerMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/logger"
+	memberMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/member"
+	ratelimitMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/ratelimit"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 
 	"github.com/gin-contrib/cors"
@@ -28,11 +42,6 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-const (
-	userRole  = "user"
-	adminRole = "admin"
-)
-
 type HttpApp struct {
 	HTTPServer *http.Server
 	engine     *gin.Engine
@@ -44,6 +53,7 @@ func New(
 	log *slog.Logger,
 	cfg config.Config,
 	ssoClient *grpc.SSOClient,
+	tokenVerifier authMiddleware.TokenVerifier,
 	authService authHandler.AuthService,
 	personService personHandler.PersonService,
 	subscriptionService subscriptionHandler.SubscriptionService,
@@ -51,6 +61,20 @@ func New(
 	statService statHandler.StatService,
 	subFreezeService subFreezeHandler.SubFreezeService,
 	singleVisitService singleVisitHandler.SingleVisitService,
+	singleVisitTariffService singleVisitTariffHandler.SingleVisitTariffService,
+	visitService visitHandler.VisitService,
+	exportService exportHandler.ExportService,
+	importService importHandler.ImportService,
+	documentService documentHandler.DocumentService,
+	notificationService notificationHandler.NotificationService,
+	memberService memberHandler.MemberService,
+	memberResolver memberMiddleware.MemberResolver,
+	webhookService webhookHandler.WebhookService,
+	liveService liveHandler.LiveService,
+	accessService accessHandler.AccessService,
+	deviceAuthenticator deviceMiddleware.DeviceAuthenticator,
+	staffService staffHandler.StaffService,
+	rateCounter ratelimitMiddleware.Counter,
 ) *HttpApp {
 	gin.SetMode(gin.ReleaseMode)
 	engine := gin.New()
@@ -61,8 +85,16 @@ func New(
 		engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
-	userMiddleware := authMiddleware.AuthMiddleware(log, ssoClient, cfg.AppID, userRole)
-	adminMiddleware := authMiddleware.AuthMiddleware(log, ssoClient, cfg.AppID, adminRole)
+	userMiddleware := authMiddleware.AuthMiddleware(log, ssoClient, tokenVerifier, cfg.AppID)
+	can := func(permission string) gin.HandlerFunc {
+		return authMiddleware.RequirePermission(log, permission)
+	}
+	memberOnlyMiddleware := memberMiddleware.MemberOnly(log, memberResolver)
+	staffOnlyMiddleware := memberMiddleware.StaffOnly(log)
+	deviceAuthMiddleware := deviceMiddleware.DeviceAuth(log, deviceAuthenticator)
+	// Регистрация клиента открыта без авторизации, а ответ подсказывает, подошли ли номер абонемента и телефон
+	memberRegisterLimit := ratelimitMiddleware.Limit(log, rateCounter, "member_register",
+		cfg.RateLimit.MemberRegisterAttempts, cfg.RateLimit.MemberRegisterWindow)
 
 	api := engine.Group("/api/v1")
 
@@ -73,29 +105,82 @@ func New(
 	statHandle := statHandler.New(log, statService)
 	freezeHandle := subFreezeHandler.New(log, subFreezeService)
 	singleVisitHandle := singleVisitHandler.New(log, singleVisitService)
+	singleVisitTariffHandle := singleVisitTariffHandler.New(log, singleVisitTariffService)
+	visitHandle := visitHandler.New(log, visitService)
+	exportHandle := exportHandler.New(log, exportService)
+	importHandle := importHandler.New(log, importService)
+	documentHandle := documentHandler.New(log, documentService)
+	notificationHandle := notificationHandler.New(log, notificationService)
+	memberHandle := memberHandler.New(log, memberService)
+	webhookHandle := webhookHandler.New(log, webhookService)
+	liveHandle := liveHandler.New(log, liveService)
+	accessHandle := accessHandler.New(log, accessService)
+	staffHandle := staffHandler.New(log, staffService)
 
 	// --- Auth routes ---
 	auth := api.Group("/auth")
 	{
 		auth.POST("/register", authHandle.RegisterNewUser)
 		auth.POST("/login", authHandle.Login)
+		auth.POST("/login/2fa", authHandle.LoginTwoFactor)
+		auth.POST("/refresh", authHandle.Refresh)
 		auth.GET("/me", authHandle.Me)
+		auth.POST("/logout", authHandle.Logout)
+		auth.POST("/password/change", authHandle.ChangePassword)
+		auth.POST("/password/forgot", authHandle.ForgotPassword)
+		auth.POST("/password/reset", authHandle.ResetPassword)
+		auth.POST("/email/verify", authHandle.VerifyEmail)
+		auth.POST("/email/resend", authHandle.ResendVerificationEmail)
+		auth.POST("/2fa/enroll", authHandle.EnrollTOTP)
+		auth.POST("/2fa/confirm", authHandle.ConfirmTOTP)
+		auth.POST("/2fa/disable", authHandle.DisableTOTP)
+		auth.POST("/member/register", memberRegisterLimit, memberHandle.Register)
 	}
 
-	api.Use(userMiddleware)
+	// --- Member portal routes ---
+	// Группа создается до api.Use, чтобы StaffOnly на нее не действовал
+	registerMemberRoutes(api, memberHandle, userMiddleware, memberOnlyMiddleware)
+
+	// --- Turnstile controller routes ---
+	// Контроллер авторизуется своим токеном, а не cookie сотрудника
+	registerAccessRoutes(api, accessHandle, deviceAuthMiddleware)
+
+	api.Use(userMiddleware, staffOnlyMiddleware)
 	{
 		// --- User routes ---
-		registerPersonRoutes(api, personHandle, adminMiddleware)
+		registerPersonRoutes(api, personHandle, can)
 		// --- Subscription routes ---
-		registerSubscriptionRoutes(api, subscriptionHandle, adminMiddleware)
+		registerSubscriptionRoutes(api, subscriptionHandle, can)
 		// --- Person Subscription routes ---
-		registerPersonSubRoutes(api, personSubHandle, adminMiddleware)
+		registerPersonSubRoutes(api, personSubHandle, can)
 		// --- Freeze routes ---
-		registerFreezeRoutes(api, freezeHandle, adminMiddleware)
+		registerFreezeRoutes(api, freezeHandle, can)
 		// --- Single Visit routes ---
-		registerSingleVisitRoutes(api, singleVisitHandle, adminMiddleware)
+		registerSingleVisitRoutes(api, singleVisitHandle, can)
+		// --- Single Visit Tariff routes ---
+		registerSingleVisitTariffRoutes(api, singleVisitTariffHandle, can)
+		// --- Visit routes ---
+		registerVisitRoutes(api, visitHandle, can)
+		// --- Export routes ---
+		registerExportRoutes(api, exportHandle, can)
+		// --- Import routes ---
+		registerImportRoutes(api, importHandle, can)
+		// --- Document routes ---
+		registerDocumentRoutes(api, documentHandle, can)
+		// --- Notification routes ---
+		registerNotificationRoutes(api, notificationHandle, can)
+		// --- Member account routes ---
+		registerMemberAccountRoutes(api, memberHandle, can)
+		// --- Webhook routes ---
+		registerWebhookRoutes(api, webhookHandle, can)
 		// --- Statistics routes ---
-		registerStatRoutes(api, statHandle)
+		registerStatRoutes(api, statHandle, can)
+		// --- Access control admin routes ---
+		registerAccessAdminRoutes(api, accessHandle, can)
+		// --- Live dashboard routes ---
+		registerLiveRoutes(api, liveHandle, can)
+		// --- Staff accounts and roles routes ---
+		registerStaffRoutes(api, staffHandle, can)
 	}
 
 	srv := &http.Server{
@@ -146,6 +231,11 @@ func (a *HttpApp) Stop(ctx context.Context) error {
 }
 
 func setupMiddleware(engine *gin.Engine, log *slog.Logger, cfg config.Config) {
+	// По умолчанию gin верит X-Forwarded-For от любого клиента, а по IP ограничиваются попытки входа
+	if err := engine.SetTrustedProxies(cfg.HTTPServer.TrustedProxies); err != nil {
+		panic(err)
+	}
+
 	corsConfig := cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:80", "http://localhost"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "HEAD"},
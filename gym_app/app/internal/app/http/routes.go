@@ -1,77 +1,217 @@
 package httpApp
 
 import (
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	accessHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/access"
+	documentHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/document"
+	exportHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/export"
+	importHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/importer"
+	liveHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/live"
+	memberHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/member"
+	notificationHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/notification"
 	personHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/person"
 	personSubHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/person_sub"
 	singleVisitHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/single_visit"
+	singleVisitTariffHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/single_visit_tariff"
+	staffHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/staff"
 	statHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/statistics"
 	subFreezeHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/sub_freeze"
 	subscriptionHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/subscription"
+	visitHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/visit"
+	webhookHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/webhook"
 	"github.com/gin-gonic/gin"
 )
 
-func registerPersonRoutes(api *gin.RouterGroup, h *personHandler.PersonHandler, admin gin.HandlerFunc) {
-	r := api.Group("/people")
-	r.GET("", h.FindAllPeople)
-	r.GET("/find", h.FindPersonByName)
-	r.GET("/find/:id", h.FindPersonById)
+// permissionFunc возвращает middleware, требующий у пользователя право permission
+type permissionFunc func(permission string) gin.HandlerFunc
 
-	adminGroup := r.Group("")
-	adminGroup.Use(admin)
-	adminGroup.POST("/add", h.AddPerson)
-	adminGroup.PUT("update/:id", h.UpdatePerson)
-	adminGroup.DELETE("delete/:id", h.DeletePerson)
+func registerPersonRoutes(api *gin.RouterGroup, h *personHandler.PersonHandler, can permissionFunc) {
+	r := api.Group("/people")
+	r.GET("", can(models.PermissionPeopleRead), h.FindAllPeople)
+	r.GET("/find", can(models.PermissionPeopleRead), h.FindPersonByName)
+	r.GET("/find/:id", can(models.PermissionPeopleRead), h.FindPersonById)
+	r.POST("/add", can(models.PermissionPeopleWrite), h.AddPerson)
+	r.PUT("update/:id", can(models.PermissionPeopleWrite), h.UpdatePerson)
+	r.DELETE("delete/:id", can(models.PermissionPeopleDelete), h.DeletePerson)
 }
 
-func registerSubscriptionRoutes(api *gin.RouterGroup, h *subscriptionHandler.SubscriptionHandler, admin gin.HandlerFunc) {
+func registerSubscriptionRoutes(api *gin.RouterGroup, h *subscriptionHandler.SubscriptionHandler, can permissionFunc) {
 	r := api.Group("/subscription")
-	r.GET("", h.FindAllSubscriptions)
+	r.GET("", can(models.PermissionSubscriptionsRead), h.FindAllSubscriptions)
 
-	adminGroup := r.Group("")
-	adminGroup.Use(admin)
-	adminGroup.POST("/add", h.AddSubscription)
-	adminGroup.PUT("update/:id", h.UpdateSubscription)
-	adminGroup.DELETE("delete/:id", h.DeleteSubscription)
+	manage := r.Group("")
+	manage.Use(can(models.PermissionSubscriptionsManage))
+	manage.POST("/add", h.AddSubscription)
+	manage.PUT("update/:id", h.UpdateSubscription)
+	manage.DELETE("delete/:id", h.DeleteSubscription)
 }
 
-func registerPersonSubRoutes(api *gin.RouterGroup, h *personSubHandler.PersonSubHandler, admin gin.HandlerFunc) {
+func registerPersonSubRoutes(api *gin.RouterGroup, h *personSubHandler.PersonSubHandler, can permissionFunc) {
 	r := api.Group("/person_sub")
-	r.GET("", h.FindAllPersonSubs)
-	r.GET("/find", h.FindPersonSubByPersonName)
-	r.GET("/find/:number", h.FindPersonSubByNumber)
-	r.GET("/find/id/:id", h.FindPersonSubByPersonId)
 
-	adminGroup := r.Group("")
-	adminGroup.Use(admin)
-	adminGroup.POST("/add", h.AddPersonSub)
-	adminGroup.DELETE("delete/:number", h.DeletePersonSub)
+	read := r.Group("")
+	read.Use(can(models.PermissionPersonSubRead))
+	read.GET("", h.FindAllPersonSubs)
+	read.GET("/find", h.FindPersonSubByPersonName)
+	read.GET("/find/:number", h.FindPersonSubByNumber)
+	read.GET("/find/id/:id", h.FindPersonSubByPersonId)
+	read.GET("/expiring", h.FindExpiringPersonSubs)
+
+	r.POST("/add", can(models.PermissionPersonSubCreate), h.AddPersonSub)
+	r.DELETE("delete/:number", can(models.PermissionPersonSubDelete), h.DeletePersonSub)
 }
 
-func registerFreezeRoutes(api *gin.RouterGroup, h *subFreezeHandler.SubFreezeHandler, admin gin.HandlerFunc) {
+func registerFreezeRoutes(api *gin.RouterGroup, h *subFreezeHandler.SubFreezeHandler, can permissionFunc) {
 	r := api.Group("/freeze")
-	r.GET("", h.GetAllActiveFreeze)
+	r.GET("", can(models.PermissionFreezeRead), h.GetAllActiveFreeze)
+	r.GET("/requests", can(models.PermissionFreezeRead), h.FindFreezeRequests)
 
-	adminGroup := r.Group("")
-	adminGroup.Use(admin)
-	adminGroup.POST("/add", h.FreezeSubscription)
-	adminGroup.POST("/unfreeze", h.UnfreezeSubscription)
+	write := r.Group("")
+	write.Use(can(models.PermissionFreezeWrite))
+	write.POST("/add", h.FreezeSubscription)
+	write.POST("/unfreeze", h.UnfreezeSubscription)
+	write.POST("/requests/:id/approve", h.ApproveFreezeRequest)
+	write.POST("/requests/:id/reject", h.RejectFreezeRequest)
 }
 
-func registerSingleVisitRoutes(api *gin.RouterGroup, h *singleVisitHandler.SingleVisitHandler, admin gin.HandlerFunc) {
+func registerSingleVisitRoutes(api *gin.RouterGroup, h *singleVisitHandler.SingleVisitHandler, can permissionFunc) {
 	r := api.Group("/single_visit")
-	r.GET("", h.GetAllSingleVisits)
-	r.GET("/:id", h.GetSingleVisitById)
-	r.GET("/day", h.GetSingleVisitsByDay)
-	r.GET("/period", h.GetSingleVisitsByPeriod)
 
-	adminGroup := r.Group("")
-	adminGroup.Use(admin)
-	adminGroup.POST("/add", h.AddSingleVisit)
-	adminGroup.DELETE("/delete/:id", h.DeleteSingleVisit)
+	read := r.Group("")
+	read.Use(can(models.PermissionSingleVisitRead))
+	read.GET("", h.GetAllSingleVisits)
+	read.GET("/:id", h.GetSingleVisitById)
+	read.GET("/day", h.GetSingleVisitsByDay)
+	read.GET("/period", h.GetSingleVisitsByPeriod)
+
+	r.POST("/add", can(models.PermissionSingleVisitCreate), h.AddSingleVisit)
+	r.DELETE("/delete/:id", can(models.PermissionSingleVisitDelete), h.DeleteSingleVisit)
+}
+
+func registerSingleVisitTariffRoutes(api *gin.RouterGroup, h *singleVisitTariffHandler.SingleVisitTariffHandler, can permissionFunc) {
+	r := api.Group("/single_visit_tariff")
+	r.GET("", can(models.PermissionTariffsRead), h.FindAllTariffs)
+
+	manage := r.Group("")
+	manage.Use(can(models.PermissionTariffsManage))
+	manage.POST("/add", h.AddTariff)
+	manage.PUT("update/:id", h.UpdateTariff)
+	manage.DELETE("delete/:id", h.DeleteTariff)
+}
+
+func registerVisitRoutes(api *gin.RouterGroup, h *visitHandler.VisitHandler, can permissionFunc) {
+	r := api.Group("/visit")
+	r.GET("", can(models.PermissionVisitsRead), h.GetOpenVisits)
+	r.POST("/check_in", can(models.PermissionVisitsWrite), h.CheckIn)
+	r.POST("/check_out", can(models.PermissionVisitsWrite), h.CheckOut)
+}
+
+func registerExportRoutes(api *gin.RouterGroup, h *exportHandler.ExportHandler, can permissionFunc) {
+	r := api.Group("/export")
+	r.Use(can(models.PermissionDataExport))
+	r.GET("/people", h.ExportPeople)
+	r.GET("/person_subs", h.ExportPersonSubs)
+	r.GET("/single_visits", h.ExportSingleVisits)
+	r.GET("/freezes", h.ExportFreezes)
+}
+
+func registerDocumentRoutes(api *gin.RouterGroup, h *documentHandler.DocumentHandler, can permissionFunc) {
+	r := api.Group("/documents")
+	r.Use(can(models.PermissionDocumentsRead))
+	r.GET("/receipt/:number", h.Receipt)
+	r.GET("/contract/:number", h.Contract)
+}
+
+func registerImportRoutes(api *gin.RouterGroup, h *importHandler.ImportHandler, can permissionFunc) {
+	r := api.Group("/import")
+	r.Use(can(models.PermissionDataImport))
+	r.POST("/people", h.ImportPeople)
+	r.POST("/person_subs", h.ImportPersonSubs)
+}
+
+func registerNotificationRoutes(api *gin.RouterGroup, h *notificationHandler.NotificationHandler, can permissionFunc) {
+	r := api.Group("/notifications")
+	r.GET("/contacts/:person_id", can(models.PermissionNotificationsRead), h.GetContacts)
+
+	manage := r.Group("")
+	manage.Use(can(models.PermissionNotificationsManage))
+	manage.PUT("/contacts/:person_id", h.UpdateContacts)
+	manage.GET("/log", h.FindNotifications)
+	manage.POST("/run/:kind", h.Run)
+}
+
+func registerMemberRoutes(api *gin.RouterGroup, h *memberHandler.MemberHandler, auth, member gin.HandlerFunc) {
+	r := api.Group("/member", auth, member)
+	r.GET("/me", h.Me)
+	r.GET("/subscriptions", h.Subscriptions)
+	r.GET("/visits", h.Visits)
+	r.GET("/payments", h.Payments)
+	r.GET("/freeze_requests", h.FreezeRequests)
+	r.POST("/freeze_requests", h.RequestFreeze)
+}
+
+func registerMemberAccountRoutes(api *gin.RouterGroup, h *memberHandler.MemberHandler, can permissionFunc) {
+	r := api.Group("/member_accounts")
+	r.Use(can(models.PermissionMemberAccountsManage))
+	r.POST("", h.LinkAccount)
+	r.DELETE("/:user_id", h.UnlinkAccount)
+}
+
+func registerWebhookRoutes(api *gin.RouterGroup, h *webhookHandler.WebhookHandler, can permissionFunc) {
+	r := api.Group("/webhooks")
+	r.Use(can(models.PermissionWebhooksManage))
+	r.GET("", h.FindWebhooks)
+	r.GET("/events", h.Events)
+	r.POST("", h.CreateWebhook)
+	r.POST("/:id/enable", h.EnableWebhook)
+	r.POST("/:id/disable", h.DisableWebhook)
+	r.DELETE("/:id", h.DeleteWebhook)
+	r.GET("/:id/deliveries", h.FindDeliveries)
+}
+
+func registerAccessRoutes(api *gin.RouterGroup, h *accessHandler.AccessHandler, device gin.HandlerFunc) {
+	r := api.Group("/access", device)
+	r.POST("/check", h.Check)
+	r.GET("/allow_list", h.AllowList)
+}
+
+func registerAccessAdminRoutes(api *gin.RouterGroup, h *accessHandler.AccessHandler, can permissionFunc) {
+	devices := api.Group("/access_devices")
+	devices.Use(can(models.PermissionAccessManage))
+	devices.GET("", h.FindDevices)
+	devices.POST("", h.CreateDevice)
+	devices.POST("/:id/enable", h.EnableDevice)
+	devices.POST("/:id/disable", h.DisableDevice)
+	devices.DELETE("/:id", h.DeleteDevice)
+
+	cards := api.Group("/access_cards")
+	cards.GET("", can(models.PermissionAccessRead), h.FindCards)
+	cards.POST("", can(models.PermissionAccessManage), h.AddCard)
+	cards.DELETE("/:card_number", can(models.PermissionAccessManage), h.DeleteCard)
+
+	api.GET("/access_log", can(models.PermissionAccessManage), h.FindLog)
+}
+
+func registerStaffRoutes(api *gin.RouterGroup, h *staffHandler.StaffHandler, can permissionFunc) {
+	r := api.Group("/staff")
+	r.GET("/users", can(models.PermissionUsersRead), h.FindUsers)
+	r.GET("/roles", can(models.PermissionUsersRead), h.FindRoles)
+	r.POST("/users/:id/roles", can(models.PermissionRolesManage), h.AssignRole)
+	r.DELETE("/users/:id/roles/:role", can(models.PermissionRolesManage), h.RevokeRole)
+	r.GET("/users/:id/permissions", can(models.PermissionUsersRead), h.CheckPermission)
+	r.POST("/unlock", can(models.PermissionUsersUnlock), h.UnlockLogin)
+	r.GET("/invites", can(models.PermissionUsersRead), h.FindInvites)
+	r.POST("/invites", can(models.PermissionRolesManage), h.CreateInvite)
+	r.DELETE("/invites/:id", can(models.PermissionRolesManage), h.RevokeInvite)
+}
+
+func registerLiveRoutes(api *gin.RouterGroup, h *liveHandler.LiveHandler, can permissionFunc) {
+	api.GET("/live", can(models.PermissionStatisticsRead), h.Stream)
 }
 
-func registerStatRoutes(api *gin.RouterGroup, h *statHandler.StatHandler) {
+func registerStatRoutes(api *gin.RouterGroup, h *statHandler.StatHandler, can permissionFunc) {
 	r := api.Group("/statistics")
+	r.Use(can(models.PermissionStatisticsRead))
 	r.GET("/total_clients", h.TotalClients)
 	r.GET("/new_clients", h.NewClients)
 	r.GET("/total_income", h.TotalIncome)
@@ -82,4 +222,12 @@ func registerStatRoutes(api *gin.RouterGroup, h *statHandler.StatHandler) {
 	r.GET("/total_single_visits", h.TotalSingleVisits)
 	r.GET("/single_visits", h.SingleVisits)
 	r.GET("/single_visits_income", h.SingleVisitsIncome)
+	r.GET("/single_visits_by_tariff", h.SingleVisitsByTariff)
+	r.GET("/retention", h.Retention)
+	r.GET("/churn", h.MonthlyChurn)
+	r.GET("/cohorts", h.Cohorts)
+	r.GET("/ltv", h.LifetimeValue)
+	r.GET("/revenue", h.RevenueReport)
+	r.GET("/heatmap", h.VisitsHeatmap)
+	r.GET("/occupancy", h.CurrentOccupancy)
 }
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	ssov1 "github.com/Muaz717/gym_app/app/pkg/sso"
 	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
@@ -10,6 +11,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"log/slog"
 	"net"
 
@@ -17,9 +19,10 @@ import (
 )
 
 type SSOClient struct {
-	api  ssov1.AuthClient
-	conn *grpc.ClientConn
-	log  *slog.Logger
+	api       ssov1.AuthClient
+	conn      *grpc.ClientConn
+	log       *slog.Logger
+	appSecret string
 }
 
 func NewSSOClient(
@@ -28,6 +31,7 @@ func NewSSOClient(
 	port string,
 	timeout time.Duration,
 	retriesCount int,
+	appSecret string,
 ) (*SSOClient, error) {
 	const op = "sso.grpc.NewClient"
 
@@ -57,9 +61,10 @@ func NewSSOClient(
 	}
 
 	return &SSOClient{
-		api:  ssov1.NewAuthClient(cc),
-		conn: cc,
-		log:  log,
+		api:       ssov1.NewAuthClient(cc),
+		conn:      cc,
+		log:       log,
+		appSecret: appSecret,
 	}, nil
 }
 
@@ -67,20 +72,36 @@ func (c *SSOClient) Close() error {
 	return c.conn.Close()
 }
 
-func (c *SSOClient) RegisterNewUser(ctx context.Context, email, password string) (int64, error) {
+func (c *SSOClient) RegisterNewUser(ctx context.Context, email, password, inviteCode string) (int64, error) {
 	const op = "sso.grpc.RegisterNewUser"
 
+	return c.register(ctx, op, &ssov1.RegisterRequest{
+		Email:      email,
+		Password:   password,
+		InviteCode: inviteCode,
+	})
+}
+
+// RegisterMember регистрирует аккаунт клиента: без ролей, даже если в SSO открыта регистрация
+func (c *SSOClient) RegisterMember(ctx context.Context, email, password string) (int64, error) {
+	const op = "sso.grpc.RegisterMember"
+
+	return c.register(ctx, op, &ssov1.RegisterRequest{
+		Email:        email,
+		Password:     password,
+		WithoutRoles: true,
+	})
+}
+
+func (c *SSOClient) register(ctx context.Context, op string, req *ssov1.RegisterRequest) (int64, error) {
 	log := c.log.With(
 		slog.String("op", op),
-		slog.String("email", email),
+		slog.String("email", req.GetEmail()),
 	)
 
 	log.Info("registering new user")
 
-	resp, err := c.api.Register(ctx, &ssov1.RegisterRequest{
-		Email:    email,
-		Password: password,
-	})
+	resp, err := c.api.Register(ctx, req)
 
 	if err != nil {
 		log.Error("failed to register new user", sl.Error(err))
@@ -95,7 +116,7 @@ func (c *SSOClient) RegisterNewUser(ctx context.Context, email, password string)
 	return resp.GetUserId(), nil
 }
 
-func (c *SSOClient) Login(ctx context.Context, appID int32, email, password string) (string, error) {
+func (c *SSOClient) Login(ctx context.Context, appID int32, email, password, device, ip string) (dto.AuthTokens, error) {
 	const op = "sso.grpc.Login"
 
 	log := c.log.With(
@@ -109,19 +130,92 @@ func (c *SSOClient) Login(ctx context.Context, appID int32, email, password stri
 		AppId:    appID,
 		Email:    email,
 		Password: password,
+		Device:   device,
+		Ip:       ip,
 	})
 
 	if err != nil {
 		log.Error("failed to login", sl.Error(err))
-		return "", err
+		return dto.AuthTokens{}, err
+	}
+
+	if resp.GetTwoFactorRequired() {
+		log.Info("second factor required")
+		return dto.AuthTokens{TwoFactorChallenge: resp.GetChallenge()}, nil
 	}
 
-	if resp.GetToken() == "" {
+	if resp.GetToken() == "" || resp.GetRefreshToken() == "" {
 		log.Error("empty token received")
-		return "", fmt.Errorf("%s: empty token received", op)
+		return dto.AuthTokens{}, fmt.Errorf("%s: empty token received", op)
 	}
 	log.Info("login successful")
-	return resp.GetToken(), nil
+	return dto.AuthTokens{
+		AccessToken:      resp.GetToken(),
+		RefreshToken:     resp.GetRefreshToken(),
+		AccessExpiresIn:  time.Duration(resp.GetExpiresIn()) * time.Second,
+		RefreshExpiresIn: time.Duration(resp.GetRefreshExpiresIn()) * time.Second,
+	}, nil
+}
+
+// LoginTwoFactor завершает вход с 2FA: challenge из ответа Login и код обмениваются на токены
+func (c *SSOClient) LoginTwoFactor(ctx context.Context, appID int32, challenge, code, device, ip string) (dto.AuthTokens, error) {
+	const op = "sso.grpc.LoginTwoFactor"
+
+	resp, err := c.api.LoginTwoFactor(ctx, &ssov1.LoginTwoFactorRequest{
+		Challenge: challenge,
+		Code:      code,
+		AppId:     appID,
+		Device:    device,
+		Ip:        ip,
+	})
+	if err != nil {
+		c.log.Error("failed to login with 2fa", slog.String("op", op), sl.Error(err))
+		return dto.AuthTokens{}, err
+	}
+
+	if resp.GetToken() == "" || resp.GetRefreshToken() == "" {
+		return dto.AuthTokens{}, fmt.Errorf("%s: empty token received", op)
+	}
+
+	return dto.AuthTokens{
+		AccessToken:      resp.GetToken(),
+		RefreshToken:     resp.GetRefreshToken(),
+		AccessExpiresIn:  time.Duration(resp.GetExpiresIn()) * time.Second,
+		RefreshExpiresIn: time.Duration(resp.GetRefreshExpiresIn()) * time.Second,
+	}, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару токенов. Старый refresh-токен больше не действует.
+func (c *SSOClient) Refresh(ctx context.Context, appID int32, refreshToken string) (dto.AuthTokens, error) {
+	const op = "sso.grpc.Refresh"
+
+	log := c.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("refreshing token")
+
+	resp, err := c.api.Refresh(ctx, &ssov1.RefreshRequest{
+		AppId:        appID,
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		log.Error("failed to refresh token", sl.Error(err))
+		return dto.AuthTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if resp.GetToken() == "" || resp.GetRefreshToken() == "" {
+		log.Error("empty token received")
+		return dto.AuthTokens{}, fmt.Errorf("%s: empty token received", op)
+	}
+
+	log.Info("token refreshed")
+	return dto.AuthTokens{
+		AccessToken:      resp.GetToken(),
+		RefreshToken:     resp.GetRefreshToken(),
+		AccessExpiresIn:  time.Duration(resp.GetExpiresIn()) * time.Second,
+		RefreshExpiresIn: time.Duration(resp.GetRefreshExpiresIn()) * time.Second,
+	}, nil
 }
 
 func (c *SSOClient) CheckToken(ctx context.Context, appID int32, token string) (*ssov1.CheckTokenResponse, error) {
@@ -134,7 +228,7 @@ func (c *SSOClient) CheckToken(ctx context.Context, appID int32, token string) (
 
 	log.Info("checking token")
 
-	resp, err := c.api.CheckToken(ctx, &ssov1.CheckTokenRequest{
+	resp, err := c.api.CheckToken(c.withAppSecret(ctx), &ssov1.CheckTokenRequest{
 		AppId: appID,
 		Token: token,
 	})
@@ -146,6 +240,328 @@ func (c *SSOClient) CheckToken(ctx context.Context, appID int32, token string) (
 	return resp, nil
 }
 
+// Logout отзывает токен в SSO. При all = true отзываются все сессии пользователя.
+func (c *SSOClient) Logout(ctx context.Context, appID int32, token string, all bool) error {
+	const op = "sso.grpc.Logout"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Bool("all_sessions", all),
+	)
+
+	log.Info("logging out")
+
+	_, err := c.api.Logout(ctx, &ssov1.LogoutRequest{
+		AppId:       appID,
+		Token:       token,
+		AllSessions: all,
+	})
+	if err != nil {
+		log.Error("failed to logout", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("logout successful")
+	return nil
+}
+
+// GetJWKS возвращает публичные ключи, которыми SSO подписывает токены приложения
+func (c *SSOClient) GetJWKS(ctx context.Context, appID int32) ([]*ssov1.JWK, error) {
+	const op = "sso.grpc.GetJWKS"
+
+	log := c.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("fetching jwks")
+
+	resp, err := c.api.GetJWKS(c.withAppSecret(ctx), &ssov1.GetJWKSRequest{AppId: appID})
+	if err != nil {
+		log.Error("failed to fetch jwks", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetKeys(), nil
+}
+
+// withAppSecret передает секрет приложения: без него SSO не проверяет токены
+func (c *SSOClient) withAppSecret(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-app-secret", c.appSecret)
+}
+
+// withToken передает токен вызывающего в SSO: ему нужны права на управление ролями
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func (c *SSOClient) ListUsers(ctx context.Context, token string, filter dto.StaffUsersFilter) (dto.StaffUsersPage, error) {
+	const op = "sso.grpc.ListUsers"
+
+	resp, err := c.api.ListUsers(withToken(ctx, token), &ssov1.ListUsersRequest{
+		Email:  filter.Email,
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+	})
+	if err != nil {
+		c.log.Error("failed to list users", slog.String("op", op), sl.Error(err))
+		return dto.StaffUsersPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	page := dto.StaffUsersPage{
+		Users: make([]dto.StaffUser, 0, len(resp.GetUsers())),
+		Total: resp.GetTotal(),
+	}
+	for _, user := range resp.GetUsers() {
+		page.Users = append(page.Users, dto.StaffUser{
+			UserID: user.GetUserId(),
+			Email:  user.GetEmail(),
+			Roles:  user.GetRoles(),
+		})
+	}
+
+	return page, nil
+}
+
+func (c *SSOClient) ListRoles(ctx context.Context, token string) ([]dto.StaffRole, error) {
+	const op = "sso.grpc.ListRoles"
+
+	resp, err := c.api.ListRoles(withToken(ctx, token), &ssov1.ListRolesRequest{})
+	if err != nil {
+		c.log.Error("failed to list roles", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles := make([]dto.StaffRole, 0, len(resp.GetRoles()))
+	for _, role := range resp.GetRoles() {
+		roles = append(roles, dto.StaffRole{
+			Name:        role.GetName(),
+			Description: role.GetDescription(),
+			Permissions: role.GetPermissions(),
+		})
+	}
+
+	return roles, nil
+}
+
+func (c *SSOClient) AssignRole(ctx context.Context, token string, userID int64, role string) error {
+	const op = "sso.grpc.AssignRole"
+
+	_, err := c.api.AssignRole(withToken(ctx, token), &ssov1.AssignRoleRequest{UserId: userID, Role: role})
+	if err != nil {
+		c.log.Error("failed to assign role", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) RevokeRole(ctx context.Context, token string, userID int64, role string) error {
+	const op = "sso.grpc.RevokeRole"
+
+	_, err := c.api.RevokeRole(withToken(ctx, token), &ssov1.RevokeRoleRequest{UserId: userID, Role: role})
+	if err != nil {
+		c.log.Error("failed to revoke role", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) CheckPermission(ctx context.Context, token string, userID int64, permission string) (bool, error) {
+	const op = "sso.grpc.CheckPermission"
+
+	resp, err := c.api.CheckPermission(withToken(ctx, token), &ssov1.CheckPermissionRequest{
+		UserId:     userID,
+		Permission: permission,
+	})
+	if err != nil {
+		c.log.Error("failed to check permission", slog.String("op", op), sl.Error(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetAllowed(), nil
+}
+
+func (c *SSOClient) UnlockLogin(ctx context.Context, token, email, ip string) (bool, error) {
+	const op = "sso.grpc.UnlockLogin"
+
+	resp, err := c.api.UnlockLogin(withToken(ctx, token), &ssov1.UnlockLoginRequest{Email: email, Ip: ip})
+	if err != nil {
+		c.log.Error("failed to unlock login", slog.String("op", op), sl.Error(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetUnlocked(), nil
+}
+
+func (c *SSOClient) CreateInvite(ctx context.Context, token string, input dto.InviteInput) (dto.CreatedInvite, error) {
+	const op = "sso.grpc.CreateInvite"
+
+	resp, err := c.api.CreateInvite(withToken(ctx, token), &ssov1.CreateInviteRequest{
+		Email: input.Email,
+		Roles: input.Roles,
+	})
+	if err != nil {
+		c.log.Error("failed to create invite", slog.String("op", op), sl.Error(err))
+		return dto.CreatedInvite{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.CreatedInvite{
+		Invite: inviteFromProto(resp.GetInvite()),
+		Code:   resp.GetCode(),
+	}, nil
+}
+
+func (c *SSOClient) ListInvites(ctx context.Context, token string) ([]dto.StaffInvite, error) {
+	const op = "sso.grpc.ListInvites"
+
+	resp, err := c.api.ListInvites(withToken(ctx, token), &ssov1.ListInvitesRequest{})
+	if err != nil {
+		c.log.Error("failed to list invites", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invites := make([]dto.StaffInvite, 0, len(resp.GetInvites()))
+	for _, invite := range resp.GetInvites() {
+		invites = append(invites, inviteFromProto(invite))
+	}
+
+	return invites, nil
+}
+
+func (c *SSOClient) RevokeInvite(ctx context.Context, token string, inviteID int64) error {
+	const op = "sso.grpc.RevokeInvite"
+
+	_, err := c.api.RevokeInvite(withToken(ctx, token), &ssov1.RevokeInviteRequest{InviteId: inviteID})
+	if err != nil {
+		c.log.Error("failed to revoke invite", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func inviteFromProto(invite *ssov1.Invite) dto.StaffInvite {
+	res := dto.StaffInvite{
+		ID:        invite.GetId(),
+		Email:     invite.GetEmail(),
+		Roles:     invite.GetRoles(),
+		CreatedBy: invite.GetCreatedBy(),
+		CreatedAt: time.Unix(invite.GetCreatedAt(), 0),
+		ExpiresAt: time.Unix(invite.GetExpiresAt(), 0),
+		UsedBy:    invite.GetUsedBy(),
+	}
+	if invite.GetUsedAt() != 0 {
+		usedAt := time.Unix(invite.GetUsedAt(), 0)
+		res.UsedAt = &usedAt
+	}
+	return res
+}
+
+func (c *SSOClient) ChangePassword(ctx context.Context, appID int32, token, currentPassword, newPassword string) error {
+	const op = "sso.grpc.ChangePassword"
+
+	_, err := c.api.ChangePassword(ctx, &ssov1.ChangePasswordRequest{
+		Token:           token,
+		AppId:           appID,
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	if err != nil {
+		c.log.Error("failed to change password", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) RequestPasswordReset(ctx context.Context, email string) error {
+	const op = "sso.grpc.RequestPasswordReset"
+
+	_, err := c.api.RequestPasswordReset(ctx, &ssov1.RequestPasswordResetRequest{Email: email})
+	if err != nil {
+		c.log.Error("failed to request password reset", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) ResetPassword(ctx context.Context, token, newPassword string) error {
+	const op = "sso.grpc.ResetPassword"
+
+	_, err := c.api.ResetPassword(ctx, &ssov1.ResetPasswordRequest{Token: token, NewPassword: newPassword})
+	if err != nil {
+		c.log.Error("failed to reset password", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) VerifyEmail(ctx context.Context, token string) error {
+	const op = "sso.grpc.VerifyEmail"
+
+	_, err := c.api.VerifyEmail(ctx, &ssov1.VerifyEmailRequest{Token: token})
+	if err != nil {
+		c.log.Error("failed to verify email", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) ResendVerificationEmail(ctx context.Context, email string) error {
+	const op = "sso.grpc.ResendVerificationEmail"
+
+	_, err := c.api.ResendVerificationEmail(ctx, &ssov1.ResendVerificationEmailRequest{Email: email})
+	if err != nil {
+		c.log.Error("failed to resend verification email", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *SSOClient) EnrollTOTP(ctx context.Context, appID int32, token string) (dto.TOTPEnrollment, error) {
+	const op = "sso.grpc.EnrollTOTP"
+
+	resp, err := c.api.EnrollTOTP(ctx, &ssov1.EnrollTOTPRequest{Token: token, AppId: appID})
+	if err != nil {
+		c.log.Error("failed to enroll totp", slog.String("op", op), sl.Error(err))
+		return dto.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.TOTPEnrollment{
+		Secret:     resp.GetSecret(),
+		OtpauthURI: resp.GetOtpauthUri(),
+	}, nil
+}
+
+func (c *SSOClient) ConfirmTOTP(ctx context.Context, appID int32, token, code string) ([]string, error) {
+	const op = "sso.grpc.ConfirmTOTP"
+
+	resp, err := c.api.ConfirmTOTP(ctx, &ssov1.ConfirmTOTPRequest{Token: token, AppId: appID, Code: code})
+	if err != nil {
+		c.log.Error("failed to confirm totp", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetRecoveryCodes(), nil
+}
+
+func (c *SSOClient) DisableTOTP(ctx context.Context, appID int32, token, code string) error {
+	const op = "sso.grpc.DisableTOTP"
+
+	_, err := c.api.DisableTOTP(ctx, &ssov1.DisableTOTPRequest{Token: token, AppId: appID, Code: code})
+	if err != nil {
+		c.log.Error("failed to disable totp", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 // InterceptorLogger adapts slog logger to interceptor logger.
 // This code is simple enough to be copied and not imported.
 func InterceptorLogger(l *slog.Logger) grpclog.Logger {
@@ -0,0 +1,160 @@
+// Package telegram — минимальный клиент Telegram Bot API: long polling и отправка сообщений.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://api.telegram.org"
+
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+type Message struct {
+	MessageID int64    `json:"message_id"`
+	From      *User    `json:"from,omitempty"`
+	Chat      Chat     `json:"chat"`
+	Text      string   `json:"text,omitempty"`
+	Contact   *Contact `json:"contact,omitempty"`
+}
+
+type User struct {
+	ID int64 `json:"id"`
+}
+
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// Contact — контакт, отправленный кнопкой request_contact
+type Contact struct {
+	PhoneNumber string `json:"phone_number"`
+	UserID      int64  `json:"user_id,omitempty"`
+}
+
+type KeyboardButton struct {
+	Text           string `json:"text"`
+	RequestContact bool   `json:"request_contact,omitempty"`
+}
+
+type ReplyKeyboardMarkup struct {
+	Keyboard        [][]KeyboardButton `json:"keyboard"`
+	ResizeKeyboard  bool               `json:"resize_keyboard,omitempty"`
+	OneTimeKeyboard bool               `json:"one_time_keyboard,omitempty"`
+}
+
+type ReplyKeyboardRemove struct {
+	RemoveKeyboard bool `json:"remove_keyboard"`
+}
+
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	Description string          `json:"description"`
+}
+
+// New создает клиента. baseURL можно оставить пустым — будет использован api.telegram.org.
+func New(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{},
+	}
+}
+
+// GetUpdates ждет новые сообщения до timeout (long polling)
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	const op = "telegram.GetUpdates"
+
+	params := map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}
+
+	var updates []Update
+	if err := c.call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updates, nil
+}
+
+// SendMessage отправляет текст в чат. markup — клавиатура или nil.
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string, markup any) error {
+	const op = "telegram.SendMessage"
+
+	params := map[string]any{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	if markup != nil {
+		params["reply_markup"] = markup
+	}
+
+	if err := c.call(ctx, "sendMessage", params, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/%s", c.baseURL, c.token, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// В URL зашит токен бота — не отдаем его в логи
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = strings.Replace(urlErr.URL, c.token, "<token>", 1)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	var apiResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("decode response (status %s): %w", resp.Status, err)
+	}
+	if !apiResp.OK {
+		return fmt.Errorf("telegram api error: %s", apiResp.Description)
+	}
+
+	if result != nil {
+		return json.Unmarshal(apiResp.Result, result)
+	}
+
+	return nil
+}
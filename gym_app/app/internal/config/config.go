@@ -9,13 +9,19 @@ import (
 )
 
 type Config struct {
-	Env        string        `yaml:"env" env-default:"local"`
-	TokenTTL   time.Duration `yaml:"token_ttl" env-required:"true"`
-	AppID      int32         `yaml:"app_id" env-required:"true"`
-	HTTPServer `yaml:"http_server"`
-	DB         `yaml:"db"`
-	Redis      `yaml:"redis"`
-	Clients    ClientConfig `yaml:"clients"`
+	Env           string        `yaml:"env" env-default:"local"`
+	TokenTTL      time.Duration `yaml:"token_ttl" env-required:"true"`
+	AppID         int32         `yaml:"app_id" env-required:"true"`
+	HTTPServer    `yaml:"http_server"`
+	DB            `yaml:"db"`
+	Redis         `yaml:"redis"`
+	Clients       ClientConfig  `yaml:"clients"`
+	Documents     Documents     `yaml:"documents"`
+	Notifications Notifications `yaml:"notifications"`
+	Bot           Bot           `yaml:"bot"`
+	Webhooks      Webhooks      `yaml:"webhooks"`
+	AccessControl AccessControl `yaml:"access_control"`
+	RateLimit     RateLimit     `yaml:"rate_limit"`
 }
 
 type HTTPServer struct {
@@ -23,6 +29,9 @@ type HTTPServer struct {
 	Port        string        `yaml:"port" env-default:"8082"`
 	Timeout     time.Duration `yaml:"timeout" env-default:"local"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+	// TrustedProxies — адреса/подсети прокси, чьим X-Forwarded-For можно верить.
+	// Пусто — IP клиента берется из соединения, заголовок игнорируется
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 type DB struct {
@@ -40,11 +49,97 @@ type Redis struct {
 	DBRedis  int    `yaml:"dbredis"`
 }
 
+// Documents — настройки печатных документов (чек, договор)
+type Documents struct {
+	TemplatesDir string       `yaml:"templates_dir" env-default:"templates"`
+	FontPath     string       `yaml:"font_path" env-default:"/usr/share/fonts/dejavu/DejaVuSans.ttf"` // TTF с кириллицей
+	BoldFontPath string       `yaml:"bold_font_path"`                                                 // Необязательный жирный вариант шрифта
+	Organization Organization `yaml:"organization"`
+}
+
+// Organization — реквизиты зала, подставляемые в документы
+type Organization struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	Phone   string `yaml:"phone"`
+	INN     string `yaml:"inn"`
+}
+
+// Notifications — напоминания об окончании абонемента и поздравления с днем рождения
+type Notifications struct {
+	Enabled          bool        `yaml:"enabled"`
+	Schedule         string      `yaml:"schedule" env-default:"0 10 * * *"` // cron-выражение ежедневной рассылки
+	RemindBeforeDays int         `yaml:"remind_before_days" env-default:"3"`
+	TemplatesDir     string      `yaml:"templates_dir" env-default:"templates/notifications"`
+	SMS              SMSGateway  `yaml:"sms"`
+	Email            SMTP        `yaml:"email"`
+	Telegram         TelegramBot `yaml:"telegram"`
+}
+
+// SMSGateway — HTTP-шлюз отправки SMS. Канал выключен, если url пустой.
+type SMSGateway struct {
+	URL     string        `yaml:"url"`
+	APIKey  string        `yaml:"api_key" env:"SMS_API_KEY"`
+	Sender  string        `yaml:"sender"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
+// SMTP — почтовый сервер. Канал выключен, если host пустой.
+type SMTP struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port" env-default:"587"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from"`
+}
+
+// TelegramBot — бот для уведомлений. Канал выключен, если token пустой.
+type TelegramBot struct {
+	Token   string        `yaml:"token" env:"TELEGRAM_BOT_TOKEN"`
+	BaseURL string        `yaml:"base_url"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
+// Bot — Telegram-бот для клиентов. Использует токен из notifications.telegram.
+type Bot struct {
+	Enabled     bool          `yaml:"enabled"`
+	PollTimeout time.Duration `yaml:"poll_timeout" env-default:"30s"` // Long polling getUpdates
+}
+
+// Webhooks — доставка событий во внешние системы (CRM, бухгалтерия)
+type Webhooks struct {
+	Enabled     bool          `yaml:"enabled"`
+	Interval    time.Duration `yaml:"interval" env-default:"5s"` // Как часто диспетчер разбирает outbox
+	BatchSize   int           `yaml:"batch_size" env-default:"50"`
+	Timeout     time.Duration `yaml:"timeout" env-default:"10s"`
+	MaxAttempts int           `yaml:"max_attempts" env-default:"10"`
+	BaseBackoff time.Duration `yaml:"base_backoff" env-default:"30s"` // Пауза после первой неудачи, дальше удваивается
+	MaxBackoff  time.Duration `yaml:"max_backoff" env-default:"6h"`
+}
+
+// AccessControl — проверка проходов через турникет
+type AccessControl struct {
+	Timeout      time.Duration `yaml:"timeout" env-default:"300ms"` // Сколько контроллер готов ждать решения
+	AntiPassback bool          `yaml:"anti_passback"`               // Не пускать повторно, пока клиент не вышел через турникет
+	FailOpen     bool          `yaml:"fail_open"`                   // Пускать, если база не ответила вовремя
+}
+
+// RateLimit — ограничение запросов к открытым эндпоинтам, по IP клиента
+type RateLimit struct {
+	MemberRegisterAttempts int           `yaml:"member_register_attempts" env-default:"5"` // Попыток регистрации клиента за окно
+	MemberRegisterWindow   time.Duration `yaml:"member_register_window" env-default:"1h"`
+}
+
 type Client struct {
 	Host         string        `yaml:"host" env-default:"0.0.0.0"`
 	Port         string        `yaml:"port" env-default:"44044"`
 	Timeout      time.Duration `yaml:"timeout" env-default:"5s"`
 	RetriesCount int           `yaml:"retries_count" env-default:"3"`
+	// Только для SSO: как часто спрашивать, не отозван ли токен. Подпись и срок проверяются локально
+	// на каждом запросе, 0 — проверять отзыв на каждом запросе
+	RevocationCheckInterval time.Duration `yaml:"revocation_check_interval" env-default:"30s"`
+	// Только для SSO: секрет приложения, с ним SSO отвечает на CheckToken и GetJWKS
+	AppSecret string `yaml:"app_secret" env:"SSO_APP_SECRET"`
 }
 
 type ClientConfig struct {
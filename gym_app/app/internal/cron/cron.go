@@ -2,19 +2,43 @@ package cron
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	notificationService "github.com/Muaz717/gym_app/app/internal/services/notification"
 	personSubService "github.com/Muaz717/gym_app/app/internal/services/person_sub"
+	webhookService "github.com/Muaz717/gym_app/app/internal/services/webhook"
 	"github.com/robfig/cron/v3"
 )
 
 type CronJobs struct {
-	cronScheduler    *cron.Cron
-	personSubService *personSubService.PersonSubService
+	log                 *slog.Logger
+	cronScheduler       *cron.Cron
+	personSubService    *personSubService.PersonSubService
+	notificationService *notificationService.NotificationService
+	notificationCfg     config.Notifications
+	webhookService      *webhookService.WebhookService
+	webhookCfg          config.Webhooks
 }
 
-func New(personSubService *personSubService.PersonSubService) *CronJobs {
+func New(
+	log *slog.Logger,
+	personSubService *personSubService.PersonSubService,
+	notificationService *notificationService.NotificationService,
+	notificationCfg config.Notifications,
+	webhookService *webhookService.WebhookService,
+	webhookCfg config.Webhooks,
+) *CronJobs {
 	return &CronJobs{
-		cronScheduler:    cron.New(),
-		personSubService: personSubService,
+		log:                 log,
+		cronScheduler:       cron.New(),
+		personSubService:    personSubService,
+		notificationService: notificationService,
+		notificationCfg:     notificationCfg,
+		webhookService:      webhookService,
+		webhookCfg:          webhookCfg,
 	}
 }
 
@@ -27,6 +51,33 @@ func (c *CronJobs) Start(ctx context.Context) {
 		}
 	})
 
+	if c.notificationCfg.Enabled {
+		// Ошибка рассылки не должна ронять сервис: пишем в лог и ждем следующего запуска
+		_, err := c.cronScheduler.AddFunc(c.notificationCfg.Schedule, func() {
+			if _, err := c.notificationService.SendExpiryReminders(ctx); err != nil {
+				c.log.Error("expiry reminders failed", sl.Error(err))
+			}
+			if _, err := c.notificationService.SendBirthdayGreetings(ctx); err != nil {
+				c.log.Error("birthday greetings failed", sl.Error(err))
+			}
+		})
+		if err != nil {
+			c.log.Error("invalid notifications schedule", slog.String("schedule", c.notificationCfg.Schedule), sl.Error(err))
+		}
+	}
+
+	if c.webhookCfg.Enabled {
+		// Проход может затянуться из-за медленных получателей: следующий ждет окончания предыдущего
+		job := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(func() {
+			if _, err := c.webhookService.Dispatch(ctx); err != nil {
+				c.log.Error("webhook dispatch failed", sl.Error(err))
+			}
+		}))
+		if _, err := c.cronScheduler.AddJob(fmt.Sprintf("@every %s", c.webhookCfg.Interval), job); err != nil {
+			c.log.Error("invalid webhooks interval", slog.Duration("interval", c.webhookCfg.Interval), sl.Error(err))
+		}
+	}
+
 	c.cronScheduler.Start()
 }
 
@@ -0,0 +1,70 @@
+package dto
+
+import "time"
+
+// Направления прохода
+const (
+	AccessIn  = "in"
+	AccessOut = "out"
+)
+
+// Причины решения турникета. Контроллер показывает их на табло или пишет в свой журнал.
+const (
+	AccessReasonOK            = "ok"
+	AccessReasonUnknown       = "unknown_credential" // Нет ни карты, ни абонемента с таким номером
+	AccessReasonNotStarted    = "not_started"        // Абонемент начнет действовать позже
+	AccessReasonExpired       = "expired"            // Срок абонемента закончился
+	AccessReasonFrozen        = "frozen"             // Абонемент заморожен
+	AccessReasonInactive      = "inactive"           // Другой статус абонемента
+	AccessReasonAlreadyInside = "already_inside"     // Повторный вход без выхода (anti-passback)
+	AccessReasonUnavailable   = "unavailable"        // База не ответила в отведенное время
+)
+
+type AccessCheckInput struct {
+	Credential string `json:"credential"`
+	Direction  string `json:"direction"` // По умолчанию in
+}
+
+// AccessDecision — ответ контроллеру
+type AccessDecision struct {
+	Allowed            bool       `json:"allowed"`
+	Reason             string     `json:"reason"`
+	Message            string     `json:"message"` // Текст для табло турникета
+	SubscriptionNumber string     `json:"subscription_number,omitempty"`
+	PersonName         string     `json:"person_name,omitempty"`
+	ValidUntil         *time.Time `json:"valid_until,omitempty"`
+	VisitID            int        `json:"visit_id,omitempty"`
+}
+
+// AccessCredential — абонемент, найденный по номеру карты или абонемента
+type AccessCredential struct {
+	SubscriptionNumber string
+	PersonID           int
+	PersonName         string
+	Status             string
+	StartDate          time.Time
+	EndDate            time.Time
+}
+
+// AllowListEntry — номер, по которому контроллер пускает без связи с сервером
+type AllowListEntry struct {
+	Credential         string    `json:"credential"`
+	SubscriptionNumber string    `json:"subscription_number"`
+	ValidFrom          time.Time `json:"valid_from"`
+	ValidUntil         time.Time `json:"valid_until"`
+}
+
+// AllowList — выгрузка для офлайн-режима. Контроллер заменяет свой список целиком.
+type AllowList struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Entries     []AllowListEntry `json:"entries"`
+}
+
+type AccessDeviceInput struct {
+	Name string `json:"name"`
+}
+
+type AccessCardInput struct {
+	CardNumber string `json:"card_number"`
+	PersonID   int    `json:"person_id"`
+}
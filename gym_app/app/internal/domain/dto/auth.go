@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -10,6 +12,64 @@ type LoginResponse struct {
 }
 
 type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	InviteCode string `json:"invite_code,omitempty"` // Код приглашения, выданного администратором
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// EmailRequest — запрос письма для сброса пароля или подтверждения email
+type EmailRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"` // Токен из ссылки в письме
+	NewPassword string `json:"new_password"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"` // Токен из ссылки в письме
+}
+
+// AuthTokens — пара токенов, выданная SSO при входе или обновлении
+type AuthTokens struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresIn  time.Duration
+	RefreshExpiresIn time.Duration
+	// TwoFactorChallenge не пуст, если у пользователя включена 2FA: токенов тогда нет,
+	// вход завершается кодом из приложения-аутентификатора
+	TwoFactorChallenge string
+}
+
+// TwoFactorChallenge — ответ на вход по паролю, когда нужен второй фактор
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	Challenge         string `json:"challenge"`
+}
+
+type LoginTwoFactorRequest struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"` // Код из приложения или резервный код
+}
+
+// TOTPCodeRequest — код из приложения-аутентификатора, для отключения подойдет и резервный
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TOTPEnrollment — секрет для приложения-аутентификатора. URI обычно показывают QR-кодом
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// RecoveryCodes показываются один раз, каждый заменяет код из приложения один раз
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// Виды отчета по клиентам, которым нужно позвонить
+const (
+	ExpiringKindExpiring = "expiring" // абонемент заканчивается в ближайшие дни
+	ExpiringKindLapsed   = "lapsed"   // абонемент закончился недавно и не продлен
+	ExpiringKindFrozen   = "frozen"   // абонемент заморожен слишком долго
+)
+
+// ExpiringFilter задает параметры отчета по заканчивающимся абонементам
+type ExpiringFilter struct {
+	Kind           string
+	Days           int
+	SubscriptionID int // 0 — все тарифы
+}
+
+// ExpiringPersonSub — строка отчета по заканчивающимся, просроченным и замороженным абонементам
+type ExpiringPersonSub struct {
+	Number            string     `json:"number"`
+	PersonID          int        `json:"person_id"`
+	PersonName        string     `json:"person_name"`
+	Phone             string     `json:"phone"`
+	SubscriptionID    int        `json:"subscription_id"`
+	SubscriptionTitle string     `json:"subscription_title"`
+	StartDate         time.Time  `json:"start_date"`
+	EndDate           time.Time  `json:"end_date"`
+	Status            string     `json:"status"`
+	DaysLeft          int        `json:"days_left"` // отрицательное значение — сколько дней назад закончился
+	FrozenSince       *time.Time `json:"frozen_since,omitempty"`
+	FrozenDays        int        `json:"frozen_days,omitempty"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// PeopleFilter — фильтр выгрузки клиентов. Пустые поля не ограничивают выборку.
+type PeopleFilter struct {
+	Name string // часть ФИО, как в поиске клиентов
+}
+
+// PersonSubFilter — фильтр выгрузки абонементов клиентов. Пустые поля не ограничивают выборку.
+type PersonSubFilter struct {
+	PersonID   int
+	PersonName string
+	Status     string
+}
+
+// SingleVisitFilter — фильтр выгрузки разовых посещений по дате посещения.
+// Нулевые даты не ограничивают выборку.
+type SingleVisitFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+// FreezeFilter — фильтр выгрузки заморозок
+type FreezeFilter struct {
+	ActiveOnly bool // только абонементы, которые сейчас заморожены
+}
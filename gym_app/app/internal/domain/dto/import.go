@@ -0,0 +1,17 @@
+package dto
+
+// ImportRowError — ошибки валидации одной строки импортируемого файла.
+// Ключи Errors совпадают с полями Person/PersonSubInput, значения — сообщения их Validate.
+type ImportRowError struct {
+	Line   int               `json:"line"`
+	Errors map[string]string `json:"errors"`
+}
+
+// ImportResult — итог импорта. Если есть ошибки, ни одна строка не сохраняется.
+type ImportResult struct {
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Valid    int              `json:"valid"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// Типы сообщений, которые получает экран ресепшена по /live
+const (
+	LiveSale   = "sale"
+	LiveVisit  = "visit"
+	LiveFreeze = "freeze"
+	LiveIncome = "income"
+)
+
+// Виды продаж в LiveSaleEvent
+const (
+	SaleSubscription = "subscription"
+	SaleSingleVisit  = "single_visit"
+)
+
+// LiveMessage — одно сообщение потока: Event уходит в поле event, Data — в data в виде JSON
+type LiveMessage struct {
+	Event string
+	Data  any
+}
+
+// LiveSaleEvent — продан абонемент или разовое посещение
+type LiveSaleEvent struct {
+	Kind               string    `json:"kind"`
+	SubscriptionNumber string    `json:"subscription_number,omitempty"`
+	PersonID           int       `json:"person_id,omitempty"`
+	SingleVisitID      int       `json:"single_visit_id,omitempty"`
+	Amount             float64   `json:"amount"`
+	Date               time.Time `json:"date"`
+}
+
+// LiveVisitEvent — клиент отметился по абонементу
+type LiveVisitEvent struct {
+	VisitID            int       `json:"visit_id"`
+	SubscriptionNumber string    `json:"subscription_number"`
+	PersonID           int       `json:"person_id"`
+	PersonName         string    `json:"person_name"`
+	At                 time.Time `json:"at"`
+}
+
+// LiveFreezeEvent — абонемент заморожен или разморожен
+type LiveFreezeEvent struct {
+	SubscriptionNumber string    `json:"subscription_number"`
+	Frozen             bool      `json:"frozen"`
+	Date               time.Time `json:"date"`
+}
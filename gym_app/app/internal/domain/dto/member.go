@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+)
+
+// MemberSubscription — абонемент глазами клиента: сроки, остаток заморозки и ее история
+type MemberSubscription struct {
+	PersonName     string                      `json:"person_name"`
+	Subscription   PersonSubResponse           `json:"subscription"`
+	DaysLeft       int                         `json:"days_left"`        // 0, если абонемент уже закончился
+	FreezeDaysLeft int                         `json:"freeze_days_left"` // Сколько дней заморозки еще доступно
+	Frozen         bool                        `json:"frozen"`           // Есть незакрытая заморозка
+	Freezes        []models.SubscriptionFreeze `json:"freezes"`
+}
+
+const (
+	FreezeRequestPending  = "pending"
+	FreezeRequestApproved = "approved"
+	FreezeRequestRejected = "rejected"
+)
+
+// MemberProfile — карточка клиента в личном кабинете
+type MemberProfile struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Phone     string     `json:"phone"`
+	Email     string     `json:"email,omitempty"`
+	BirthDate *time.Time `json:"birth_date,omitempty"`
+}
+
+// MemberPayment — оплата абонемента
+type MemberPayment struct {
+	Date               time.Time `json:"date"`
+	SubscriptionNumber string    `json:"subscription_number"`
+	SubscriptionTitle  string    `json:"subscription_title"`
+	Price              float64   `json:"price"`
+	Discount           float64   `json:"discount"`
+	Paid               float64   `json:"paid"`
+}
+
+// MemberRegisterInput — самостоятельная регистрация клиента.
+// Номер абонемента и телефон подтверждают, что аккаунт создает владелец карточки.
+type MemberRegisterInput struct {
+	Email              string `json:"email"`
+	Password           string `json:"password"`
+	Phone              string `json:"phone"`
+	SubscriptionNumber string `json:"subscription_number"`
+}
+
+// MemberLinkInput — привязка аккаунта к клиенту администратором
+type MemberLinkInput struct {
+	UserID   int64 `json:"user_id"`
+	PersonID int   `json:"person_id"`
+}
+
+// FreezeRequestInput — заявка на заморозку из личного кабинета
+type FreezeRequestInput struct {
+	SubscriptionNumber string `json:"subscription_number"`
+	FreezeStart        string `json:"freeze_start"` // YYYY-MM-DD
+	Comment            string `json:"comment"`
+}
+
+// FreezeRequestFilter — фильтр заявок на заморозку
+type FreezeRequestFilter struct {
+	PersonID int
+	Status   string
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+const (
+	NotificationExpiry   = "expiry"
+	NotificationBirthday = "birthday"
+	NotificationFreeze   = "freeze"
+	NotificationUnfreeze = "unfreeze"
+
+	NotificationSent   = "sent"
+	NotificationFailed = "failed"
+)
+
+// NotificationTarget — клиент, которому нужно отправить уведомление, вместе с контактами
+type NotificationTarget struct {
+	PersonID           int
+	PersonName         string
+	Phone              string
+	Email              string
+	TelegramChatID     *int64
+	SubscriptionNumber string // Только для напоминаний об окончании абонемента
+	SubscriptionTitle  string
+	EndDate            time.Time
+}
+
+// ContactsInput — тело запроса на изменение контактов клиента
+type ContactsInput struct {
+	Email          string `json:"email"`
+	TelegramChatID *int64 `json:"telegram_chat_id"`
+	BirthDate      string `json:"birth_date"` // YYYY-MM-DD, пустая строка — не указана
+	OptedOut       bool   `json:"opted_out"`
+}
+
+// NotificationFilter — фильтр журнала уведомлений
+type NotificationFilter struct {
+	PersonID int
+	Kind     string
+	Limit    int
+}
+
+// NotificationRun — итог одного запуска рассылки
+type NotificationRun struct {
+	Kind    string `json:"kind"`
+	Targets int    `json:"targets"`
+	Sent    int    `json:"sent"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"` // Нет ни одного подходящего канала
+}
@@ -1,6 +1,6 @@
 package dto
 
 type SingleVisitInput struct {
-	VisitDate  string  `json:"visit_date"`
-	FinalPrice float64 `json:"final_price"`
+	VisitDate string `json:"visit_date"`
+	TariffID  int    `json:"tariff_id"`
 }
@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+// StaffUser — учетная запись SSO с ролями
+type StaffUser struct {
+	UserID int64    `json:"user_id"`
+	Email  string   `json:"email"`
+	Roles  []string `json:"roles"`
+}
+
+type StaffUsersFilter struct {
+	Email  string `form:"email"` // Подстрока email, без учета регистра
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+type StaffUsersPage struct {
+	Users []StaffUser `json:"users"`
+	Total int64       `json:"total"`
+}
+
+type StaffRole struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleInput struct {
+	Role string `json:"role"`
+}
+
+// UnlockLoginInput — email и/или IP, с которых снимается блокировка входа
+type UnlockLoginInput struct {
+	Email string `json:"email"`
+	IP    string `json:"ip"`
+}
+
+// StaffInvite — приглашение на регистрацию с заранее назначенными ролями
+type StaffInvite struct {
+	ID        int64      `json:"id"`
+	Email     string     `json:"email,omitempty"`
+	Roles     []string   `json:"roles"`
+	CreatedBy int64      `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedBy    int64      `json:"used_by,omitempty"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// InviteInput — пустой email означает приглашение для любого адреса
+type InviteInput struct {
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+// CreatedInvite — код возвращается только при создании, SSO хранит лишь его хеш
+type CreatedInvite struct {
+	Invite StaffInvite `json:"invite"`
+	Code   string      `json:"code"`
+}
+
+type PermissionCheck struct {
+	Permission string `json:"permission"`
+	Allowed    bool   `json:"allowed"`
+}
@@ -11,3 +11,93 @@ type MonthlyStat struct {
 	SingleVisitsIncome float64   `json:"single_visits_income"`
 	SingleVisitsCount  int       `json:"single_visits_count"`
 }
+
+// SingleVisitTariffStat описывает продажи разовых посещений по одному тарифу.
+type SingleVisitTariffStat struct {
+	TariffID    *int    `json:"tariff_id"`
+	TariffTitle string  `json:"tariff_title"`
+	Count       int     `json:"count"`
+	Income      float64 `json:"income"`
+}
+
+// RetentionStat описывает долю клиентов, продливших абонемент в течение окна после окончания.
+type RetentionStat struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	WindowDays    int       `json:"window_days"`
+	Expired       int       `json:"expired"`
+	Renewed       int       `json:"renewed"`
+	RetentionRate float64   `json:"retention_rate"`
+}
+
+// ChurnStat описывает отток клиентов за месяц.
+type ChurnStat struct {
+	Month         time.Time `json:"month"`
+	ActiveAtStart int       `json:"active_at_start"`
+	Churned       int       `json:"churned"`
+	ChurnRate     float64   `json:"churn_rate"`
+}
+
+// CohortStat описывает когорту клиентов по месяцу первой покупки.
+// Active[i] — сколько клиентов когорты имели действующий абонемент через i месяцев.
+type CohortStat struct {
+	Cohort         time.Time `json:"cohort"`
+	Size           int       `json:"size"`
+	Active         []int     `json:"active"`
+	RetentionRates []float64 `json:"retention_rates"`
+}
+
+// LTVStat описывает среднюю пожизненную ценность клиента.
+type LTVStat struct {
+	Clients             int     `json:"clients"`
+	TotalRevenue        float64 `json:"total_revenue"`
+	AverageLTV          float64 `json:"average_ltv"`
+	AverageLifetimeDays float64 `json:"average_lifetime_days"`
+}
+
+// Гранулярность отчета по доходам
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+	GranularityYear  = "year"
+)
+
+// RevenueStat описывает доход от продажи абонементов за один период.
+// При группировке по тарифам SubscriptionID указывает на абонемент, иначе он пустой.
+type RevenueStat struct {
+	Period            time.Time `json:"period"`
+	SubscriptionID    *int      `json:"subscription_id"`
+	SubscriptionTitle string    `json:"subscription_title"`
+	Sold              int       `json:"sold"`
+	Gross             float64   `json:"gross"`
+	Discount          float64   `json:"discount"`
+	Net               float64   `json:"net"`
+}
+
+// OccupancyHeatmap описывает количество приходов по дням недели и часам.
+// Matrix[0] — понедельник, Matrix[6] — воскресенье; второй индекс — час суток.
+type OccupancyHeatmap struct {
+	From   time.Time  `json:"from"`
+	To     time.Time  `json:"to"`
+	Total  int        `json:"total"`
+	Matrix [7][24]int `json:"matrix"`
+}
+
+// Occupancy описывает текущее количество людей в зале.
+type Occupancy struct {
+	Members      int       `json:"members"`       // Клиенты по абонементам, не отметившие уход
+	SingleVisits int       `json:"single_visits"` // Разовые посещения за последние часы
+	Total        int       `json:"total"`
+	At           time.Time `json:"at"`
+}
+
+// DailyIncome описывает выручку за один день: проданные абонементы и разовые посещения.
+type DailyIncome struct {
+	Date                time.Time `json:"date"`
+	SubscriptionsSold   int       `json:"subscriptions_sold"`
+	SubscriptionsIncome float64   `json:"subscriptions_income"`
+	SingleVisitsSold    int       `json:"single_visits_sold"`
+	SingleVisitsIncome  float64   `json:"single_visits_income"`
+	Total               float64   `json:"total"`
+}
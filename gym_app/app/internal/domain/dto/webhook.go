@@ -0,0 +1,77 @@
+package dto
+
+import "time"
+
+// Типы событий для вебхуков
+const (
+	EventPersonCreated        = "person.created"
+	EventSubscriptionSold     = "subscription.sold"
+	EventSubscriptionFrozen   = "subscription.frozen"
+	EventSubscriptionUnfrozen = "subscription.unfrozen"
+	EventSubscriptionExpired  = "subscription.expired"
+	EventSingleVisitAdded     = "single_visit.added"
+)
+
+// WebhookEvents — все события, на которые можно подписаться
+var WebhookEvents = []string{
+	EventPersonCreated,
+	EventSubscriptionSold,
+	EventSubscriptionFrozen,
+	EventSubscriptionUnfrozen,
+	EventSubscriptionExpired,
+	EventSingleVisitAdded,
+}
+
+// Статусы доставки вебхука
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+type WebhookInput struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret,omitempty"` // Если пусто — сгенерируем
+}
+
+// DeliveryResult — итог попытки доставки
+type DeliveryResult struct {
+	StatusCode  int
+	Error       string
+	NextAttempt time.Time
+	Final       bool // Попытки закончились, доставка помечается failed
+}
+
+// PersonCreatedEvent — данные события person.created
+type PersonCreatedEvent struct {
+	PersonID int    `json:"person_id"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+}
+
+// SubscriptionSoldEvent — данные события subscription.sold
+type SubscriptionSoldEvent struct {
+	Number         string    `json:"number"`
+	PersonID       int       `json:"person_id"`
+	SubscriptionID int       `json:"subscription_id"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	Price          float64   `json:"price"`
+	Discount       float64   `json:"discount"`
+	FinalPrice     float64   `json:"final_price"`
+}
+
+// SubscriptionStatusEvent — данные событий заморозки, разморозки и окончания абонемента
+type SubscriptionStatusEvent struct {
+	Number string    `json:"number"`
+	Date   time.Time `json:"date"`
+}
+
+// SingleVisitAddedEvent — данные события single_visit.added
+type SingleVisitAddedEvent struct {
+	ID         int       `json:"id"`
+	VisitDate  time.Time `json:"visit_date"`
+	TariffID   *int      `json:"tariff_id,omitempty"`
+	FinalPrice float64   `json:"final_price"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// AccessDevice — контроллер турникета
+type AccessDevice struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Token      string     `json:"token,omitempty"` // Отдаем только при создании
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// AccessCard — карта клиента для прохода через турникет
+type AccessCard struct {
+	CardNumber string    `json:"card_number"`
+	PersonID   int       `json:"person_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AccessLogEntry — попытка прохода через турникет
+type AccessLogEntry struct {
+	ID                 int64     `json:"id"`
+	DeviceID           *int      `json:"device_id,omitempty"`
+	Credential         string    `json:"credential"`
+	Direction          string    `json:"direction"`
+	Allowed            bool      `json:"allowed"`
+	Reason             string    `json:"reason"`
+	SubscriptionNumber string    `json:"subscription_number,omitempty"`
+	VisitID            *int      `json:"visit_id,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// MemberAccount — аккаунт SSO, привязанный к карточке клиента
+type MemberAccount struct {
+	UserID    int64     `json:"user_id"`
+	PersonID  int       `json:"person_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FreezeRequest — заявка клиента на заморозку абонемента
+type FreezeRequest struct {
+	ID                 int        `json:"id"`
+	SubscriptionNumber string     `json:"subscription_number"`
+	PersonID           int        `json:"person_id"`
+	PersonName         string     `json:"person_name"`
+	FreezeStart        time.Time  `json:"freeze_start"`
+	Comment            string     `json:"comment,omitempty"`
+	Status             string     `json:"status"` // pending / approved / rejected
+	CreatedAt          time.Time  `json:"created_at"`
+	DecidedAt          *time.Time `json:"decided_at,omitempty"`
+}
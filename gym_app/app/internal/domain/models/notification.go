@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PersonContacts — контакты клиента для уведомлений
+type PersonContacts struct {
+	PersonID       int        `json:"person_id"`
+	Email          string     `json:"email,omitempty"`
+	TelegramChatID *int64     `json:"telegram_chat_id,omitempty"`
+	BirthDate      *time.Time `json:"birth_date,omitempty"`
+	OptedOut       bool       `json:"opted_out"` // Клиент отказался от уведомлений
+}
+
+// Notification — запись журнала доставки уведомления
+type Notification struct {
+	ID        int       `json:"id"`
+	PersonID  int       `json:"person_id"`
+	Kind      string    `json:"kind"`    // expiry / birthday
+	Ref       string    `json:"ref"`     // Номер абонемента или год поздравления
+	Channel   string    `json:"channel"` // sms / email / telegram
+	Recipient string    `json:"recipient"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status"` // sent / failed
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
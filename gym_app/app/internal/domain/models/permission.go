@@ -0,0 +1,52 @@
+package models
+
+// Права сотрудников. Роли SSO сопоставляются с правами в таблице role_permissions,
+// права приходят в access-токене
+const (
+	// PermissionAll дает все права, есть у роли admin
+	PermissionAll = "*"
+
+	PermissionPeopleRead   = "people:read"
+	PermissionPeopleWrite  = "people:write"
+	PermissionPeopleDelete = "people:delete"
+
+	PermissionSubscriptionsRead   = "subscriptions:read"
+	PermissionSubscriptionsManage = "subscriptions:manage"
+
+	PermissionPersonSubRead   = "person_sub:read"
+	PermissionPersonSubCreate = "person_sub:create"
+	PermissionPersonSubDelete = "person_sub:delete"
+
+	PermissionFreezeRead  = "freeze:read"
+	PermissionFreezeWrite = "freeze:write"
+
+	PermissionSingleVisitRead   = "single_visit:read"
+	PermissionSingleVisitCreate = "single_visit:create"
+	PermissionSingleVisitDelete = "single_visit:delete"
+
+	PermissionTariffsRead   = "tariffs:read"
+	PermissionTariffsManage = "tariffs:manage"
+
+	PermissionVisitsRead  = "visits:read"
+	PermissionVisitsWrite = "visits:write"
+
+	PermissionDataExport = "data:export"
+	PermissionDataImport = "data:import"
+
+	PermissionDocumentsRead = "documents:read"
+
+	PermissionNotificationsRead   = "notifications:read"
+	PermissionNotificationsManage = "notifications:manage"
+
+	PermissionMemberAccountsManage = "member_accounts:manage"
+	PermissionWebhooksManage       = "webhooks:manage"
+	PermissionStatisticsRead       = "statistics:read"
+
+	PermissionAccessRead   = "access:read"
+	PermissionAccessManage = "access:manage"
+
+	// Проверяются и в SSO, в gym_app — чтобы не ходить в SSO без прав
+	PermissionUsersRead   = "users:read"
+	PermissionRolesManage = "roles:manage"
+	PermissionUsersUnlock = "users:unlock"
+)
@@ -3,7 +3,10 @@ package models
 import "time"
 
 type SingleVisit struct {
-	Id         int       `json:"id"`
-	VisitDate  time.Time `json:"visit_date"`
-	FinalPrice float64   `json:"final_price"`
+	Id          int        `json:"id"`
+	VisitDate   time.Time  `json:"visit_date"`
+	VisitedAt   *time.Time `json:"visited_at,omitempty"` // Время прихода, если посещение оформлено в тот же день
+	FinalPrice  float64    `json:"final_price"`
+	TariffID    *int       `json:"tariff_id,omitempty"`
+	TariffTitle string     `json:"tariff_title,omitempty"`
 }
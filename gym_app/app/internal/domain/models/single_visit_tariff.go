@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// SingleVisitTariff представляет тариф разового посещения
+type SingleVisitTariff struct {
+	ID           int      `json:"id,omitempty"`
+	Title        string   `json:"title"`                   // Название тарифа
+	Price        float64  `json:"price"`                   // Цена в будние дни
+	WeekendPrice *float64 `json:"weekend_price,omitempty"` // Цена в выходные, если отличается
+}
+
+// PriceFor возвращает цену посещения по тарифу на указанную дату
+func (t SingleVisitTariff) PriceFor(date time.Time) float64 {
+	if t.WeekendPrice != nil {
+		if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			return *t.WeekendPrice
+		}
+	}
+
+	return t.Price
+}
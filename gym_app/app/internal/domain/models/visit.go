@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Visit — отметка прихода клиента по абонементу
+type Visit struct {
+	ID                 int        `json:"id"`
+	SubscriptionNumber string     `json:"subscription_number"` // Связь с PersonSubscription.Number
+	PersonID           int        `json:"person_id"`
+	PersonName         string     `json:"person_name"`
+	CheckedInAt        time.Time  `json:"checked_in_at"`
+	CheckedOutAt       *time.Time `json:"checked_out_at"` // nil, пока клиент в зале
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Handler обрабатывает событие. Ошибки обработчик логирует сам: публикующий сервис
+// уже сохранил изменение и не должен из-за них отвечать ошибкой.
+type Handler func(ctx context.Context, event Event)
+
+// On оборачивает обработчик конкретного типа события; остальные события он пропускает
+func On[E Event](handle func(ctx context.Context, event E)) Handler {
+	return func(ctx context.Context, event Event) {
+		if e, ok := event.(E); ok {
+			handle(ctx, e)
+		}
+	}
+}
+
+// Bus — внутренняя шина событий. Синхронные обработчики выполняются до возврата из Publish
+// (инвалидация кэша должна закончиться раньше ответа клиенту), асинхронные — в отдельных горутинах.
+type Bus struct {
+	log           *slog.Logger
+	mu            sync.RWMutex
+	handlers      []Handler
+	asyncHandlers []Handler
+	wg            sync.WaitGroup
+}
+
+func NewBus(log *slog.Logger) *Bus {
+	return &Bus{log: log}
+}
+
+// Subscribe добавляет обработчик, который выполняется внутри Publish
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// SubscribeAsync добавляет обработчик для медленных действий (отправка сообщений).
+// Он получает контекст без отмены: запрос, опубликовавший событие, может уже завершиться.
+func (b *Bus) SubscribeAsync(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.asyncHandlers = append(b.asyncHandlers, h)
+}
+
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers, asyncHandlers := b.handlers, b.asyncHandlers
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		b.call(ctx, h, event)
+	}
+
+	if len(asyncHandlers) == 0 {
+		return
+	}
+	detached := context.WithoutCancel(ctx)
+	for _, h := range asyncHandlers {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.call(detached, h, event)
+		}()
+	}
+}
+
+// Wait дожидается асинхронных обработчиков. Вызывать при остановке приложения.
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
+
+// call не дает панике в подписчике уронить запрос, который опубликовал событие
+func (b *Bus) call(ctx context.Context, h Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.Error("event handler panicked",
+				slog.String("event", event.EventName()),
+				slog.String("panic", fmt.Sprint(r)),
+			)
+		}
+	}()
+	h(ctx, event)
+}
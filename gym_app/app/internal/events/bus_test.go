@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishFiltersByType(t *testing.T) {
+	bus := NewBus(slogdiscard.NewDiscardLogger())
+
+	var created []int
+	var all []string
+	bus.Subscribe(On(func(_ context.Context, e PersonCreated) {
+		created = append(created, e.PersonID)
+	}))
+	bus.Subscribe(func(_ context.Context, e Event) {
+		all = append(all, e.EventName())
+	})
+
+	bus.Publish(context.Background(), PersonCreated{PersonID: 1})
+	bus.Publish(context.Background(), PersonDeleted{PersonID: 1})
+
+	assert.Equal(t, []int{1}, created)
+	assert.Equal(t, []string{"person.created", "person.deleted"}, all)
+}
+
+func TestBus_HandlerPanicDoesNotStopOthers(t *testing.T) {
+	bus := NewBus(slogdiscard.NewDiscardLogger())
+
+	var called atomic.Int32
+	bus.Subscribe(func(context.Context, Event) { panic("boom") })
+	bus.Subscribe(func(context.Context, Event) { called.Add(1) })
+	bus.SubscribeAsync(func(context.Context, Event) { panic("boom") })
+	bus.SubscribeAsync(func(context.Context, Event) { called.Add(1) })
+
+	assert.NotPanics(t, func() {
+		bus.Publish(context.Background(), SingleVisitDeleted{ID: 1})
+	})
+	bus.Wait()
+
+	assert.Equal(t, int32(2), called.Load())
+}
+
+func TestBus_AsyncGetsDetachedContext(t *testing.T) {
+	bus := NewBus(slogdiscard.NewDiscardLogger())
+
+	var ctxErr atomic.Value
+	bus.SubscribeAsync(func(ctx context.Context, _ Event) {
+		ctxErr.Store(ctx.Err() == nil)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bus.Publish(ctx, SubscriptionFrozen{Number: "A-1"})
+	bus.Wait()
+
+	assert.Equal(t, true, ctxErr.Load())
+}
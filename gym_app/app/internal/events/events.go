@@ -0,0 +1,113 @@
+package events
+
+import "time"
+
+// Event — доменное событие. Сервисы публикуют события после успешного изменения,
+// а кэш, аудит и уведомления подписываются на них через Bus.
+type Event interface {
+	EventName() string
+}
+
+// PersonCreated — добавлен клиент
+type PersonCreated struct {
+	PersonID int
+	Name     string
+	Phone    string
+}
+
+// PersonUpdated — изменены ФИО или телефон клиента
+type PersonUpdated struct {
+	PersonID int
+	Name     string
+	Phone    string
+}
+
+// PersonDeleted — клиент удален вместе с абонементами
+type PersonDeleted struct {
+	PersonID int
+}
+
+// SubscriptionSold — клиенту продан абонемент
+type SubscriptionSold struct {
+	Number         string
+	PersonID       int
+	SubscriptionID int
+	StartDate      time.Time
+	EndDate        time.Time
+	FinalPrice     float64
+}
+
+// SubscriptionDeleted — продажа абонемента удалена
+type SubscriptionDeleted struct {
+	Number   string
+	PersonID int
+}
+
+// SubscriptionStatusChanged — статус абонемента пересчитан по датам
+type SubscriptionStatusChanged struct {
+	Number   string
+	PersonID int
+	From     string
+	To       string
+}
+
+// SubscriptionFrozen — абонемент заморожен
+type SubscriptionFrozen struct {
+	Number string
+	Date   time.Time
+}
+
+// SubscriptionUnfrozen — абонемент разморожен
+type SubscriptionUnfrozen struct {
+	Number string
+	Date   time.Time
+}
+
+// SingleVisitAdded — продано разовое посещение
+type SingleVisitAdded struct {
+	ID         int
+	VisitDate  time.Time
+	TariffID   int
+	FinalPrice float64
+}
+
+// SingleVisitDeleted — разовое посещение удалено
+type SingleVisitDeleted struct {
+	ID int
+}
+
+// VisitCheckedIn — клиент пришел по абонементу
+type VisitCheckedIn struct {
+	VisitID            int
+	SubscriptionNumber string
+	PersonID           int
+	PersonName         string
+	At                 time.Time
+}
+
+// VisitCheckedOut — клиент ушел
+type VisitCheckedOut struct {
+	VisitID            int
+	SubscriptionNumber string
+	At                 time.Time
+}
+
+// DataImported — загружены клиенты или абонементы из файла
+type DataImported struct {
+	Kind  string // people / person_subs
+	Count int
+}
+
+func (PersonCreated) EventName() string             { return "person.created" }
+func (PersonUpdated) EventName() string             { return "person.updated" }
+func (PersonDeleted) EventName() string             { return "person.deleted" }
+func (SubscriptionSold) EventName() string          { return "subscription.sold" }
+func (SubscriptionDeleted) EventName() string       { return "subscription.deleted" }
+func (SubscriptionStatusChanged) EventName() string { return "subscription.status_changed" }
+func (SubscriptionFrozen) EventName() string        { return "subscription.frozen" }
+func (SubscriptionUnfrozen) EventName() string      { return "subscription.unfrozen" }
+func (SingleVisitAdded) EventName() string          { return "single_visit.added" }
+func (SingleVisitDeleted) EventName() string        { return "single_visit.deleted" }
+func (VisitCheckedIn) EventName() string            { return "visit.checked_in" }
+func (VisitCheckedOut) EventName() string           { return "visit.checked_out" }
+func (DataImported) EventName() string              { return "data.imported" }
@@ -0,0 +1,427 @@
+package accessHandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	deviceMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/device"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	accessService "github.com/Muaz717/gym_app/app/internal/services/access"
+	"github.com/gin-gonic/gin"
+)
+
+type AccessService interface {
+	Check(ctx context.Context, deviceID int, input dto.AccessCheckInput) (dto.AccessDecision, error)
+	AllowList(ctx context.Context) (dto.AllowList, error)
+
+	CreateDevice(ctx context.Context, input dto.AccessDeviceInput) (models.AccessDevice, error)
+	FindDevices(ctx context.Context) ([]models.AccessDevice, error)
+	SetDeviceActive(ctx context.Context, id int, active bool) error
+	DeleteDevice(ctx context.Context, id int) error
+
+	AddCard(ctx context.Context, input dto.AccessCardInput) (models.AccessCard, error)
+	FindCards(ctx context.Context, personID int) ([]models.AccessCard, error)
+	DeleteCard(ctx context.Context, cardNumber string) error
+
+	FindLog(ctx context.Context, limit int) ([]models.AccessLogEntry, error)
+}
+
+type AccessHandler struct {
+	log           *slog.Logger
+	accessService AccessService
+}
+
+func New(
+	log *slog.Logger,
+	accessService AccessService,
+) *AccessHandler {
+	return &AccessHandler{
+		log:           log,
+		accessService: accessService,
+	}
+}
+
+// Check godoc
+// @Summary      Проверка прохода через турникет
+// @Description  Вызывается контроллером турникета с токеном устройства. Решение приходит всегда с кодом 200:
+// @Description  allowed и reason (ok, unknown_credential, not_started, expired, frozen, inactive, already_inside, unavailable).
+// @Description  При входе отмечается посещение, при выходе — уход клиента.
+// @Tags         access
+// @Accept       json
+// @Produce      json
+// @Param        input  body  dto.AccessCheckInput  true  "Номер карты или абонемента и направление (in/out)"
+// @Success      200   {object}  dto.AccessDecision
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      401   {object}  response.Response "Неизвестный контроллер"
+// @Router       /access/check [post]
+func (h *AccessHandler) Check(c *gin.Context) {
+	const op = "handlers.access.Check"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	device, ok := deviceMiddleware.GetDevice(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("device token required"))
+		return
+	}
+
+	var input dto.AccessCheckInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	decision, err := h.accessService.Check(c.Request.Context(), device.ID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, accessService.ErrInvalidCredential):
+			c.JSON(http.StatusBadRequest, response.Error(accessService.ErrInvalidCredential.Error()))
+		case errors.Is(err, accessService.ErrInvalidDirection):
+			c.JSON(http.StatusBadRequest, response.Error(accessService.ErrInvalidDirection.Error()))
+		default:
+			log.Error("failed to check access", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to check access"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, decision)
+}
+
+// AllowList godoc
+// @Summary      Офлайн-список пропусков
+// @Description  Номера карт и абонементов с периодом действия. Контроллер заменяет им свой список целиком
+// @Description  и пускает по нему, пока сервер недоступен.
+// @Tags         access
+// @Produce      json
+// @Success      200   {object}  dto.AllowList
+// @Failure      401   {object}  response.Response "Неизвестный контроллер"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access/allow_list [get]
+func (h *AccessHandler) AllowList(c *gin.Context) {
+	const op = "handlers.access.AllowList"
+
+	list, err := h.accessService.AllowList(c.Request.Context())
+	if err != nil {
+		h.log.Error("failed to build allow list", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to build allow list"))
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// FindDevices godoc
+// @Summary      Контроллеры турникетов
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Success      200   {object}  []models.AccessDevice
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_devices [get]
+func (h *AccessHandler) FindDevices(c *gin.Context) {
+	const op = "handlers.access.FindDevices"
+
+	devices, err := h.accessService.FindDevices(c.Request.Context())
+	if err != nil {
+		h.log.Error("failed to find access devices", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find access devices"))
+		return
+	}
+
+	c.JSON(http.StatusOK, devices)
+}
+
+// CreateDevice godoc
+// @Summary      Зарегистрировать контроллер турникета
+// @Description  Токен для заголовка Authorization возвращается только в этом ответе
+// @Security BearerAuth
+// @Tags         access
+// @Accept       json
+// @Produce      json
+// @Param        device  body  dto.AccessDeviceInput  true  "Название контроллера"
+// @Success      201   {object}  models.AccessDevice
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_devices [post]
+func (h *AccessHandler) CreateDevice(c *gin.Context) {
+	const op = "handlers.access.CreateDevice"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var input dto.AccessDeviceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	device, err := h.accessService.CreateDevice(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, accessService.ErrInvalidDeviceName) {
+			c.JSON(http.StatusBadRequest, response.Error(accessService.ErrInvalidDeviceName.Error()))
+			return
+		}
+		log.Error("failed to create access device", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to create access device"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, device)
+}
+
+// EnableDevice godoc
+// @Summary      Включить контроллер турникета
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Param        id  path  int  true  "ID контроллера"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Контроллер не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_devices/{id}/enable [post]
+func (h *AccessHandler) EnableDevice(c *gin.Context) {
+	h.setDeviceActive(c, true)
+}
+
+// DisableDevice godoc
+// @Summary      Выключить контроллер турникета
+// @Description  Токен выключенного контроллера перестает приниматься сразу
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Param        id  path  int  true  "ID контроллера"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Контроллер не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_devices/{id}/disable [post]
+func (h *AccessHandler) DisableDevice(c *gin.Context) {
+	h.setDeviceActive(c, false)
+}
+
+func (h *AccessHandler) setDeviceActive(c *gin.Context, active bool) {
+	const op = "handlers.access.setDeviceActive"
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid device ID"))
+		return
+	}
+
+	if err := h.accessService.SetDeviceActive(c.Request.Context(), id, active); err != nil {
+		if errors.Is(err, accessService.ErrDeviceNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("access device not found"))
+			return
+		}
+		h.log.Error("failed to update access device", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to update access device"))
+		return
+	}
+
+	if active {
+		c.JSON(http.StatusOK, response.OK("access device enabled"))
+		return
+	}
+	c.JSON(http.StatusOK, response.OK("access device disabled"))
+}
+
+// DeleteDevice godoc
+// @Summary      Удалить контроллер турникета
+// @Description  Журнал проходов сохраняется без ссылки на контроллер
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Param        id  path  int  true  "ID контроллера"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Контроллер не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_devices/{id} [delete]
+func (h *AccessHandler) DeleteDevice(c *gin.Context) {
+	const op = "handlers.access.DeleteDevice"
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid device ID"))
+		return
+	}
+
+	if err := h.accessService.DeleteDevice(c.Request.Context(), id); err != nil {
+		if errors.Is(err, accessService.ErrDeviceNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("access device not found"))
+			return
+		}
+		h.log.Error("failed to delete access device", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to delete access device"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("access device deleted"))
+}
+
+// FindCards godoc
+// @Summary      Карты клиента
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Param        person_id  query  int  true  "ID клиента"
+// @Success      200   {object}  []models.AccessCard
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_cards [get]
+func (h *AccessHandler) FindCards(c *gin.Context) {
+	const op = "handlers.access.FindCards"
+
+	personID, err := strconv.Atoi(c.Query("person_id"))
+	if err != nil || personID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error("invalid person ID"))
+		return
+	}
+
+	cards, err := h.accessService.FindCards(c.Request.Context(), personID)
+	if err != nil {
+		h.log.Error("failed to find access cards", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find access cards"))
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+// AddCard godoc
+// @Summary      Выдать карту клиенту
+// @Description  По карте клиент проходит по своему действующему абонементу
+// @Security BearerAuth
+// @Tags         access
+// @Accept       json
+// @Produce      json
+// @Param        card  body  dto.AccessCardInput  true  "Номер карты и ID клиента"
+// @Success      201   {object}  models.AccessCard
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Клиент не найден"
+// @Failure      409   {object}  response.Response "Карта уже выдана"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_cards [post]
+func (h *AccessHandler) AddCard(c *gin.Context) {
+	const op = "handlers.access.AddCard"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var input dto.AccessCardInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	card, err := h.accessService.AddCard(c.Request.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, accessService.ErrInvalidCard):
+			c.JSON(http.StatusBadRequest, response.Error(accessService.ErrInvalidCard.Error()))
+		case errors.Is(err, accessService.ErrPersonNotFound):
+			c.JSON(http.StatusNotFound, response.Error("person not found"))
+		case errors.Is(err, accessService.ErrCardExists):
+			c.JSON(http.StatusConflict, response.Error("card is already assigned"))
+		default:
+			log.Error("failed to add access card", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to add access card"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, card)
+}
+
+// DeleteCard godoc
+// @Summary      Заблокировать карту
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Param        card_number  path  string  true  "Номер карты"
+// @Success      200   {object}  response.Response
+// @Failure      404   {object}  response.Response "Карта не найдена"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_cards/{card_number} [delete]
+func (h *AccessHandler) DeleteCard(c *gin.Context) {
+	const op = "handlers.access.DeleteCard"
+
+	if err := h.accessService.DeleteCard(c.Request.Context(), c.Param("card_number")); err != nil {
+		if errors.Is(err, accessService.ErrCardNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("card not found"))
+			return
+		}
+		h.log.Error("failed to delete access card", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to delete access card"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("card deleted"))
+}
+
+// FindLog godoc
+// @Summary      Журнал проходов
+// @Security BearerAuth
+// @Tags         access
+// @Produce      json
+// @Param        limit  query  int  false  "Сколько последних записей вернуть (по умолчанию 100, максимум 1000)"
+// @Success      200   {object}  []models.AccessLogEntry
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /access_log [get]
+func (h *AccessHandler) FindLog(c *gin.Context) {
+	const op = "handlers.access.FindLog"
+
+	var limit int
+	if v := c.Query("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error("limit must be a positive number"))
+			return
+		}
+	}
+
+	entries, err := h.accessService.FindLog(c.Request.Context(), limit)
+	if err != nil {
+		h.log.Error("failed to find access log", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find access log"))
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
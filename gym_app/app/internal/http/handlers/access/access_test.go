@@ -0,0 +1,424 @@
+package accessHandler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	accessHandler "github.com/Muaz717/gym_app/app/internal/http/handlers/access"
+	deviceMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/device"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	accessService "github.com/Muaz717/gym_app/app/internal/services/access"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// controller имитирует контроллер турникета: спрашивает сервер, а если тот не ответил
+// или ответил unavailable — решает по последнему скачанному офлайн-списку
+type controller struct {
+	baseURL   string
+	token     string
+	client    *http.Client
+	allowList []dto.AllowListEntry
+}
+
+type swipeResult struct {
+	Allowed bool
+	Reason  string
+	Offline bool
+}
+
+func (c *controller) sync(t *testing.T) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/access/allow_list", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list dto.AllowList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	c.allowList = list.Entries
+}
+
+func (c *controller) swipe(credential, direction string, today time.Time) swipeResult {
+	body, _ := json.Marshal(dto.AccessCheckInput{Credential: credential, Direction: direction})
+
+	req, _ := http.NewRequest(http.MethodPost, c.baseURL+"/access/check", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err == nil {
+		defer resp.Body.Close()
+
+		var decision dto.AccessDecision
+		if resp.StatusCode == http.StatusOK && json.NewDecoder(resp.Body).Decode(&decision) == nil &&
+			decision.Reason != dto.AccessReasonUnavailable {
+			return swipeResult{Allowed: decision.Allowed, Reason: decision.Reason}
+		}
+	}
+
+	day := today.Format(time.DateOnly)
+	for _, e := range c.allowList {
+		if e.Credential == credential && e.ValidFrom.Format(time.DateOnly) <= day && e.ValidUntil.Format(time.DateOnly) >= day {
+			return swipeResult{Allowed: true, Reason: dto.AccessReasonOK, Offline: true}
+		}
+	}
+	return swipeResult{Allowed: false, Reason: dto.AccessReasonUnknown, Offline: true}
+}
+
+// memStorage — хранилище в памяти с теми же правилами, что и запросы postgres
+type memStorage struct {
+	mu       sync.Mutex
+	delay    time.Duration
+	devices  map[string]models.AccessDevice
+	cards    map[string]int
+	subs     map[string]dto.AccessCredential
+	open     map[string]models.Visit
+	visitSeq int
+	log      []models.AccessLogEntry
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		devices: make(map[string]models.AccessDevice),
+		cards:   make(map[string]int),
+		subs:    make(map[string]dto.AccessCredential),
+		open:    make(map[string]models.Visit),
+	}
+}
+
+func (m *memStorage) wait(ctx context.Context) error {
+	if m.delay == 0 {
+		return nil
+	}
+	select {
+	case <-time.After(m.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *memStorage) SaveAccessDevice(_ context.Context, name, tokenHash string) (models.AccessDevice, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d := models.AccessDevice{ID: len(m.devices) + 1, Name: name, Active: true, CreatedAt: time.Now()}
+	m.devices[tokenHash] = d
+	return d, nil
+}
+
+func (m *memStorage) FindAccessDevices(context.Context) ([]models.AccessDevice, error) {
+	return nil, nil
+}
+
+func (m *memStorage) SetAccessDeviceActive(_ context.Context, id int, active bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, d := range m.devices {
+		if d.ID == id {
+			d.Active = active
+			m.devices[hash] = d
+			return nil
+		}
+	}
+	return storage.ErrAccessDeviceNotFound
+}
+
+func (m *memStorage) DeleteAccessDevice(context.Context, int) error {
+	return nil
+}
+
+func (m *memStorage) AccessDeviceByToken(_ context.Context, tokenHash string) (models.AccessDevice, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d, ok := m.devices[tokenHash]
+	if !ok || !d.Active {
+		return models.AccessDevice{}, storage.ErrAccessDeviceNotFound
+	}
+	return d, nil
+}
+
+func (m *memStorage) SaveAccessCard(_ context.Context, card models.AccessCard) (models.AccessCard, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.cards[card.CardNumber]; ok {
+		return models.AccessCard{}, storage.ErrAccessCardExists
+	}
+	m.cards[card.CardNumber] = card.PersonID
+	return card, nil
+}
+
+func (m *memStorage) FindAccessCards(context.Context, int) ([]models.AccessCard, error) {
+	return nil, nil
+}
+
+func (m *memStorage) DeleteAccessCard(context.Context, string) error {
+	return nil
+}
+
+func (m *memStorage) FindAccessCredential(ctx context.Context, credential string) (dto.AccessCredential, error) {
+	if err := m.wait(ctx); err != nil {
+		return dto.AccessCredential{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.subs[credential]; ok {
+		return c, nil
+	}
+	if personID, ok := m.cards[credential]; ok {
+		for _, c := range m.subs {
+			if c.PersonID == personID {
+				return c, nil
+			}
+		}
+	}
+	return dto.AccessCredential{}, storage.ErrCredentialNotFound
+}
+
+func (m *memStorage) EnterVisit(_ context.Context, number string, antiPassback bool) (models.Visit, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if v, ok := m.open[number]; ok {
+		if antiPassback {
+			return models.Visit{}, false, storage.ErrAlreadyCheckedIn
+		}
+		return v, false, nil
+	}
+	m.visitSeq++
+	v := models.Visit{ID: m.visitSeq, SubscriptionNumber: number, CheckedInAt: time.Now()}
+	m.open[number] = v
+	return v, true, nil
+}
+
+func (m *memStorage) CheckOut(_ context.Context, number string) (models.Visit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.open[number]
+	if !ok {
+		return models.Visit{}, storage.ErrVisitNotFound
+	}
+	delete(m.open, number)
+	now := time.Now()
+	v.CheckedOutAt = &now
+	return v, nil
+}
+
+func (m *memStorage) SaveAccessLog(_ context.Context, entry models.AccessLogEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.log = append(m.log, entry)
+	return nil
+}
+
+func (m *memStorage) FindAccessLog(context.Context, int) ([]models.AccessLogEntry, error) {
+	return nil, nil
+}
+
+func (m *memStorage) AllowList(context.Context) ([]dto.AllowListEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]dto.AllowListEntry, 0)
+	for number, c := range m.subs {
+		if c.Status != "active" {
+			continue
+		}
+		entries = append(entries, dto.AllowListEntry{Credential: number, SubscriptionNumber: number, ValidFrom: c.StartDate, ValidUntil: c.EndDate})
+		for card, personID := range m.cards {
+			if personID == c.PersonID {
+				entries = append(entries, dto.AllowListEntry{Credential: card, SubscriptionNumber: number, ValidFrom: c.StartDate, ValidUntil: c.EndDate})
+			}
+		}
+	}
+	return entries, nil
+}
+
+type testEnv struct {
+	server     *httptest.Server
+	storage    *memStorage
+	service    *accessService.AccessService
+	controller *controller
+	today      time.Time
+}
+
+func setup(t *testing.T, cfg config.AccessControl) *testEnv {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := slogdiscard.NewDiscardLogger()
+	st := newMemStorage()
+	srv := accessService.New(log, st, events.NewBus(log), cfg)
+
+	device, err := srv.CreateDevice(context.Background(), dto.AccessDeviceInput{Name: "Главный вход"})
+	require.NoError(t, err)
+	require.NotEmpty(t, device.Token)
+
+	today := time.Now()
+	day := func(offset int) time.Time {
+		y, m, d := today.AddDate(0, 0, offset).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	st.subs["A-100"] = dto.AccessCredential{SubscriptionNumber: "A-100", PersonID: 1, PersonName: "Иван Иванов", Status: "active", StartDate: day(-10), EndDate: day(20)}
+	st.subs["A-200"] = dto.AccessCredential{SubscriptionNumber: "A-200", PersonID: 2, PersonName: "Петр Петров", Status: "expired", StartDate: day(-40), EndDate: day(-10)}
+	st.subs["A-300"] = dto.AccessCredential{SubscriptionNumber: "A-300", PersonID: 3, PersonName: "Анна Смирнова", Status: "frozen", StartDate: day(-5), EndDate: day(25)}
+	st.subs["A-400"] = dto.AccessCredential{SubscriptionNumber: "A-400", PersonID: 4, PersonName: "Олег Сидоров", Status: "active", StartDate: day(3), EndDate: day(33)}
+	_, err = srv.AddCard(context.Background(), dto.AccessCardInput{CardNumber: "CARD-1", PersonID: 1})
+	require.NoError(t, err)
+
+	h := accessHandler.New(log, srv)
+	r := gin.New()
+	api := r.Group("/access", deviceMiddleware.DeviceAuth(log, srv))
+	api.POST("/check", h.Check)
+	api.GET("/allow_list", h.AllowList)
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return &testEnv{
+		server:  server,
+		storage: st,
+		service: srv,
+		controller: &controller{
+			baseURL: server.URL,
+			token:   device.Token,
+			client:  &http.Client{Timeout: time.Second},
+		},
+		today: today,
+	}
+}
+
+func TestTurnstile_Online(t *testing.T) {
+	env := setup(t, config.AccessControl{Timeout: time.Second})
+
+	tests := []struct {
+		name       string
+		credential string
+		allowed    bool
+		reason     string
+	}{
+		{name: "Active subscription", credential: "A-100", allowed: true, reason: dto.AccessReasonOK},
+		{name: "Card of the same client", credential: "CARD-1", allowed: true, reason: dto.AccessReasonOK},
+		{name: "Expired", credential: "A-200", allowed: false, reason: dto.AccessReasonExpired},
+		{name: "Frozen", credential: "A-300", allowed: false, reason: dto.AccessReasonFrozen},
+		{name: "Not started", credential: "A-400", allowed: false, reason: dto.AccessReasonNotStarted},
+		{name: "Unknown card", credential: "NOPE", allowed: false, reason: dto.AccessReasonUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := env.controller.swipe(tt.credential, dto.AccessIn, env.today)
+			assert.False(t, res.Offline)
+			assert.Equal(t, tt.allowed, res.Allowed)
+			assert.Equal(t, tt.reason, res.Reason)
+		})
+	}
+
+	// Вход по карте после входа по абонементу — то же открытое посещение, без anti-passback пускаем
+	assert.Len(t, env.storage.open, 1)
+	assert.Len(t, env.storage.log, len(tests))
+
+	res := env.controller.swipe("A-100", dto.AccessOut, env.today)
+	assert.True(t, res.Allowed)
+	assert.Empty(t, env.storage.open, "exit must close the visit")
+}
+
+func TestTurnstile_AntiPassback(t *testing.T) {
+	env := setup(t, config.AccessControl{Timeout: time.Second, AntiPassback: true})
+
+	assert.True(t, env.controller.swipe("A-100", dto.AccessIn, env.today).Allowed)
+
+	res := env.controller.swipe("CARD-1", dto.AccessIn, env.today)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, dto.AccessReasonAlreadyInside, res.Reason)
+
+	assert.True(t, env.controller.swipe("A-100", dto.AccessOut, env.today).Allowed)
+	assert.True(t, env.controller.swipe("CARD-1", dto.AccessIn, env.today).Allowed)
+}
+
+func TestTurnstile_Unauthorized(t *testing.T) {
+	env := setup(t, config.AccessControl{Timeout: time.Second})
+
+	for _, token := range []string{"", "wrong"} {
+		t.Run(fmt.Sprintf("token %q", token), func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, env.server.URL+"/access/check", bytes.NewBufferString(`{"credential":"A-100"}`))
+			require.NoError(t, err)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+	assert.Empty(t, env.storage.open)
+}
+
+func TestTurnstile_SlowDatabase(t *testing.T) {
+	env := setup(t, config.AccessControl{Timeout: 50 * time.Millisecond})
+	env.controller.sync(t)
+	env.storage.delay = time.Second
+
+	start := time.Now()
+	res := env.controller.swipe("A-100", dto.AccessIn, env.today)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "decision must respect the latency budget")
+
+	// Сервер ответил unavailable, контроллер пустил по офлайн-списку
+	assert.True(t, res.Allowed)
+	assert.True(t, res.Offline)
+	require.NotEmpty(t, env.storage.log)
+	assert.Equal(t, dto.AccessReasonUnavailable, env.storage.log[len(env.storage.log)-1].Reason)
+}
+
+func TestTurnstile_Offline(t *testing.T) {
+	env := setup(t, config.AccessControl{Timeout: time.Second})
+	env.controller.sync(t)
+	env.server.Close()
+
+	tests := []struct {
+		credential string
+		allowed    bool
+	}{
+		{credential: "A-100", allowed: true},
+		{credential: "CARD-1", allowed: true},
+		{credential: "A-200", allowed: false},
+		{credential: "A-300", allowed: false},
+		{credential: "A-400", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.credential, func(t *testing.T) {
+			res := env.controller.swipe(tt.credential, dto.AccessIn, env.today)
+			assert.True(t, res.Offline)
+			assert.Equal(t, tt.allowed, res.Allowed)
+		})
+	}
+}
@@ -2,21 +2,37 @@ package authHandler
 
 import (
 	"context"
+	"errors"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	authMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/auth"
 	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
 	"github.com/Muaz717/gym_app/app/internal/lib/grpcerrors"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
 )
 
 type AuthService interface {
-	Login(ctx context.Context, email, password string) (string, error)
-	RegisterNewUser(ctx context.Context, email, password string) (int64, error)
+	Login(ctx context.Context, email, password, device, ip string) (dto.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (dto.AuthTokens, error)
+	RegisterNewUser(ctx context.Context, email, password, inviteCode string) (int64, error)
 	CheckToken(ctx context.Context, token string) (dto.User, error)
+	Logout(ctx context.Context, token string, all bool) error
+	ChangePassword(ctx context.Context, token, currentPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerificationEmail(ctx context.Context, email string) error
+	LoginTwoFactor(ctx context.Context, challenge, code, device, ip string) (dto.AuthTokens, error)
+	EnrollTOTP(ctx context.Context, token string) (dto.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, token, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, token, code string) error
 }
 
 type AuthHandler struct {
@@ -43,8 +59,10 @@ func New(
 // @Produce json
 // @Param login body models.LoginRequest true "Login"
 // @Success 200 {object} response.Response "Login successful"
+// @Success 200 {object} dto.TwoFactorChallenge "2FA enabled: finish with /auth/login/2fa"
 // @Failure 400 {object} response.Response "Bad request"
 // @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 429 {object} response.Response "Too many failed attempts, see Retry-After"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -59,10 +77,18 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error("failed to bind json", slog.String("op", op), sl.Error(err))
 		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
 	}
 
-	token, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
+	tokens, err := h.authService.Login(c.Request.Context(), req.Email, req.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
+		// SSO временно блокирует вход после серии неудачных попыток
+		if status.Code(err) == codes.ResourceExhausted {
+			log.Warn("login locked", sl.Error(err))
+			tooManyAttempts(c, err)
+			return
+		}
+
 		log.Error("failed to login", slog.String("op", op), sl.Error(err))
 
 		prettyErr := grpcerrors.ParseValidationError(err)
@@ -70,12 +96,93 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if tokens.TwoFactorChallenge != "" {
+		log.Info("second factor required")
+
+		c.JSON(http.StatusOK, dto.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			Challenge:         tokens.TwoFactorChallenge,
+		})
+		return
+	}
+
 	log.Info("login successful")
 
-	c.SetCookie("token", token, 360000, "/", "", false, true)
+	authMiddleware.SetTokenCookies(c, tokens)
 	c.JSON(http.StatusOK, response.OK("login successful"))
 }
 
+// LoginTwoFactor godoc
+// @Summary Login, second step
+// @Description Завершает вход с 2FA: challenge из ответа /auth/login и код из приложения или резервный код.
+// @Description После неверного кода вход начинается заново с пароля
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.LoginTwoFactorRequest true "Challenge и код"
+// @Success 200 {object} response.Response "Login successful"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 429 {object} response.Response "Too many failed attempts, see Retry-After"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	const op = "handlers.auth.loginTwoFactor"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	tokens, err := h.authService.LoginTwoFactor(
+		c.Request.Context(), req.Challenge, req.Code, c.Request.UserAgent(), c.ClientIP(),
+	)
+	if err != nil {
+		h.ssoError(c, log, "failed to login", err)
+		return
+	}
+
+	log.Info("login successful")
+
+	authMiddleware.SetTokenCookies(c, tokens)
+	c.JSON(http.StatusOK, response.OK("login successful"))
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Выдает новую пару токенов по refresh-cookie. Повторное использование refresh-токена завершает сессию
+// @Tags auth
+// @Produce json
+// @Success 200 {object} response.Response "Token refreshed"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	const op = "handlers.auth.refresh"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	if _, err := h.refresh(c); err != nil {
+		if errors.Is(err, errNoRefreshToken) || status.Code(err) == codes.Unauthenticated {
+			c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+			return
+		}
+
+		log.Error("failed to refresh token", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to refresh token"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("token refreshed"))
+}
+
 // RegisterNewUser godoc
 // @Summary Register new user
 // @Description Register new user
@@ -85,7 +192,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce json
 // @Param register body models.RegisterRequest true "Register"
 // @Success 200 {object} response.Response "User registered successfully"
-// @Failure 400 {object} response.Response "Bad request"
+// @Failure 400 {object} response.Response "Bad request or invalid invite"
 // @Failure 409 {object} response.Response "Conflict"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /auth/register [post]
@@ -104,8 +211,13 @@ func (h *AuthHandler) RegisterNewUser(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.authService.RegisterNewUser(c.Request.Context(), req.Email, req.Password)
+	userID, err := h.authService.RegisterNewUser(c.Request.Context(), req.Email, req.Password, req.InviteCode)
 	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, response.Error(grpcerrors.ParseValidationError(err)))
+			return
+		}
+
 		log.Error("failed to register new user", slog.String("op", op), sl.Error(err))
 
 		prettyErr := grpcerrors.ParseValidationError(err)
@@ -118,6 +230,65 @@ func (h *AuthHandler) RegisterNewUser(c *gin.Context) {
 	c.JSON(http.StatusOK, response.OK(strconv.Itoa(int(userID))))
 }
 
+// Logout godoc
+// @Summary Logout
+// @Description Отзывает текущий токен, с all=true — все сессии пользователя
+// @Tags auth
+// @Produce json
+// @Param all query bool false "Выйти со всех устройств"
+// @Success 200 {object} response.Response "Logout successful"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	const op = "handlers.auth.logout"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	all := false
+	if raw := c.Query("all"); raw != "" {
+		var err error
+		all, err = strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("invalid all parameter"))
+			return
+		}
+	}
+
+	// Access-токен нужен SSO, чтобы найти сессию. Если cookie уже истекла, получаем новый по refresh-токену
+	token, err := c.Cookie(authMiddleware.AccessTokenCookie)
+	if err != nil || token == "" {
+		tokens, err := h.refresh(c)
+		if err != nil {
+			authMiddleware.ClearTokenCookies(c)
+			c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+			return
+		}
+		token = tokens.AccessToken
+	}
+
+	// Cookie удаляем в любом случае: даже если SSO недоступен, клиент должен выйти
+	authMiddleware.ClearTokenCookies(c)
+
+	if err := h.authService.Logout(c.Request.Context(), token, all); err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			c.JSON(http.StatusUnauthorized, response.Error("invalid token"))
+			return
+		}
+
+		log.Error("failed to logout", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to logout"))
+		return
+	}
+
+	log.Info("logout successful")
+
+	c.JSON(http.StatusOK, response.OK("logout successful"))
+}
+
 // Me godoc
 // @Summary Get current user info
 // @Description Returns info about the authenticated user
@@ -128,14 +299,26 @@ func (h *AuthHandler) RegisterNewUser(c *gin.Context) {
 // @Router /auth/me [get]
 // @Security BearerAuth
 func (h *AuthHandler) Me(c *gin.Context) {
-	cookie, err := c.Request.Cookie("token")
-	if err != nil || cookie.Value == "" {
-		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
-		return
+	token, _ := c.Cookie(authMiddleware.AccessTokenCookie)
+
+	var (
+		user dto.User
+		err  error
+	)
+	if token != "" {
+		user, err = h.authService.CheckToken(c.Request.Context(), token)
+	}
+
+	// Истекший или отозванный access-токен обновляем прозрачно для клиента
+	if token == "" || status.Code(err) == codes.Unauthenticated {
+		tokens, refreshErr := h.refresh(c)
+		if refreshErr != nil {
+			c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+			return
+		}
+		user, err = h.authService.CheckToken(c.Request.Context(), tokens.AccessToken)
 	}
-	token := cookie.Value
 
-	user, err := h.authService.CheckToken(c.Request.Context(), token)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, response.Error("invalid token"))
 		return
@@ -151,3 +334,366 @@ func (h *AuthHandler) Me(c *gin.Context) {
 		Roles:  user.Roles,
 	})
 }
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Меняет пароль текущего пользователя. SSO завершает все сессии, cookie удаляются — нужно войти заново
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.ChangePasswordRequest true "Текущий и новый пароль"
+// @Success 200 {object} response.Response "Password changed"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/password/change [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	const op = "handlers.auth.changePassword"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	token, ok := h.accessToken(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	if err := h.authService.ChangePassword(c.Request.Context(), token, req.CurrentPassword, req.NewPassword); err != nil {
+		h.ssoError(c, log, "failed to change password", err)
+		return
+	}
+
+	authMiddleware.ClearTokenCookies(c)
+
+	c.JSON(http.StatusOK, response.OK("password changed"))
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Отправляет письмо со ссылкой для сброса пароля. Ответ одинаковый для зарегистрированного и неизвестного email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.EmailRequest true "Email"
+// @Success 200 {object} response.Response "Reset email sent"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	const op = "handlers.auth.forgotPassword"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		h.ssoError(c, log, "failed to request password reset", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("if the email is registered, a reset link has been sent"))
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Задает новый пароль по токену из письма. Ссылка одноразовая, все сессии пользователя завершаются
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.ResetPasswordRequest true "Токен из письма и новый пароль"
+// @Success 200 {object} response.Response "Password reset"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	const op = "handlers.auth.resetPassword"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		h.ssoError(c, log, "failed to reset password", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("password reset"))
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Подтверждает email по токену из письма, отправленного при регистрации
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.VerifyEmailRequest true "Токен из письма"
+// @Success 200 {object} response.Response "Email verified"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/email/verify [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	const op = "handlers.auth.verifyEmail"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if err := h.authService.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		h.ssoError(c, log, "failed to verify email", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("email verified"))
+}
+
+// ResendVerificationEmail godoc
+// @Summary Resend verification email
+// @Description Повторно отправляет письмо для подтверждения email, если он еще не подтвержден
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.EmailRequest true "Email"
+// @Success 200 {object} response.Response "Verification email sent"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/email/resend [post]
+func (h *AuthHandler) ResendVerificationEmail(c *gin.Context) {
+	const op = "handlers.auth.resendVerificationEmail"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if err := h.authService.ResendVerificationEmail(c.Request.Context(), req.Email); err != nil {
+		h.ssoError(c, log, "failed to resend verification email", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("if the email is not verified yet, a verification link has been sent"))
+}
+
+// ssoError отвечает клиенту по статусу ошибки SSO: ошибки валидации — 400 с текстом по полям
+// EnrollTOTP godoc
+// @Summary Start 2FA enrollment
+// @Description Выдает секрет для приложения-аутентификатора. 2FA включится после подтверждения кодом
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.TOTPEnrollment
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "2FA already enabled"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/2fa/enroll [post]
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	const op = "handlers.auth.enrollTOTP"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	token, ok := h.accessToken(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	enrollment, err := h.authService.EnrollTOTP(c.Request.Context(), token)
+	if err != nil {
+		h.ssoError(c, log, "failed to enroll 2fa", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm 2FA enrollment
+// @Description Включает 2FA по коду из приложения и возвращает резервные коды — они показываются один раз.
+// @Description Токены обновляются: роли, для которых 2FA обязательна, получают свои права
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.TOTPCodeRequest true "Код из приложения"
+// @Success 200 {object} dto.RecoveryCodes
+// @Failure 400 {object} response.Response "Invalid code"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "Enrollment not started or 2FA already enabled"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/2fa/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	const op = "handlers.auth.confirmTOTP"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.TOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	token, ok := h.accessToken(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	recoveryCodes, err := h.authService.ConfirmTOTP(c.Request.Context(), token, req.Code)
+	if err != nil {
+		h.ssoError(c, log, "failed to confirm 2fa", err)
+		return
+	}
+
+	// Без 2FA токены обязательных ролей выдавались без прав, новые придут уже с ними
+	if _, err := h.refresh(c); err != nil {
+		log.Warn("failed to refresh tokens after enabling 2fa", sl.Error(err))
+	}
+
+	c.JSON(http.StatusOK, dto.RecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+// DisableTOTP godoc
+// @Summary Disable 2FA
+// @Description Отключает 2FA по коду из приложения или резервному коду
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.TOTPCodeRequest true "Код из приложения или резервный код"
+// @Success 200 {object} response.Response "2FA disabled"
+// @Failure 400 {object} response.Response "Invalid code"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "2FA not enabled"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /auth/2fa/disable [post]
+func (h *AuthHandler) DisableTOTP(c *gin.Context) {
+	const op = "handlers.auth.disableTOTP"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req dto.TOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error("failed to bind json", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	token, ok := h.accessToken(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	if err := h.authService.DisableTOTP(c.Request.Context(), token, req.Code); err != nil {
+		h.ssoError(c, log, "failed to disable 2fa", err)
+		return
+	}
+
+	// Если 2FA обязательна для роли, права пропадут из токенов сразу, а не при следующем обновлении
+	if _, err := h.refresh(c); err != nil {
+		log.Warn("failed to refresh tokens after disabling 2fa", sl.Error(err))
+	}
+
+	c.JSON(http.StatusOK, response.OK("two-factor authentication disabled"))
+}
+
+func (h *AuthHandler) ssoError(c *gin.Context, log *slog.Logger, msg string, err error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, response.Error(grpcerrors.ParseValidationError(err)))
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+	case codes.FailedPrecondition:
+		c.JSON(http.StatusConflict, response.Error(status.Convert(err).Message()))
+	case codes.ResourceExhausted:
+		log.Warn(msg, sl.Error(err))
+		tooManyAttempts(c, err)
+	default:
+		log.Error(msg, sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error(msg))
+	}
+}
+
+// tooManyAttempts отвечает 429, когда SSO временно заблокировал вход
+func tooManyAttempts(c *gin.Context, err error) {
+	if retryAfter, ok := grpcerrors.RetryAfter(err); ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	c.JSON(http.StatusTooManyRequests, response.Error("too many failed login attempts, try again later"))
+}
+
+// accessToken возвращает access-токен из cookie, а если он истек — обновляет пару по refresh-cookie
+func (h *AuthHandler) accessToken(c *gin.Context) (string, bool) {
+	token, err := c.Cookie(authMiddleware.AccessTokenCookie)
+	if err == nil && token != "" {
+		return token, true
+	}
+
+	tokens, err := h.refresh(c)
+	if err != nil {
+		return "", false
+	}
+	return tokens.AccessToken, true
+}
+
+var errNoRefreshToken = errors.New("refresh token cookie missing")
+
+// refresh обновляет пару токенов по refresh-cookie и сохраняет новые cookie.
+// Если SSO отверг refresh-токен, cookie удаляются.
+func (h *AuthHandler) refresh(c *gin.Context) (dto.AuthTokens, error) {
+	refreshToken, err := c.Cookie(authMiddleware.RefreshTokenCookie)
+	if err != nil || refreshToken == "" {
+		return dto.AuthTokens{}, errNoRefreshToken
+	}
+
+	tokens, err := h.authService.Refresh(c.Request.Context(), refreshToken)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			authMiddleware.ClearTokenCookies(c)
+		}
+		return dto.AuthTokens{}, err
+	}
+
+	authMiddleware.SetTokenCookies(c, tokens)
+
+	return tokens, nil
+}
@@ -38,6 +38,54 @@ func (_m *AuthService) EXPECT() *AuthService_Expecter {
 	return &AuthService_Expecter{mock: &_m.Mock}
 }
 
+// ChangePassword provides a mock function for the type AuthService
+func (_mock *AuthService) ChangePassword(ctx context.Context, token string, currentPassword string, newPassword string) error {
+	ret := _mock.Called(ctx, token, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, token, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type AuthService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - currentPassword
+//   - newPassword
+func (_e *AuthService_Expecter) ChangePassword(ctx interface{}, token interface{}, currentPassword interface{}, newPassword interface{}) *AuthService_ChangePassword_Call {
+	return &AuthService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, token, currentPassword, newPassword)}
+}
+
+func (_c *AuthService_ChangePassword_Call) Run(run func(ctx context.Context, token string, currentPassword string, newPassword string)) *AuthService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_ChangePassword_Call) Return(err error) *AuthService_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, token string, currentPassword string, newPassword string) error) *AuthService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckToken provides a mock function for the type AuthService
 func (_mock *AuthService) CheckToken(ctx context.Context, token string) (dto.User, error) {
 	ret := _mock.Called(ctx, token)
@@ -93,26 +141,186 @@ func (_c *AuthService_CheckToken_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// ConfirmTOTP provides a mock function for the type AuthService
+func (_mock *AuthService) ConfirmTOTP(ctx context.Context, token string, code string) ([]string, error) {
+	ret := _mock.Called(ctx, token, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, token, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, token, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AuthService_ConfirmTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTP'
+type AuthService_ConfirmTOTP_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTP is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - code
+func (_e *AuthService_Expecter) ConfirmTOTP(ctx interface{}, token interface{}, code interface{}) *AuthService_ConfirmTOTP_Call {
+	return &AuthService_ConfirmTOTP_Call{Call: _e.mock.On("ConfirmTOTP", ctx, token, code)}
+}
+
+func (_c *AuthService_ConfirmTOTP_Call) Run(run func(ctx context.Context, token string, code string)) *AuthService_ConfirmTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_ConfirmTOTP_Call) Return(strings []string, err error) *AuthService_ConfirmTOTP_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *AuthService_ConfirmTOTP_Call) RunAndReturn(run func(ctx context.Context, token string, code string) ([]string, error)) *AuthService_ConfirmTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableTOTP provides a mock function for the type AuthService
+func (_mock *AuthService) DisableTOTP(ctx context.Context, token string, code string) error {
+	ret := _mock.Called(ctx, token, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, token, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_DisableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTOTP'
+type AuthService_DisableTOTP_Call struct {
+	*mock.Call
+}
+
+// DisableTOTP is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - code
+func (_e *AuthService_Expecter) DisableTOTP(ctx interface{}, token interface{}, code interface{}) *AuthService_DisableTOTP_Call {
+	return &AuthService_DisableTOTP_Call{Call: _e.mock.On("DisableTOTP", ctx, token, code)}
+}
+
+func (_c *AuthService_DisableTOTP_Call) Run(run func(ctx context.Context, token string, code string)) *AuthService_DisableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_DisableTOTP_Call) Return(err error) *AuthService_DisableTOTP_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthService_DisableTOTP_Call) RunAndReturn(run func(ctx context.Context, token string, code string) error) *AuthService_DisableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnrollTOTP provides a mock function for the type AuthService
+func (_mock *AuthService) EnrollTOTP(ctx context.Context, token string) (dto.TOTPEnrollment, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 dto.TOTPEnrollment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (dto.TOTPEnrollment, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) dto.TOTPEnrollment); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(dto.TOTPEnrollment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AuthService_EnrollTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTOTP'
+type AuthService_EnrollTOTP_Call struct {
+	*mock.Call
+}
+
+// EnrollTOTP is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *AuthService_Expecter) EnrollTOTP(ctx interface{}, token interface{}) *AuthService_EnrollTOTP_Call {
+	return &AuthService_EnrollTOTP_Call{Call: _e.mock.On("EnrollTOTP", ctx, token)}
+}
+
+func (_c *AuthService_EnrollTOTP_Call) Run(run func(ctx context.Context, token string)) *AuthService_EnrollTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_EnrollTOTP_Call) Return(tOTPEnrollment dto.TOTPEnrollment, err error) *AuthService_EnrollTOTP_Call {
+	_c.Call.Return(tOTPEnrollment, err)
+	return _c
+}
+
+func (_c *AuthService_EnrollTOTP_Call) RunAndReturn(run func(ctx context.Context, token string) (dto.TOTPEnrollment, error)) *AuthService_EnrollTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function for the type AuthService
-func (_mock *AuthService) Login(ctx context.Context, email string, password string) (string, error) {
-	ret := _mock.Called(ctx, email, password)
+func (_mock *AuthService) Login(ctx context.Context, email string, password string, device string, ip string) (dto.AuthTokens, error) {
+	ret := _mock.Called(ctx, email, password, device, ip)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 dto.AuthTokens
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (dto.AuthTokens, error)); ok {
+		return returnFunc(ctx, email, password, device, ip)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) dto.AuthTokens); ok {
+		r0 = returnFunc(ctx, email, password, device, ip)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(dto.AuthTokens)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password, device, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,30 +336,192 @@ type AuthService_Login_Call struct {
 //   - ctx
 //   - email
 //   - password
-func (_e *AuthService_Expecter) Login(ctx interface{}, email interface{}, password interface{}) *AuthService_Login_Call {
-	return &AuthService_Login_Call{Call: _e.mock.On("Login", ctx, email, password)}
+//   - device
+//   - ip
+func (_e *AuthService_Expecter) Login(ctx interface{}, email interface{}, password interface{}, device interface{}, ip interface{}) *AuthService_Login_Call {
+	return &AuthService_Login_Call{Call: _e.mock.On("Login", ctx, email, password, device, ip)}
 }
 
-func (_c *AuthService_Login_Call) Run(run func(ctx context.Context, email string, password string)) *AuthService_Login_Call {
+func (_c *AuthService_Login_Call) Run(run func(ctx context.Context, email string, password string, device string, ip string)) *AuthService_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_Login_Call) Return(authTokens dto.AuthTokens, err error) *AuthService_Login_Call {
+	_c.Call.Return(authTokens, err)
+	return _c
+}
+
+func (_c *AuthService_Login_Call) RunAndReturn(run func(ctx context.Context, email string, password string, device string, ip string) (dto.AuthTokens, error)) *AuthService_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginTwoFactor provides a mock function for the type AuthService
+func (_mock *AuthService) LoginTwoFactor(ctx context.Context, challenge string, code string, device string, ip string) (dto.AuthTokens, error) {
+	ret := _mock.Called(ctx, challenge, code, device, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginTwoFactor")
+	}
+
+	var r0 dto.AuthTokens
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (dto.AuthTokens, error)); ok {
+		return returnFunc(ctx, challenge, code, device, ip)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) dto.AuthTokens); ok {
+		r0 = returnFunc(ctx, challenge, code, device, ip)
+	} else {
+		r0 = ret.Get(0).(dto.AuthTokens)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, challenge, code, device, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AuthService_LoginTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginTwoFactor'
+type AuthService_LoginTwoFactor_Call struct {
+	*mock.Call
+}
+
+// LoginTwoFactor is a helper method to define mock.On call
+//   - ctx
+//   - challenge
+//   - code
+//   - device
+//   - ip
+func (_e *AuthService_Expecter) LoginTwoFactor(ctx interface{}, challenge interface{}, code interface{}, device interface{}, ip interface{}) *AuthService_LoginTwoFactor_Call {
+	return &AuthService_LoginTwoFactor_Call{Call: _e.mock.On("LoginTwoFactor", ctx, challenge, code, device, ip)}
+}
+
+func (_c *AuthService_LoginTwoFactor_Call) Run(run func(ctx context.Context, challenge string, code string, device string, ip string)) *AuthService_LoginTwoFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_LoginTwoFactor_Call) Return(authTokens dto.AuthTokens, err error) *AuthService_LoginTwoFactor_Call {
+	_c.Call.Return(authTokens, err)
+	return _c
+}
+
+func (_c *AuthService_LoginTwoFactor_Call) RunAndReturn(run func(ctx context.Context, challenge string, code string, device string, ip string) (dto.AuthTokens, error)) *AuthService_LoginTwoFactor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type AuthService
+func (_mock *AuthService) Logout(ctx context.Context, token string, all bool) error {
+	ret := _mock.Called(ctx, token, all)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, token, all)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type AuthService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - all
+func (_e *AuthService_Expecter) Logout(ctx interface{}, token interface{}, all interface{}) *AuthService_Logout_Call {
+	return &AuthService_Logout_Call{Call: _e.mock.On("Logout", ctx, token, all)}
+}
+
+func (_c *AuthService_Logout_Call) Run(run func(ctx context.Context, token string, all bool)) *AuthService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
 	})
 	return _c
 }
 
-func (_c *AuthService_Login_Call) Return(s string, err error) *AuthService_Login_Call {
-	_c.Call.Return(s, err)
+func (_c *AuthService_Logout_Call) Return(err error) *AuthService_Logout_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *AuthService_Login_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (string, error)) *AuthService_Login_Call {
+func (_c *AuthService_Logout_Call) RunAndReturn(run func(ctx context.Context, token string, all bool) error) *AuthService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function for the type AuthService
+func (_mock *AuthService) Refresh(ctx context.Context, refreshToken string) (dto.AuthTokens, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 dto.AuthTokens
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (dto.AuthTokens, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) dto.AuthTokens); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(dto.AuthTokens)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// AuthService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type AuthService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx
+//   - refreshToken
+func (_e *AuthService_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *AuthService_Refresh_Call {
+	return &AuthService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *AuthService_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *AuthService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_Refresh_Call) Return(authTokens dto.AuthTokens, err error) *AuthService_Refresh_Call {
+	_c.Call.Return(authTokens, err)
+	return _c
+}
+
+func (_c *AuthService_Refresh_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (dto.AuthTokens, error)) *AuthService_Refresh_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // RegisterNewUser provides a mock function for the type AuthService
-func (_mock *AuthService) RegisterNewUser(ctx context.Context, email string, password string) (int64, error) {
-	ret := _mock.Called(ctx, email, password)
+func (_mock *AuthService) RegisterNewUser(ctx context.Context, email string, password string, inviteCode string) (int64, error) {
+	ret := _mock.Called(ctx, email, password, inviteCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegisterNewUser")
@@ -159,16 +529,16 @@ func (_mock *AuthService) RegisterNewUser(ctx context.Context, email string, pas
 
 	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
-		return returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (int64, error)); ok {
+		return returnFunc(ctx, email, password, inviteCode)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
-		r0 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
+		r0 = returnFunc(ctx, email, password, inviteCode)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password, inviteCode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -184,13 +554,14 @@ type AuthService_RegisterNewUser_Call struct {
 //   - ctx
 //   - email
 //   - password
-func (_e *AuthService_Expecter) RegisterNewUser(ctx interface{}, email interface{}, password interface{}) *AuthService_RegisterNewUser_Call {
-	return &AuthService_RegisterNewUser_Call{Call: _e.mock.On("RegisterNewUser", ctx, email, password)}
+//   - inviteCode
+func (_e *AuthService_Expecter) RegisterNewUser(ctx interface{}, email interface{}, password interface{}, inviteCode interface{}) *AuthService_RegisterNewUser_Call {
+	return &AuthService_RegisterNewUser_Call{Call: _e.mock.On("RegisterNewUser", ctx, email, password, inviteCode)}
 }
 
-func (_c *AuthService_RegisterNewUser_Call) Run(run func(ctx context.Context, email string, password string)) *AuthService_RegisterNewUser_Call {
+func (_c *AuthService_RegisterNewUser_Call) Run(run func(ctx context.Context, email string, password string, inviteCode string)) *AuthService_RegisterNewUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -200,7 +571,192 @@ func (_c *AuthService_RegisterNewUser_Call) Return(n int64, err error) *AuthServ
 	return _c
 }
 
-func (_c *AuthService_RegisterNewUser_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (int64, error)) *AuthService_RegisterNewUser_Call {
+func (_c *AuthService_RegisterNewUser_Call) RunAndReturn(run func(ctx context.Context, email string, password string, inviteCode string) (int64, error)) *AuthService_RegisterNewUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestPasswordReset provides a mock function for the type AuthService
+func (_mock *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_RequestPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestPasswordReset'
+type AuthService_RequestPasswordReset_Call struct {
+	*mock.Call
+}
+
+// RequestPasswordReset is a helper method to define mock.On call
+//   - ctx
+//   - email
+func (_e *AuthService_Expecter) RequestPasswordReset(ctx interface{}, email interface{}) *AuthService_RequestPasswordReset_Call {
+	return &AuthService_RequestPasswordReset_Call{Call: _e.mock.On("RequestPasswordReset", ctx, email)}
+}
+
+func (_c *AuthService_RequestPasswordReset_Call) Run(run func(ctx context.Context, email string)) *AuthService_RequestPasswordReset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_RequestPasswordReset_Call) Return(err error) *AuthService_RequestPasswordReset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthService_RequestPasswordReset_Call) RunAndReturn(run func(ctx context.Context, email string) error) *AuthService_RequestPasswordReset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerificationEmail provides a mock function for the type AuthService
+func (_mock *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerificationEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_ResendVerificationEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerificationEmail'
+type AuthService_ResendVerificationEmail_Call struct {
+	*mock.Call
+}
+
+// ResendVerificationEmail is a helper method to define mock.On call
+//   - ctx
+//   - email
+func (_e *AuthService_Expecter) ResendVerificationEmail(ctx interface{}, email interface{}) *AuthService_ResendVerificationEmail_Call {
+	return &AuthService_ResendVerificationEmail_Call{Call: _e.mock.On("ResendVerificationEmail", ctx, email)}
+}
+
+func (_c *AuthService_ResendVerificationEmail_Call) Run(run func(ctx context.Context, email string)) *AuthService_ResendVerificationEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_ResendVerificationEmail_Call) Return(err error) *AuthService_ResendVerificationEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthService_ResendVerificationEmail_Call) RunAndReturn(run func(ctx context.Context, email string) error) *AuthService_ResendVerificationEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type AuthService
+func (_mock *AuthService) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _mock.Called(ctx, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type AuthService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx
+//   - token
+//   - newPassword
+func (_e *AuthService_Expecter) ResetPassword(ctx interface{}, token interface{}, newPassword interface{}) *AuthService_ResetPassword_Call {
+	return &AuthService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, newPassword)}
+}
+
+func (_c *AuthService_ResetPassword_Call) Run(run func(ctx context.Context, token string, newPassword string)) *AuthService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_ResetPassword_Call) Return(err error) *AuthService_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthService_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, token string, newPassword string) error) *AuthService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function for the type AuthService
+func (_mock *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// AuthService_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type AuthService_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *AuthService_Expecter) VerifyEmail(ctx interface{}, token interface{}) *AuthService_VerifyEmail_Call {
+	return &AuthService_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, token)}
+}
+
+func (_c *AuthService_VerifyEmail_Call) Run(run func(ctx context.Context, token string)) *AuthService_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_VerifyEmail_Call) Return(err error) *AuthService_VerifyEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthService_VerifyEmail_Call) RunAndReturn(run func(ctx context.Context, token string) error) *AuthService_VerifyEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,97 @@
+package documentHandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	documentService "github.com/Muaz717/gym_app/app/internal/services/document"
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentService interface {
+	Receipt(ctx context.Context, number string) ([]byte, error)
+	Contract(ctx context.Context, number string) ([]byte, error)
+}
+
+type DocumentHandler struct {
+	log             *slog.Logger
+	documentService DocumentService
+}
+
+func New(
+	log *slog.Logger,
+	documentService DocumentService,
+) *DocumentHandler {
+	return &DocumentHandler{
+		log:             log,
+		documentService: documentService,
+	}
+}
+
+// download формирует документ и отдает его как PDF-вложение
+func (h *DocumentHandler) download(c *gin.Context, op, name string, generate func(ctx context.Context, number string) ([]byte, error)) {
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	number := c.Param("number")
+	if number == "" {
+		c.JSON(http.StatusBadRequest, response.Error("subscription number is required"))
+		return
+	}
+
+	doc, err := generate(c.Request.Context(), number)
+	if err != nil {
+		if errors.Is(err, documentService.ErrSubNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("subscription not found"))
+			return
+		}
+
+		log.Error("failed to generate document", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to generate document"))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s_%s.pdf", name, number)))
+	c.Data(http.StatusOK, "application/pdf", doc)
+}
+
+// Receipt godoc
+// @Summary      Чек по абонементу
+// @Description  Формирует PDF-чек об оплате абонемента клиента
+// @Security BearerAuth
+// @Tags         documents
+// @Produce      application/pdf
+// @Param        number  path  string  true  "Номер абонемента"
+// @Success      200
+// @Failure      404   {object}  response.Response "Абонемент не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /documents/receipt/{number} [get]
+func (h *DocumentHandler) Receipt(c *gin.Context) {
+	const op = "handlers.document.Receipt"
+
+	h.download(c, op, "receipt", h.documentService.Receipt)
+}
+
+// Contract godoc
+// @Summary      Договор по абонементу
+// @Description  Формирует PDF-договор на оказание услуг по абонементу клиента
+// @Security BearerAuth
+// @Tags         documents
+// @Produce      application/pdf
+// @Param        number  path  string  true  "Номер абонемента"
+// @Success      200
+// @Failure      404   {object}  response.Response "Абонемент не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /documents/contract/{number} [get]
+func (h *DocumentHandler) Contract(c *gin.Context) {
+	const op = "handlers.document.Contract"
+
+	h.download(c, op, "contract", h.documentService.Contract)
+}
@@ -0,0 +1,188 @@
+package exportHandler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportService interface {
+	ExportPeople(ctx context.Context, format export.Format, out io.Writer, filter dto.PeopleFilter) error
+	ExportPersonSubs(ctx context.Context, format export.Format, out io.Writer, filter dto.PersonSubFilter) error
+	ExportSingleVisits(ctx context.Context, format export.Format, out io.Writer, filter dto.SingleVisitFilter) error
+	ExportFreezes(ctx context.Context, format export.Format, out io.Writer, filter dto.FreezeFilter) error
+}
+
+type ExportHandler struct {
+	log           *slog.Logger
+	exportService ExportService
+}
+
+func New(
+	log *slog.Logger,
+	exportService ExportService,
+) *ExportHandler {
+	return &ExportHandler{
+		log:           log,
+		exportService: exportService,
+	}
+}
+
+// download разбирает формат, выставляет заголовки файла и запускает выгрузку.
+// Если ошибка случилась до первой записанной строки, клиент получает JSON с ошибкой.
+func (h *ExportHandler) download(c *gin.Context, op, name string, run func(format export.Format, out io.Writer) error) {
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("format must be one of csv, xlsx"))
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", format.FileName(name)))
+
+	if err := run(format, c.Writer); err != nil {
+		log.Error("failed to export", sl.Error(err))
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			c.JSON(http.StatusInternalServerError, response.Error("failed to export"))
+		}
+	}
+}
+
+// ExportPeople godoc
+// @Summary      Выгрузка клиентов
+// @Description  Выгружает клиентов в CSV или XLSX
+// @Security BearerAuth
+// @Tags         export
+// @Produce      text/csv
+// @Param        format  query  string  false  "csv (по умолчанию) или xlsx"
+// @Param        name    query  string  false  "Часть ФИО"
+// @Success      200
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /export/people [get]
+func (h *ExportHandler) ExportPeople(c *gin.Context) {
+	filter := dto.PeopleFilter{Name: c.Query("name")}
+
+	h.download(c, "handlers.export.exportPeople", "people", func(format export.Format, out io.Writer) error {
+		return h.exportService.ExportPeople(c.Request.Context(), format, out, filter)
+	})
+}
+
+// ExportPersonSubs godoc
+// @Summary      Выгрузка абонементов клиентов
+// @Description  Выгружает абонементы клиентов в CSV или XLSX
+// @Security BearerAuth
+// @Tags         export
+// @Produce      text/csv
+// @Param        format     query  string  false  "csv (по умолчанию) или xlsx"
+// @Param        person_id  query  int     false  "ID клиента"
+// @Param        name       query  string  false  "ФИО клиента"
+// @Param        status     query  string  false  "Статус абонемента"
+// @Success      200
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /export/person_subs [get]
+func (h *ExportHandler) ExportPersonSubs(c *gin.Context) {
+	filter := dto.PersonSubFilter{
+		PersonName: c.Query("name"),
+		Status:     c.Query("status"),
+	}
+
+	if personIDStr := c.Query("person_id"); personIDStr != "" {
+		personID, err := strconv.Atoi(personIDStr)
+		if err != nil || personID <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error("invalid person_id"))
+			return
+		}
+		filter.PersonID = personID
+	}
+
+	h.download(c, "handlers.export.exportPersonSubs", "person_subs", func(format export.Format, out io.Writer) error {
+		return h.exportService.ExportPersonSubs(c.Request.Context(), format, out, filter)
+	})
+}
+
+// ExportSingleVisits godoc
+// @Summary      Выгрузка разовых посещений
+// @Description  Выгружает разовые посещения в CSV или XLSX
+// @Security BearerAuth
+// @Tags         export
+// @Produce      text/csv
+// @Param        format  query  string  false  "csv (по умолчанию) или xlsx"
+// @Param        from    query  string  false  "Дата начала (YYYY-MM-DD)"
+// @Param        to      query  string  false  "Дата окончания (YYYY-MM-DD)"
+// @Success      200
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /export/single_visits [get]
+func (h *ExportHandler) ExportSingleVisits(c *gin.Context) {
+	var filter dto.SingleVisitFilter
+	var err error
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		if filter.From, err = time.Parse(time.DateOnly, fromStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("invalid 'from' date format, expected YYYY-MM-DD"))
+			return
+		}
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		if filter.To, err = time.Parse(time.DateOnly, toStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("invalid 'to' date format, expected YYYY-MM-DD"))
+			return
+		}
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		c.JSON(http.StatusBadRequest, response.Error("'from' date must be before 'to' date"))
+		return
+	}
+
+	h.download(c, "handlers.export.exportSingleVisits", "single_visits", func(format export.Format, out io.Writer) error {
+		return h.exportService.ExportSingleVisits(c.Request.Context(), format, out, filter)
+	})
+}
+
+// ExportFreezes godoc
+// @Summary      Выгрузка заморозок
+// @Description  Выгружает заморозки абонементов в CSV или XLSX
+// @Security BearerAuth
+// @Tags         export
+// @Produce      text/csv
+// @Param        format  query  string  false  "csv (по умолчанию) или xlsx"
+// @Param        active  query  bool    false  "Только действующие заморозки"
+// @Success      200
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /export/freezes [get]
+func (h *ExportHandler) ExportFreezes(c *gin.Context) {
+	var filter dto.FreezeFilter
+
+	if activeStr := c.Query("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("'active' must be a boolean"))
+			return
+		}
+		filter.ActiveOnly = active
+	}
+
+	h.download(c, "handlers.export.exportFreezes", "freezes", func(format export.Format, out io.Writer) error {
+		return h.exportService.ExportFreezes(c.Request.Context(), format, out, filter)
+	})
+}
@@ -0,0 +1,160 @@
+package importHandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	importService "github.com/Muaz717/gym_app/app/internal/services/importer"
+	"github.com/gin-gonic/gin"
+)
+
+// maxUploadSize — максимальный размер загружаемого файла
+const maxUploadSize = 10 << 20
+
+type ImportService interface {
+	ImportPeople(ctx context.Context, format export.Format, r io.Reader, dryRun bool) (dto.ImportResult, error)
+	ImportPersonSubs(ctx context.Context, format export.Format, r io.Reader, dryRun bool) (dto.ImportResult, error)
+}
+
+type ImportHandler struct {
+	log           *slog.Logger
+	importService ImportService
+}
+
+func New(
+	log *slog.Logger,
+	importService ImportService,
+) *ImportHandler {
+	return &ImportHandler{
+		log:           log,
+		importService: importService,
+	}
+}
+
+type importFunc func(ctx context.Context, format export.Format, r io.Reader, dryRun bool) (dto.ImportResult, error)
+
+// upload читает файл из поля file multipart-формы и передает его в run.
+// Если в строках есть ошибки, отвечает 422 и ничего не сохраняет.
+func (h *ImportHandler) upload(c *gin.Context, op string, run importFunc) {
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	dryRun := false
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(dryRunStr); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("'dry_run' must be a boolean"))
+			return
+		}
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("file is required"))
+		return
+	}
+
+	format, err := export.FormatFromFileName(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("file must be .csv or .xlsx"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Error("failed to open uploaded file", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to read file"))
+		return
+	}
+	defer file.Close()
+
+	result, err := run(c.Request.Context(), format, file, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, importService.ErrInvalidFile),
+			errors.Is(err, importService.ErrEmptyFile),
+			errors.Is(err, importService.ErrTooManyRows),
+			errors.Is(err, importService.ErrMissingColumns):
+			c.JSON(http.StatusBadRequest, response.Error(importErrorMessage(err)))
+		case errors.Is(err, importService.ErrConflict):
+			c.JSON(http.StatusConflict, response.Error(importService.ErrConflict.Error()))
+		default:
+			log.Error("failed to import", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to import"))
+		}
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// importErrorMessage отрезает от ошибки префиксы op, оставляя описание для пользователя
+func importErrorMessage(err error) string {
+	for _, target := range []error{
+		importService.ErrMissingColumns,
+		importService.ErrTooManyRows,
+		importService.ErrInvalidFile,
+		importService.ErrEmptyFile,
+	} {
+		if errors.Is(err, target) {
+			msg := err.Error()
+			return msg[strings.Index(msg, target.Error()):]
+		}
+	}
+	return err.Error()
+}
+
+// ImportPeople godoc
+// @Summary      Импорт клиентов
+// @Description  Загружает клиентов из CSV или XLSX (колонки «ФИО», «Телефон»). С dry_run=true только проверяет файл.
+// @Security BearerAuth
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file  true   "Файл .csv или .xlsx"
+// @Param        dry_run  query     bool  false  "Только проверить"
+// @Success      200   {object}  dto.ImportResult
+// @Failure      400   {object}  response.Response "Ошибка формата файла"
+// @Failure      409   {object}  response.Response "Конфликт"
+// @Failure      422   {object}  dto.ImportResult "Ошибки в строках"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /import/people [post]
+func (h *ImportHandler) ImportPeople(c *gin.Context) {
+	h.upload(c, "handlers.importer.importPeople", h.importService.ImportPeople)
+}
+
+// ImportPersonSubs godoc
+// @Summary      Импорт абонементов клиентов
+// @Description  Загружает абонементы из CSV или XLSX. С dry_run=true только проверяет файл.
+// @Security BearerAuth
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file  true   "Файл .csv или .xlsx"
+// @Param        dry_run  query     bool  false  "Только проверить"
+// @Success      200   {object}  dto.ImportResult
+// @Failure      400   {object}  response.Response "Ошибка формата файла"
+// @Failure      409   {object}  response.Response "Конфликт"
+// @Failure      422   {object}  dto.ImportResult "Ошибки в строках"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /import/person_subs [post]
+func (h *ImportHandler) ImportPersonSubs(c *gin.Context) {
+	h.upload(c, "handlers.importer.importPersonSubs", h.importService.ImportPersonSubs)
+}
@@ -0,0 +1,119 @@
+package liveHandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeat — как часто отправляем комментарий, чтобы прокси не закрывали молчащее соединение
+const heartbeat = 20 * time.Second
+
+type LiveService interface {
+	Subscribe() (<-chan dto.LiveMessage, func())
+	Income(ctx context.Context) (dto.LiveMessage, error)
+}
+
+type LiveHandler struct {
+	log         *slog.Logger
+	liveService LiveService
+}
+
+func New(
+	log *slog.Logger,
+	liveService LiveService,
+) *LiveHandler {
+	return &LiveHandler{
+		log:         log,
+		liveService: liveService,
+	}
+}
+
+// Stream godoc
+// @Summary      Живые обновления для ресепшена
+// @Description  Поток server-sent events: sale, visit, freeze и income (выручка за сегодня).
+// @Description  Сразу после подключения приходит текущая выручка. Авторизация — cookie token, как у остальных запросов.
+// @Security BearerAuth
+// @Tags         live
+// @Produce      text/event-stream
+// @Success      200
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /live [get]
+func (h *LiveHandler) Stream(c *gin.Context) {
+	const op = "handlers.live.Stream"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	ctx := c.Request.Context()
+
+	// Подписываемся до чтения выручки, чтобы не пропустить продажу между ними
+	messages, unsubscribe := h.liveService.Subscribe()
+	defer unsubscribe()
+
+	income, err := h.liveService.Income(ctx)
+	if err != nil {
+		log.Error("failed to get daily income", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
+		return
+	}
+
+	// Общий WriteTimeout сервера оборвал бы поток через несколько секунд
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn("failed to reset write deadline", sl.Error(err))
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if err := writeMessage(c.Writer, income); err != nil {
+		return
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if err := writeMessage(c.Writer, msg); err != nil {
+				log.Debug("live client disconnected", sl.Error(err))
+				return
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeMessage(w io.Writer, msg dto.LiveMessage) error {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Event, data)
+	return err
+}
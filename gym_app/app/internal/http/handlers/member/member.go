@@ -0,0 +1,431 @@
+package memberHandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	memberMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/member"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/grpcerrors"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	memberService "github.com/Muaz717/gym_app/app/internal/services/member"
+	subFreezeService "github.com/Muaz717/gym_app/app/internal/services/sub_freeze"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultVisitsPeriod — за какой период показываем посещения, если даты не заданы
+const defaultVisitsPeriod = 90 * 24 * time.Hour
+
+type MemberService interface {
+	Register(ctx context.Context, input dto.MemberRegisterInput) (int64, error)
+	LinkAccount(ctx context.Context, userID int64, personID int) error
+	UnlinkAccount(ctx context.Context, userID int64) error
+	Profile(ctx context.Context, personID int) (dto.MemberProfile, error)
+	Subscriptions(ctx context.Context, personID int) ([]dto.MemberSubscription, error)
+	Visits(ctx context.Context, personID int, from, to time.Time) ([]models.Visit, error)
+	Payments(ctx context.Context, personID int) ([]dto.MemberPayment, error)
+	RequestFreeze(ctx context.Context, personID int, input dto.FreezeRequestInput) (int, error)
+	FreezeRequests(ctx context.Context, personID int) ([]models.FreezeRequest, error)
+}
+
+type MemberHandler struct {
+	log           *slog.Logger
+	memberService MemberService
+}
+
+func New(
+	log *slog.Logger,
+	memberService MemberService,
+) *MemberHandler {
+	return &MemberHandler{
+		log:           log,
+		memberService: memberService,
+	}
+}
+
+// personID достает клиента из контекста. Без MemberOnly в цепочке — ошибка конфигурации маршрутов.
+func personID(c *gin.Context) (int, bool) {
+	id, ok := memberMiddleware.GetPersonID(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, response.Error("member account required"))
+	}
+	return id, ok
+}
+
+// Register godoc
+// @Summary      Регистрация клиента
+// @Description  Создает аккаунт клиента и привязывает его к карточке по номеру абонемента и телефону
+// @Tags         member
+// @Accept       json
+// @Produce      json
+// @Param        register  body  dto.MemberRegisterInput  true  "Данные регистрации"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      409   {object}  response.Response "У клиента уже есть аккаунт или регистрация уже идет"
+// @Failure      429   {object}  response.Response "Слишком много попыток"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /auth/member/register [post]
+func (h *MemberHandler) Register(c *gin.Context) {
+	const op = "handlers.member.Register"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var input dto.MemberRegisterInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if input.Email == "" || input.Password == "" || input.Phone == "" || input.SubscriptionNumber == "" {
+		c.JSON(http.StatusBadRequest, response.Error("email, password, phone and subscription_number are required"))
+		return
+	}
+
+	userID, err := h.memberService.Register(c.Request.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, memberService.ErrVerificationFailed):
+			c.JSON(http.StatusBadRequest, response.Error(memberService.ErrVerificationFailed.Error()))
+		case errors.Is(err, memberService.ErrMemberExists):
+			c.JSON(http.StatusConflict, response.Error(memberService.ErrMemberExists.Error()))
+		case errors.Is(err, memberService.ErrRegistrationBusy):
+			c.JSON(http.StatusConflict, response.Error(memberService.ErrRegistrationBusy.Error()))
+		default:
+			log.Error("failed to register member", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error(grpcerrors.ParseValidationError(err)))
+		}
+		return
+	}
+
+	log.Info("member registered", slog.Int64("user_id", userID))
+
+	c.JSON(http.StatusOK, response.OK(strconv.FormatInt(userID, 10)))
+}
+
+// Me godoc
+// @Summary      Профиль клиента
+// @Security BearerAuth
+// @Tags         member
+// @Produce      json
+// @Success      200   {object}  dto.MemberProfile
+// @Failure      403   {object}  response.Response "Нужен аккаунт клиента"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member/me [get]
+func (h *MemberHandler) Me(c *gin.Context) {
+	const op = "handlers.member.Me"
+
+	id, ok := personID(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.memberService.Profile(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("failed to get profile", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get profile"))
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Subscriptions godoc
+// @Summary      Абонементы клиента
+// @Description  Все абонементы клиента с остатком и историей заморозок, последние первыми
+// @Security BearerAuth
+// @Tags         member
+// @Produce      json
+// @Success      200   {object}  []dto.MemberSubscription
+// @Failure      403   {object}  response.Response "Нужен аккаунт клиента"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member/subscriptions [get]
+func (h *MemberHandler) Subscriptions(c *gin.Context) {
+	const op = "handlers.member.Subscriptions"
+
+	id, ok := personID(c)
+	if !ok {
+		return
+	}
+
+	subs, err := h.memberService.Subscriptions(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("failed to get subscriptions", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get subscriptions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+// Visits godoc
+// @Summary      Посещения клиента
+// @Security BearerAuth
+// @Tags         member
+// @Produce      json
+// @Param        from  query  string  false  "Начало периода YYYY-MM-DD (по умолчанию 90 дней назад)"
+// @Param        to    query  string  false  "Конец периода YYYY-MM-DD включительно (по умолчанию сегодня)"
+// @Success      200   {object}  []models.Visit
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Нужен аккаунт клиента"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member/visits [get]
+func (h *MemberHandler) Visits(c *gin.Context) {
+	const op = "handlers.member.Visits"
+
+	id, ok := personID(c)
+	if !ok {
+		return
+	}
+
+	to := time.Now()
+	from := to.Add(-defaultVisitsPeriod)
+
+	if v := c.Query("from"); v != "" {
+		date, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("from must be in YYYY-MM-DD format"))
+			return
+		}
+		from = date
+	}
+	if v := c.Query("to"); v != "" {
+		date, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("to must be in YYYY-MM-DD format"))
+			return
+		}
+		to = date.AddDate(0, 0, 1)
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, response.Error("from must be before to"))
+		return
+	}
+
+	visits, err := h.memberService.Visits(c.Request.Context(), id, from, to)
+	if err != nil {
+		h.log.Error("failed to get visits", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get visits"))
+		return
+	}
+
+	c.JSON(http.StatusOK, visits)
+}
+
+// Payments godoc
+// @Summary      Оплаты клиента
+// @Security BearerAuth
+// @Tags         member
+// @Produce      json
+// @Success      200   {object}  []dto.MemberPayment
+// @Failure      403   {object}  response.Response "Нужен аккаунт клиента"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member/payments [get]
+func (h *MemberHandler) Payments(c *gin.Context) {
+	const op = "handlers.member.Payments"
+
+	id, ok := personID(c)
+	if !ok {
+		return
+	}
+
+	payments, err := h.memberService.Payments(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("failed to get payments", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get payments"))
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+// RequestFreeze godoc
+// @Summary      Заявка на заморозку
+// @Description  Создает заявку на заморозку своего абонемента; заморозку оформляет администратор
+// @Security BearerAuth
+// @Tags         member
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.FreezeRequestInput  true  "Заявка"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Абонемент не найден"
+// @Failure      409   {object}  response.Response "Заявка уже есть"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member/freeze_requests [post]
+func (h *MemberHandler) RequestFreeze(c *gin.Context) {
+	const op = "handlers.member.RequestFreeze"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	id, ok := personID(c)
+	if !ok {
+		return
+	}
+
+	var input dto.FreezeRequestInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if input.SubscriptionNumber == "" || input.FreezeStart == "" {
+		c.JSON(http.StatusBadRequest, response.Error("subscription_number and freeze_start are required"))
+		return
+	}
+
+	requestID, err := h.memberService.RequestFreeze(c.Request.Context(), id, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, memberService.ErrInvalidFreezeStart):
+			c.JSON(http.StatusBadRequest, response.Error(memberService.ErrInvalidFreezeStart.Error()))
+		case errors.Is(err, memberService.ErrFreezeUnavailable):
+			c.JSON(http.StatusBadRequest, response.Error(memberService.ErrFreezeUnavailable.Error()))
+		case errors.Is(err, memberService.ErrSubNotFound):
+			c.JSON(http.StatusNotFound, response.Error("subscription not found"))
+		case errors.Is(err, subFreezeService.ErrFreezeRequestExists):
+			c.JSON(http.StatusConflict, response.Error(subFreezeService.ErrFreezeRequestExists.Error()))
+		default:
+			log.Error("failed to request freeze", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to request freeze"))
+		}
+		return
+	}
+
+	log.Info("freeze requested", slog.Int("request_id", requestID))
+
+	c.JSON(http.StatusOK, response.OK(strconv.Itoa(requestID)))
+}
+
+// FreezeRequests godoc
+// @Summary      Заявки клиента на заморозку
+// @Security BearerAuth
+// @Tags         member
+// @Produce      json
+// @Success      200   {object}  []models.FreezeRequest
+// @Failure      403   {object}  response.Response "Нужен аккаунт клиента"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member/freeze_requests [get]
+func (h *MemberHandler) FreezeRequests(c *gin.Context) {
+	const op = "handlers.member.FreezeRequests"
+
+	id, ok := personID(c)
+	if !ok {
+		return
+	}
+
+	requests, err := h.memberService.FreezeRequests(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("failed to get freeze requests", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get freeze requests"))
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// LinkAccount godoc
+// @Summary      Привязать аккаунт к клиенту
+// @Description  Открывает существующему аккаунту SSO портал клиента. Права аккаунта в SSO не меняются: если у него есть роли, доступ к API сотрудников остается
+// @Security BearerAuth
+// @Tags         member
+// @Accept       json
+// @Produce      json
+// @Param        link  body  dto.MemberLinkInput  true  "Аккаунт и клиент"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Клиент не найден"
+// @Failure      409   {object}  response.Response "Уже привязан"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member_accounts [post]
+func (h *MemberHandler) LinkAccount(c *gin.Context) {
+	const op = "handlers.member.LinkAccount"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var input dto.MemberLinkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Error("failed to decode request body", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if input.UserID <= 0 || input.PersonID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error("user_id and person_id are required"))
+		return
+	}
+
+	if err := h.memberService.LinkAccount(c.Request.Context(), input.UserID, input.PersonID); err != nil {
+		switch {
+		case errors.Is(err, memberService.ErrPersonNotFound):
+			c.JSON(http.StatusNotFound, response.Error("person not found"))
+		case errors.Is(err, memberService.ErrMemberExists):
+			c.JSON(http.StatusConflict, response.Error("account or person is already linked"))
+		default:
+			log.Error("failed to link account", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to link account"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("account linked"))
+}
+
+// UnlinkAccount godoc
+// @Summary      Отвязать аккаунт от клиента
+// @Security BearerAuth
+// @Tags         member
+// @Produce      json
+// @Param        user_id  path  int  true  "ID пользователя SSO"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Аккаунт не привязан"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /member_accounts/{user_id} [delete]
+func (h *MemberHandler) UnlinkAccount(c *gin.Context) {
+	const op = "handlers.member.UnlinkAccount"
+
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid user ID"))
+		return
+	}
+
+	if err := h.memberService.UnlinkAccount(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, memberService.ErrNotMember) {
+			c.JSON(http.StatusNotFound, response.Error("account is not linked"))
+			return
+		}
+		h.log.Error("failed to unlink account", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to unlink account"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("account unlinked"))
+}
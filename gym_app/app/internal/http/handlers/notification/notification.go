@@ -0,0 +1,228 @@
+package notificationHandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	notificationService "github.com/Muaz717/gym_app/app/internal/services/notification"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationService interface {
+	Run(ctx context.Context, kind string) (dto.NotificationRun, error)
+	FindNotifications(ctx context.Context, filter dto.NotificationFilter) ([]models.Notification, error)
+	GetContacts(ctx context.Context, personID int) (models.PersonContacts, error)
+	UpdateContacts(ctx context.Context, personID int, input dto.ContactsInput) (models.PersonContacts, error)
+}
+
+type NotificationHandler struct {
+	log                 *slog.Logger
+	notificationService NotificationService
+}
+
+func New(
+	log *slog.Logger,
+	notificationService NotificationService,
+) *NotificationHandler {
+	return &NotificationHandler{
+		log:                 log,
+		notificationService: notificationService,
+	}
+}
+
+// GetContacts godoc
+// @Summary      Контакты клиента
+// @Description  Возвращает email, Telegram, дату рождения и отказ от рассылки
+// @Security BearerAuth
+// @Tags         notifications
+// @Produce      json
+// @Param        person_id  path  int  true  "ID клиента"
+// @Success      200   {object}  models.PersonContacts
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Клиент не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /notifications/contacts/{person_id} [get]
+func (h *NotificationHandler) GetContacts(c *gin.Context) {
+	const op = "handlers.notification.GetContacts"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	personID, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid person ID"))
+		return
+	}
+
+	contacts, err := h.notificationService.GetContacts(c.Request.Context(), personID)
+	if err != nil {
+		if errors.Is(err, notificationService.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("person not found"))
+			return
+		}
+
+		log.Error("failed to get contacts", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get contacts"))
+		return
+	}
+
+	c.JSON(http.StatusOK, contacts)
+}
+
+// UpdateContacts godoc
+// @Summary      Изменить контакты клиента
+// @Description  Перезаписывает контакты клиента для уведомлений, в том числе отказ от рассылки
+// @Security BearerAuth
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        person_id  path  int                true  "ID клиента"
+// @Param        contacts   body  dto.ContactsInput  true  "Контакты"
+// @Success      200   {object}  models.PersonContacts
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Клиент не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /notifications/contacts/{person_id} [put]
+func (h *NotificationHandler) UpdateContacts(c *gin.Context) {
+	const op = "handlers.notification.UpdateContacts"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	personID, err := strconv.Atoi(c.Param("person_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid person ID"))
+		return
+	}
+
+	var input dto.ContactsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	contacts, err := h.notificationService.UpdateContacts(c.Request.Context(), personID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, notificationService.ErrInvalidEmail),
+			errors.Is(err, notificationService.ErrInvalidBirthDate):
+			c.JSON(http.StatusBadRequest, response.Error(errors.Unwrap(err).Error()))
+		case errors.Is(err, notificationService.ErrPersonNotFound):
+			c.JSON(http.StatusNotFound, response.Error("person not found"))
+		default:
+			log.Error("failed to update contacts", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to update contacts"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, contacts)
+}
+
+// FindNotifications godoc
+// @Summary      Журнал уведомлений
+// @Description  Возвращает попытки доставки уведомлений, новые первыми
+// @Security BearerAuth
+// @Tags         notifications
+// @Produce      json
+// @Param        person_id  query  int     false  "ID клиента"
+// @Param        kind       query  string  false  "expiry или birthday"
+// @Param        limit      query  int     false  "Количество записей (по умолчанию 100, максимум 500)"
+// @Success      200   {object}  []models.Notification
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /notifications/log [get]
+func (h *NotificationHandler) FindNotifications(c *gin.Context) {
+	const op = "handlers.notification.FindNotifications"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	filter := dto.NotificationFilter{Kind: c.Query("kind")}
+
+	if v := c.Query("person_id"); v != "" {
+		personID, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("invalid person_id"))
+			return
+		}
+		filter.PersonID = personID
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("invalid limit"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	notifications, err := h.notificationService.FindNotifications(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, notificationService.ErrUnknownKind) {
+			c.JSON(http.StatusBadRequest, response.Error("kind must be one of expiry, birthday"))
+			return
+		}
+
+		log.Error("failed to find notifications", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find notifications"))
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// Run godoc
+// @Summary      Запустить рассылку
+// @Description  Запускает рассылку вне расписания. Уже доставленные уведомления повторно не отправляются.
+// @Security BearerAuth
+// @Tags         notifications
+// @Produce      json
+// @Param        kind  path  string  true  "expiry или birthday"
+// @Success      200   {object}  dto.NotificationRun
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /notifications/run/{kind} [post]
+func (h *NotificationHandler) Run(c *gin.Context) {
+	const op = "handlers.notification.Run"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	run, err := h.notificationService.Run(c.Request.Context(), c.Param("kind"))
+	if err != nil {
+		if errors.Is(err, notificationService.ErrUnknownKind) {
+			c.JSON(http.StatusBadRequest, response.Error("kind must be one of expiry, birthday"))
+			return
+		}
+
+		log.Error("failed to run notifications", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to run notifications"))
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
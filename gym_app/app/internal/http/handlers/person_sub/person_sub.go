@@ -3,8 +3,10 @@ package person_sub
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	personSubService "github.com/Muaz717/gym_app/app/internal/services/person_sub"
 	"github.com/gin-gonic/gin"
@@ -22,6 +24,7 @@ type PersonSubService interface {
 	DeletePersonSub(ctx context.Context, number string) error
 	FindPersonSubByPersonName(ctx context.Context, name string) ([]dto.PersonSubResponse, error)
 	FindPersonSubByPersonId(ctx context.Context, personID int) ([]dto.PersonSubResponse, error)
+	FindExpiringPersonSubs(ctx context.Context, filter dto.ExpiringFilter) ([]dto.ExpiringPersonSub, error)
 }
 
 type PersonSubHandler struct {
@@ -72,10 +75,10 @@ func (h *PersonSubHandler) AddPersonSub(c *gin.Context) {
 		return
 	}
 
-	log.Info("", personSub)
+	log.Info("adding person subscription", slog.Any("person_sub", personSub))
 
 	if err := personSub.Validate(); err != nil {
-		log.Error("failed to validate person subscription", err)
+		log.Error("failed to validate person subscription", slog.Any("errors", err))
 		c.JSON(http.StatusBadRequest, err)
 		return
 	}
@@ -297,6 +300,123 @@ func (h *PersonSubHandler) FindPersonSubByPersonId(c *gin.Context) {
 	c.JSON(http.StatusOK, subs)
 }
 
+// FindExpiringPersonSubs godoc
+// @Summary      Отчет для обзвона клиентов
+// @Description  Возвращает абонементы, заканчивающиеся в ближайшие дни (expiring), закончившиеся без продления (lapsed) или долго замороженные (frozen)
+// @Security BearerAuth
+// @Tags         person_sub
+// @Produce      json
+// @Produce      text/csv
+// @Param        kind             query     string  false  "expiring, lapsed или frozen (по умолчанию expiring)"
+// @Param        days             query     int     false  "Количество дней (по умолчанию 7)"
+// @Param        subscription_id  query     int     false  "ID тарифа"
+// @Param        format           query     string  false  "json, csv или xlsx"
+// @Success      200   {array}   dto.ExpiringPersonSub
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /person_sub/expiring [get]
+func (h *PersonSubHandler) FindExpiringPersonSubs(c *gin.Context) {
+	const op = "handlers.personSub.findExpiringPersonSubs"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	filter := dto.ExpiringFilter{
+		Kind: c.DefaultQuery("kind", dto.ExpiringKindExpiring),
+		Days: defaultExpiringDays,
+	}
+
+	if daysStr := c.Query("days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("invalid days"))
+			return
+		}
+		filter.Days = days
+	}
+
+	if subIDStr := c.Query("subscription_id"); subIDStr != "" {
+		subID, err := strconv.Atoi(subIDStr)
+		if err != nil || subID <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error("invalid subscription_id"))
+			return
+		}
+		filter.SubscriptionID = subID
+	}
+
+	subs, err := h.personSubService.FindExpiringPersonSubs(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, personSubService.ErrInvalidKind) {
+			c.JSON(http.StatusBadRequest, response.Error("kind must be one of expiring, lapsed, frozen"))
+			return
+		}
+		if errors.Is(err, personSubService.ErrInvalidDays) {
+			c.JSON(http.StatusBadRequest, response.Error(err.Error()))
+			return
+		}
+
+		log.Error("failed to find expiring person subscriptions", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find expiring person subscriptions"))
+		return
+	}
+
+	if formatStr := c.Query("format"); formatStr != "" && formatStr != "json" {
+		format, err := export.ParseFormat(formatStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("format must be one of json, csv, xlsx"))
+			return
+		}
+
+		c.Header("Content-Type", format.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", format.FileName(filter.Kind)))
+
+		if err := writeExpiring(format, c.Writer, filter.Kind, subs); err != nil {
+			log.Error("failed to export report", sl.Error(err))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+// defaultExpiringDays — горизонт отчета для обзвона по умолчанию
+const defaultExpiringDays = 7
+
+func writeExpiring(format export.Format, out io.Writer, sheet string, subs []dto.ExpiringPersonSub) error {
+	w, err := export.NewWriter(format, out, sheet, []string{
+		"Номер", "Клиент", "Телефон", "Тариф", "Начало", "Окончание", "Статус", "Осталось дней", "Заморожен с", "Дней в заморозке",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		frozenSince := ""
+		if sub.FrozenSince != nil {
+			frozenSince = export.Date(*sub.FrozenSince)
+		}
+
+		err := w.Write([]string{
+			sub.Number,
+			sub.PersonName,
+			sub.Phone,
+			sub.SubscriptionTitle,
+			export.Date(sub.StartDate),
+			export.Date(sub.EndDate),
+			sub.Status,
+			strconv.Itoa(sub.DaysLeft),
+			frozenSince,
+			strconv.Itoa(sub.FrozenDays),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
 //func (h *PersonSubHandler) UpdatePersonSub(c *gin.Context) {
 //	const op = "handlers.personSub.UpdatePersonSub"
 //
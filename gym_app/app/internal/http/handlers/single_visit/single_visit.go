@@ -2,8 +2,10 @@ package singleVisitHandler
 
 import (
 	"context"
+	"errors"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	singleVisitService "github.com/Muaz717/gym_app/app/internal/services/single_visit"
 	"github.com/gin-gonic/gin"
 	"log/slog"
 	"net/http"
@@ -45,7 +47,17 @@ func (h *SingleVisitHandler) AddSingleVisit(c *gin.Context) {
 		return
 	}
 
+	if req.TariffID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tariff_id is required"})
+		return
+	}
+
 	if err := h.singleVisitService.AddSingleVisit(c.Request.Context(), req); err != nil {
+		if errors.Is(err, singleVisitService.ErrTariffNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tariff not found"})
+			return
+		}
+
 		log.Error("failed to add single visit", slog.Any("error", err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -0,0 +1,249 @@
+package singleVisitTariffHandler
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	singleVisitTariffService "github.com/Muaz717/gym_app/app/internal/services/single_visit_tariff"
+	"github.com/gin-gonic/gin"
+
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type SingleVisitTariffService interface {
+	AddTariff(ctx context.Context, tariff models.SingleVisitTariff) (int, error)
+	FindAllTariffs(ctx context.Context) ([]models.SingleVisitTariff, error)
+	UpdateTariff(ctx context.Context, tariff models.SingleVisitTariff, tariffID int) (int, error)
+	DeleteTariff(ctx context.Context, tariffID int) error
+}
+
+type SingleVisitTariffHandler struct {
+	log           *slog.Logger
+	tariffService SingleVisitTariffService
+}
+
+func New(
+	log *slog.Logger,
+	tariffService SingleVisitTariffService,
+) *SingleVisitTariffHandler {
+	return &SingleVisitTariffHandler{
+		log:           log,
+		tariffService: tariffService,
+	}
+}
+
+// AddTariff godoc
+// @Summary      Добавить тариф разового посещения
+// @Description  Добавляет новый тариф разового посещения
+// @Security BearerAuth
+// @Tags         single_visit_tariff
+// @Accept       json
+// @Produce      json
+// @Param        tariff  body     models.SingleVisitTariff  true  "Тариф"
+// @Success      200   {object}  response.Response "Тариф добавлен"
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      409   {object}  response.Response "Конфликт"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /single_visit_tariff/add [post]
+func (h *SingleVisitTariffHandler) AddTariff(c *gin.Context) {
+	const op = "handlers.single_visit_tariff.addTariff"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var tariff models.SingleVisitTariff
+
+	if err := c.ShouldBindJSON(&tariff); err != nil {
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if tariff.Title == "" {
+		c.JSON(http.StatusBadRequest, response.Error("title is required"))
+		return
+	}
+
+	tariffID, err := h.tariffService.AddTariff(c.Request.Context(), tariff)
+	if err != nil {
+		if errors.Is(err, singleVisitTariffService.ErrInvalidPrice) {
+			c.JSON(http.StatusBadRequest, response.Error("tariff price must be positive"))
+			return
+		}
+		if errors.Is(err, singleVisitTariffService.ErrTariffExists) {
+			c.JSON(http.StatusConflict, response.Error("tariff with that title already exists"))
+			return
+		}
+
+		log.Error("failed to add tariff", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to add tariff"))
+		return
+	}
+
+	log.Info("Tariff added", slog.Int("tariff_id", tariffID))
+	c.JSON(http.StatusOK, response.OK("Tariff added"))
+}
+
+// UpdateTariff godoc
+// @Summary      Обновить тариф разового посещения
+// @Description  Обновляет существующий тариф разового посещения
+// @Security BearerAuth
+// @Tags         single_visit_tariff
+// @Accept       json
+// @Produce      json
+// @Param        id      path     int                       true  "ID тарифа"
+// @Param        tariff  body     models.SingleVisitTariff  true  "Тариф"
+// @Success      200   {object}  response.Response "Тариф обновлен"
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Не найдено"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /single_visit_tariff/update/{id} [put]
+func (h *SingleVisitTariffHandler) UpdateTariff(c *gin.Context) {
+	const op = "handlers.single_visit_tariff.updateTariff"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	tariffID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse tariff ID", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("invalid tariff ID"))
+		return
+	}
+
+	var tariff models.SingleVisitTariff
+
+	if err := c.ShouldBindJSON(&tariff); err != nil {
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if tariff.Title == "" {
+		c.JSON(http.StatusBadRequest, response.Error("title is required"))
+		return
+	}
+
+	if _, err := h.tariffService.UpdateTariff(c.Request.Context(), tariff, tariffID); err != nil {
+		if errors.Is(err, singleVisitTariffService.ErrInvalidPrice) {
+			c.JSON(http.StatusBadRequest, response.Error("tariff price must be positive"))
+			return
+		}
+		if errors.Is(err, singleVisitTariffService.ErrTariffNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("tariff not found"))
+			return
+		}
+		if errors.Is(err, singleVisitTariffService.ErrTariffExists) {
+			c.JSON(http.StatusConflict, response.Error("tariff with that title already exists"))
+			return
+		}
+
+		log.Error("failed to update tariff", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to update tariff"))
+		return
+	}
+
+	log.Info("Tariff updated", slog.Int("tariff_id", tariffID))
+	c.JSON(http.StatusOK, response.OK("Tariff updated"))
+}
+
+// DeleteTariff godoc
+// @Summary      Удалить тариф разового посещения
+// @Description  Удаляет тариф разового посещения по ID
+// @Security BearerAuth
+// @Tags         single_visit_tariff
+// @Accept       json
+// @Produce      json
+// @Param        id  path     int  true  "ID тарифа"
+// @Success      200   {object}  response.Response "Тариф удален"
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Тариф не найден"
+// @Failure      409   {object}  response.Response "Тариф используется в разовых посещениях"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /single_visit_tariff/delete/{id} [delete]
+func (h *SingleVisitTariffHandler) DeleteTariff(c *gin.Context) {
+	const op = "handlers.single_visit_tariff.deleteTariff"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	tariffID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Error("failed to parse tariff ID", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error("invalid tariff ID"))
+		return
+	}
+
+	if err := h.tariffService.DeleteTariff(c.Request.Context(), tariffID); err != nil {
+		if errors.Is(err, singleVisitTariffService.ErrTariffNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("tariff not found"))
+			return
+		}
+		if errors.Is(err, singleVisitTariffService.ErrTariffInUse) {
+			c.JSON(http.StatusConflict, response.Error("tariff is used by single visits"))
+			return
+		}
+
+		log.Error("failed to delete tariff", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to delete tariff"))
+		return
+	}
+
+	log.Info("Tariff deleted")
+	c.JSON(http.StatusOK, response.OK("Tariff deleted"))
+}
+
+// FindAllTariffs godoc
+// @Summary      Получить все тарифы разовых посещений
+// @Description  Возвращает список всех тарифов разовых посещений
+// @Security BearerAuth
+// @Tags         single_visit_tariff
+// @Accept       json
+// @Produce      json
+// @Success      200   {object}  []models.SingleVisitTariff "Список тарифов"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /single_visit_tariff [get]
+func (h *SingleVisitTariffHandler) FindAllTariffs(c *gin.Context) {
+	const op = "handlers.single_visit_tariff.findAllTariffs"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	tariffs, err := h.tariffService.FindAllTariffs(c.Request.Context())
+	if err != nil {
+		log.Error("failed to get tariffs", sl.Error(err))
+
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get tariffs"))
+		return
+	}
+
+	c.JSON(http.StatusOK, tariffs)
+}
@@ -0,0 +1,423 @@
+package staffHandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	authMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/auth"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	staffService "github.com/Muaz717/gym_app/app/internal/services/staff"
+	"github.com/gin-gonic/gin"
+)
+
+type StaffService interface {
+	FindUsers(ctx context.Context, token string, filter dto.StaffUsersFilter) (dto.StaffUsersPage, error)
+	FindRoles(ctx context.Context, token string) ([]dto.StaffRole, error)
+	AssignRole(ctx context.Context, token string, userID int64, role string) error
+	RevokeRole(ctx context.Context, token string, userID int64, role string) error
+	CheckPermission(ctx context.Context, token string, userID int64, permission string) (bool, error)
+	UnlockLogin(ctx context.Context, token, email, ip string) (bool, error)
+	CreateInvite(ctx context.Context, token string, input dto.InviteInput) (dto.CreatedInvite, error)
+	FindInvites(ctx context.Context, token string) ([]dto.StaffInvite, error)
+	RevokeInvite(ctx context.Context, token string, inviteID int64) error
+}
+
+type StaffHandler struct {
+	log          *slog.Logger
+	staffService StaffService
+}
+
+func New(
+	log *slog.Logger,
+	staffService StaffService,
+) *StaffHandler {
+	return &StaffHandler{
+		log:          log,
+		staffService: staffService,
+	}
+}
+
+// FindUsers godoc
+// @Summary      Учетные записи SSO с ролями
+// @Security BearerAuth
+// @Tags         staff
+// @Produce      json
+// @Param        email   query  string  false  "Подстрока email"
+// @Param        limit   query  int     false  "Размер страницы (по умолчанию 50, максимум 500)"
+// @Param        offset  query  int     false  "Смещение"
+// @Success      200   {object}  dto.StaffUsersPage
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/users [get]
+func (h *StaffHandler) FindUsers(c *gin.Context) {
+	const op = "handlers.staff.FindUsers"
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	var filter dto.StaffUsersFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid query parameters"))
+		return
+	}
+
+	page, err := h.staffService.FindUsers(c.Request.Context(), token, filter)
+	if err != nil {
+		h.error(c, op, "failed to find users", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// FindRoles godoc
+// @Summary      Роли и их права
+// @Security BearerAuth
+// @Tags         staff
+// @Produce      json
+// @Success      200   {object}  []dto.StaffRole
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/roles [get]
+func (h *StaffHandler) FindRoles(c *gin.Context) {
+	const op = "handlers.staff.FindRoles"
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	roles, err := h.staffService.FindRoles(c.Request.Context(), token)
+	if err != nil {
+		h.error(c, op, "failed to find roles", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// AssignRole godoc
+// @Summary      Выдать роль пользователю
+// @Description  Пользователь получит новую роль после обновления токена, не позже интервала проверки отзыва
+// @Security BearerAuth
+// @Tags         staff
+// @Accept       json
+// @Produce      json
+// @Param        id     path  int            true  "ID пользователя SSO"
+// @Param        input  body  dto.RoleInput  true  "Роль"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      404   {object}  response.Response "Пользователь или роль не найдены"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/users/{id}/roles [post]
+func (h *StaffHandler) AssignRole(c *gin.Context) {
+	const op = "handlers.staff.AssignRole"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error("invalid user ID"))
+		return
+	}
+
+	var input dto.RoleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	if err := h.staffService.AssignRole(c.Request.Context(), token, userID, input.Role); err != nil {
+		h.error(c, op, "failed to assign role", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("role assigned"))
+}
+
+// RevokeRole godoc
+// @Summary      Отозвать роль у пользователя
+// @Description  Последнего администратора лишить роли admin нельзя
+// @Security BearerAuth
+// @Tags         staff
+// @Produce      json
+// @Param        id    path  int     true  "ID пользователя SSO"
+// @Param        role  path  string  true  "Роль"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      404   {object}  response.Response "Пользователь или роль не найдены"
+// @Failure      409   {object}  response.Response "Последний администратор"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/users/{id}/roles/{role} [delete]
+func (h *StaffHandler) RevokeRole(c *gin.Context) {
+	const op = "handlers.staff.RevokeRole"
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error("invalid user ID"))
+		return
+	}
+
+	if err := h.staffService.RevokeRole(c.Request.Context(), token, userID, c.Param("role")); err != nil {
+		h.error(c, op, "failed to revoke role", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("role revoked"))
+}
+
+// UnlockLogin godoc
+// @Summary      Снять блокировку входа
+// @Description  Сбрасывает счетчик неудачных попыток входа для email и/или IP
+// @Security BearerAuth
+// @Tags         staff
+// @Accept       json
+// @Produce      json
+// @Param        input  body  dto.UnlockLoginInput  true  "Email и/или IP"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/unlock [post]
+func (h *StaffHandler) UnlockLogin(c *gin.Context) {
+	const op = "handlers.staff.UnlockLogin"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	var input dto.UnlockLoginInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	unlocked, err := h.staffService.UnlockLogin(c.Request.Context(), token, input.Email, input.IP)
+	if err != nil {
+		h.error(c, op, "failed to unlock login", err)
+		return
+	}
+
+	if !unlocked {
+		c.JSON(http.StatusOK, response.OK("nothing to unlock"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("login unlocked"))
+}
+
+// CreateInvite godoc
+// @Summary      Пригласить сотрудника
+// @Description  Создает приглашение на регистрацию с заранее назначенными ролями. Код возвращается только в этом ответе
+// @Security BearerAuth
+// @Tags         staff
+// @Accept       json
+// @Produce      json
+// @Param        input  body  dto.InviteInput  true  "Email (необязательно) и роли"
+// @Success      201   {object}  dto.CreatedInvite
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      404   {object}  response.Response "Роль не найдена"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/invites [post]
+func (h *StaffHandler) CreateInvite(c *gin.Context) {
+	const op = "handlers.staff.CreateInvite"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	var input dto.InviteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	invite, err := h.staffService.CreateInvite(c.Request.Context(), token, input)
+	if err != nil {
+		h.error(c, op, "failed to create invite", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+// FindInvites godoc
+// @Summary      Приглашения на регистрацию
+// @Security BearerAuth
+// @Tags         staff
+// @Produce      json
+// @Success      200   {array}   dto.StaffInvite
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/invites [get]
+func (h *StaffHandler) FindInvites(c *gin.Context) {
+	const op = "handlers.staff.FindInvites"
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	invites, err := h.staffService.FindInvites(c.Request.Context(), token)
+	if err != nil {
+		h.error(c, op, "failed to find invites", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+// RevokeInvite godoc
+// @Summary      Отозвать приглашение
+// @Security BearerAuth
+// @Tags         staff
+// @Produce      json
+// @Param        id  path  int  true  "ID приглашения"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      404   {object}  response.Response "Приглашение не найдено или уже использовано"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/invites/{id} [delete]
+func (h *StaffHandler) RevokeInvite(c *gin.Context) {
+	const op = "handlers.staff.RevokeInvite"
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	inviteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || inviteID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error("invalid invite ID"))
+		return
+	}
+
+	if err := h.staffService.RevokeInvite(c.Request.Context(), token, inviteID); err != nil {
+		h.error(c, op, "failed to revoke invite", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("invite revoked"))
+}
+
+// CheckPermission godoc
+// @Summary      Проверить право пользователя
+// @Security BearerAuth
+// @Tags         staff
+// @Produce      json
+// @Param        id          path   int     true  "ID пользователя SSO"
+// @Param        permission  query  string  true  "Право, например users:read"
+// @Success      200   {object}  dto.PermissionCheck
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      403   {object}  response.Response "Недостаточно прав"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /staff/users/{id}/permissions [get]
+func (h *StaffHandler) CheckPermission(c *gin.Context) {
+	const op = "handlers.staff.CheckPermission"
+
+	token, ok := authMiddleware.GetTokenFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.Error("unauthorized"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error("invalid user ID"))
+		return
+	}
+
+	permission := c.Query("permission")
+
+	allowed, err := h.staffService.CheckPermission(c.Request.Context(), token, userID, permission)
+	if err != nil {
+		h.error(c, op, "failed to check permission", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PermissionCheck{
+		Permission: permission,
+		Allowed:    allowed,
+	})
+}
+
+// error отвечает клиенту по ошибке сервиса, внутренние ошибки логируются
+func (h *StaffHandler) error(c *gin.Context, op, msg string, err error) {
+	switch {
+	case errors.Is(err, staffService.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
+	case errors.Is(err, staffService.ErrForbidden):
+		c.JSON(http.StatusForbidden, response.Error(staffService.ErrForbidden.Error()))
+	case errors.Is(err, staffService.ErrNotFound):
+		c.JSON(http.StatusNotFound, response.Error(staffService.ErrNotFound.Error()))
+	case errors.Is(err, staffService.ErrLastAdmin):
+		c.JSON(http.StatusConflict, response.Error(staffService.ErrLastAdmin.Error()))
+	default:
+		h.log.Error(msg, slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error(msg))
+	}
+}
@@ -0,0 +1,214 @@
+package statistics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+)
+
+// table — отчет в табличном виде для выгрузки в файл
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// respond отдает отчет в JSON, а если задан параметр format=csv|xlsx — файлом выгрузки.
+// Таблица строится только при выгрузке.
+func (h *StatHandler) respond(c *gin.Context, report string, body gin.H, toTable func() table) {
+	const op = "handlers.statistics.respond"
+
+	formatStr := c.Query("format")
+	if formatStr == "" || formatStr == "json" {
+		c.JSON(http.StatusOK, body)
+		return
+	}
+
+	format, err := export.ParseFormat(formatStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("format must be one of json, csv, xlsx"))
+		return
+	}
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("report", report),
+	)
+
+	t := toTable()
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", format.FileName(report)))
+
+	w, err := export.NewWriter(format, c.Writer, report, t.header)
+	if err != nil {
+		log.Error("failed to create export writer", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
+		return
+	}
+
+	for _, row := range t.rows {
+		if err := w.Write(row); err != nil {
+			log.Error("failed to write export row", sl.Error(err))
+			return
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		log.Error("failed to finish export", sl.Error(err))
+	}
+}
+
+func scalarTable(title, value string) func() table {
+	return func() table {
+		return table{
+			header: []string{title},
+			rows:   [][]string{{value}},
+		}
+	}
+}
+
+func monthlyTable(stats []dto.MonthlyStat) table {
+	t := table{header: []string{
+		"Месяц", "Доход", "Новые клиенты", "Продано абонементов", "Доход с разовых посещений", "Разовых посещений",
+	}}
+	for _, s := range stats {
+		t.rows = append(t.rows, []string{
+			s.Month.Format("2006-01"),
+			export.Money(s.Income),
+			strconv.Itoa(s.NewClients),
+			strconv.Itoa(s.SoldSubscriptions),
+			export.Money(s.SingleVisitsIncome),
+			strconv.Itoa(s.SingleVisitsCount),
+		})
+	}
+	return t
+}
+
+func tariffTable(stats []dto.SingleVisitTariffStat) table {
+	t := table{header: []string{"Тариф", "Посещений", "Доход"}}
+	for _, s := range stats {
+		t.rows = append(t.rows, []string{s.TariffTitle, strconv.Itoa(s.Count), export.Money(s.Income)})
+	}
+	return t
+}
+
+func retentionTable(s dto.RetentionStat) table {
+	return table{
+		header: []string{"С", "По", "Окно, дней", "Закончилось", "Продлено", "Удержание"},
+		rows: [][]string{{
+			export.Date(s.From),
+			export.Date(s.To),
+			strconv.Itoa(s.WindowDays),
+			strconv.Itoa(s.Expired),
+			strconv.Itoa(s.Renewed),
+			formatRate(s.RetentionRate),
+		}},
+	}
+}
+
+func churnTable(stats []dto.ChurnStat) table {
+	t := table{header: []string{"Месяц", "Активных на начало", "Ушло", "Отток"}}
+	for _, s := range stats {
+		t.rows = append(t.rows, []string{
+			s.Month.Format("2006-01"),
+			strconv.Itoa(s.ActiveAtStart),
+			strconv.Itoa(s.Churned),
+			formatRate(s.ChurnRate),
+		})
+	}
+	return t
+}
+
+func cohortTable(stats []dto.CohortStat) table {
+	months := 0
+	for _, s := range stats {
+		months = max(months, len(s.Active))
+	}
+
+	t := table{header: []string{"Когорта", "Размер"}}
+	for i := 0; i < months; i++ {
+		t.header = append(t.header, fmt.Sprintf("Месяц %d", i))
+	}
+
+	for _, s := range stats {
+		row := []string{s.Cohort.Format("2006-01"), strconv.Itoa(s.Size)}
+		for i := 0; i < months; i++ {
+			if i < len(s.Active) {
+				row = append(row, strconv.Itoa(s.Active[i]))
+			} else {
+				row = append(row, "")
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+func ltvTable(s dto.LTVStat) table {
+	return table{
+		header: []string{"Клиентов", "Выручка", "Средний LTV", "Средний срок, дней"},
+		rows: [][]string{{
+			strconv.Itoa(s.Clients),
+			export.Money(s.TotalRevenue),
+			export.Money(s.AverageLTV),
+			strconv.FormatFloat(s.AverageLifetimeDays, 'f', 1, 64),
+		}},
+	}
+}
+
+func revenueTable(stats []dto.RevenueStat) table {
+	t := table{header: []string{"Период", "Абонемент", "Продано", "Сумма", "Скидки", "Доход"}}
+	for _, s := range stats {
+		t.rows = append(t.rows, []string{
+			export.Date(s.Period),
+			s.SubscriptionTitle,
+			strconv.Itoa(s.Sold),
+			export.Money(s.Gross),
+			export.Money(s.Discount),
+			export.Money(s.Net),
+		})
+	}
+	return t
+}
+
+var weekdays = [7]string{"Пн", "Вт", "Ср", "Чт", "Пт", "Сб", "Вс"}
+
+func heatmapTable(h dto.OccupancyHeatmap) table {
+	t := table{header: []string{"День"}}
+	for hour := 0; hour < 24; hour++ {
+		t.header = append(t.header, fmt.Sprintf("%02d:00", hour))
+	}
+
+	for day, counts := range h.Matrix {
+		row := []string{weekdays[day]}
+		for _, count := range counts {
+			row = append(row, strconv.Itoa(count))
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+func occupancyTable(o dto.Occupancy) table {
+	return table{
+		header: []string{"Время", "По абонементам", "Разовые посещения", "Всего"},
+		rows: [][]string{{
+			export.DateTime(o.At),
+			strconv.Itoa(o.Members),
+			strconv.Itoa(o.SingleVisits),
+			strconv.Itoa(o.Total),
+		}},
+	}
+}
+
+// formatRate выводит долю в процентах
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate*100, 'f', 2, 64) + "%"
+}
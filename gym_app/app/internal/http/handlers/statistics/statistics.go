@@ -2,12 +2,16 @@ package statistics
 
 import (
 	"context"
+	"errors"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	statService "github.com/Muaz717/gym_app/app/internal/services/statistics"
 	"github.com/gin-gonic/gin"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -19,6 +23,7 @@ type StatService interface {
 	TotalSingleVisits(ctx context.Context) (int, error)
 	SingleVisits(ctx context.Context, from, to time.Time) (int, error)
 	SingleVisitsIncome(ctx context.Context) (float64, error)
+	SingleVisitsByTariff(ctx context.Context, from, to time.Time) ([]dto.SingleVisitTariffStat, error)
 	// Статистика по продажам абонементов
 	TotalSoldSubscriptions(ctx context.Context) (int, error)
 	SoldSubscriptions(ctx context.Context, from, to time.Time) (int, error)
@@ -27,8 +32,63 @@ type StatService interface {
 	Income(ctx context.Context, from, to time.Time) (float64, error)
 	// Ежемесячная статистика
 	MonthlyStatistics(ctx context.Context, from, to time.Time) ([]dto.MonthlyStat, error)
+	// Удержание и отток клиентов
+	Retention(ctx context.Context, from, to time.Time, windowDays int) (dto.RetentionStat, error)
+	MonthlyChurn(ctx context.Context, from, to time.Time, windowDays int) ([]dto.ChurnStat, error)
+	Cohorts(ctx context.Context, from, to time.Time) ([]dto.CohortStat, error)
+	LifetimeValue(ctx context.Context) (dto.LTVStat, error)
+	// Доход с разбивкой по периодам и абонементам
+	RevenueReport(ctx context.Context, from, to time.Time, granularity string, byPlan bool) ([]dto.RevenueStat, error)
+	// Загруженность зала
+	VisitsHeatmap(ctx context.Context, from, to time.Time) (dto.OccupancyHeatmap, error)
+	CurrentOccupancy(ctx context.Context) (dto.Occupancy, error)
 }
 
+// dateLayouts — допустимые форматы параметров from и to
+var dateLayouts = []string{time.RFC3339, time.DateOnly}
+
+// parseDateRange разбирает параметры from и to запроса.
+// Даты принимаются в формате RFC3339 или YYYY-MM-DD.
+func parseDateRange(c *gin.Context) (time.Time, time.Time, error) {
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+
+	if fromStr == "" || toStr == "" {
+		return time.Time{}, time.Time{}, errors.New("missing 'from' or 'to' date")
+	}
+
+	from, err := parseDate(fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid 'from' date format")
+	}
+
+	to, err := parseDate(toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid 'to' date format")
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("'from' date must be before 'to' date")
+	}
+
+	return from, to, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// defaultWindowDays — окно продления абонемента по умолчанию для отчетов удержания и оттока
+const defaultWindowDays = 30
+
 type StatHandler struct {
 	log         *slog.Logger
 	statService StatService
@@ -48,30 +108,10 @@ func (h *StatHandler) MonthlyStatistics(c *gin.Context) {
 	const op = "handlers.statistics.monthlyStatistics"
 	log := h.log.With(slog.String("op", op))
 
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
-
-	if fromStr == "" || toStr == "" {
-		c.JSON(http.StatusBadRequest, response.Error("Missing 'from' or 'to' date"))
-		return
-	}
-
-	from, err := time.Parse(time.RFC3339, fromStr)
+	from, to, err := parseDateRange(c)
 	if err != nil {
-		log.Error("failed to parse 'from' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'from' date format"))
-		return
-	}
-
-	to, err := time.Parse(time.RFC3339, toStr)
-	if err != nil {
-		log.Error("failed to parse 'to' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'to' date format"))
-		return
-	}
-
-	if from.After(to) {
-		c.JSON(http.StatusBadRequest, response.Error("'from' date must be before 'to' date"))
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
@@ -82,7 +122,7 @@ func (h *StatHandler) MonthlyStatistics(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"statistics": stats})
+	h.respond(c, "monthly", gin.H{"statistics": stats}, func() table { return monthlyTable(stats) })
 }
 
 func (h *StatHandler) TotalClients(c *gin.Context) {
@@ -99,7 +139,7 @@ func (h *StatHandler) TotalClients(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "total_clients", gin.H{"total": total}, scalarTable("Всего клиентов", strconv.Itoa(total)))
 }
 
 func (h *StatHandler) NewClients(c *gin.Context) {
@@ -109,239 +149,371 @@ func (h *StatHandler) NewClients(c *gin.Context) {
 		slog.String("op", op),
 	)
 
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
-
-	if fromStr == "" || toStr == "" {
-		c.JSON(http.StatusBadRequest, response.Error("Missing 'from' or 'to' date"))
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	from, err := time.Parse(time.RFC3339, fromStr)
+	total, err := h.statService.NewClients(c.Request.Context(), from, to)
 	if err != nil {
-		log.Error("failed to parse 'from' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'from' date format"))
+		log.Error("failed to get new clients", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	to, err := time.Parse(time.RFC3339, toStr)
+	h.respond(c, "new_clients", gin.H{"total": total}, scalarTable("Новые клиенты", strconv.Itoa(total)))
+}
+
+func (h *StatHandler) TotalIncome(c *gin.Context) {
+	const op = "handlers.statistics.totalIncome"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	total, err := h.statService.TotalIncome(c.Request.Context())
 	if err != nil {
-		log.Error("failed to parse 'to' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'to' date format"))
+		log.Error("failed to get total income", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	if from.After(to) {
-		c.JSON(http.StatusBadRequest, response.Error("'from' date must be before 'to' date"))
+	h.respond(c, "total_income", gin.H{"total": total}, scalarTable("Общий доход", export.Money(total)))
+}
+
+func (h *StatHandler) Income(c *gin.Context) {
+	const op = "handlers.statistics.income"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	total, err := h.statService.NewClients(c.Request.Context(), from, to)
+	income, err := h.statService.Income(c.Request.Context(), from, to)
 	if err != nil {
-		log.Error("failed to get new clients", sl.Error(err))
+		log.Error("failed to get income", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "income", gin.H{"income": income}, scalarTable("Доход", export.Money(income)))
 }
 
-func (h *StatHandler) TotalIncome(c *gin.Context) {
-	const op = "handlers.statistics.totalIncome"
+func (h *StatHandler) TotalSoldSubscriptions(c *gin.Context) {
+	const op = "handlers.statistics.totalSoldSubscriptions"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	total, err := h.statService.TotalIncome(c.Request.Context())
+	total, err := h.statService.TotalSoldSubscriptions(c.Request.Context())
 	if err != nil {
-		log.Error("failed to get total income", sl.Error(err))
+		log.Error("failed to get total sold subscriptions", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "total_sold_subscriptions", gin.H{"total": total}, scalarTable("Всего продано абонементов", strconv.Itoa(total)))
 }
 
-func (h *StatHandler) Income(c *gin.Context) {
-	const op = "handlers.statistics.income"
+func (h *StatHandler) SoldSubscriptions(c *gin.Context) {
+	const op = "handlers.statistics.soldSubscriptions"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
-
-	if fromStr == "" || toStr == "" {
-		c.JSON(http.StatusBadRequest, response.Error("Missing 'from' or 'to' date"))
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	from, err := time.Parse(time.RFC3339, fromStr)
+	total, err := h.statService.SoldSubscriptions(c.Request.Context(), from, to)
 	if err != nil {
-		log.Error("failed to parse 'from' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'from' date format"))
+		log.Error("failed to get sold subscriptions", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	to, err := time.Parse(time.RFC3339, toStr)
+	h.respond(c, "sold_subscriptions", gin.H{"total": total}, scalarTable("Продано абонементов", strconv.Itoa(total)))
+}
+
+func (h *StatHandler) TotalSingleVisits(c *gin.Context) {
+	const op = "handlers.statistics.totalSingleVisits"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	total, err := h.statService.TotalSingleVisits(c.Request.Context())
 	if err != nil {
-		log.Error("failed to parse 'to' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'to' date format"))
+		log.Error("failed to get total single visits", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	if from.After(to) {
-		c.JSON(http.StatusBadRequest, response.Error("'from' date must be before 'to' date"))
+	h.respond(c, "total_single_visits", gin.H{"total": total}, scalarTable("Всего разовых посещений", strconv.Itoa(total)))
+}
+
+func (h *StatHandler) SingleVisits(c *gin.Context) {
+	const op = "handlers.statistics.singleVisits"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	income, err := h.statService.Income(c.Request.Context(), from, to)
+	total, err := h.statService.SingleVisits(c.Request.Context(), from, to)
 	if err != nil {
-		log.Error("failed to get income", sl.Error(err))
+		log.Error("failed to get single visits", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"income": income})
+	h.respond(c, "single_visits", gin.H{"total": total}, scalarTable("Разовые посещения", strconv.Itoa(total)))
 }
 
-func (h *StatHandler) TotalSoldSubscriptions(c *gin.Context) {
-	const op = "handlers.statistics.totalSoldSubscriptions"
+func (h *StatHandler) SingleVisitsIncome(c *gin.Context) {
+	const op = "handlers.statistics.singleVisitsIncome"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	total, err := h.statService.TotalSoldSubscriptions(c.Request.Context())
+	income, err := h.statService.SingleVisitsIncome(c.Request.Context())
 	if err != nil {
-		log.Error("failed to get total sold subscriptions", sl.Error(err))
+		log.Error("failed to get single visits income", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "single_visits_income", gin.H{"income": income}, scalarTable("Доход с разовых посещений", export.Money(income)))
 }
 
-func (h *StatHandler) SoldSubscriptions(c *gin.Context) {
-	const op = "handlers.statistics.soldSubscriptions"
+func (h *StatHandler) SingleVisitsByTariff(c *gin.Context) {
+	const op = "handlers.statistics.singleVisitsByTariff"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
+		return
+	}
 
-	if fromStr == "" || toStr == "" {
-		c.JSON(http.StatusBadRequest, response.Error("Missing 'from' or 'to' date"))
+	stats, err := h.statService.SingleVisitsByTariff(c.Request.Context(), from, to)
+	if err != nil {
+		log.Error("failed to get single visits by tariff", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	from, err := time.Parse(time.RFC3339, fromStr)
+	h.respond(c, "single_visits_by_tariff", gin.H{"statistics": stats}, func() table { return tariffTable(stats) })
+}
+
+func (h *StatHandler) Retention(c *gin.Context) {
+	const op = "handlers.statistics.retention"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	from, to, err := parseDateRange(c)
 	if err != nil {
-		log.Error("failed to parse 'from' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'from' date format"))
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	to, err := time.Parse(time.RFC3339, toStr)
+	windowDays := defaultWindowDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		windowDays, err = strconv.Atoi(daysStr)
+		if err != nil || windowDays <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error("'days' must be a positive number"))
+			return
+		}
+	}
+
+	stats, err := h.statService.Retention(c.Request.Context(), from, to, windowDays)
 	if err != nil {
-		log.Error("failed to parse 'to' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'to' date format"))
+		log.Error("failed to get retention", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	if from.After(to) {
-		c.JSON(http.StatusBadRequest, response.Error("'from' date must be before 'to' date"))
+	h.respond(c, "retention", gin.H{"statistics": stats}, func() table { return retentionTable(stats) })
+}
+
+func (h *StatHandler) MonthlyChurn(c *gin.Context) {
+	const op = "handlers.statistics.monthlyChurn"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	total, err := h.statService.SoldSubscriptions(c.Request.Context(), from, to)
+	windowDays := defaultWindowDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		windowDays, err = strconv.Atoi(daysStr)
+		if err != nil || windowDays <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error("'days' must be a positive number"))
+			return
+		}
+	}
+
+	stats, err := h.statService.MonthlyChurn(c.Request.Context(), from, to, windowDays)
 	if err != nil {
-		log.Error("failed to get sold subscriptions", sl.Error(err))
+		log.Error("failed to get monthly churn", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "churn", gin.H{"statistics": stats}, func() table { return churnTable(stats) })
 }
 
-func (h *StatHandler) TotalSingleVisits(c *gin.Context) {
-	const op = "handlers.statistics.totalSingleVisits"
+func (h *StatHandler) Cohorts(c *gin.Context) {
+	const op = "handlers.statistics.cohorts"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	total, err := h.statService.TotalSingleVisits(c.Request.Context())
+	from, to, err := parseDateRange(c)
 	if err != nil {
-		log.Error("failed to get total single visits", sl.Error(err))
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
+		return
+	}
+
+	stats, err := h.statService.Cohorts(c.Request.Context(), from, to)
+	if err != nil {
+		log.Error("failed to get cohorts", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "cohorts", gin.H{"statistics": stats}, func() table { return cohortTable(stats) })
 }
 
-func (h *StatHandler) SingleVisits(c *gin.Context) {
-	const op = "handlers.statistics.singleVisits"
+func (h *StatHandler) LifetimeValue(c *gin.Context) {
+	const op = "handlers.statistics.lifetimeValue"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
-
-	if fromStr == "" || toStr == "" {
-		c.JSON(http.StatusBadRequest, response.Error("Missing 'from' or 'to' date"))
+	stat, err := h.statService.LifetimeValue(c.Request.Context())
+	if err != nil {
+		log.Error("failed to get lifetime value", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	from, err := time.Parse(time.RFC3339, fromStr)
+	h.respond(c, "ltv", gin.H{"statistics": stat}, func() table { return ltvTable(stat) })
+}
+
+func (h *StatHandler) RevenueReport(c *gin.Context) {
+	const op = "handlers.statistics.revenueReport"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	from, to, err := parseDateRange(c)
 	if err != nil {
-		log.Error("failed to parse 'from' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'from' date format"))
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	to, err := time.Parse(time.RFC3339, toStr)
+	granularity := c.DefaultQuery("granularity", dto.GranularityMonth)
+
+	byPlan := false
+	if byPlanStr := c.Query("by_plan"); byPlanStr != "" {
+		byPlan, err = strconv.ParseBool(byPlanStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("'by_plan' must be a boolean"))
+			return
+		}
+	}
+
+	stats, err := h.statService.RevenueReport(c.Request.Context(), from, to, granularity, byPlan)
 	if err != nil {
-		log.Error("failed to parse 'to' date", sl.Error(err))
-		c.JSON(http.StatusBadRequest, response.Error("Invalid 'to' date format"))
+		if errors.Is(err, statService.ErrInvalidGranularity) {
+			c.JSON(http.StatusBadRequest, response.Error("'granularity' must be one of day, week, month, year"))
+			return
+		}
+
+		log.Error("failed to get revenue report", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	if from.After(to) {
-		c.JSON(http.StatusBadRequest, response.Error("'from' date must be before 'to' date"))
+	h.respond(c, "revenue", gin.H{"statistics": stats}, func() table { return revenueTable(stats) })
+}
+
+func (h *StatHandler) VisitsHeatmap(c *gin.Context) {
+	const op = "handlers.statistics.visitsHeatmap"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		log.Warn("invalid date range", sl.Error(err))
+		c.JSON(http.StatusBadRequest, response.Error(err.Error()))
 		return
 	}
 
-	total, err := h.statService.SingleVisits(c.Request.Context(), from, to)
+	heatmap, err := h.statService.VisitsHeatmap(c.Request.Context(), from, to)
 	if err != nil {
-		log.Error("failed to get single visits", sl.Error(err))
+		log.Error("failed to get visits heatmap", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"total": total})
+	h.respond(c, "heatmap", gin.H{"statistics": heatmap}, func() table { return heatmapTable(heatmap) })
 }
 
-func (h *StatHandler) SingleVisitsIncome(c *gin.Context) {
-	const op = "handlers.statistics.singleVisitsIncome"
+func (h *StatHandler) CurrentOccupancy(c *gin.Context) {
+	const op = "handlers.statistics.currentOccupancy"
 
 	log := h.log.With(
 		slog.String("op", op),
 	)
 
-	income, err := h.statService.SingleVisitsIncome(c.Request.Context())
+	occ, err := h.statService.CurrentOccupancy(c.Request.Context())
 	if err != nil {
-		log.Error("failed to get single visits income", sl.Error(err))
+		log.Error("failed to get current occupancy", sl.Error(err))
 		c.JSON(http.StatusInternalServerError, response.Error("Internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"income": income})
+	h.respond(c, "occupancy", gin.H{"statistics": occ}, func() table { return occupancyTable(occ) })
 }
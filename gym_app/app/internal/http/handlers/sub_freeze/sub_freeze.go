@@ -2,10 +2,14 @@ package subFreezeHandler
 
 import (
 	"context"
+	"errors"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	subFreezeService "github.com/Muaz717/gym_app/app/internal/services/sub_freeze"
 	"github.com/gin-gonic/gin"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -13,6 +17,9 @@ type SubFreezeService interface {
 	FreezeSubscription(ctx context.Context, subscriptionNumber string, freezeStart time.Time) error
 	UnfreezeSubscription(ctx context.Context, subscriptionNumber string, unfreezeDate time.Time) error
 	GetAllActiveFreeze(ctx context.Context) ([]models.SubscriptionFreeze, error)
+	FindFreezeRequests(ctx context.Context, filter dto.FreezeRequestFilter) ([]models.FreezeRequest, error)
+	ApproveFreezeRequest(ctx context.Context, id int) error
+	RejectFreezeRequest(ctx context.Context, id int) error
 }
 
 type SubFreezeHandler struct {
@@ -100,3 +107,82 @@ func (h *SubFreezeHandler) GetAllActiveFreeze(c *gin.Context) {
 
 	c.JSON(http.StatusOK, freezedSubs)
 }
+
+// FindFreezeRequests godoc
+// @Summary      Заявки на заморозку
+// @Description  Возвращает заявки клиентов на заморозку, новые первыми
+// @Security BearerAuth
+// @Tags         sub_freeze
+// @Produce      json
+// @Param        status  query  string  false  "pending, approved или rejected"
+// @Success      200   {object}  []models.FreezeRequest
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /freeze/requests [get]
+func (h *SubFreezeHandler) FindFreezeRequests(c *gin.Context) {
+	log := h.log.With(slog.String("op", "handlers.sub_freeze.FindFreezeRequests"))
+
+	requests, err := h.subFreezeService.FindFreezeRequests(c.Request.Context(), dto.FreezeRequestFilter{Status: c.Query("status")})
+	if err != nil {
+		if errors.Is(err, subFreezeService.ErrInvalidStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": subFreezeService.ErrInvalidStatus.Error()})
+			return
+		}
+		log.Error("failed to find freeze requests", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to find freeze requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// ApproveFreezeRequest godoc
+// @Summary      Одобрить заявку на заморозку
+// @Description  Замораживает абонемент с даты из заявки
+// @Security BearerAuth
+// @Tags         sub_freeze
+// @Produce      json
+// @Param        id  path  int  true  "ID заявки"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Заморозка невозможна"
+// @Failure      404   {object}  response.Response "Необработанная заявка не найдена"
+// @Router       /freeze/requests/{id}/approve [post]
+func (h *SubFreezeHandler) ApproveFreezeRequest(c *gin.Context) {
+	h.decideFreezeRequest(c, "handlers.sub_freeze.ApproveFreezeRequest", h.subFreezeService.ApproveFreezeRequest, "freeze request approved")
+}
+
+// RejectFreezeRequest godoc
+// @Summary      Отклонить заявку на заморозку
+// @Security BearerAuth
+// @Tags         sub_freeze
+// @Produce      json
+// @Param        id  path  int  true  "ID заявки"
+// @Success      200   {object}  response.Response
+// @Failure      404   {object}  response.Response "Необработанная заявка не найдена"
+// @Router       /freeze/requests/{id}/reject [post]
+func (h *SubFreezeHandler) RejectFreezeRequest(c *gin.Context) {
+	h.decideFreezeRequest(c, "handlers.sub_freeze.RejectFreezeRequest", h.subFreezeService.RejectFreezeRequest, "freeze request rejected")
+}
+
+func (h *SubFreezeHandler) decideFreezeRequest(c *gin.Context, op string, decide func(ctx context.Context, id int) error, message string) {
+	log := h.log.With(slog.String("op", op))
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request ID"})
+		return
+	}
+
+	if err := decide(c.Request.Context(), id); err != nil {
+		if errors.Is(err, subFreezeService.ErrFreezeRequestNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": subFreezeService.ErrFreezeRequestNotFound.Error()})
+			return
+		}
+		// Как и при ручной заморозке, причина отказа (лимит дней и т.п.) уходит клиенту
+		log.Error("failed to decide freeze request", slog.Any("error", err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
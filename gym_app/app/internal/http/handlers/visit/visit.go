@@ -0,0 +1,151 @@
+package visitHandler
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	visitService "github.com/Muaz717/gym_app/app/internal/services/visit"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+)
+
+type VisitService interface {
+	CheckIn(ctx context.Context, subscriptionNumber string) (models.Visit, error)
+	CheckOut(ctx context.Context, subscriptionNumber string) (models.Visit, error)
+	GetOpenVisits(ctx context.Context) ([]models.Visit, error)
+}
+
+type VisitHandler struct {
+	log          *slog.Logger
+	visitService VisitService
+}
+
+func New(
+	log *slog.Logger,
+	visitService VisitService,
+) *VisitHandler {
+	return &VisitHandler{
+		log:          log,
+		visitService: visitService,
+	}
+}
+
+type VisitRequest struct {
+	SubscriptionNumber string `json:"subscription_number"`
+}
+
+// CheckIn godoc
+// @Summary      Отметить приход
+// @Description  Отмечает приход клиента по номеру абонемента
+// @Security BearerAuth
+// @Tags         visit
+// @Accept       json
+// @Produce      json
+// @Param        visit  body     VisitRequest  true  "Номер абонемента"
+// @Success      200   {object}  models.Visit
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Абонемент не найден"
+// @Failure      409   {object}  response.Response "Клиент уже в зале"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /visit/check_in [post]
+func (h *VisitHandler) CheckIn(c *gin.Context) {
+	const op = "handlers.visit.checkIn"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req VisitRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.SubscriptionNumber == "" {
+		c.JSON(http.StatusBadRequest, response.Error("subscription_number is required"))
+		return
+	}
+
+	visit, err := h.visitService.CheckIn(c.Request.Context(), req.SubscriptionNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, visitService.ErrSubNotFound):
+			c.JSON(http.StatusNotFound, response.Error("subscription not found"))
+		case errors.Is(err, visitService.ErrSubInactive):
+			c.JSON(http.StatusBadRequest, response.Error("subscription is not active"))
+		case errors.Is(err, visitService.ErrAlreadyCheckedIn):
+			c.JSON(http.StatusConflict, response.Error("client already checked in"))
+		default:
+			log.Error("failed to check in", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to check in"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, visit)
+}
+
+// CheckOut godoc
+// @Summary      Отметить уход
+// @Description  Закрывает текущее посещение клиента по номеру абонемента
+// @Security BearerAuth
+// @Tags         visit
+// @Accept       json
+// @Produce      json
+// @Param        visit  body     VisitRequest  true  "Номер абонемента"
+// @Success      200   {object}  models.Visit
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Незакрытое посещение не найдено"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /visit/check_out [post]
+func (h *VisitHandler) CheckOut(c *gin.Context) {
+	const op = "handlers.visit.checkOut"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var req VisitRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.SubscriptionNumber == "" {
+		c.JSON(http.StatusBadRequest, response.Error("subscription_number is required"))
+		return
+	}
+
+	visit, err := h.visitService.CheckOut(c.Request.Context(), req.SubscriptionNumber)
+	if err != nil {
+		if errors.Is(err, visitService.ErrOpenVisitNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("open visit not found"))
+			return
+		}
+
+		log.Error("failed to check out", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to check out"))
+		return
+	}
+
+	c.JSON(http.StatusOK, visit)
+}
+
+// GetOpenVisits godoc
+// @Summary      Клиенты в зале
+// @Description  Возвращает сегодняшние посещения по абонементам без отметки ухода
+// @Security BearerAuth
+// @Tags         visit
+// @Produce      json
+// @Success      200   {array}   models.Visit
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /visit [get]
+func (h *VisitHandler) GetOpenVisits(c *gin.Context) {
+	const op = "handlers.visit.getOpenVisits"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	visits, err := h.visitService.GetOpenVisits(c.Request.Context())
+	if err != nil {
+		log.Error("failed to get open visits", sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to get open visits"))
+		return
+	}
+
+	c.JSON(http.StatusOK, visits)
+}
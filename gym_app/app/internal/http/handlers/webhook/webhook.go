@@ -0,0 +1,252 @@
+package webhookHandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/api/response"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	webhookService "github.com/Muaz717/gym_app/app/internal/services/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, input dto.WebhookInput) (models.Webhook, error)
+	FindWebhooks(ctx context.Context) ([]models.Webhook, error)
+	SetActive(ctx context.Context, id int, active bool) error
+	DeleteWebhook(ctx context.Context, id int) error
+	FindDeliveries(ctx context.Context, webhookID int, limit int) ([]models.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	log            *slog.Logger
+	webhookService WebhookService
+}
+
+func New(
+	log *slog.Logger,
+	webhookService WebhookService,
+) *WebhookHandler {
+	return &WebhookHandler{
+		log:            log,
+		webhookService: webhookService,
+	}
+}
+
+// Events godoc
+// @Summary      Типы событий
+// @Description  Список событий, на которые можно подписать вебхук
+// @Security BearerAuth
+// @Tags         webhooks
+// @Produce      json
+// @Success      200   {object}  []string
+// @Router       /webhooks/events [get]
+func (h *WebhookHandler) Events(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.WebhookEvents)
+}
+
+// FindWebhooks godoc
+// @Summary      Список вебхуков
+// @Security BearerAuth
+// @Tags         webhooks
+// @Produce      json
+// @Success      200   {object}  []models.Webhook
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /webhooks [get]
+func (h *WebhookHandler) FindWebhooks(c *gin.Context) {
+	const op = "handlers.webhook.FindWebhooks"
+
+	webhooks, err := h.webhookService.FindWebhooks(c.Request.Context())
+	if err != nil {
+		h.log.Error("failed to find webhooks", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find webhooks"))
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// CreateWebhook godoc
+// @Summary      Зарегистрировать вебхук
+// @Description  Секрет для проверки подписи возвращается только в этом ответе; если не передан — генерируется
+// @Security BearerAuth
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body  dto.WebhookInput  true  "Адрес и события"
+// @Success      201   {object}  models.Webhook
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	const op = "handlers.webhook.CreateWebhook"
+
+	log := h.log.With(
+		slog.String("op", op),
+	)
+
+	var input dto.WebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		if errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, response.Error("empty request"))
+			return
+		}
+
+		log.Error("failed to decode request body", sl.Error(err))
+
+		c.JSON(http.StatusBadRequest, response.Error("failed to decode request"))
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, webhookService.ErrInvalidURL):
+			c.JSON(http.StatusBadRequest, response.Error(webhookService.ErrInvalidURL.Error()))
+		case errors.Is(err, webhookService.ErrInvalidEvents):
+			c.JSON(http.StatusBadRequest, response.Error(webhookService.ErrInvalidEvents.Error()))
+		default:
+			log.Error("failed to create webhook", sl.Error(err))
+			c.JSON(http.StatusInternalServerError, response.Error("failed to create webhook"))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// EnableWebhook godoc
+// @Summary      Включить вебхук
+// @Security BearerAuth
+// @Tags         webhooks
+// @Produce      json
+// @Param        id  path  int  true  "ID вебхука"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Вебхук не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /webhooks/{id}/enable [post]
+func (h *WebhookHandler) EnableWebhook(c *gin.Context) {
+	h.setActive(c, true)
+}
+
+// DisableWebhook godoc
+// @Summary      Выключить вебхук
+// @Description  Новые события выключенному вебхуку не назначаются, уже созданные доставки продолжают отправляться
+// @Security BearerAuth
+// @Tags         webhooks
+// @Produce      json
+// @Param        id  path  int  true  "ID вебхука"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Вебхук не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /webhooks/{id}/disable [post]
+func (h *WebhookHandler) DisableWebhook(c *gin.Context) {
+	h.setActive(c, false)
+}
+
+func (h *WebhookHandler) setActive(c *gin.Context, active bool) {
+	const op = "handlers.webhook.setActive"
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid webhook ID"))
+		return
+	}
+
+	if err := h.webhookService.SetActive(c.Request.Context(), id, active); err != nil {
+		if errors.Is(err, webhookService.ErrWebhookNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("webhook not found"))
+			return
+		}
+		h.log.Error("failed to update webhook", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to update webhook"))
+		return
+	}
+
+	if active {
+		c.JSON(http.StatusOK, response.OK("webhook enabled"))
+		return
+	}
+	c.JSON(http.StatusOK, response.OK("webhook disabled"))
+}
+
+// DeleteWebhook godoc
+// @Summary      Удалить вебхук
+// @Description  Удаляет вебхук вместе с журналом доставок
+// @Security BearerAuth
+// @Tags         webhooks
+// @Produce      json
+// @Param        id  path  int  true  "ID вебхука"
+// @Success      200   {object}  response.Response
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      404   {object}  response.Response "Вебхук не найден"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	const op = "handlers.webhook.DeleteWebhook"
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid webhook ID"))
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		if errors.Is(err, webhookService.ErrWebhookNotFound) {
+			c.JSON(http.StatusNotFound, response.Error("webhook not found"))
+			return
+		}
+		h.log.Error("failed to delete webhook", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to delete webhook"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.OK("webhook deleted"))
+}
+
+// FindDeliveries godoc
+// @Summary      Журнал доставок вебхука
+// @Security BearerAuth
+// @Tags         webhooks
+// @Produce      json
+// @Param        id     path   int  true   "ID вебхука"
+// @Param        limit  query  int  false  "Сколько последних доставок вернуть (по умолчанию 50, максимум 500)"
+// @Success      200   {object}  []models.WebhookDelivery
+// @Failure      400   {object}  response.Response "Ошибка валидации"
+// @Failure      500   {object}  response.Response "Внутренняя ошибка сервера"
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) FindDeliveries(c *gin.Context) {
+	const op = "handlers.webhook.FindDeliveries"
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("invalid webhook ID"))
+		return
+	}
+
+	var limit int
+	if v := c.Query("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error("limit must be a positive number"))
+			return
+		}
+	}
+
+	deliveries, err := h.webhookService.FindDeliveries(c.Request.Context(), id, limit)
+	if err != nil {
+		h.log.Error("failed to find deliveries", slog.String("op", op), sl.Error(err))
+		c.JSON(http.StatusInternalServerError, response.Error("failed to find deliveries"))
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
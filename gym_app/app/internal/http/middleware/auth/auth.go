@@ -1,19 +1,59 @@
 package authMiddleware
 
 import (
+	"context"
 	"fmt"
 	"github.com/Muaz717/gym_app/app/internal/clients/sso/grpc"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	ssov1 "github.com/Muaz717/gym_app/app/pkg/sso"
 	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"log/slog"
 	"net/http"
 )
 
-const userContextKey = "user"
+const (
+	userContextKey  = "user"
+	tokenContextKey = "token"
+)
+
+// TokenVerifier проверяет access-токен. Недействительный токен — ошибка с codes.Unauthenticated
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*ssov1.CheckTokenResponse, error)
+}
+
+// refreshes объединяет одновременные обновления по одному refresh-токену: после истечения access-токена
+// страница и SSE-поток приходят с одной и той же cookie, а повторное предъявление токена SSO считает кражей
+var refreshes singleflight.Group
+
+// refreshOnce обновляет пару токенов, параллельные запросы с тем же refresh-токеном получают общий результат
+func refreshOnce(ctx context.Context, ssoClient *grpc.SSOClient, appId int32, refreshToken string) (dto.AuthTokens, error) {
+	// Контекст первого запроса не должен отменять обновление для остальных
+	ctx = context.WithoutCancel(ctx)
 
-func AuthMiddleware(log *slog.Logger, ssoClient *grpc.SSOClient, appId int32, requiredRole string) gin.HandlerFunc {
+	tokens, err, _ := refreshes.Do(refreshToken, func() (interface{}, error) {
+		return ssoClient.Refresh(ctx, appId, refreshToken)
+	})
+	if err != nil {
+		return dto.AuthTokens{}, err
+	}
+
+	return tokens.(dto.AuthTokens), nil
+}
+
+// AuthMiddleware проверяет access-токен из cookie и кладет пользователя в контекст.
+// Права на конкретный маршрут проверяет RequirePermission
+func AuthMiddleware(
+	log *slog.Logger,
+	ssoClient *grpc.SSOClient,
+	verifier TokenVerifier,
+	appId int32,
+) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		const op = "middleware.AuthMiddleware"
 
@@ -35,13 +75,45 @@ func AuthMiddleware(log *slog.Logger, ssoClient *grpc.SSOClient, appId int32, re
 		//	return
 		//}
 
-		token, err := c.Cookie("token")
-		if err != nil || token == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		// Пользователь уже проверен предыдущим AuthMiddleware в цепочке
+		if _, ok := GetUserFromContext(c); ok {
+			c.Next()
 			return
 		}
 
-		resp, err := ssoClient.CheckToken(c.Request.Context(), appId, token)
+		token, _ := c.Cookie(AccessTokenCookie)
+
+		var (
+			resp *ssov1.CheckTokenResponse
+			err  error
+		)
+		if token != "" {
+			resp, err = verifier.Verify(c.Request.Context(), token)
+		}
+
+		// Access-токен истек или отозван — прозрачно обновляем его по refresh-cookie
+		if token == "" || status.Code(err) == codes.Unauthenticated {
+			refreshToken, _ := c.Cookie(RefreshTokenCookie)
+			if refreshToken == "" {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+				return
+			}
+
+			tokens, refreshErr := refreshOnce(c.Request.Context(), ssoClient, appId, refreshToken)
+			if refreshErr != nil {
+				reqLog.Warn("failed to refresh token", slog.String("op", op), sl.Error(refreshErr))
+				if status.Code(refreshErr) == codes.Unauthenticated {
+					ClearTokenCookies(c)
+				}
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+				return
+			}
+
+			SetTokenCookies(c, tokens)
+			token = tokens.AccessToken
+			resp, err = verifier.Verify(c.Request.Context(), token)
+		}
+
 		if err != nil {
 			reqLog.Error("failed to check token", slog.String("op", op), sl.Error(err))
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token validation failed"})
@@ -51,6 +123,7 @@ func AuthMiddleware(log *slog.Logger, ssoClient *grpc.SSOClient, appId int32, re
 		reqLog.Info("token check result",
 
 			slog.Any("roles", resp.Roles),
+			slog.Any("permissions", resp.Permissions),
 			slog.String("token", token),
 			slog.Int("app_id", int(appId)),
 		)
@@ -61,25 +134,48 @@ func AuthMiddleware(log *slog.Logger, ssoClient *grpc.SSOClient, appId int32, re
 			return
 		}
 
-		hasRequiredRole := false
-		for _, role := range resp.Roles {
-			if role == requiredRole {
-				hasRequiredRole = true
-				break
-			}
+		c.Set(userContextKey, resp)
+		c.Set(tokenContextKey, token)
+		c.Next()
+	}
+}
+
+// RequirePermission пропускает пользователя, у которого в токене есть право permission или "*".
+// Должен стоять после AuthMiddleware.
+func RequirePermission(log *slog.Logger, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "middleware.RequirePermission"
+
+		user, ok := GetUserFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
 		}
 
-		if !hasRequiredRole {
-			reqLog.Warn(fmt.Sprintf("%s role required", requiredRole), slog.String("op", op), slog.Int64("user_id", resp.GetUserId()))
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("%s role required", requiredRole)})
+		if !HasPermission(user, permission) {
+			log.Warn("permission required",
+				slog.String("op", op),
+				slog.String("permission", permission),
+				slog.Int64("user_id", user.GetUserId()),
+			)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("%s permission required", permission)})
 			return
 		}
 
-		c.Set(userContextKey, resp)
 		c.Next()
 	}
 }
 
+// HasPermission сообщает, есть ли у пользователя право permission
+func HasPermission(user *ssov1.CheckTokenResponse, permission string) bool {
+	for _, p := range user.GetPermissions() {
+		if p == permission || p == models.PermissionAll {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUserFromContext достаёт пользователя (SSO CheckTokenResponse) из контекста gin
 func GetUserFromContext(c *gin.Context) (*ssov1.CheckTokenResponse, bool) {
 	val, exists := c.Get(userContextKey)
@@ -89,3 +185,9 @@ func GetUserFromContext(c *gin.Context) (*ssov1.CheckTokenResponse, bool) {
 	user, ok := val.(*ssov1.CheckTokenResponse)
 	return user, ok
 }
+
+// GetTokenFromContext возвращает проверенный access-токен запроса, в том числе только что обновленный
+func GetTokenFromContext(c *gin.Context) (string, bool) {
+	token := c.GetString(tokenContextKey)
+	return token, token != ""
+}
@@ -0,0 +1,75 @@
+package authMiddleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	authMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/auth"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	ssov1 "github.com/Muaz717/gym_app/app/pkg/sso"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		user       *ssov1.CheckTokenResponse
+		permission string
+		wantStatus int
+	}{
+		{
+			name:       "receptionist sells subscription",
+			user:       &ssov1.CheckTokenResponse{UserId: 1, Permissions: []string{models.PermissionPersonSubRead, models.PermissionPersonSubCreate}},
+			permission: models.PermissionPersonSubCreate,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "receptionist can't delete subscription",
+			user:       &ssov1.CheckTokenResponse{UserId: 1, Permissions: []string{models.PermissionPersonSubRead, models.PermissionPersonSubCreate}},
+			permission: models.PermissionPersonSubDelete,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin has every permission",
+			user:       &ssov1.CheckTokenResponse{UserId: 2, Roles: []string{"admin"}, Permissions: []string{models.PermissionAll}},
+			permission: models.PermissionWebhooksManage,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "role without permission",
+			user:       &ssov1.CheckTokenResponse{UserId: 3, Roles: []string{"admin"}},
+			permission: models.PermissionStatisticsRead,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "not authenticated",
+			permission: models.PermissionStatisticsRead,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/",
+				func(c *gin.Context) {
+					if tt.user != nil {
+						c.Set("user", tt.user)
+					}
+				},
+				authMiddleware.RequirePermission(slogdiscard.NewDiscardLogger(), tt.permission),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
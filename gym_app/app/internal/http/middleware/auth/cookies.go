@@ -0,0 +1,24 @@
+package authMiddleware
+
+import (
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AccessTokenCookie  = "token"
+	RefreshTokenCookie = "refresh_token"
+)
+
+// SetTokenCookies сохраняет пару токенов в cookie. Access-cookie живет столько же, сколько токен:
+// когда она пропадает, токен обновляется по refresh-cookie.
+func SetTokenCookies(c *gin.Context, tokens dto.AuthTokens) {
+	c.SetCookie(AccessTokenCookie, tokens.AccessToken, int(tokens.AccessExpiresIn.Seconds()), "/", "", false, true)
+	c.SetCookie(RefreshTokenCookie, tokens.RefreshToken, int(tokens.RefreshExpiresIn.Seconds()), "/", "", false, true)
+}
+
+// ClearTokenCookies удаляет оба токена у клиента
+func ClearTokenCookies(c *gin.Context) {
+	c.SetCookie(AccessTokenCookie, "", -1, "/", "", false, true)
+	c.SetCookie(RefreshTokenCookie, "", -1, "/", "", false, true)
+}
@@ -0,0 +1,59 @@
+package deviceMiddleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	accessService "github.com/Muaz717/gym_app/app/internal/services/access"
+	"github.com/gin-gonic/gin"
+)
+
+const deviceContextKey = "access_device"
+
+type DeviceAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (models.AccessDevice, error)
+}
+
+// DeviceAuth пускает контроллеры турникетов по токену "Authorization: Bearer <token>".
+// Cookie SSO контроллеру недоступна, поэтому у него свой токен, выданный администратором.
+func DeviceAuth(log *slog.Logger, authenticator DeviceAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "middleware.DeviceAuth"
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "device token required"})
+			return
+		}
+
+		device, err := authenticator.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			if errors.Is(err, accessService.ErrDeviceNotFound) {
+				log.Warn("unknown or disabled access device", slog.String("op", op), slog.String("ip", c.ClientIP()))
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid device token"})
+				return
+			}
+			log.Error("failed to authenticate device", slog.String("op", op), sl.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate device"})
+			return
+		}
+
+		c.Set(deviceContextKey, device)
+		c.Next()
+	}
+}
+
+// GetDevice достает контроллер, положенный DeviceAuth
+func GetDevice(c *gin.Context) (models.AccessDevice, bool) {
+	val, exists := c.Get(deviceContextKey)
+	if !exists {
+		return models.AccessDevice{}, false
+	}
+	device, ok := val.(models.AccessDevice)
+	return device, ok
+}
@@ -0,0 +1,80 @@
+package memberMiddleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	authMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/auth"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	memberService "github.com/Muaz717/gym_app/app/internal/services/member"
+	"github.com/gin-gonic/gin"
+)
+
+const personContextKey = "person_id"
+
+type MemberResolver interface {
+	PersonIDByUser(ctx context.Context, userID int64) (int, error)
+}
+
+// MemberOnly пропускает только аккаунты, привязанные к клиенту, и кладет person_id в контекст.
+// Должен стоять после AuthMiddleware.
+func MemberOnly(log *slog.Logger, resolver MemberResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "middleware.MemberOnly"
+
+		user, ok := authMiddleware.GetUserFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		personID, err := resolver.PersonIDByUser(c.Request.Context(), user.GetUserId())
+		if err != nil {
+			if errors.Is(err, memberService.ErrNotMember) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "member account required"})
+				return
+			}
+			log.Error("failed to resolve member", slog.String("op", op), sl.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve member"})
+			return
+		}
+
+		c.Set(personContextKey, personID)
+		c.Next()
+	}
+}
+
+// StaffOnly пускает в API сотрудников только аккаунты, у которых есть хоть одно право.
+// Аккаунты клиентов создаются без ролей, поэтому привязка к карточке здесь не проверяется.
+// Должен стоять после AuthMiddleware.
+func StaffOnly(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "middleware.StaffOnly"
+
+		user, ok := authMiddleware.GetUserFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+
+		if len(user.GetPermissions()) == 0 {
+			log.Warn("account without permissions tried to access staff api", slog.String("op", op), slog.Int64("user_id", user.GetUserId()))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "staff access required"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetPersonID достает id клиента, положенный MemberOnly
+func GetPersonID(c *gin.Context) (int, bool) {
+	val, exists := c.Get(personContextKey)
+	if !exists {
+		return 0, false
+	}
+	personID, ok := val.(int)
+	return personID, ok
+}
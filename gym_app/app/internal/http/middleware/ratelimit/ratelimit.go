@@ -0,0 +1,41 @@
+package ratelimitMiddleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type Counter interface {
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// Limit пропускает не больше limit запросов с одного IP за window, остальным отвечает 429.
+// Счетчик общий для всех экземпляров приложения. Если он недоступен, запрос пропускается:
+// лимит защищает от перебора, а не ценой недоступной регистрации.
+func Limit(log *slog.Logger, counter Counter, name string, limit int, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		const op = "middleware.RateLimit"
+
+		count, err := counter.Incr(c.Request.Context(), "ratelimit:"+name+":"+c.ClientIP(), window)
+		if err != nil {
+			log.Error("failed to count request", slog.String("op", op), slog.String("limit", name), sl.Error(err))
+			c.Next()
+			return
+		}
+
+		if count > int64(limit) {
+			log.Warn("rate limit exceeded", slog.String("op", op), slog.String("limit", name), slog.String("ip", c.ClientIP()))
+			c.Header("Retry-After", strconv.Itoa(int(window.Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package ratelimitMiddleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ratelimitMiddleware "github.com/Muaz717/gym_app/app/internal/http/middleware/ratelimit"
+	"github.com/Muaz717/gym_app/app/internal/http/middleware/ratelimit/mocks"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		count      int64
+		err        error
+		wantStatus int
+	}{
+		{name: "within limit", count: 3, wantStatus: http.StatusOK},
+		{name: "last allowed", count: 5, wantStatus: http.StatusOK},
+		{name: "over limit", count: 6, wantStatus: http.StatusTooManyRequests},
+		{name: "counter unavailable", err: errors.New("redis: connection refused"), wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := mocks.NewCounter(t)
+			counter.EXPECT().Incr(mock.Anything, "ratelimit:register:192.0.2.1", time.Hour).Return(tt.count, tt.err).Once()
+
+			router := gin.New()
+			router.POST("/register",
+				ratelimitMiddleware.Limit(slogdiscard.NewDiscardLogger(), counter, "register", 5, time.Hour),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/register", nil)
+			req.RemoteAddr = "192.0.2.1:5000"
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusTooManyRequests {
+				assert.Equal(t, "3600", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
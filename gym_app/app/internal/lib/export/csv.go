@@ -0,0 +1,33 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSV создает CSV-выгрузку. BOM нужен, чтобы Excel корректно открыл кириллицу.
+func NewCSV(w io.Writer) (Writer, error) {
+	if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) Write(row []string) error {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		cells[i] = escapeFormula(v)
+	}
+
+	return c.w.Write(cells)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,128 @@
+// Package export пишет табличные данные в CSV и XLSX построчно,
+// чтобы большие выгрузки не приходилось целиком держать в памяти.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Writer принимает строки таблицы по одной. Close дописывает буферизованные данные.
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+// ParseFormat разбирает формат выгрузки; пустая строка означает CSV
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// NewWriter создает Writer для формата, пишущий в w. Первой строкой сразу пишется header.
+func NewWriter(format Format, w io.Writer, sheet string, header []string) (Writer, error) {
+	var (
+		writer Writer
+		err    error
+	)
+
+	switch format {
+	case FormatCSV:
+		writer, err = NewCSV(w)
+	case FormatXLSX:
+		writer, err = NewXLSX(w, sheet)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// escapeFormula экранирует апострофом ячейки, которые Excel выполнил бы как формулу
+// (ФИО или комментарий, начинающиеся с =, +, - или @). Числа вроде -500 остаются числами.
+func escapeFormula(v string) string {
+	if !isFormula(v) {
+		return v
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v
+	}
+
+	return "'" + v
+}
+
+// unescapeFormula убирает апостроф, добавленный escapeFormula, чтобы выгрузку можно было загрузить обратно
+func unescapeFormula(v string) string {
+	if strings.HasPrefix(v, "'") && isFormula(v[1:]) {
+		return v[1:]
+	}
+
+	return v
+}
+
+func isFormula(v string) bool {
+	return v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0]))
+}
+
+// ContentType возвращает MIME-тип файла выгрузки
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// FileName формирует имя файла выгрузки вида name_2006-01-02.csv
+func (f Format) FileName(name string) string {
+	return fmt.Sprintf("%s_%s.%s", name, time.Now().Format("2006-01-02"), f)
+}
+
+// Date форматирует дату для выгрузки
+func Date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format("2006-01-02")
+}
+
+// DateTime форматирует дату и время для выгрузки
+func DateTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format("2006-01-02 15:04")
+}
+
+// Money форматирует денежную сумму
+func Money(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(FormatCSV, &buf, "people", []string{"ID", "ФИО"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]string{"1", "Иванов, Иван"}))
+	require.NoError(t, w.Close())
+
+	data := strings.TrimPrefix(buf.String(), "\uFEFF")
+	rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"ID", "ФИО"}, {"1", "Иванов, Иван"}}, rows)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(FormatXLSX, &buf, "people", []string{"ID", "ФИО"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]string{"1", "Иван"}))
+	require.NoError(t, w.Close())
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("people")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"ID", "ФИО"}, {"1", "Иван"}}, rows)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("")
+	require.NoError(t, err)
+	require.Equal(t, FormatCSV, f)
+
+	_, err = ParseFormat("pdf")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestReadRowsRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatXLSX} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w, err := NewWriter(format, &buf, "people", []string{"ФИО", "Телефон"})
+			require.NoError(t, err)
+			require.NoError(t, w.Write([]string{"", ""}))
+			require.NoError(t, w.Write([]string{"Иван Иванов", "79990000000"}))
+			require.NoError(t, w.Close())
+
+			var lines []int
+			var rows [][]string
+			err = ReadRows(format, &buf, func(line int, row []string) error {
+				lines = append(lines, line)
+				rows = append(rows, row)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, []int{1, 3}, lines)
+			require.Equal(t, [][]string{{"ФИО", "Телефон"}, {"Иван Иванов", "79990000000"}}, rows)
+		})
+	}
+}
+
+func TestFormulaCellsAreEscaped(t *testing.T) {
+	row := []string{"=HYPERLINK(\"http://evil\")", "+7 (999) 123-45-67", "@SUM(A1)", "-500", "-1+2", "Иван"}
+	escaped := []string{"'=HYPERLINK(\"http://evil\")", "'+7 (999) 123-45-67", "'@SUM(A1)", "-500", "'-1+2", "Иван"}
+
+	for _, format := range []Format{FormatCSV, FormatXLSX} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w, err := NewWriter(format, &buf, "people", []string{"A", "B", "C", "D", "E", "F"})
+			require.NoError(t, err)
+			require.NoError(t, w.Write(row))
+			require.NoError(t, w.Close())
+			data := buf.Bytes()
+
+			var written [][]string
+			if format == FormatCSV {
+				written, err = csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\uFEFF"))).ReadAll()
+				require.NoError(t, err)
+			} else {
+				f, err := excelize.OpenReader(bytes.NewReader(data))
+				require.NoError(t, err)
+				defer f.Close()
+				written, err = f.GetRows("people")
+				require.NoError(t, err)
+			}
+			require.Equal(t, escaped, written[1])
+
+			// Обратная загрузка выгрузки возвращает исходные значения
+			var read [][]string
+			err = ReadRows(format, bytes.NewReader(data), func(_ int, r []string) error {
+				read = append(read, r)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, row, read[1])
+		})
+	}
+}
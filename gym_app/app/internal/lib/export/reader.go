@@ -0,0 +1,109 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FormatFromFileName определяет формат по расширению загруженного файла
+func FormatFromFileName(name string) (Format, error) {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+	if ext == "" {
+		return "", ErrUnknownFormat
+	}
+
+	return ParseFormat(ext)
+}
+
+// ReadRows читает таблицу из CSV или с первого листа XLSX и вызывает fn для каждой строки.
+// line — номер строки в файле, начиная с 1. Полностью пустые строки пропускаются.
+func ReadRows(format Format, r io.Reader, fn func(line int, row []string) error) error {
+	const op = "export.ReadRows"
+
+	switch format {
+	case FormatCSV:
+		return readCSV(r, fn)
+	case FormatXLSX:
+		return readXLSX(r, fn)
+	default:
+		return fmt.Errorf("%s: %w", op, ErrUnknownFormat)
+	}
+}
+
+func readCSV(r io.Reader, fn func(line int, row []string) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	for line := 1; ; line++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if line == 1 && len(row) > 0 {
+			row[0] = strings.TrimPrefix(row[0], "\uFEFF")
+		}
+		if isEmptyRow(row) {
+			continue
+		}
+		for i := range row {
+			row[i] = unescapeFormula(row[i])
+		}
+		if err := fn(line, row); err != nil {
+			return err
+		}
+	}
+}
+
+func readXLSX(r io.Reader, fn func(line int, row []string) error) error {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	sheet := file.GetSheetName(file.GetActiveSheetIndex())
+
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for line := 1; rows.Next(); line++ {
+		row, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		if isEmptyRow(row) {
+			continue
+		}
+		for i := range row {
+			row[i] = unescapeFormula(row[i])
+		}
+		if err := fn(line, row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Error()
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxWriter пишет строки через StreamWriter excelize: строки сбрасываются во временный файл,
+// а не накапливаются в памяти
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func NewXLSX(w io.Writer, sheet string) (Writer, error) {
+	const op = "export.NewXLSX"
+
+	file := excelize.NewFile()
+
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &xlsxWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (x *xlsxWriter) Write(row []string) error {
+	x.row++
+
+	cells := make([]interface{}, len(row))
+	for i, v := range row {
+		cells[i] = escapeFormula(v)
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
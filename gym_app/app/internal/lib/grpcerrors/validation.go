@@ -5,6 +5,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"strings"
+	"time"
 )
 
 func ParseValidationError(err error) string {
@@ -27,3 +28,19 @@ func ParseValidationError(err error) string {
 	// fallback
 	return st.Message()
 }
+
+// RetryAfter возвращает задержку из RetryInfo, которую SSO передает вместе с ResourceExhausted
+func RetryAfter(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		if t, ok := detail.(*errdetails.RetryInfo); ok && t.GetRetryDelay() != nil {
+			return t.GetRetryDelay().AsDuration(), true
+		}
+	}
+
+	return 0, false
+}
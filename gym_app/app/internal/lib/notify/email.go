@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Email отправляет письма через SMTP-сервер
+type Email struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewEmail(host, port, username, password, from string) *Email {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &Email{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (e *Email) Name() string {
+	return "email"
+}
+
+func (e *Email) Address(to Recipient) (string, error) {
+	if to.Email == "" {
+		return "", ErrNoAddress
+	}
+	return to.Email, nil
+}
+
+// Send не поддерживает отмену через ctx: net/smtp работает синхронно
+func (e *Email) Send(_ context.Context, to Recipient, msg Message) error {
+	const op = "notify.Email.Send"
+
+	addr, err := e.Address(to)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := smtp.SendMail(e.addr, e.auth, e.from, []string{addr}, buildMail(e.from, addr, msg)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func buildMail(from, to string, msg Message) []byte {
+	var b strings.Builder
+
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
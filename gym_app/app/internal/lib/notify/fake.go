@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeMessage — сообщение, принятое Fake-каналом
+type FakeMessage struct {
+	To  Recipient
+	Msg Message
+}
+
+// Fake хранит сообщения в памяти. Подходит для тестов и локального запуска без шлюзов.
+// Fake доставляет по телефону; Err, если задан, возвращается вместо отправки.
+type Fake struct {
+	Err error
+
+	mu   sync.Mutex
+	sent []FakeMessage
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Address(to Recipient) (string, error) {
+	if to.Phone == "" {
+		return "", ErrNoAddress
+	}
+	return to.Phone, nil
+}
+
+func (f *Fake) Send(_ context.Context, to Recipient, msg Message) error {
+	if f.Err != nil {
+		return f.Err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, FakeMessage{To: to, Msg: msg})
+
+	return nil
+}
+
+// Sent возвращает копию отправленных сообщений
+func (f *Fake) Sent() []FakeMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]FakeMessage(nil), f.sent...)
+}
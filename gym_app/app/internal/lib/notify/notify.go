@@ -0,0 +1,33 @@
+// Package notify содержит каналы доставки уведомлений: SMS-шлюз, почту и Telegram.
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoAddress — у получателя нет адреса для этого канала, сообщение пропускается
+var ErrNoAddress = errors.New("recipient has no address for channel")
+
+// Recipient — адреса получателя во всех каналах; канал берет нужный ему
+type Recipient struct {
+	Name           string
+	Phone          string
+	Email          string
+	TelegramChatID *int64
+}
+
+// Message — готовое к отправке сообщение
+type Message struct {
+	Subject string // Используется только в почте
+	Text    string
+}
+
+// Channel — способ доставки уведомлений
+type Channel interface {
+	// Name — короткое имя канала для журнала доставки
+	Name() string
+	// Address возвращает адрес получателя в канале или ErrNoAddress
+	Address(to Recipient) (string, error)
+	Send(ctx context.Context, to Recipient, msg Message) error
+}
@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SMS отправляет сообщения через HTTP-шлюз: POST {url} с JSON {"from", "to", "text"}
+// и ключом в заголовке Authorization: Bearer.
+type SMS struct {
+	url    string
+	apiKey string
+	sender string
+	client *http.Client
+}
+
+func NewSMS(url, apiKey, sender string, timeout time.Duration) *SMS {
+	return &SMS{
+		url:    url,
+		apiKey: apiKey,
+		sender: sender,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *SMS) Name() string {
+	return "sms"
+}
+
+func (s *SMS) Address(to Recipient) (string, error) {
+	if to.Phone == "" {
+		return "", ErrNoAddress
+	}
+	return to.Phone, nil
+}
+
+func (s *SMS) Send(ctx context.Context, to Recipient, msg Message) error {
+	const op = "notify.SMS.Send"
+
+	phone, err := s.Address(to)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"from": s.sender,
+		"to":   phone,
+		"text": msg.Text,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return postJSON(ctx, s.client, s.url, s.apiKey, body, op)
+}
+
+// postJSON отправляет JSON и считает ошибкой любой ответ кроме 2xx
+func postJSON(ctx context.Context, client *http.Client, url, token string, body []byte, op string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected status %s", op, resp.Status)
+	}
+
+	return nil
+}
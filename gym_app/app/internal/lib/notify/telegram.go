@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const telegramAPI = "https://api.telegram.org"
+
+// Telegram отправляет сообщения от имени бота в чат клиента
+type Telegram struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewTelegram создает канал бота. baseURL можно оставить пустым — будет использован api.telegram.org.
+func NewTelegram(baseURL, token string, timeout time.Duration) *Telegram {
+	if baseURL == "" {
+		baseURL = telegramAPI
+	}
+
+	return &Telegram{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (t *Telegram) Name() string {
+	return "telegram"
+}
+
+func (t *Telegram) Address(to Recipient) (string, error) {
+	if to.TelegramChatID == nil {
+		return "", ErrNoAddress
+	}
+	return fmt.Sprint(*to.TelegramChatID), nil
+}
+
+func (t *Telegram) Send(ctx context.Context, to Recipient, msg Message) error {
+	const op = "notify.Telegram.Send"
+
+	if _, err := t.Address(to); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"chat_id": *to.TelegramChatID,
+		"text":    msg.Text,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", t.baseURL, t.token)
+
+	if err := postJSON(ctx, t.client, endpoint, "", body, op); err != nil {
+		// Ошибка транспорта содержит URL с токеном бота — он попадет в журнал доставки
+		return redactURLError(err, t.token, op)
+	}
+
+	return nil
+}
+
+// redactURLError заменяет ошибку транспорта копией, в URL которой секрет скрыт. Исходная ошибка
+// из цепочки не доступна, поэтому токен нельзя достать и через errors.As/Unwrap
+func redactURLError(err error, secret, op string) error {
+	var urlErr *url.Error
+	if secret == "" || !errors.As(err, &urlErr) {
+		return err
+	}
+
+	redacted := *urlErr
+	redacted.URL = strings.ReplaceAll(urlErr.URL, secret, "<token>")
+	return fmt.Errorf("%s: %w", op, &redacted)
+}
@@ -0,0 +1,113 @@
+// Package pdf собирает простые текстовые документы (чеки, договоры) в PDF.
+//
+// Шаблон — обычный text/template, результат которого размечается построчно:
+//
+//	# Заголовок        — крупный заголовок по центру
+//	## Подзаголовок    — заголовок раздела
+//	---                — горизонтальная линия
+//	пустая строка      — отступ
+//	любой другой текст — абзац с переносом по ширине страницы
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	fontFamily = "main"
+
+	textSize       = 11.0
+	headingSize    = 16.0
+	subheadingSize = 13.0
+	lineHeight     = 6.0
+)
+
+// Renderer отрисовывает шаблоны в PDF шрифтом с поддержкой кириллицы
+type Renderer struct {
+	font     []byte
+	boldFont []byte
+}
+
+// New загружает TTF-шрифты один раз при старте. boldFontPath можно не указывать.
+func New(fontPath, boldFontPath string) (*Renderer, error) {
+	const op = "pdf.New"
+
+	font, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: font: %w", op, err)
+	}
+
+	var boldFont []byte
+	if boldFontPath != "" {
+		boldFont, err = os.ReadFile(boldFontPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: bold font: %w", op, err)
+		}
+	}
+
+	return &Renderer{
+		font:     font,
+		boldFont: boldFont,
+	}, nil
+}
+
+// Render исполняет шаблон с данными data и возвращает готовый PDF
+func (r *Renderer) Render(tmpl *template.Template, data any) ([]byte, error) {
+	const op = "pdf.Render"
+
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("%s: execute template: %w", op, err)
+	}
+
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(20, 20, 20)
+	doc.SetAutoPageBreak(true, 20)
+
+	doc.AddUTF8FontFromBytes(fontFamily, "", r.font)
+	boldStyle := ""
+	if r.boldFont != nil {
+		doc.AddUTF8FontFromBytes(fontFamily, "B", r.boldFont)
+		boldStyle = "B"
+	}
+
+	doc.AddPage()
+
+	for _, line := range strings.Split(text.String(), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+
+		switch {
+		case strings.HasPrefix(line, "## "):
+			doc.SetFont(fontFamily, boldStyle, subheadingSize)
+			doc.MultiCell(0, lineHeight+1, strings.TrimPrefix(line, "## "), "", "L", false)
+		case strings.HasPrefix(line, "# "):
+			doc.SetFont(fontFamily, boldStyle, headingSize)
+			doc.MultiCell(0, lineHeight+2, strings.TrimPrefix(line, "# "), "", "C", false)
+			doc.Ln(2)
+		case line == "---":
+			left, _, right, _ := doc.GetMargins()
+			width, _ := doc.GetPageSize()
+			y := doc.GetY() + 1
+			doc.Line(left, y, width-right, y)
+			doc.Ln(3)
+		case line == "":
+			doc.Ln(lineHeight / 2)
+		default:
+			doc.SetFont(fontFamily, "", textSize)
+			doc.MultiCell(0, lineHeight, line, "", "L", false)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := doc.Output(&out); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,32 @@
+package pdf
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testFont = "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"
+
+func TestRender(t *testing.T) {
+	if _, err := os.Stat(testFont); err != nil {
+		t.Skip("DejaVuSans font is not installed")
+	}
+
+	r, err := New(testFont, "")
+	require.NoError(t, err)
+
+	tmpl := template.Must(template.New("doc").Parse("# Чек №{{.Number}}\n---\nКлиент: {{.Name}}\n\n## Итого\n{{.Sum}} руб."))
+
+	data, err := r.Render(tmpl, map[string]string{"Number": "42", "Name": "Иван Иванов", "Sum": "3000.00"})
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+}
+
+func TestNewMissingFont(t *testing.T) {
+	_, err := New("/nonexistent/font.ttf", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Заголовки запроса вебхука. Получатель проверяет подпись:
+// HMAC-SHA256(secret, timestamp + "." + body) в hex с префиксом "sha256=".
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxErrorBody — сколько байт ответа получателя сохраняем в ошибке
+const maxErrorBody = 256
+
+// Sign подписывает тело запроса секретом вебхука
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись за постоянное время
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Request — одна отправка события на адрес вебхука
+type Request struct {
+	URL     string
+	Secret  string
+	Event   string
+	EventID int64
+	Body    []byte
+}
+
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Редирект считаем ошибкой: подписанное тело не должно уходить на другой адрес
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send отправляет событие и возвращает код ответа. Успех — только ответ 2xx.
+func (s *Sender) Send(ctx context.Context, r Request) (int, error) {
+	const op = "webhook.Send"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gym-app-webhooks")
+	req.Header.Set(HeaderEvent, r.Event)
+	req.Header.Set(HeaderEventID, strconv.FormatInt(r.EventID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(r.Secret, timestamp, r.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("%s: unexpected status %s: %s", op, resp.Status, bytes.TrimSpace(body))
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend_SignsRequest(t *testing.T) {
+	const secret = "top-secret"
+	body := []byte(`{"type":"person.created"}`)
+
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sender := NewSender(time.Second)
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	code, err := sender.Send(context.Background(), Request{
+		URL:     srv.URL,
+		Secret:  secret,
+		Event:   "person.created",
+		EventID: 42,
+		Body:    body,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	assert.Equal(t, body, gotBody)
+	assert.Equal(t, "person.created", got.Header.Get(HeaderEvent))
+	assert.Equal(t, "42", got.Header.Get(HeaderEventID))
+
+	timestamp, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), timestamp)
+	assert.True(t, Verify(secret, timestamp, gotBody, got.Header.Get(HeaderSignature)))
+	assert.False(t, Verify("other", timestamp, gotBody, got.Header.Get(HeaderSignature)))
+}
+
+func TestSend_NonSuccessStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://example.com", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	code, err := NewSender(time.Second).Send(context.Background(), Request{URL: srv.URL, Body: []byte("{}")})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, code)
+}
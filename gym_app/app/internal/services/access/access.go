@@ -0,0 +1,462 @@
+package accessService
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+const (
+	activeStatus = "active"
+	frozenStatus = "frozen"
+
+	maxCredentialLength = 64
+	maxDeviceNameLength = 100
+
+	defaultLogLimit = 100
+	maxLogLimit     = 1000
+)
+
+type AccessStorage interface {
+	SaveAccessDevice(ctx context.Context, name, tokenHash string) (models.AccessDevice, error)
+	FindAccessDevices(ctx context.Context) ([]models.AccessDevice, error)
+	SetAccessDeviceActive(ctx context.Context, id int, active bool) error
+	DeleteAccessDevice(ctx context.Context, id int) error
+	AccessDeviceByToken(ctx context.Context, tokenHash string) (models.AccessDevice, error)
+
+	SaveAccessCard(ctx context.Context, card models.AccessCard) (models.AccessCard, error)
+	FindAccessCards(ctx context.Context, personID int) ([]models.AccessCard, error)
+	DeleteAccessCard(ctx context.Context, cardNumber string) error
+
+	FindAccessCredential(ctx context.Context, credential string) (dto.AccessCredential, error)
+	EnterVisit(ctx context.Context, subscriptionNumber string, antiPassback bool) (models.Visit, bool, error)
+	CheckOut(ctx context.Context, subscriptionNumber string) (models.Visit, error)
+
+	SaveAccessLog(ctx context.Context, entry models.AccessLogEntry) error
+	FindAccessLog(ctx context.Context, limit int) ([]models.AccessLogEntry, error)
+	AllowList(ctx context.Context) ([]dto.AllowListEntry, error)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
+}
+
+type AccessService struct {
+	log           *slog.Logger
+	accessStorage AccessStorage
+	publisher     Publisher
+	cfg           config.AccessControl
+	now           func() time.Time
+}
+
+var (
+	ErrInvalidCredential = errors.New("credential must be 1-64 characters")
+	ErrInvalidDirection  = errors.New("direction must be in or out")
+	ErrInvalidDeviceName = errors.New("device name must be 1-100 characters")
+	ErrInvalidCard       = errors.New("card number must be 1-64 characters and person id positive")
+	ErrDeviceNotFound    = errors.New("access device not found")
+	ErrCardExists        = errors.New("card is already assigned")
+	ErrCardNotFound      = errors.New("card not found")
+	ErrPersonNotFound    = errors.New("person not found")
+)
+
+// messages — тексты для табло турникета
+var messages = map[string]string{
+	dto.AccessReasonOK:            "Проходите",
+	dto.AccessReasonUnknown:       "Карта не найдена",
+	dto.AccessReasonNotStarted:    "Абонемент еще не начал действовать",
+	dto.AccessReasonExpired:       "Срок абонемента истек",
+	dto.AccessReasonFrozen:        "Абонемент заморожен",
+	dto.AccessReasonInactive:      "Абонемент не активен",
+	dto.AccessReasonAlreadyInside: "Вход уже отмечен",
+	dto.AccessReasonUnavailable:   "Обратитесь на ресепшен",
+}
+
+func New(
+	log *slog.Logger,
+	accessStorage AccessStorage,
+	publisher Publisher,
+	cfg config.AccessControl,
+) *AccessService {
+	return &AccessService{
+		log:           log,
+		accessStorage: accessStorage,
+		publisher:     publisher,
+		cfg:           cfg,
+		now:           time.Now,
+	}
+}
+
+// Check решает, пустить ли клиента, и отмечает посещение. Ошибка возвращается только
+// для некорректного запроса: если база не ответила за cfg.Timeout, контроллер получает
+// отказ с причиной unavailable (или пропуск при fail_open) и может перейти на офлайн-список.
+func (a *AccessService) Check(ctx context.Context, deviceID int, input dto.AccessCheckInput) (dto.AccessDecision, error) {
+	const op = "services.access.Check"
+
+	credential := strings.TrimSpace(input.Credential)
+	if credential == "" || utf8.RuneCountInString(credential) > maxCredentialLength {
+		return dto.AccessDecision{}, fmt.Errorf("%s: %w", op, ErrInvalidCredential)
+	}
+
+	direction := input.Direction
+	if direction == "" {
+		direction = dto.AccessIn
+	}
+	if direction != dto.AccessIn && direction != dto.AccessOut {
+		return dto.AccessDecision{}, fmt.Errorf("%s: %w", op, ErrInvalidDirection)
+	}
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int("device_id", deviceID),
+		slog.String("direction", direction),
+	)
+
+	decideCtx, cancel := context.WithTimeout(ctx, a.cfg.Timeout)
+	defer cancel()
+
+	var decision dto.AccessDecision
+	var err error
+	if direction == dto.AccessIn {
+		decision, err = a.enter(decideCtx, credential)
+	} else {
+		decision, err = a.exit(decideCtx, credential)
+	}
+	if err != nil {
+		log.Error("access decision failed", sl.Error(err))
+		decision = dto.AccessDecision{Allowed: a.cfg.FailOpen, Reason: dto.AccessReasonUnavailable}
+	}
+	decision.Message = messages[decision.Reason]
+
+	entry := models.AccessLogEntry{
+		DeviceID:           &deviceID,
+		Credential:         credential,
+		Direction:          direction,
+		Allowed:            decision.Allowed,
+		Reason:             decision.Reason,
+		SubscriptionNumber: decision.SubscriptionNumber,
+	}
+	if decision.VisitID != 0 {
+		entry.VisitID = &decision.VisitID
+	}
+	// Журнал пишем уже после решения: его задержка не должна превращать пропуск в отказ
+	if err := a.accessStorage.SaveAccessLog(context.WithoutCancel(ctx), entry); err != nil {
+		log.Error("failed to save access log", sl.Error(err))
+	}
+
+	log.Info("access decision",
+		slog.Bool("allowed", decision.Allowed),
+		slog.String("reason", decision.Reason),
+		slog.String("subscription_number", decision.SubscriptionNumber),
+	)
+
+	return decision, nil
+}
+
+func (a *AccessService) enter(ctx context.Context, credential string) (dto.AccessDecision, error) {
+	cred, err := a.accessStorage.FindAccessCredential(ctx, credential)
+	if err != nil {
+		if errors.Is(err, storage.ErrCredentialNotFound) {
+			return dto.AccessDecision{Reason: dto.AccessReasonUnknown}, nil
+		}
+		return dto.AccessDecision{}, err
+	}
+
+	validUntil := cred.EndDate
+	decision := dto.AccessDecision{
+		Reason:             a.reason(cred),
+		SubscriptionNumber: cred.SubscriptionNumber,
+		PersonName:         cred.PersonName,
+		ValidUntil:         &validUntil,
+	}
+	if decision.Reason != dto.AccessReasonOK {
+		return decision, nil
+	}
+
+	visit, created, err := a.accessStorage.EnterVisit(ctx, cred.SubscriptionNumber, a.cfg.AntiPassback)
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyCheckedIn) {
+			decision.Reason = dto.AccessReasonAlreadyInside
+			return decision, nil
+		}
+		return dto.AccessDecision{}, err
+	}
+
+	decision.Allowed = true
+	decision.VisitID = visit.ID
+
+	if created {
+		a.publisher.Publish(ctx, events.VisitCheckedIn{
+			VisitID:            visit.ID,
+			SubscriptionNumber: cred.SubscriptionNumber,
+			PersonID:           cred.PersonID,
+			PersonName:         cred.PersonName,
+			At:                 visit.CheckedInAt,
+		})
+	}
+
+	return decision, nil
+}
+
+// exit всегда выпускает клиента, а посещение закрывает, если оно было открыто
+func (a *AccessService) exit(ctx context.Context, credential string) (dto.AccessDecision, error) {
+	decision := dto.AccessDecision{Allowed: true, Reason: dto.AccessReasonOK}
+
+	cred, err := a.accessStorage.FindAccessCredential(ctx, credential)
+	if err != nil {
+		if errors.Is(err, storage.ErrCredentialNotFound) {
+			return decision, nil
+		}
+		return dto.AccessDecision{}, err
+	}
+	decision.SubscriptionNumber = cred.SubscriptionNumber
+	decision.PersonName = cred.PersonName
+
+	visit, err := a.accessStorage.CheckOut(ctx, cred.SubscriptionNumber)
+	if err != nil {
+		if errors.Is(err, storage.ErrVisitNotFound) {
+			return decision, nil
+		}
+		return dto.AccessDecision{}, err
+	}
+	decision.VisitID = visit.ID
+
+	at := a.now()
+	if visit.CheckedOutAt != nil {
+		at = *visit.CheckedOutAt
+	}
+	a.publisher.Publish(ctx, events.VisitCheckedOut{
+		VisitID:            visit.ID,
+		SubscriptionNumber: cred.SubscriptionNumber,
+		At:                 at,
+	})
+
+	return decision, nil
+}
+
+// reason проверяет статус и даты абонемента. Даты сравниваем без времени и часового пояса:
+// DATE из базы приходит полуночью UTC.
+func (a *AccessService) reason(cred dto.AccessCredential) string {
+	today := dateOnly(a.now())
+
+	switch {
+	case cred.Status == frozenStatus:
+		return dto.AccessReasonFrozen
+	case cred.Status != activeStatus:
+		if dateOnly(cred.EndDate).Before(today) {
+			return dto.AccessReasonExpired
+		}
+		return dto.AccessReasonInactive
+	case dateOnly(cred.StartDate).After(today):
+		return dto.AccessReasonNotStarted
+	case dateOnly(cred.EndDate).Before(today):
+		return dto.AccessReasonExpired
+	}
+
+	return dto.AccessReasonOK
+}
+
+// AllowList выгружает номера для офлайн-режима контроллера
+func (a *AccessService) AllowList(ctx context.Context) (dto.AllowList, error) {
+	const op = "services.access.AllowList"
+
+	log := a.log.With(slog.String("op", op))
+
+	entries, err := a.accessStorage.AllowList(ctx)
+	if err != nil {
+		log.Error("failed to build allow list", sl.Error(err))
+		return dto.AllowList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.AllowList{GeneratedAt: a.now(), Entries: entries}, nil
+}
+
+// Authenticate находит активный контроллер по токену из заголовка Authorization
+func (a *AccessService) Authenticate(ctx context.Context, token string) (models.AccessDevice, error) {
+	const op = "services.access.Authenticate"
+
+	if token == "" {
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, ErrDeviceNotFound)
+	}
+
+	device, err := a.accessStorage.AccessDeviceByToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, storage.ErrAccessDeviceNotFound) {
+			return models.AccessDevice{}, fmt.Errorf("%s: %w", op, ErrDeviceNotFound)
+		}
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return device, nil
+}
+
+// CreateDevice регистрирует контроллер. Токен возвращается только здесь, в базе хранится его хэш.
+func (a *AccessService) CreateDevice(ctx context.Context, input dto.AccessDeviceInput) (models.AccessDevice, error) {
+	const op = "services.access.CreateDevice"
+
+	log := a.log.With(slog.String("op", op))
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxDeviceNameLength {
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, ErrInvalidDeviceName)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		log.Error("failed to generate token", sl.Error(err))
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	device, err := a.accessStorage.SaveAccessDevice(ctx, name, hashToken(token))
+	if err != nil {
+		log.Error("failed to save access device", sl.Error(err))
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, err)
+	}
+	device.Token = token
+
+	log.Info("access device created", slog.Int("id", device.ID))
+	return device, nil
+}
+
+func (a *AccessService) FindDevices(ctx context.Context) ([]models.AccessDevice, error) {
+	const op = "services.access.FindDevices"
+
+	devices, err := a.accessStorage.FindAccessDevices(ctx)
+	if err != nil {
+		a.log.Error("failed to find access devices", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return devices, nil
+}
+
+func (a *AccessService) SetDeviceActive(ctx context.Context, id int, active bool) error {
+	const op = "services.access.SetDeviceActive"
+
+	if err := a.accessStorage.SetAccessDeviceActive(ctx, id, active); err != nil {
+		if errors.Is(err, storage.ErrAccessDeviceNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrDeviceNotFound)
+		}
+		a.log.Error("failed to update access device", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *AccessService) DeleteDevice(ctx context.Context, id int) error {
+	const op = "services.access.DeleteDevice"
+
+	if err := a.accessStorage.DeleteAccessDevice(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrAccessDeviceNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrDeviceNotFound)
+		}
+		a.log.Error("failed to delete access device", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *AccessService) AddCard(ctx context.Context, input dto.AccessCardInput) (models.AccessCard, error) {
+	const op = "services.access.AddCard"
+
+	log := a.log.With(slog.String("op", op))
+
+	number := strings.TrimSpace(input.CardNumber)
+	if number == "" || utf8.RuneCountInString(number) > maxCredentialLength || input.PersonID <= 0 {
+		return models.AccessCard{}, fmt.Errorf("%s: %w", op, ErrInvalidCard)
+	}
+
+	card, err := a.accessStorage.SaveAccessCard(ctx, models.AccessCard{CardNumber: number, PersonID: input.PersonID})
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrAccessCardExists):
+			return models.AccessCard{}, fmt.Errorf("%s: %w", op, ErrCardExists)
+		case errors.Is(err, storage.ErrPersonNotFound):
+			return models.AccessCard{}, fmt.Errorf("%s: %w", op, ErrPersonNotFound)
+		}
+		log.Error("failed to save access card", sl.Error(err))
+		return models.AccessCard{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("access card added", slog.Int("person_id", card.PersonID))
+	return card, nil
+}
+
+func (a *AccessService) FindCards(ctx context.Context, personID int) ([]models.AccessCard, error) {
+	const op = "services.access.FindCards"
+
+	cards, err := a.accessStorage.FindAccessCards(ctx, personID)
+	if err != nil {
+		a.log.Error("failed to find access cards", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cards, nil
+}
+
+func (a *AccessService) DeleteCard(ctx context.Context, cardNumber string) error {
+	const op = "services.access.DeleteCard"
+
+	if err := a.accessStorage.DeleteAccessCard(ctx, cardNumber); err != nil {
+		if errors.Is(err, storage.ErrAccessCardNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrCardNotFound)
+		}
+		a.log.Error("failed to delete access card", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *AccessService) FindLog(ctx context.Context, limit int) ([]models.AccessLogEntry, error) {
+	const op = "services.access.FindLog"
+
+	if limit <= 0 {
+		limit = defaultLogLimit
+	}
+	if limit > maxLogLimit {
+		limit = maxLogLimit
+	}
+
+	entries, err := a.accessStorage.FindAccessLog(ctx, limit)
+	if err != nil {
+		a.log.Error("failed to find access log", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -11,10 +11,25 @@ import (
 )
 
 type SSOClient interface {
-	Login(ctx context.Context, appId int32, email, password string) (string, error)
-	RegisterNewUser(ctx context.Context, email, password string) (int64, error)
+	Login(ctx context.Context, appId int32, email, password, device, ip string) (dto.AuthTokens, error)
+	Refresh(ctx context.Context, appID int32, refreshToken string) (dto.AuthTokens, error)
+	RegisterNewUser(ctx context.Context, email, password, inviteCode string) (int64, error)
+	RegisterMember(ctx context.Context, email, password string) (int64, error)
 	CheckToken(ctx context.Context, appID int32, token string) (*ssov1.CheckTokenResponse, error)
+	Logout(ctx context.Context, appID int32, token string, all bool) error
+	ChangePassword(ctx context.Context, appID int32, token, currentPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerificationEmail(ctx context.Context, email string) error
+	LoginTwoFactor(ctx context.Context, appID int32, challenge, code, device, ip string) (dto.AuthTokens, error)
+	EnrollTOTP(ctx context.Context, appID int32, token string) (dto.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, appID int32, token, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, appID int32, token, code string) error
 }
+
+const maxDeviceLen = 255
+
 type AuthService struct {
 	log       *slog.Logger
 	appId     int32
@@ -33,7 +48,7 @@ func New(
 	}
 }
 
-func (a *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+func (a *AuthService) Login(ctx context.Context, email, password, device, ip string) (dto.AuthTokens, error) {
 	const op = "services.auth.login"
 
 	log := a.log.With(
@@ -42,17 +57,65 @@ func (a *AuthService) Login(ctx context.Context, email, password string) (string
 
 	log.Info("logging in", slog.String("email", email))
 
-	token, err := a.ssoClient.Login(ctx, a.appId, email, password)
+	// SSO ограничивает название устройства 255 символами
+	if runes := []rune(device); len(runes) > maxDeviceLen {
+		device = string(runes[:maxDeviceLen])
+	}
+
+	tokens, err := a.ssoClient.Login(ctx, a.appId, email, password, device, ip)
 	if err != nil {
 		log.Error("failed to login", slog.String("email", email), sl.Error(err))
-		return "", fmt.Errorf("%s: %w", op, err)
+		return dto.AuthTokens{}, fmt.Errorf("%s: %w", op, err)
 	}
 
 	log.Info("login successful")
-	return token, nil
+	return tokens, nil
 }
 
-func (a *AuthService) RegisterNewUser(ctx context.Context, email, password string) (int64, error) {
+// LoginTwoFactor завершает вход пользователя с 2FA. После неверного кода challenge больше не действует
+func (a *AuthService) LoginTwoFactor(ctx context.Context, challenge, code, device, ip string) (dto.AuthTokens, error) {
+	const op = "services.auth.loginTwoFactor"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if runes := []rune(device); len(runes) > maxDeviceLen {
+		device = string(runes[:maxDeviceLen])
+	}
+
+	tokens, err := a.ssoClient.LoginTwoFactor(ctx, a.appId, challenge, code, device, ip)
+	if err != nil {
+		log.Error("failed to login with 2fa", sl.Error(err))
+		return dto.AuthTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("login successful")
+	return tokens, nil
+}
+
+func (a *AuthService) Refresh(ctx context.Context, refreshToken string) (dto.AuthTokens, error) {
+	const op = "services.auth.refresh"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("refreshing token")
+
+	tokens, err := a.ssoClient.Refresh(ctx, a.appId, refreshToken)
+	if err != nil {
+		log.Error("failed to refresh token", sl.Error(err))
+		return dto.AuthTokens{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("token refreshed")
+	return tokens, nil
+}
+
+// RegisterNewUser регистрирует пользователя в SSO. С кодом приглашения учетная запись
+// сразу получает роли из приглашения
+func (a *AuthService) RegisterNewUser(ctx context.Context, email, password, inviteCode string) (int64, error) {
 	const op = "services.auth.registerNewUser"
 
 	log := a.log.With(
@@ -61,7 +124,7 @@ func (a *AuthService) RegisterNewUser(ctx context.Context, email, password strin
 
 	log.Info("registering new user", slog.String("email", email))
 
-	userId, err := a.ssoClient.RegisterNewUser(ctx, email, password)
+	userId, err := a.ssoClient.RegisterNewUser(ctx, email, password, inviteCode)
 	if err != nil {
 		log.Error("failed to register new user", slog.String("email", email), sl.Error(err))
 		return 0, err
@@ -71,6 +134,27 @@ func (a *AuthService) RegisterNewUser(ctx context.Context, email, password strin
 	return userId, nil
 }
 
+// RegisterMember регистрирует в SSO аккаунт клиента. Ролей у него нет:
+// доступ к порталу дает привязка к карточке, а не права
+func (a *AuthService) RegisterMember(ctx context.Context, email, password string) (int64, error) {
+	const op = "services.auth.registerMember"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("registering member", slog.String("email", email))
+
+	userId, err := a.ssoClient.RegisterMember(ctx, email, password)
+	if err != nil {
+		log.Error("failed to register member", slog.String("email", email), sl.Error(err))
+		return 0, err
+	}
+
+	log.Info("member registered successfully")
+	return userId, nil
+}
+
 func (a *AuthService) CheckToken(ctx context.Context, token string) (dto.User, error) {
 	const op = "services.auth.checkToken"
 
@@ -90,10 +174,164 @@ func (a *AuthService) CheckToken(ctx context.Context, token string) (dto.User, e
 	return CheckTokenResponseToUser(resp), nil
 }
 
+func (a *AuthService) Logout(ctx context.Context, token string, all bool) error {
+	const op = "services.auth.logout"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("logging out", slog.Bool("all_sessions", all))
+
+	if err := a.ssoClient.Logout(ctx, a.appId, token, all); err != nil {
+		log.Error("failed to logout", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("logout successful")
+	return nil
+}
+
+// ChangePassword меняет пароль владельца токена. SSO завершает все его сессии, включая текущую
+func (a *AuthService) ChangePassword(ctx context.Context, token, currentPassword, newPassword string) error {
+	const op = "services.auth.changePassword"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("changing password")
+
+	if err := a.ssoClient.ChangePassword(ctx, a.appId, token, currentPassword, newPassword); err != nil {
+		log.Error("failed to change password", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("password changed")
+	return nil
+}
+
+// RequestPasswordReset просит SSO отправить письмо со ссылкой для сброса пароля.
+// Для незарегистрированного email ответ такой же, как для существующего
+func (a *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	const op = "services.auth.requestPasswordReset"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("requesting password reset")
+
+	if err := a.ssoClient.RequestPasswordReset(ctx, email); err != nil {
+		log.Error("failed to request password reset", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	const op = "services.auth.resetPassword"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("resetting password")
+
+	if err := a.ssoClient.ResetPassword(ctx, token, newPassword); err != nil {
+		log.Error("failed to reset password", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("password reset")
+	return nil
+}
+
+func (a *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	const op = "services.auth.verifyEmail"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if err := a.ssoClient.VerifyEmail(ctx, token); err != nil {
+		log.Error("failed to verify email", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("email verified")
+	return nil
+}
+
+func (a *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	const op = "services.auth.resendVerificationEmail"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if err := a.ssoClient.ResendVerificationEmail(ctx, email); err != nil {
+		log.Error("failed to resend verification email", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func CheckTokenResponseToUser(resp *ssov1.CheckTokenResponse) dto.User {
 	return dto.User{
-		UserID: resp.UserId,
-		Email:  resp.Email,
-		Roles:  resp.Roles,
+		UserID:      resp.UserId,
+		Email:       resp.Email,
+		Roles:       resp.Roles,
+		Permissions: resp.Permissions,
+	}
+}
+
+// EnrollTOTP выдает секрет для приложения-аутентификатора. 2FA включится после ConfirmTOTP
+func (a *AuthService) EnrollTOTP(ctx context.Context, token string) (dto.TOTPEnrollment, error) {
+	const op = "services.auth.enrollTOTP"
+
+	enrollment, err := a.ssoClient.EnrollTOTP(ctx, a.appId, token)
+	if err != nil {
+		a.log.Error("failed to enroll totp", slog.String("op", op), sl.Error(err))
+		return dto.TOTPEnrollment{}, fmt.Errorf("%s: %w", op, err)
 	}
+
+	return enrollment, nil
+}
+
+// ConfirmTOTP включает 2FA по коду из приложения и возвращает резервные коды
+func (a *AuthService) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	const op = "services.auth.confirmTOTP"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	recoveryCodes, err := a.ssoClient.ConfirmTOTP(ctx, a.appId, token, code)
+	if err != nil {
+		log.Error("failed to confirm totp", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("2fa enabled")
+	return recoveryCodes, nil
+}
+
+// DisableTOTP отключает 2FA по коду из приложения или резервному коду
+func (a *AuthService) DisableTOTP(ctx context.Context, token, code string) error {
+	const op = "services.auth.disableTOTP"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	if err := a.ssoClient.DisableTOTP(ctx, a.appId, token, code); err != nil {
+		log.Error("failed to disable totp", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("2fa disabled")
+	return nil
 }
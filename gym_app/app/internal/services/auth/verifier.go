@@ -0,0 +1,285 @@
+package authService
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	ssov1 "github.com/Muaz717/gym_app/app/pkg/sso"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Не чаще этого перезапрашиваем JWKS из-за неизвестного kid, чтобы поддельные токены не нагружали SSO
+	jwksRefetchInterval = 10 * time.Second
+	// При таком размере кэша проверок из него удаляются истекшие токены
+	checkedPruneSize = 10000
+)
+
+var (
+	errNoKeyID      = errors.New("token has no kid")
+	errUnknownKeyID = errors.New("unknown kid")
+)
+
+type KeySource interface {
+	GetJWKS(ctx context.Context, appID int32) ([]*ssov1.JWK, error)
+	CheckToken(ctx context.Context, appID int32, token string) (*ssov1.CheckTokenResponse, error)
+}
+
+// Verifier проверяет access-токены локально по публичным ключам SSO.
+// CheckToken вызывается только для проверки отзыва, не чаще раза в revocationCheckInterval на токен,
+// и для старых токенов без kid, подписанных секретом приложения.
+// Ошибки повторяют контракт CheckToken: недействительный токен — codes.Unauthenticated.
+type Verifier struct {
+	log                     *slog.Logger
+	sso                     KeySource
+	appID                   int32
+	revocationCheckInterval time.Duration
+
+	keysMu        sync.RWMutex
+	keys          map[string]publicKey
+	keysFetchedAt time.Time
+
+	checkedMu sync.Mutex
+	checked   map[string]checkedToken
+}
+
+type publicKey struct {
+	alg string
+	key any
+}
+
+type checkedToken struct {
+	checkedAt time.Time
+	expiresAt time.Time
+}
+
+type tokenClaims struct {
+	UserID      int64    `json:"uid"`
+	AppID       int32    `json:"app_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"perms"`
+	jwt.RegisteredClaims
+}
+
+func NewVerifier(
+	log *slog.Logger,
+	sso KeySource,
+	appID int32,
+	revocationCheckInterval time.Duration,
+) *Verifier {
+	return &Verifier{
+		log:                     log,
+		sso:                     sso,
+		appID:                   appID,
+		revocationCheckInterval: revocationCheckInterval,
+		keys:                    make(map[string]publicKey),
+		checked:                 make(map[string]checkedToken),
+	}
+}
+
+// Verify проверяет токен и возвращает пользователя в том же виде, что и CheckToken
+func (v *Verifier) Verify(ctx context.Context, token string) (*ssov1.CheckTokenResponse, error) {
+	const op = "services.auth.verify"
+
+	log := v.log.With(
+		slog.String("op", op),
+	)
+
+	claims, err := v.parse(ctx, token)
+	if errors.Is(err, errNoKeyID) {
+		return v.sso.CheckToken(ctx, v.appID, token)
+	}
+	if err != nil {
+		log.Warn("invalid token", sl.Error(err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if v.recentlyChecked(claims.ID) {
+		return &ssov1.CheckTokenResponse{
+			UserId:      claims.UserID,
+			IsValid:     true,
+			Roles:       claims.Roles,
+			Permissions: claims.Permissions,
+			Email:       claims.Email,
+		}, nil
+	}
+
+	resp, err := v.sso.CheckToken(ctx, v.appID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	v.markChecked(claims.ID, claims.ExpiresAt.Time)
+
+	return resp, nil
+}
+
+func (v *Verifier) parse(ctx context.Context, token string) (*tokenClaims, error) {
+	claims := &tokenClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, errNoKeyID
+		}
+
+		key, err := v.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if t.Method.Alg() != key.alg {
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+
+		return key.key, nil
+	}, jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.AppID != v.appID {
+		return nil, fmt.Errorf("token issued for app %d", claims.AppID)
+	}
+
+	return claims, nil
+}
+
+// key ищет ключ в кэше и перезапрашивает JWKS, если kid неизвестен (SSO мог сменить ключ)
+func (v *Verifier) key(ctx context.Context, kid string) (publicKey, error) {
+	v.keysMu.RLock()
+	key, ok := v.keys[kid]
+	v.keysMu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	v.keysMu.Lock()
+	defer v.keysMu.Unlock()
+
+	// Пока ждали блокировку, ключи мог обновить другой запрос
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(v.keysFetchedAt) < jwksRefetchInterval {
+		return publicKey{}, errUnknownKeyID
+	}
+
+	if err := v.fetchKeys(ctx); err != nil {
+		return publicKey{}, err
+	}
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+
+	return publicKey{}, errUnknownKeyID
+}
+
+// fetchKeys загружает JWKS. Вызывается под v.keysMu
+func (v *Verifier) fetchKeys(ctx context.Context) error {
+	const op = "services.auth.fetchKeys"
+
+	log := v.log.With(
+		slog.String("op", op),
+	)
+
+	jwks, err := v.sso.GetJWKS(ctx, v.appID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys := make(map[string]publicKey, len(jwks))
+	for _, jwk := range jwks {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			log.Warn("skipping key", slog.String("kid", jwk.GetKid()), sl.Error(err))
+			continue
+		}
+		keys[jwk.GetKid()] = key
+	}
+
+	v.keys = keys
+	v.keysFetchedAt = time.Now()
+
+	log.Info("jwks loaded", slog.Int("keys", len(keys)))
+
+	return nil
+}
+
+func (v *Verifier) recentlyChecked(jti string) bool {
+	if jti == "" || v.revocationCheckInterval <= 0 {
+		return false
+	}
+
+	v.checkedMu.Lock()
+	defer v.checkedMu.Unlock()
+
+	checked, ok := v.checked[jti]
+	return ok && time.Since(checked.checkedAt) < v.revocationCheckInterval
+}
+
+func (v *Verifier) markChecked(jti string, expiresAt time.Time) {
+	if jti == "" || v.revocationCheckInterval <= 0 {
+		return
+	}
+
+	v.checkedMu.Lock()
+	defer v.checkedMu.Unlock()
+
+	if len(v.checked) >= checkedPruneSize {
+		now := time.Now()
+		for id, checked := range v.checked {
+			if checked.expiresAt.Before(now) {
+				delete(v.checked, id)
+			}
+		}
+	}
+
+	v.checked[jti] = checkedToken{checkedAt: time.Now(), expiresAt: expiresAt}
+}
+
+func parseJWK(jwk *ssov1.JWK) (publicKey, error) {
+	switch jwk.GetKty() {
+	case "OKP":
+		if jwk.GetCrv() != "Ed25519" {
+			return publicKey{}, fmt.Errorf("unsupported curve %q", jwk.GetCrv())
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.GetX())
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return publicKey{}, fmt.Errorf("invalid ed25519 key")
+		}
+
+		return publicKey{alg: jwk.GetAlg(), key: ed25519.PublicKey(x)}, nil
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.GetN())
+		if err != nil {
+			return publicKey{}, fmt.Errorf("invalid rsa modulus: %w", err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.GetE())
+		if err != nil {
+			return publicKey{}, fmt.Errorf("invalid rsa exponent: %w", err)
+		}
+
+		return publicKey{alg: jwk.GetAlg(), key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	default:
+		return publicKey{}, fmt.Errorf("unsupported key type %q", jwk.GetKty())
+	}
+}
@@ -0,0 +1,177 @@
+package authService
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	ssov1 "github.com/Muaz717/gym_app/app/pkg/sso"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testAppID = 1
+
+type fakeSSO struct {
+	keys        map[string]ed25519.PrivateKey
+	jwksCalls   int
+	checkCalls  int
+	checkResult error
+}
+
+func newFakeSSO() *fakeSSO {
+	return &fakeSSO{keys: make(map[string]ed25519.PrivateKey)}
+}
+
+func (f *fakeSSO) addKey(t *testing.T, kid string) {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	f.keys[kid] = private
+}
+
+func (f *fakeSSO) GetJWKS(_ context.Context, _ int32) ([]*ssov1.JWK, error) {
+	f.jwksCalls++
+
+	jwks := make([]*ssov1.JWK, 0, len(f.keys))
+	for kid, private := range f.keys {
+		jwks = append(jwks, &ssov1.JWK{
+			Kty: "OKP",
+			Kid: kid,
+			Alg: "EdDSA",
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(private.Public().(ed25519.PublicKey)),
+		})
+	}
+	return jwks, nil
+}
+
+func (f *fakeSSO) CheckToken(_ context.Context, _ int32, _ string) (*ssov1.CheckTokenResponse, error) {
+	f.checkCalls++
+	if f.checkResult != nil {
+		return nil, f.checkResult
+	}
+	return &ssov1.CheckTokenResponse{UserId: 7, IsValid: true, Roles: []string{"user"}, Permissions: []string{"people:read"}, Email: "a@b.c"}, nil
+}
+
+func (f *fakeSSO) token(t *testing.T, kid string, ttl time.Duration) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+		"jti":    kid + "-token",
+		"uid":    7,
+		"email":  "a@b.c",
+		"roles":  []string{"user"},
+		"perms":  []string{"people:read"},
+		"app_id": testAppID,
+		"exp":    time.Now().Add(ttl).Unix(),
+	})
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(f.keys[kid])
+	require.NoError(t, err)
+	return signed
+}
+
+func TestVerifier_ChecksRevocationOncePerInterval(t *testing.T) {
+	sso := newFakeSSO()
+	sso.addKey(t, "k1")
+	v := NewVerifier(slogdiscard.NewDiscardLogger(), sso, testAppID, time.Minute)
+
+	token := sso.token(t, "k1", time.Minute)
+
+	for range 3 {
+		user, err := v.Verify(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), user.GetUserId())
+		assert.Equal(t, []string{"user"}, user.GetRoles())
+		assert.Equal(t, []string{"people:read"}, user.GetPermissions())
+	}
+
+	assert.Equal(t, 1, sso.jwksCalls)
+	assert.Equal(t, 1, sso.checkCalls)
+}
+
+func TestVerifier_RevokedToken(t *testing.T) {
+	sso := newFakeSSO()
+	sso.addKey(t, "k1")
+	sso.checkResult = status.Error(codes.Unauthenticated, "token revoked")
+	v := NewVerifier(slogdiscard.NewDiscardLogger(), sso, testAppID, time.Minute)
+
+	_, err := v.Verify(context.Background(), sso.token(t, "k1", time.Minute))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Отозванный токен не попадает в кэш проверок
+	_, err = v.Verify(context.Background(), sso.token(t, "k1", time.Minute))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, 2, sso.checkCalls)
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	sso := newFakeSSO()
+	sso.addKey(t, "k1")
+	v := NewVerifier(slogdiscard.NewDiscardLogger(), sso, testAppID, time.Minute)
+
+	_, err := v.Verify(context.Background(), sso.token(t, "k1", time.Minute))
+	require.NoError(t, err)
+
+	sso.addKey(t, "k2")
+	v.keysFetchedAt = time.Now().Add(-jwksRefetchInterval)
+
+	_, err = v.Verify(context.Background(), sso.token(t, "k2", time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, sso.jwksCalls)
+}
+
+func TestVerifier_UnknownKeyDoesNotHammerSSO(t *testing.T) {
+	sso := newFakeSSO()
+	sso.addKey(t, "k1")
+	v := NewVerifier(slogdiscard.NewDiscardLogger(), sso, testAppID, time.Minute)
+
+	_, err := v.Verify(context.Background(), sso.token(t, "k1", time.Minute))
+	require.NoError(t, err)
+
+	forged := newFakeSSO()
+	forged.addKey(t, "forged")
+	for range 3 {
+		_, err = v.Verify(context.Background(), forged.token(t, "forged", time.Minute))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	assert.Equal(t, 1, sso.jwksCalls)
+}
+
+func TestVerifier_ExpiredToken(t *testing.T) {
+	sso := newFakeSSO()
+	sso.addKey(t, "k1")
+	v := NewVerifier(slogdiscard.NewDiscardLogger(), sso, testAppID, time.Minute)
+
+	_, err := v.Verify(context.Background(), sso.token(t, "k1", -time.Minute))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Zero(t, sso.checkCalls)
+}
+
+func TestVerifier_LegacyTokenFallsBackToSSO(t *testing.T) {
+	sso := newFakeSSO()
+	v := NewVerifier(slogdiscard.NewDiscardLogger(), sso, testAppID, time.Minute)
+
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"uid": 7,
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	user, err := v.Verify(context.Background(), legacy)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), user.GetUserId())
+	assert.Equal(t, 1, sso.checkCalls)
+	assert.Zero(t, sso.jwksCalls)
+}
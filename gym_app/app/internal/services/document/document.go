@@ -0,0 +1,151 @@
+package documentService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/lib/pdf"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+const (
+	receiptTemplate  = "receipt.tmpl"
+	contractTemplate = "contract.tmpl"
+)
+
+type PersonSubFinder interface {
+	GetPersonSubByNumber(ctx context.Context, number string) (dto.PersonSubResponse, error)
+}
+
+type PersonFinder interface {
+	FindPersonById(ctx context.Context, id int) (models.Person, error)
+}
+
+type DocumentService struct {
+	log             *slog.Logger
+	personSubFinder PersonSubFinder
+	personFinder    PersonFinder
+	renderer        *pdf.Renderer
+	templates       *template.Template
+	org             config.Organization
+}
+
+var (
+	ErrSubNotFound = errors.New("subscription not found")
+)
+
+// documentData — данные, доступные в шаблонах документов
+type documentData struct {
+	Sub      dto.PersonSubResponse
+	Person   models.Person
+	Org      config.Organization
+	IssuedAt time.Time
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("02.01.2006")
+	},
+	"datetime": func(t time.Time) string {
+		return t.Format("02.01.2006 15:04")
+	},
+	"money": func(v float64) string {
+		return fmt.Sprintf("%.2f", v)
+	},
+}
+
+// New загружает шаблоны и шрифты. Ошибка означает неверную конфигурацию документов.
+func New(
+	log *slog.Logger,
+	personSubFinder PersonSubFinder,
+	personFinder PersonFinder,
+	cfg config.Documents,
+) (*DocumentService, error) {
+	const op = "services.document.New"
+
+	templates, err := template.New("documents").Funcs(templateFuncs).ParseFiles(
+		filepath.Join(cfg.TemplatesDir, receiptTemplate),
+		filepath.Join(cfg.TemplatesDir, contractTemplate),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	renderer, err := pdf.New(cfg.FontPath, cfg.BoldFontPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &DocumentService{
+		log:             log,
+		personSubFinder: personSubFinder,
+		personFinder:    personFinder,
+		renderer:        renderer,
+		templates:       templates,
+		org:             cfg.Organization,
+	}, nil
+}
+
+// Receipt формирует PDF-чек об оплате абонемента
+func (d *DocumentService) Receipt(ctx context.Context, number string) ([]byte, error) {
+	const op = "services.document.Receipt"
+
+	return d.render(ctx, op, receiptTemplate, number)
+}
+
+// Contract формирует PDF-договор на абонемент
+func (d *DocumentService) Contract(ctx context.Context, number string) ([]byte, error) {
+	const op = "services.document.Contract"
+
+	return d.render(ctx, op, contractTemplate, number)
+}
+
+func (d *DocumentService) render(ctx context.Context, op, name, number string) ([]byte, error) {
+	log := d.log.With(
+		slog.String("op", op),
+		slog.String("number", number),
+	)
+
+	personSub, err := d.personSubFinder.GetPersonSubByNumber(ctx, number)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			log.Warn("subscription not found")
+			return nil, fmt.Errorf("%s: %w", op, ErrSubNotFound)
+		}
+		log.Error("failed to find subscription", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Телефон клиента не входит в PersonSubResponse — берем его из карточки
+	person, err := d.personFinder.FindPersonById(ctx, personSub.PersonID)
+	if err != nil && !errors.Is(err, storage.ErrPersonNotFound) {
+		log.Error("failed to find person", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	data := documentData{
+		Sub:      personSub,
+		Person:   person,
+		Org:      d.org,
+		IssuedAt: time.Now(),
+	}
+
+	doc, err := d.renderer.Render(d.templates.Lookup(name), data)
+	if err != nil {
+		log.Error("failed to render document", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("document generated", slog.String("template", name))
+
+	return doc, nil
+}
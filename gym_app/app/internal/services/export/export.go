@@ -0,0 +1,152 @@
+package exportService
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+)
+
+type ExportStorage interface {
+	StreamPeople(ctx context.Context, filter dto.PeopleFilter, fn func(models.Person) error) error
+	StreamPersonSubs(ctx context.Context, filter dto.PersonSubFilter, fn func(dto.PersonSubResponse) error) error
+	StreamSingleVisits(ctx context.Context, filter dto.SingleVisitFilter, fn func(models.SingleVisit) error) error
+	StreamFreezes(ctx context.Context, filter dto.FreezeFilter, fn func(models.SubscriptionFreeze) error) error
+}
+
+type ExportService struct {
+	log           *slog.Logger
+	exportStorage ExportStorage
+}
+
+func New(
+	log *slog.Logger,
+	exportStorage ExportStorage,
+) *ExportService {
+	return &ExportService{
+		log:           log,
+		exportStorage: exportStorage,
+	}
+}
+
+// stream создает Writer с заголовком, передает его в fill и завершает файл
+func (e *ExportService) stream(
+	op string,
+	format export.Format,
+	out io.Writer,
+	sheet string,
+	header []string,
+	fill func(w export.Writer) error,
+) error {
+	log := e.log.With(
+		slog.String("op", op),
+		slog.String("format", string(format)),
+	)
+
+	w, err := export.NewWriter(format, out, sheet, header)
+	if err != nil {
+		log.Error("failed to create export writer", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := fill(w); err != nil {
+		log.Error("failed to export rows", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := w.Close(); err != nil {
+		log.Error("failed to finish export", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("export finished")
+	return nil
+}
+
+func (e *ExportService) ExportPeople(ctx context.Context, format export.Format, out io.Writer, filter dto.PeopleFilter) error {
+	const op = "services.export.ExportPeople"
+
+	header := []string{"ID", "ФИО", "Телефон"}
+
+	return e.stream(op, format, out, "people", header, func(w export.Writer) error {
+		return e.exportStorage.StreamPeople(ctx, filter, func(p models.Person) error {
+			return w.Write([]string{strconv.Itoa(p.Id), p.Name, p.Phone})
+		})
+	})
+}
+
+func (e *ExportService) ExportPersonSubs(ctx context.Context, format export.Format, out io.Writer, filter dto.PersonSubFilter) error {
+	const op = "services.export.ExportPersonSubs"
+
+	header := []string{
+		"Номер", "ID клиента", "Клиент", "Тариф", "Цена", "Скидка", "Итого",
+		"Начало", "Окончание", "Статус", "Дней заморозки", "Использовано дней заморозки",
+	}
+
+	return e.stream(op, format, out, "person_subs", header, func(w export.Writer) error {
+		return e.exportStorage.StreamPersonSubs(ctx, filter, func(s dto.PersonSubResponse) error {
+			return w.Write([]string{
+				s.Number,
+				strconv.Itoa(s.PersonID),
+				s.PersonName,
+				s.SubscriptionTitle,
+				export.Money(s.SubscriptionPrice),
+				export.Money(s.Discount),
+				export.Money(s.FinalPrice),
+				export.Date(s.StartDate),
+				export.Date(s.EndDate),
+				s.Status,
+				strconv.Itoa(s.FreezeDays),
+				strconv.Itoa(s.UsedFreezeDays),
+			})
+		})
+	})
+}
+
+func (e *ExportService) ExportSingleVisits(ctx context.Context, format export.Format, out io.Writer, filter dto.SingleVisitFilter) error {
+	const op = "services.export.ExportSingleVisits"
+
+	header := []string{"ID", "Дата", "Время", "Тариф", "Цена"}
+
+	return e.stream(op, format, out, "single_visits", header, func(w export.Writer) error {
+		return e.exportStorage.StreamSingleVisits(ctx, filter, func(v models.SingleVisit) error {
+			visitedAt := ""
+			if v.VisitedAt != nil {
+				visitedAt = v.VisitedAt.Format("15:04")
+			}
+
+			return w.Write([]string{
+				strconv.Itoa(v.Id),
+				export.Date(v.VisitDate),
+				visitedAt,
+				v.TariffTitle,
+				export.Money(v.FinalPrice),
+			})
+		})
+	})
+}
+
+func (e *ExportService) ExportFreezes(ctx context.Context, format export.Format, out io.Writer, filter dto.FreezeFilter) error {
+	const op = "services.export.ExportFreezes"
+
+	header := []string{"ID", "Номер абонемента", "Начало", "Окончание", "Дней", "Создана"}
+
+	return e.stream(op, format, out, "freezes", header, func(w export.Writer) error {
+		return e.exportStorage.StreamFreezes(ctx, filter, func(f models.SubscriptionFreeze) error {
+			return w.Write([]string{
+				strconv.Itoa(f.ID),
+				f.SubscriptionNumber,
+				export.Date(f.FreezeStart),
+				export.Date(f.FreezeEnd),
+				strconv.Itoa(f.DaysUsed),
+				export.DateTime(f.CreatedAt),
+			})
+		})
+	})
+}
@@ -0,0 +1,251 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+type ImportStorage interface {
+	FindPeopleByNameAndPhone(ctx context.Context, people []models.Person) ([]models.Person, error)
+	ExistingPersonIDs(ctx context.Context, ids []int) ([]int, error)
+	ExistingPersonSubNumbers(ctx context.Context, numbers []string) ([]string, error)
+	FindAllSubscriptions(ctx context.Context) ([]models.Subscription, error)
+	ImportPeople(ctx context.Context, people []models.Person) (int, error)
+	ImportPersonSubs(ctx context.Context, subs []models.PersonSubscription) (int, error)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
+}
+
+type ImportService struct {
+	log           *slog.Logger
+	importStorage ImportStorage
+	publisher     Publisher
+}
+
+// maxImportRows ограничивает размер одного импорта: файл целиком валидируется в памяти
+const maxImportRows = 5000
+
+var (
+	ErrInvalidFile    = errors.New("failed to read file")
+	ErrEmptyFile      = errors.New("file has no data rows")
+	ErrTooManyRows    = errors.New("too many rows in file")
+	ErrMissingColumns = errors.New("required columns are missing")
+	ErrConflict       = errors.New("data was changed during import, run it again")
+)
+
+func New(
+	log *slog.Logger,
+	importStorage ImportStorage,
+	publisher Publisher,
+) *ImportService {
+	return &ImportService{
+		log:           log,
+		importStorage: importStorage,
+		publisher:     publisher,
+	}
+}
+
+// ImportPeople загружает клиентов из CSV/XLSX. Обязательные колонки: ФИО и Телефон.
+// При dryRun или наличии ошибок в строках ничего не сохраняется.
+func (s *ImportService) ImportPeople(ctx context.Context, format export.Format, r io.Reader, dryRun bool) (dto.ImportResult, error) {
+	const op = "services.importer.ImportPeople"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	cols, rows, err := readTable(format, r, peopleColumns)
+	if err != nil {
+		log.Warn("failed to read import file", sl.Error(err))
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := cols.require(colName, colPhone); err != nil {
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := dto.ImportResult{DryRun: dryRun, Total: len(rows), Errors: []dto.ImportRowError{}}
+
+	people := make([]models.Person, len(rows))
+	rowErrs := make([]map[string]string, len(rows))
+	seen := make(map[string]int)
+
+	for i, row := range rows {
+		p := models.Person{
+			Name:  cols.value(row.cells, colName),
+			Phone: normalizePhone(cols.value(row.cells, colPhone)),
+		}
+		people[i] = p
+
+		errs := p.Validate()
+		if errs == nil {
+			errs = make(map[string]string)
+		}
+
+		key := personKey(p.Name, p.Phone)
+		if line, ok := seen[key]; ok {
+			errs["Name"] = fmt.Sprintf("Клиент с таким ФИО и телефоном уже есть в файле (строка %d)", line)
+		} else {
+			seen[key] = row.line
+		}
+
+		rowErrs[i] = errs
+	}
+
+	existing, err := s.importStorage.FindPeopleByNameAndPhone(ctx, people)
+	if err != nil {
+		log.Error("failed to check duplicates", sl.Error(err))
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	existingKeys := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		existingKeys[personKey(p.Name, p.Phone)] = true
+	}
+
+	for i, p := range people {
+		if existingKeys[personKey(p.Name, p.Phone)] {
+			rowErrs[i]["Name"] = "Клиент с таким ФИО и телефоном уже существует"
+		}
+	}
+
+	collectErrors(&result, rows, rowErrs)
+
+	if dryRun || len(result.Errors) > 0 {
+		log.Info("people import checked", slog.Int("total", result.Total), slog.Int("errors", len(result.Errors)))
+		return result, nil
+	}
+
+	imported, err := s.importStorage.ImportPeople(ctx, people)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserExists) {
+			return dto.ImportResult{}, fmt.Errorf("%s: %w", op, ErrConflict)
+		}
+		log.Error("failed to import people", sl.Error(err))
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	result.Imported = imported
+
+	s.publisher.Publish(ctx, events.DataImported{Kind: "people", Count: imported})
+
+	log.Info("people imported", slog.Int("imported", imported))
+	return result, nil
+}
+
+// ImportPersonSubs загружает абонементы клиентов из CSV/XLSX.
+// Клиент указывается колонкой «ID клиента» или парой «ФИО» + «Телефон»,
+// тариф — колонкой «ID тарифа» или названием в колонке «Тариф».
+// Если не указаны, окончание считается по сроку тарифа, а цена берется из тарифа.
+func (s *ImportService) ImportPersonSubs(ctx context.Context, format export.Format, r io.Reader, dryRun bool) (dto.ImportResult, error) {
+	const op = "services.importer.ImportPersonSubs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	cols, rows, err := readTable(format, r, personSubColumns)
+	if err != nil {
+		log.Warn("failed to read import file", sl.Error(err))
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := cols.require(colNumber, colStartDate); err != nil {
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !cols.has(colPersonID) && !(cols.has(colName) && cols.has(colPhone)) {
+		return dto.ImportResult{}, fmt.Errorf("%s: %w: %s", op, ErrMissingColumns, "ID клиента или ФИО и Телефон")
+	}
+	if !cols.has(colSubscriptionID) && !cols.has(colSubscriptionTitle) {
+		return dto.ImportResult{}, fmt.Errorf("%s: %w: %s", op, ErrMissingColumns, "ID тарифа или Тариф")
+	}
+
+	result := dto.ImportResult{DryRun: dryRun, Total: len(rows), Errors: []dto.ImportRowError{}}
+
+	lookup, err := s.newPersonSubLookup(ctx, cols, rows)
+	if err != nil {
+		log.Error("failed to prepare lookups", sl.Error(err))
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subs := make([]models.PersonSubscription, len(rows))
+	rowErrs := make([]map[string]string, len(rows))
+	seen := make(map[string]int)
+
+	for i, row := range rows {
+		sub, errs := lookup.parse(cols, row.cells)
+
+		if sub.Number != "" {
+			if line, ok := seen[sub.Number]; ok {
+				errs["Number"] = fmt.Sprintf("Абонемент с таким номером уже есть в файле (строка %d)", line)
+			} else {
+				seen[sub.Number] = row.line
+			}
+			if lookup.existingNumbers[sub.Number] {
+				errs["Number"] = "Абонемент с таким номером уже существует"
+			}
+		}
+
+		subs[i] = sub
+		rowErrs[i] = errs
+	}
+
+	collectErrors(&result, rows, rowErrs)
+
+	if dryRun || len(result.Errors) > 0 {
+		log.Info("person subscriptions import checked", slog.Int("total", result.Total), slog.Int("errors", len(result.Errors)))
+		return result, nil
+	}
+
+	imported, err := s.importStorage.ImportPersonSubs(ctx, subs)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionExists) || errors.Is(err, storage.ErrPersonNotFound) {
+			return dto.ImportResult{}, fmt.Errorf("%s: %w", op, ErrConflict)
+		}
+		log.Error("failed to import person subscriptions", sl.Error(err))
+		return dto.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	result.Imported = imported
+
+	s.publisher.Publish(ctx, events.DataImported{Kind: "person_subs", Count: imported})
+
+	log.Info("person subscriptions imported", slog.Int("imported", imported))
+	return result, nil
+}
+
+// collectErrors переносит непустые ошибки строк в результат и считает валидные строки
+func collectErrors(result *dto.ImportResult, rows []tableRow, rowErrs []map[string]string) {
+	for i, errs := range rowErrs {
+		if len(errs) == 0 {
+			result.Valid++
+			continue
+		}
+		result.Errors = append(result.Errors, dto.ImportRowError{Line: rows[i].line, Errors: errs})
+	}
+}
+
+func personKey(name, phone string) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "|" + phone
+}
+
+// normalizePhone убирает из телефона пробелы, скобки, дефисы и плюс
+func normalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '(', ')', '-', '+':
+			return -1
+		}
+		return r
+	}, phone)
+}
@@ -0,0 +1,192 @@
+package importService
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/gym_app/app/internal/services/importer/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T) (*ImportService, *mocks.ImportStorage, *mocks.Publisher) {
+	st := mocks.NewImportStorage(t)
+	pub := mocks.NewPublisher(t)
+	return New(slogdiscard.NewDiscardLogger(), st, pub), st, pub
+}
+
+func csvFile(lines ...string) *strings.Reader {
+	return strings.NewReader(strings.Join(lines, "\n") + "\n")
+}
+
+// rowErrors собирает ошибки по номеру строки файла
+func rowErrors(result dto.ImportResult) map[int]map[string]string {
+	byLine := make(map[int]map[string]string, len(result.Errors))
+	for _, e := range result.Errors {
+		byLine[e.Line] = e.Errors
+	}
+	return byLine
+}
+
+func TestImportPeople_Duplicates(t *testing.T) {
+	svc, st, _ := newTestService(t)
+
+	// Поиск в базе получает все строки файла, сравнение ФИО — без учета регистра и пробелов
+	st.EXPECT().FindPeopleByNameAndPhone(mock.Anything, mock.MatchedBy(func(people []models.Person) bool {
+		return len(people) == 5 && people[0] == models.Person{Name: "Иванов Иван", Phone: "79991234567"}
+	})).Return([]models.Person{{Id: 1, Name: "Петров Петр ", Phone: "79990000000"}}, nil).Once()
+
+	result, err := svc.ImportPeople(context.Background(), export.FormatCSV, csvFile(
+		"ФИО,Телефон",
+		"Иванов Иван,+7 (999) 123-45-67",
+		"Сидоров Сидор,89991112233",
+		"  ИВАНОВ иван ,7 999 123 45 67",
+		"петров петр,79990000000",
+		"Козлов Кирилл,123",
+	), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 2, result.Valid)
+	assert.Zero(t, result.Imported)
+
+	errs := rowErrors(result)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "Клиент с таким ФИО и телефоном уже есть в файле (строка 2)", errs[4]["Name"])
+	assert.Equal(t, "Клиент с таким ФИО и телефоном уже существует", errs[5]["Name"])
+	assert.Contains(t, errs[6], "Phone")
+
+	// Пока в файле есть ошибки, ImportPeople и Publish не вызываются
+}
+
+func TestImportPeople_Imports(t *testing.T) {
+	svc, st, pub := newTestService(t)
+
+	// Разделитель CSV — запятая, поэтому строка с ";" — одна колонка без ФИО
+	_, err := svc.ImportPeople(context.Background(), export.FormatCSV, csvFile(
+		"Телефон;ФИО",
+		"+7 (999) 123-45-67;Иванов Иван",
+	), false)
+	require.ErrorIs(t, err, ErrMissingColumns)
+
+	people := []models.Person{
+		{Name: "Иванов Иван", Phone: "79991234567"},
+		{Name: "Сидоров Сидор", Phone: "89991112233"},
+	}
+	st.EXPECT().FindPeopleByNameAndPhone(mock.Anything, people).Return(nil, nil).Twice()
+
+	result, err := svc.ImportPeople(context.Background(), export.FormatCSV, csvFile(
+		"Телефон,ФИО",
+		"+7 (999) 123-45-67,Иванов Иван",
+		"89991112233,Сидоров Сидор",
+	), true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Valid)
+	assert.Zero(t, result.Imported, "dry run")
+
+	st.EXPECT().ImportPeople(mock.Anything, people).Return(2, nil).Once()
+	pub.EXPECT().Publish(mock.Anything, events.DataImported{Kind: "people", Count: 2}).Return().Once()
+
+	result, err = svc.ImportPeople(context.Background(), export.FormatCSV, csvFile(
+		"Телефон,ФИО",
+		"+7 (999) 123-45-67,Иванов Иван",
+		"89991112233,Сидоров Сидор",
+	), false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+}
+
+func TestImportPersonSubs_ParsesRows(t *testing.T) {
+	svc, st, _ := newTestService(t)
+
+	st.EXPECT().ExistingPersonIDs(mock.Anything, []int{7, 8, 7, 7, 7, 7, 7, 7}).Return([]int{7}, nil).Once()
+	st.EXPECT().FindPeopleByNameAndPhone(mock.Anything, []models.Person{{Name: "Иванов Иван", Phone: "79991234567"}}).
+		Return([]models.Person{{Id: 7, Name: "Иванов Иван", Phone: "79991234567"}}, nil).Once()
+	st.EXPECT().ExistingPersonSubNumbers(mock.Anything, []string{"A-1", "A-2", "A-3", "A-4", "A-5", "A-6", "A-7", "A-1", "A-100"}).
+		Return([]string{"A-100"}, nil).Once()
+	st.EXPECT().FindAllSubscriptions(mock.Anything).
+		Return([]models.Subscription{{ID: "3", Title: "Месяц", Price: 3000, DurationDays: 30}}, nil).Once()
+
+	result, err := svc.ImportPersonSubs(context.Background(), export.FormatCSV, csvFile(
+		"Номер,ID клиента,ФИО,Телефон,Тариф,Начало,Окончание,Статус,Скидка",
+		`A-1,7,,,месяц,01.02.2025,,,"500,50"`,
+		"A-2,,Иванов Иван,+7 (999) 123-45-67,Месяц,2025-02-01,2025-03-15,Frozen,",
+		"A-3,8,,,Месяц,2025-02-01,,,",
+		"A-4,7,,,Год,2025-02-01,,,",
+		"A-5,7,,,Месяц,2025/02/01,,,",
+		"A-6,7,,,Месяц,2025-02-01,2025-01-01,,",
+		"A-7,7,,,Месяц,2025-02-01,,paused,",
+		"A-1,7,,,Месяц,2025-02-01,,,",
+		"A-100,7,,,Месяц,2025-02-01,,,",
+	), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 9, result.Total)
+	assert.Equal(t, 2, result.Valid)
+
+	errs := rowErrors(result)
+	assert.NotContains(t, errs, 2)
+	assert.NotContains(t, errs, 3)
+	assert.Equal(t, "Клиент с таким ID не найден", errs[4]["PersonID"])
+	assert.Equal(t, "Тариф с таким названием не найден", errs[5]["SubscriptionID"])
+	assert.Contains(t, errs[6], "StartDate")
+	assert.Equal(t, "Дата окончания раньше даты начала", errs[7]["EndDate"])
+	assert.Contains(t, errs[8], "Status")
+	assert.Equal(t, "Абонемент с таким номером уже есть в файле (строка 2)", errs[9]["Number"])
+	assert.Equal(t, "Абонемент с таким номером уже существует", errs[10]["Number"])
+}
+
+func TestImportPersonSubs_Imports(t *testing.T) {
+	svc, st, pub := newTestService(t)
+
+	st.EXPECT().ExistingPersonIDs(mock.Anything, []int{7, 7}).Return([]int{7}, nil).Once()
+	st.EXPECT().FindPeopleByNameAndPhone(mock.Anything, []models.Person(nil)).Return(nil, nil).Once()
+	st.EXPECT().ExistingPersonSubNumbers(mock.Anything, []string{"A-1", "A-2"}).Return(nil, nil).Once()
+	st.EXPECT().FindAllSubscriptions(mock.Anything).
+		Return([]models.Subscription{{ID: "3", Title: "Месяц", Price: 3000, DurationDays: 30}}, nil).Once()
+
+	start := time.Date(2025, 2, 1, 0, 0, 0, 0, time.Local)
+	st.EXPECT().ImportPersonSubs(mock.Anything, []models.PersonSubscription{
+		{
+			Number: "A-1", PersonID: 7, SubscriptionID: 3, Status: "active",
+			StartDate: start, EndDate: start.AddDate(0, 0, 30),
+			SubscriptionPrice: 3000, Discount: 500.5, FinalPrice: 2499.5,
+		},
+		{
+			Number: "A-2", PersonID: 7, SubscriptionID: 3, Status: "frozen",
+			StartDate: start, EndDate: time.Date(2025, 3, 15, 0, 0, 0, 0, time.Local),
+			SubscriptionPrice: 3000, FinalPrice: 3000,
+		},
+	}).Return(2, nil).Once()
+	pub.EXPECT().Publish(mock.Anything, events.DataImported{Kind: "person_subs", Count: 2}).Return().Once()
+
+	result, err := svc.ImportPersonSubs(context.Background(), export.FormatCSV, csvFile(
+		"Номер,ID клиента,ID тарифа,Начало,Окончание,Статус,Скидка",
+		`A-1,7,3,01.02.2025,,,"500,50"`,
+		"A-2,7,3,2025-02-01,2025-03-15,Frozen,",
+	), false)
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+	assert.Equal(t, 2, result.Imported)
+}
+
+func TestImportPersonSubs_MissingColumns(t *testing.T) {
+	svc, _, _ := newTestService(t)
+
+	_, err := svc.ImportPersonSubs(context.Background(), export.FormatCSV, csvFile(
+		"Номер,Начало,Тариф",
+		"A-1,2025-02-01,Месяц",
+	), false)
+	assert.ErrorIs(t, err, ErrMissingColumns)
+
+	_, err = svc.ImportPersonSubs(context.Background(), export.FormatCSV, csvFile("Номер,Начало"), false)
+	assert.ErrorIs(t, err, ErrEmptyFile)
+}
@@ -0,0 +1,341 @@
+package importService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/export"
+)
+
+// Поля импортируемых таблиц
+const (
+	colName              = "name"
+	colPhone             = "phone"
+	colNumber            = "number"
+	colPersonID          = "person_id"
+	colSubscriptionID    = "subscription_id"
+	colSubscriptionTitle = "subscription_title"
+	colSubscriptionPrice = "subscription_price"
+	colDiscount          = "discount"
+	colFinalPrice        = "final_price"
+	colStartDate         = "start_date"
+	colEndDate           = "end_date"
+	colStatus            = "status"
+)
+
+// Допустимые заголовки колонок (без учета регистра). Русские названия совпадают с выгрузкой,
+// поэтому выгруженный файл можно загрузить обратно.
+var (
+	peopleColumns = map[string][]string{
+		colName:  {"фио", "клиент", "full_name", "name"},
+		colPhone: {"телефон", "phone"},
+	}
+
+	personSubColumns = map[string][]string{
+		colNumber:            {"номер", "number"},
+		colPersonID:          {"id клиента", "person_id"},
+		colName:              {"фио", "клиент", "full_name", "person_name"},
+		colPhone:             {"телефон", "phone"},
+		colSubscriptionID:    {"id тарифа", "subscription_id"},
+		colSubscriptionTitle: {"тариф", "subscription_title"},
+		colSubscriptionPrice: {"цена", "subscription_price"},
+		colDiscount:          {"скидка", "discount"},
+		colFinalPrice:        {"итого", "final_price"},
+		colStartDate:         {"начало", "start_date"},
+		colEndDate:           {"окончание", "end_date"},
+		colStatus:            {"статус", "status"},
+	}
+
+	// importStatuses — статусы, с которыми абонемент можно загрузить, как в сервисе абонементов
+	importStatuses = map[string]bool{"active": true, "frozen": true, "expired": true, "closed": true}
+
+	columnTitles = map[string]string{
+		colName:      "ФИО",
+		colPhone:     "Телефон",
+		colNumber:    "Номер",
+		colStartDate: "Начало",
+	}
+)
+
+type tableRow struct {
+	line  int
+	cells []string
+}
+
+// columns сопоставляет поле с индексом колонки в файле
+type columns map[string]int
+
+func (c columns) has(field string) bool {
+	_, ok := c[field]
+	return ok
+}
+
+func (c columns) value(cells []string, field string) string {
+	idx, ok := c[field]
+	if !ok || idx >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[idx])
+}
+
+func (c columns) require(fields ...string) error {
+	var missing []string
+	for _, f := range fields {
+		if !c.has(f) {
+			missing = append(missing, columnTitles[f])
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingColumns, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// readTable читает файл целиком: первая непустая строка — заголовок
+func readTable(format export.Format, r io.Reader, aliases map[string][]string) (columns, []tableRow, error) {
+	var (
+		cols columns
+		rows []tableRow
+	)
+
+	err := export.ReadRows(format, r, func(line int, cells []string) error {
+		if cols == nil {
+			cols = mapColumns(cells, aliases)
+			return nil
+		}
+		if len(rows) >= maxImportRows {
+			return ErrTooManyRows
+		}
+		rows = append(rows, tableRow{line: line, cells: cells})
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrTooManyRows) {
+			return nil, nil, fmt.Errorf("%w: max %d", ErrTooManyRows, maxImportRows)
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, ErrEmptyFile
+	}
+
+	return cols, rows, nil
+}
+
+func mapColumns(header []string, aliases map[string][]string) columns {
+	cols := make(columns)
+	for idx, title := range header {
+		title = strings.ToLower(strings.TrimSpace(title))
+		for field, names := range aliases {
+			for _, name := range names {
+				if title == name && !cols.has(field) {
+					cols[field] = idx
+				}
+			}
+		}
+	}
+	return cols
+}
+
+// personSubLookup — справочники, загруженные одним запросом на весь файл
+type personSubLookup struct {
+	personIDs       map[int]bool
+	peopleByKey     map[string]int
+	subsByID        map[int]models.Subscription
+	subsByTitle     map[string]models.Subscription
+	existingNumbers map[string]bool
+}
+
+func (s *ImportService) newPersonSubLookup(ctx context.Context, cols columns, rows []tableRow) (*personSubLookup, error) {
+	l := &personSubLookup{
+		personIDs:       make(map[int]bool),
+		peopleByKey:     make(map[string]int),
+		subsByID:        make(map[int]models.Subscription),
+		subsByTitle:     make(map[string]models.Subscription),
+		existingNumbers: make(map[string]bool),
+	}
+
+	var (
+		ids     []int
+		people  []models.Person
+		numbers []string
+	)
+	for _, row := range rows {
+		if id, err := strconv.Atoi(cols.value(row.cells, colPersonID)); err == nil {
+			ids = append(ids, id)
+		}
+		if name := cols.value(row.cells, colName); name != "" {
+			people = append(people, models.Person{Name: name, Phone: normalizePhone(cols.value(row.cells, colPhone))})
+		}
+		if number := cols.value(row.cells, colNumber); number != "" {
+			numbers = append(numbers, number)
+		}
+	}
+
+	existingIDs, err := s.importStorage.ExistingPersonIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range existingIDs {
+		l.personIDs[id] = true
+	}
+
+	found, err := s.importStorage.FindPeopleByNameAndPhone(ctx, people)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range found {
+		l.peopleByKey[personKey(p.Name, p.Phone)] = p.Id
+	}
+
+	existingNumbers, err := s.importStorage.ExistingPersonSubNumbers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range existingNumbers {
+		l.existingNumbers[n] = true
+	}
+
+	subscriptions, err := s.importStorage.FindAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subscriptions {
+		if id, err := strconv.Atoi(sub.ID); err == nil {
+			l.subsByID[id] = sub
+		}
+		l.subsByTitle[strings.ToLower(sub.Title)] = sub
+	}
+
+	return l, nil
+}
+
+// parse собирает абонемент из строки и возвращает ошибки по полям.
+// Обязательные поля проверяются через PersonSubInput.Validate.
+func (l *personSubLookup) parse(cols columns, cells []string) (models.PersonSubscription, map[string]string) {
+	errs := make(map[string]string)
+
+	input := dto.PersonSubInput{
+		Number:    cols.value(cells, colNumber),
+		StartDate: cols.value(cells, colStartDate),
+		EndDate:   cols.value(cells, colEndDate),
+		Status:    cols.value(cells, colStatus),
+	}
+
+	// Клиент
+	if idStr := cols.value(cells, colPersonID); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		switch {
+		case err != nil:
+			errs["PersonID"] = "ID клиента должен быть числом"
+		case !l.personIDs[id]:
+			errs["PersonID"] = "Клиент с таким ID не найден"
+		default:
+			input.PersonID = id
+		}
+	} else if name := cols.value(cells, colName); name != "" {
+		id, ok := l.peopleByKey[personKey(name, normalizePhone(cols.value(cells, colPhone)))]
+		if ok {
+			input.PersonID = id
+		} else {
+			errs["PersonID"] = "Клиент с таким ФИО и телефоном не найден"
+		}
+	}
+
+	// Тариф
+	var subscription models.Subscription
+	var subFound bool
+	if idStr := cols.value(cells, colSubscriptionID); idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			errs["SubscriptionID"] = "ID тарифа должен быть числом"
+		} else if subscription, subFound = l.subsByID[id]; !subFound {
+			errs["SubscriptionID"] = "Тариф с таким ID не найден"
+		}
+	} else if title := cols.value(cells, colSubscriptionTitle); title != "" {
+		if subscription, subFound = l.subsByTitle[strings.ToLower(title)]; !subFound {
+			errs["SubscriptionID"] = "Тариф с таким названием не найден"
+		}
+	}
+	if subFound {
+		input.SubscriptionID, _ = strconv.Atoi(subscription.ID)
+	}
+
+	// Сообщения об обязательных полях берем из PersonSubInput.Validate,
+	// но не затираем более точные ошибки поиска клиента и тарифа
+	for field, msg := range input.Validate() {
+		if _, ok := errs[field]; !ok {
+			errs[field] = msg
+		}
+	}
+
+	sub := models.PersonSubscription{
+		Number:         input.Number,
+		PersonID:       input.PersonID,
+		SubscriptionID: input.SubscriptionID,
+		Status:         strings.ToLower(input.Status),
+	}
+	if sub.Status == "" {
+		sub.Status = "active"
+	}
+	if !importStatuses[sub.Status] {
+		errs["Status"] = "Статус должен быть одним из: active, frozen, expired, closed"
+	}
+
+	var err error
+	if sub.StartDate, err = parseDate(input.StartDate); err != nil {
+		errs["StartDate"] = "Дата начала должна быть в формате ГГГГ-ММ-ДД или ДД.ММ.ГГГГ"
+	}
+	if input.EndDate != "" {
+		if sub.EndDate, err = parseDate(input.EndDate); err != nil {
+			errs["EndDate"] = "Дата окончания должна быть в формате ГГГГ-ММ-ДД или ДД.ММ.ГГГГ"
+		}
+	} else if subFound && !sub.StartDate.IsZero() {
+		sub.EndDate = sub.StartDate.AddDate(0, 0, subscription.DurationDays)
+	}
+	if !sub.StartDate.IsZero() && !sub.EndDate.IsZero() && sub.EndDate.Before(sub.StartDate) {
+		errs["EndDate"] = "Дата окончания раньше даты начала"
+	}
+
+	sub.SubscriptionPrice = subscription.Price
+	if v := cols.value(cells, colSubscriptionPrice); v != "" {
+		if sub.SubscriptionPrice, err = parseMoney(v); err != nil {
+			errs["SubscriptionPrice"] = "Цена должна быть числом"
+		}
+	}
+	if v := cols.value(cells, colDiscount); v != "" {
+		if sub.Discount, err = parseMoney(v); err != nil {
+			errs["Discount"] = "Скидка должна быть числом"
+		}
+	}
+	sub.FinalPrice = sub.SubscriptionPrice - sub.Discount
+	if v := cols.value(cells, colFinalPrice); v != "" {
+		if sub.FinalPrice, err = parseMoney(v); err != nil {
+			errs["FinalPrice"] = "Итоговая цена должна быть числом"
+		}
+	}
+
+	return sub, errs
+}
+
+// parseDate принимает даты в формате ГГГГ-ММ-ДД и ДД.ММ.ГГГГ, как их обычно сохраняет Excel
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("02.01.2006", value, time.Local)
+}
+
+// parseMoney принимает суммы как с точкой, так и с запятой
+func parseMoney(value string) (float64, error) {
+	value = strings.ReplaceAll(strings.ReplaceAll(value, " ", ""), ",", ".")
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,194 @@
+package liveService
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+)
+
+// clientBuffer — сколько сообщений может ждать отправки одному клиенту.
+// Клиента, который не успевает их забирать, отключаем: браузер сам переподключится.
+const clientBuffer = 32
+
+type IncomeProvider interface {
+	DailyIncome(ctx context.Context, day time.Time) (dto.DailyIncome, error)
+}
+
+// LiveService рассылает подключенным экранам продажи, приходы, заморозки и выручку за сегодня
+type LiveService struct {
+	log    *slog.Logger
+	income IncomeProvider
+	now    func() time.Time
+
+	mu      sync.Mutex
+	clients map[chan dto.LiveMessage]struct{}
+	closed  bool
+
+	// incomeMu не дает параллельным пересчетам разослать выручку в обратном порядке
+	incomeMu sync.Mutex
+}
+
+func New(
+	log *slog.Logger,
+	income IncomeProvider,
+) *LiveService {
+	return &LiveService{
+		log:     log,
+		income:  income,
+		now:     time.Now,
+		clients: make(map[chan dto.LiveMessage]struct{}),
+	}
+}
+
+// Register подписывает рассылку на шину. Сами события отправляются синхронно (это только запись в каналы),
+// а выручка пересчитывается асинхронно, чтобы запрос в базу не задерживал ответ кассиру.
+func (s *LiveService) Register(bus *events.Bus) {
+	bus.Subscribe(events.On(func(ctx context.Context, e events.SubscriptionSold) {
+		s.broadcast(dto.LiveMessage{Event: dto.LiveSale, Data: dto.LiveSaleEvent{
+			Kind:               dto.SaleSubscription,
+			SubscriptionNumber: e.Number,
+			PersonID:           e.PersonID,
+			Amount:             e.FinalPrice,
+			Date:               e.StartDate,
+		}})
+	}))
+	bus.Subscribe(events.On(func(ctx context.Context, e events.SingleVisitAdded) {
+		s.broadcast(dto.LiveMessage{Event: dto.LiveSale, Data: dto.LiveSaleEvent{
+			Kind:          dto.SaleSingleVisit,
+			SingleVisitID: e.ID,
+			Amount:        e.FinalPrice,
+			Date:          e.VisitDate,
+		}})
+	}))
+	bus.Subscribe(events.On(func(ctx context.Context, e events.VisitCheckedIn) {
+		s.broadcast(dto.LiveMessage{Event: dto.LiveVisit, Data: dto.LiveVisitEvent{
+			VisitID:            e.VisitID,
+			SubscriptionNumber: e.SubscriptionNumber,
+			PersonID:           e.PersonID,
+			PersonName:         e.PersonName,
+			At:                 e.At,
+		}})
+	}))
+	bus.Subscribe(events.On(func(ctx context.Context, e events.SubscriptionFrozen) {
+		s.broadcast(dto.LiveMessage{Event: dto.LiveFreeze, Data: dto.LiveFreezeEvent{
+			SubscriptionNumber: e.Number,
+			Frozen:             true,
+			Date:               e.Date,
+		}})
+	}))
+	bus.Subscribe(events.On(func(ctx context.Context, e events.SubscriptionUnfrozen) {
+		s.broadcast(dto.LiveMessage{Event: dto.LiveFreeze, Data: dto.LiveFreezeEvent{
+			SubscriptionNumber: e.Number,
+			Frozen:             false,
+			Date:               e.Date,
+		}})
+	}))
+
+	bus.SubscribeAsync(func(ctx context.Context, event events.Event) {
+		switch e := event.(type) {
+		case events.SubscriptionSold, events.SubscriptionDeleted, events.PersonDeleted,
+			events.SingleVisitAdded, events.SingleVisitDeleted:
+			s.refreshIncome(ctx)
+		case events.DataImported:
+			if e.Kind == "person_subs" {
+				s.refreshIncome(ctx)
+			}
+		}
+	})
+}
+
+// Subscribe подключает клиента. Канал закрывается после отписки, при отключении
+// медленного клиента и при остановке сервиса.
+func (s *LiveService) Subscribe() (<-chan dto.LiveMessage, func()) {
+	ch := make(chan dto.LiveMessage, clientBuffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	s.clients[ch] = struct{}{}
+
+	return ch, func() { s.remove(ch) }
+}
+
+// Income возвращает выручку за сегодня: ее клиент получает сразу после подключения
+func (s *LiveService) Income(ctx context.Context) (dto.LiveMessage, error) {
+	income, err := s.income.DailyIncome(ctx, s.now())
+	if err != nil {
+		return dto.LiveMessage{}, err
+	}
+
+	return dto.LiveMessage{Event: dto.LiveIncome, Data: income}, nil
+}
+
+// Close отключает всех клиентов. Без этого открытые потоки не дают HTTP-серверу остановиться.
+func (s *LiveService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for ch := range s.clients {
+		delete(s.clients, ch)
+		close(ch)
+	}
+}
+
+func (s *LiveService) refreshIncome(ctx context.Context) {
+	const op = "services.live.refreshIncome"
+	log := s.log.With(slog.String("op", op))
+
+	if s.clientsCount() == 0 {
+		return
+	}
+
+	s.incomeMu.Lock()
+	defer s.incomeMu.Unlock()
+
+	msg, err := s.Income(ctx)
+	if err != nil {
+		log.Error("failed to get daily income", sl.Error(err))
+		return
+	}
+
+	s.broadcast(msg)
+}
+
+func (s *LiveService) broadcast(msg dto.LiveMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.clients {
+		select {
+		case ch <- msg:
+		default:
+			s.log.Warn("live client is too slow, disconnecting", slog.String("event", msg.Event))
+			delete(s.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+func (s *LiveService) remove(ch chan dto.LiveMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[ch]; ok {
+		delete(s.clients, ch)
+		close(ch)
+	}
+}
+
+func (s *LiveService) clientsCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.clients)
+}
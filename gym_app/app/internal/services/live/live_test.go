@@ -0,0 +1,108 @@
+package liveService
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubIncome struct {
+	total float64
+}
+
+func (s *stubIncome) DailyIncome(_ context.Context, day time.Time) (dto.DailyIncome, error) {
+	return dto.DailyIncome{Date: day, Total: s.total}, nil
+}
+
+func receive(t *testing.T, ch <-chan dto.LiveMessage) dto.LiveMessage {
+	t.Helper()
+
+	select {
+	case msg, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+		return dto.LiveMessage{}
+	}
+}
+
+func TestLiveService_SaleAndIncome(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+	bus := events.NewBus(log)
+	income := &stubIncome{total: 1500}
+
+	s := New(log, income)
+	s.Register(bus)
+
+	messages, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	bus.Publish(context.Background(), events.SubscriptionSold{Number: "A-1", PersonID: 7, FinalPrice: 1500})
+
+	msg := receive(t, messages)
+	assert.Equal(t, dto.LiveSale, msg.Event)
+	assert.Equal(t, dto.LiveSaleEvent{Kind: dto.SaleSubscription, SubscriptionNumber: "A-1", PersonID: 7, Amount: 1500}, msg.Data)
+
+	msg = receive(t, messages)
+	assert.Equal(t, dto.LiveIncome, msg.Event)
+	assert.Equal(t, 1500.0, msg.Data.(dto.DailyIncome).Total)
+}
+
+func TestLiveService_Freeze(t *testing.T) {
+	log := slogdiscard.NewDiscardLogger()
+	bus := events.NewBus(log)
+
+	s := New(log, &stubIncome{})
+	s.Register(bus)
+
+	messages, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	bus.Publish(context.Background(), events.SubscriptionUnfrozen{Number: "A-1"})
+
+	msg := receive(t, messages)
+	assert.Equal(t, dto.LiveFreeze, msg.Event)
+	assert.Equal(t, dto.LiveFreezeEvent{SubscriptionNumber: "A-1", Frozen: false}, msg.Data)
+}
+
+func TestLiveService_SlowClientDisconnected(t *testing.T) {
+	s := New(slogdiscard.NewDiscardLogger(), &stubIncome{})
+
+	slow, _ := s.Subscribe()
+	fast, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= clientBuffer; i++ {
+		s.broadcast(dto.LiveMessage{Event: dto.LiveVisit})
+		<-fast
+	}
+
+	for range clientBuffer {
+		<-slow
+	}
+	_, ok := <-slow
+	assert.False(t, ok, "slow client must be disconnected")
+	assert.Equal(t, 1, s.clientsCount())
+}
+
+func TestLiveService_Close(t *testing.T) {
+	s := New(slogdiscard.NewDiscardLogger(), &stubIncome{})
+
+	messages, unsubscribe := s.Subscribe()
+	s.Close()
+	unsubscribe()
+
+	_, ok := <-messages
+	assert.False(t, ok)
+
+	late, _ := s.Subscribe()
+	_, ok = <-late
+	assert.False(t, ok, "subscription after close must be closed immediately")
+}
@@ -0,0 +1,263 @@
+package memberService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	personSubService "github.com/Muaz717/gym_app/app/internal/services/person_sub"
+)
+
+type MemberStorage interface {
+	FindPeopleByPhones(ctx context.Context, phones []string) ([]models.Person, error)
+	FindPeopleByTelegramChat(ctx context.Context, chatID int64) ([]models.Person, error)
+	LinkTelegramChat(ctx context.Context, personIDs []int, chatID int64) error
+	UnlinkTelegramChat(ctx context.Context, chatID int64) error
+
+	GetMemberPersonID(ctx context.Context, userID int64) (int, error)
+	PersonHasMemberAccount(ctx context.Context, personID int) (bool, error)
+	ReserveMemberRegistration(ctx context.Context, personID int, ttl time.Duration) error
+	ReleaseMemberRegistration(ctx context.Context, personID int) error
+	LinkMemberAccount(ctx context.Context, userID int64, personID int) error
+	UnlinkMemberAccount(ctx context.Context, userID int64) error
+	FindVisitsByPerson(ctx context.Context, personID int, from, to time.Time) ([]models.Visit, error)
+	FindPersonById(ctx context.Context, id int) (models.Person, error)
+	GetPersonContacts(ctx context.Context, personID int) (models.PersonContacts, error)
+}
+
+type PersonSubFinder interface {
+	FindPersonSubByPersonId(ctx context.Context, personID int) ([]dto.PersonSubResponse, error)
+	GetPersonSubByNumber(ctx context.Context, number string) (dto.PersonSubResponse, error)
+}
+
+type FreezeService interface {
+	GetFreezeHistory(ctx context.Context, subscriptionNumber string) ([]models.SubscriptionFreeze, error)
+	RequestFreeze(ctx context.Context, req models.FreezeRequest) (int, error)
+	FindFreezeRequests(ctx context.Context, filter dto.FreezeRequestFilter) ([]models.FreezeRequest, error)
+}
+
+type AccountRegistrar interface {
+	RegisterMember(ctx context.Context, email, password string) (int64, error)
+}
+
+type MemberService struct {
+	log              *slog.Logger
+	memberStorage    MemberStorage
+	personSubFinder  PersonSubFinder
+	freezeService    FreezeService
+	accountRegistrar AccountRegistrar
+}
+
+var (
+	ErrInvalidPhone   = errors.New("invalid phone number")
+	ErrMemberNotFound = errors.New("no member with that phone number")
+	ErrNotLinked      = errors.New("chat is not linked to any member")
+
+	ErrNotMember          = errors.New("account is not linked to a member")
+	ErrMemberExists       = errors.New("member already has an account")
+	ErrRegistrationBusy   = errors.New("registration for this member is already in progress")
+	ErrPersonNotFound     = errors.New("person not found")
+	ErrVerificationFailed = errors.New("subscription number and phone do not match")
+	ErrSubNotFound        = errors.New("subscription not found")
+	ErrInvalidFreezeStart = errors.New("freeze start must be a date within the subscription period, not in the past")
+	ErrFreezeUnavailable  = errors.New("subscription cannot be frozen")
+)
+
+func New(
+	log *slog.Logger,
+	memberStorage MemberStorage,
+	personSubFinder PersonSubFinder,
+	freezeService FreezeService,
+	accountRegistrar AccountRegistrar,
+) *MemberService {
+	return &MemberService{
+		log:              log,
+		memberStorage:    memberStorage,
+		personSubFinder:  personSubFinder,
+		freezeService:    freezeService,
+		accountRegistrar: accountRegistrar,
+	}
+}
+
+// LinkTelegram привязывает чат к клиентам с указанным телефоном.
+// Если телефон указан у нескольких карточек (например, родитель и ребенок), привязываются все.
+func (m *MemberService) LinkTelegram(ctx context.Context, chatID int64, phone string) ([]models.Person, error) {
+	const op = "services.member.LinkTelegram"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+	)
+
+	variants, err := phoneVariants(phone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	people, err := m.memberStorage.FindPeopleByPhones(ctx, variants)
+	if err != nil {
+		log.Error("failed to find people by phone", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(people) == 0 {
+		log.Info("no member with that phone")
+		return nil, fmt.Errorf("%s: %w", op, ErrMemberNotFound)
+	}
+
+	ids := make([]int, 0, len(people))
+	for _, p := range people {
+		ids = append(ids, p.Id)
+	}
+
+	if err := m.memberStorage.LinkTelegramChat(ctx, ids, chatID); err != nil {
+		log.Error("failed to link chat", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("telegram chat linked", slog.Any("person_ids", ids))
+
+	return people, nil
+}
+
+// UnlinkTelegram отвязывает чат от всех клиентов
+func (m *MemberService) UnlinkTelegram(ctx context.Context, chatID int64) error {
+	const op = "services.member.UnlinkTelegram"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+	)
+
+	if err := m.memberStorage.UnlinkTelegramChat(ctx, chatID); err != nil {
+		log.Error("failed to unlink chat", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("telegram chat unlinked")
+
+	return nil
+}
+
+// TelegramSubscriptions возвращает абонементы клиентов, привязанных к чату
+func (m *MemberService) TelegramSubscriptions(ctx context.Context, chatID int64) ([]dto.MemberSubscription, error) {
+	const op = "services.member.TelegramSubscriptions"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+	)
+
+	people, err := m.memberStorage.FindPeopleByTelegramChat(ctx, chatID)
+	if err != nil {
+		log.Error("failed to find linked people", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(people) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotLinked)
+	}
+
+	subs, err := m.subscriptions(ctx, people, true)
+	if err != nil {
+		log.Error("failed to get subscriptions", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// subscriptions собирает абонементы клиентов, последние первыми. При onlyCurrent — только действующие и будущие,
+// а если таких нет, последний закончившийся: клиенту важно знать, когда он истек.
+func (m *MemberService) subscriptions(ctx context.Context, people []models.Person, onlyCurrent bool) ([]dto.MemberSubscription, error) {
+	today := truncateToDate(time.Now())
+
+	result := make([]dto.MemberSubscription, 0)
+	for _, person := range people {
+		personSubs, err := m.personSubFinder.FindPersonSubByPersonId(ctx, person.Id)
+		if err != nil {
+			if errors.Is(err, personSubService.ErrSubNotFound) || errors.Is(err, personSubService.ErrPersonNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		sort.Slice(personSubs, func(i, j int) bool {
+			return personSubs[i].EndDate.After(personSubs[j].EndDate)
+		})
+
+		shown := personSubs
+		if onlyCurrent {
+			shown = nil
+			for _, sub := range personSubs {
+				if !truncateToDate(sub.EndDate).Before(today) {
+					shown = append(shown, sub)
+				}
+			}
+			if len(shown) == 0 && len(personSubs) > 0 {
+				shown = personSubs[:1]
+			}
+		}
+
+		for _, sub := range shown {
+			freezes, err := m.freezeService.GetFreezeHistory(ctx, sub.Number)
+			if err != nil {
+				return nil, err
+			}
+
+			item := dto.MemberSubscription{
+				PersonName:     person.Name,
+				Subscription:   sub,
+				FreezeDaysLeft: max(sub.FreezeDays-sub.UsedFreezeDays, 0),
+				Freezes:        freezes,
+			}
+			if days := int(truncateToDate(sub.EndDate).Sub(today).Hours() / 24); days > 0 {
+				item.DaysLeft = days
+			}
+			for _, f := range freezes {
+				if f.FreezeEnd.IsZero() {
+					item.Frozen = true
+				}
+			}
+
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// phoneVariants приводит номер к 11 цифрам и возвращает варианты записи с 7 и 8 в начале
+func phoneVariants(phone string) ([]string, error) {
+	digits := digitsOnly(phone)
+
+	switch {
+	case len(digits) == 10:
+		digits = "7" + digits
+	case len(digits) == 11 && (digits[0] == '7' || digits[0] == '8'):
+	default:
+		return nil, ErrInvalidPhone
+	}
+
+	local := digits[1:]
+
+	return []string{"7" + local, "8" + local}, nil
+}
+
+func digitsOnly(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
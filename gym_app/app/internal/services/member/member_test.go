@@ -0,0 +1,29 @@
+package memberService
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneVariants(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  []string
+		err   error
+	}{
+		{phone: "+7 (999) 123-45-67", want: []string{"79991234567", "89991234567"}},
+		{phone: "89991234567", want: []string{"79991234567", "89991234567"}},
+		{phone: "9991234567", want: []string{"79991234567", "89991234567"}},
+		{phone: "+1 555 123 4567", err: ErrInvalidPhone},
+		{phone: "123", err: ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			got, err := phoneVariants(tt.phone)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package memberService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	personSubService "github.com/Muaz717/gym_app/app/internal/services/person_sub"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+// PersonIDByUser возвращает клиента, к которому привязан аккаунт SSO, или ErrNotMember
+func (m *MemberService) PersonIDByUser(ctx context.Context, userID int64) (int, error) {
+	const op = "services.member.PersonIDByUser"
+
+	personID, err := m.memberStorage.GetMemberPersonID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrMemberNotFound) {
+			return 0, fmt.Errorf("%s: %w", op, ErrNotMember)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return personID, nil
+}
+
+// registrationTTL — срок брони карточки на время регистрации, с запасом на ретраи запроса в SSO
+const registrationTTL = time.Minute
+
+// Register создает аккаунт клиента в SSO и привязывает его к карточке.
+// Владение карточкой подтверждается номером абонемента и телефоном из нее.
+func (m *MemberService) Register(ctx context.Context, input dto.MemberRegisterInput) (int64, error) {
+	const op = "services.member.Register"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.String("subscription_number", input.SubscriptionNumber),
+	)
+
+	personSub, err := m.personSubFinder.GetPersonSubByNumber(ctx, input.SubscriptionNumber)
+	if err != nil {
+		if errors.Is(err, personSubService.ErrSubNotFound) {
+			return 0, fmt.Errorf("%s: %w", op, ErrVerificationFailed)
+		}
+		log.Error("failed to find subscription", sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	person, err := m.memberStorage.FindPersonById(ctx, personSub.PersonID)
+	if err != nil {
+		log.Error("failed to find person", sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	variants, err := phoneVariants(input.Phone)
+	if err != nil || !slices.Contains(variants, digitsOnly(person.Phone)) {
+		log.Warn("phone does not match subscription")
+		return 0, fmt.Errorf("%s: %w", op, ErrVerificationFailed)
+	}
+
+	// Бронь снимает гонку двух регистраций одной карточки: иначе обе создадут аккаунт в SSO,
+	// а привязать удастся только один. Удалить аккаунт из SSO после неудачной привязки нельзя
+	if err := m.memberStorage.ReserveMemberRegistration(ctx, person.Id, registrationTTL); err != nil {
+		if errors.Is(err, storage.ErrRegistrationPending) {
+			return 0, fmt.Errorf("%s: %w", op, ErrRegistrationBusy)
+		}
+		log.Error("failed to reserve registration", sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		// Снимаем бронь, даже если клиент уже отключился
+		if err := m.memberStorage.ReleaseMemberRegistration(context.WithoutCancel(ctx), person.Id); err != nil {
+			log.Error("failed to release registration", sl.Error(err))
+		}
+	}()
+
+	// Проверяем до регистрации в SSO, чтобы не оставлять аккаунты без карточки
+	linked, err := m.memberStorage.PersonHasMemberAccount(ctx, person.Id)
+	if err != nil {
+		log.Error("failed to check member account", sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if linked {
+		return 0, fmt.Errorf("%s: %w", op, ErrMemberExists)
+	}
+
+	// Аккаунт клиента создается без ролей: доступ дает привязка к карточке, а не права
+	userID, err := m.accountRegistrar.RegisterMember(ctx, input.Email, input.Password)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := m.LinkAccount(ctx, userID, person.Id); err != nil {
+		log.Error("account registered but not linked", slog.Int64("user_id", userID), sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("member registered", slog.Int64("user_id", userID), slog.Int("person_id", person.Id))
+
+	return userID, nil
+}
+
+// LinkAccount привязывает существующий аккаунт SSO к клиенту. Роли аккаунта не меняются
+func (m *MemberService) LinkAccount(ctx context.Context, userID int64, personID int) error {
+	const op = "services.member.LinkAccount"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int("person_id", personID),
+	)
+
+	if err := m.memberStorage.LinkMemberAccount(ctx, userID, personID); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrMemberExists):
+			return fmt.Errorf("%s: %w", op, ErrMemberExists)
+		case errors.Is(err, storage.ErrPersonNotFound):
+			return fmt.Errorf("%s: %w", op, ErrPersonNotFound)
+		}
+		log.Error("failed to link account", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("member account linked")
+
+	return nil
+}
+
+// UnlinkAccount снимает привязку и закрывает аккаунту портал клиента.
+// Аккаунт в SSO и его роли остаются: доступ к API сотрудников определяется только правами
+func (m *MemberService) UnlinkAccount(ctx context.Context, userID int64) error {
+	const op = "services.member.UnlinkAccount"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+	)
+
+	if err := m.memberStorage.UnlinkMemberAccount(ctx, userID); err != nil {
+		if errors.Is(err, storage.ErrMemberNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrNotMember)
+		}
+		log.Error("failed to unlink account", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("member account unlinked")
+
+	return nil
+}
+
+// Profile возвращает карточку клиента вместе с контактами
+func (m *MemberService) Profile(ctx context.Context, personID int) (dto.MemberProfile, error) {
+	const op = "services.member.Profile"
+
+	person, err := m.memberStorage.FindPersonById(ctx, personID)
+	if err != nil {
+		if errors.Is(err, storage.ErrPersonNotFound) {
+			return dto.MemberProfile{}, fmt.Errorf("%s: %w", op, ErrPersonNotFound)
+		}
+		return dto.MemberProfile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	contacts, err := m.memberStorage.GetPersonContacts(ctx, personID)
+	if err != nil {
+		return dto.MemberProfile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dto.MemberProfile{
+		ID:        person.Id,
+		Name:      person.Name,
+		Phone:     person.Phone,
+		Email:     contacts.Email,
+		BirthDate: contacts.BirthDate,
+	}, nil
+}
+
+// Subscriptions возвращает все абонементы клиента с заморозками
+func (m *MemberService) Subscriptions(ctx context.Context, personID int) ([]dto.MemberSubscription, error) {
+	const op = "services.member.Subscriptions"
+
+	person, err := m.memberStorage.FindPersonById(ctx, personID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subs, err := m.subscriptions(ctx, []models.Person{person}, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// Visits возвращает посещения клиента за период [from, to)
+func (m *MemberService) Visits(ctx context.Context, personID int, from, to time.Time) ([]models.Visit, error) {
+	const op = "services.member.Visits"
+
+	visits, err := m.memberStorage.FindVisitsByPerson(ctx, personID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return visits, nil
+}
+
+// Payments возвращает оплаты абонементов клиента, последние первыми.
+// Оплатой считается оформление абонемента: отдельного учета платежей нет.
+func (m *MemberService) Payments(ctx context.Context, personID int) ([]dto.MemberPayment, error) {
+	const op = "services.member.Payments"
+
+	personSubs, err := m.personSubFinder.FindPersonSubByPersonId(ctx, personID)
+	if err != nil && !errors.Is(err, personSubService.ErrSubNotFound) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	payments := make([]dto.MemberPayment, 0, len(personSubs))
+	for _, sub := range personSubs {
+		payments = append(payments, dto.MemberPayment{
+			Date:               sub.StartDate,
+			SubscriptionNumber: sub.Number,
+			SubscriptionTitle:  sub.SubscriptionTitle,
+			Price:              sub.SubscriptionPrice,
+			Discount:           sub.Discount,
+			Paid:               sub.FinalPrice,
+		})
+	}
+
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].Date.After(payments[j].Date)
+	})
+
+	return payments, nil
+}
+
+// RequestFreeze создает заявку на заморозку собственного абонемента клиента
+func (m *MemberService) RequestFreeze(ctx context.Context, personID int, input dto.FreezeRequestInput) (int, error) {
+	const op = "services.member.RequestFreeze"
+
+	log := m.log.With(
+		slog.String("op", op),
+		slog.Int("person_id", personID),
+	)
+
+	freezeStart, err := time.Parse(time.DateOnly, input.FreezeStart)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, ErrInvalidFreezeStart)
+	}
+
+	subs, err := m.Subscriptions(ctx, personID)
+	if err != nil {
+		log.Error("failed to get subscriptions", sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Номер чужого абонемента неотличим от несуществующего
+	idx := slices.IndexFunc(subs, func(s dto.MemberSubscription) bool {
+		return s.Subscription.Number == input.SubscriptionNumber
+	})
+	if idx < 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrSubNotFound)
+	}
+	sub := subs[idx]
+
+	today := truncateToDate(time.Now())
+	if freezeStart.Before(today) || freezeStart.After(truncateToDate(sub.Subscription.EndDate)) {
+		return 0, fmt.Errorf("%s: %w", op, ErrInvalidFreezeStart)
+	}
+	if sub.Frozen || sub.FreezeDaysLeft <= 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrFreezeUnavailable)
+	}
+
+	id, err := m.freezeService.RequestFreeze(ctx, models.FreezeRequest{
+		SubscriptionNumber: input.SubscriptionNumber,
+		FreezeStart:        freezeStart,
+		Comment:            strings.TrimSpace(input.Comment),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// FreezeRequests возвращает заявки клиента на заморозку
+func (m *MemberService) FreezeRequests(ctx context.Context, personID int) ([]models.FreezeRequest, error) {
+	const op = "services.member.FreezeRequests"
+
+	requests, err := m.freezeService.FindFreezeRequests(ctx, dto.FreezeRequestFilter{PersonID: personID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return requests, nil
+}
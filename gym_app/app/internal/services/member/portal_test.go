@@ -0,0 +1,97 @@
+package memberService
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/gym_app/app/internal/services/member/mocks"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var registerInput = dto.MemberRegisterInput{
+	Email:              "client@gym.local",
+	Password:           "secret-password",
+	Phone:              "+7 (999) 123-45-67",
+	SubscriptionNumber: "A-1",
+}
+
+// newRegisterService готовит сервис, в котором номер абонемента и телефон клиента 7 совпадают с registerInput
+func newRegisterService(t *testing.T) (*MemberService, *mocks.MemberStorage, *mocks.AccountRegistrar) {
+	st := mocks.NewMemberStorage(t)
+	subs := mocks.NewPersonSubFinder(t)
+	registrar := mocks.NewAccountRegistrar(t)
+
+	subs.EXPECT().GetPersonSubByNumber(mock.Anything, "A-1").Return(dto.PersonSubResponse{Number: "A-1", PersonID: 7}, nil).Once()
+	st.EXPECT().FindPersonById(mock.Anything, 7).Return(models.Person{Id: 7, Name: "Иванов Иван", Phone: "89991234567"}, nil).Once()
+
+	return New(slogdiscard.NewDiscardLogger(), st, subs, mocks.NewFreezeService(t), registrar), st, registrar
+}
+
+func TestRegister(t *testing.T) {
+	srv, st, registrar := newRegisterService(t)
+
+	st.EXPECT().ReserveMemberRegistration(mock.Anything, 7, registrationTTL).Return(nil).Once()
+	st.EXPECT().PersonHasMemberAccount(mock.Anything, 7).Return(false, nil).Once()
+	registrar.EXPECT().RegisterMember(mock.Anything, registerInput.Email, registerInput.Password).Return(42, nil).Once()
+	st.EXPECT().LinkMemberAccount(mock.Anything, int64(42), 7).Return(nil).Once()
+	st.EXPECT().ReleaseMemberRegistration(mock.Anything, 7).Return(nil).Once()
+
+	userID, err := srv.Register(context.Background(), registerInput)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), userID)
+}
+
+func TestRegister_ConcurrentRegistrationDoesNotReachSSO(t *testing.T) {
+	srv, st, _ := newRegisterService(t)
+
+	// Вторая регистрация той же карточки, пока первая ждет ответа SSO
+	st.EXPECT().ReserveMemberRegistration(mock.Anything, 7, registrationTTL).Return(storage.ErrRegistrationPending).Once()
+
+	_, err := srv.Register(context.Background(), registerInput)
+	require.ErrorIs(t, err, ErrRegistrationBusy)
+}
+
+func TestRegister_ReleasesReservation(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(st *mocks.MemberStorage, registrar *mocks.AccountRegistrar)
+		wantErr error
+	}{
+		{
+			name: "already linked",
+			setup: func(st *mocks.MemberStorage, _ *mocks.AccountRegistrar) {
+				st.EXPECT().PersonHasMemberAccount(mock.Anything, 7).Return(true, nil).Once()
+			},
+			wantErr: ErrMemberExists,
+		},
+		{
+			name: "sso rejected",
+			setup: func(st *mocks.MemberStorage, registrar *mocks.AccountRegistrar) {
+				st.EXPECT().PersonHasMemberAccount(mock.Anything, 7).Return(false, nil).Once()
+				registrar.EXPECT().RegisterMember(mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("user already exists")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, st, registrar := newRegisterService(t)
+
+			st.EXPECT().ReserveMemberRegistration(mock.Anything, 7, registrationTTL).Return(nil).Once()
+			st.EXPECT().ReleaseMemberRegistration(mock.Anything, 7).Return(nil).Once()
+			tt.setup(st, registrar)
+
+			_, err := srv.Register(context.Background(), registerInput)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
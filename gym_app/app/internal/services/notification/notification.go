@@ -0,0 +1,404 @@
+package notificationService
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"path/filepath"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/lib/notify"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+const (
+	expiryTemplate   = "expiry.tmpl"
+	birthdayTemplate = "birthday.tmpl"
+	freezeTemplate   = "freeze.tmpl"
+	unfreezeTemplate = "unfreeze.tmpl"
+
+	defaultLogLimit = 100
+	maxLogLimit     = 500
+)
+
+// Темы писем; в SMS и Telegram уходит только текст
+var subjects = map[string]string{
+	dto.NotificationExpiry:   "Ваш абонемент скоро закончится",
+	dto.NotificationBirthday: "С днем рождения!",
+	dto.NotificationFreeze:   "Абонемент заморожен",
+	dto.NotificationUnfreeze: "Абонемент разморожен",
+}
+
+type NotificationStorage interface {
+	FindExpiryTargets(ctx context.Context, days int) ([]dto.NotificationTarget, error)
+	FindBirthdayTargets(ctx context.Context, day time.Time, ref string) ([]dto.NotificationTarget, error)
+	FindSubscriptionTarget(ctx context.Context, number string) (dto.NotificationTarget, error)
+	SaveNotification(ctx context.Context, n models.Notification) error
+	FindNotifications(ctx context.Context, filter dto.NotificationFilter) ([]models.Notification, error)
+	GetPersonContacts(ctx context.Context, personID int) (models.PersonContacts, error)
+	SavePersonContacts(ctx context.Context, contacts models.PersonContacts) error
+}
+
+type NotificationService struct {
+	log                 *slog.Logger
+	notificationStorage NotificationStorage
+	channels            []notify.Channel
+	templates           *template.Template
+	org                 config.Organization
+	remindBeforeDays    int
+}
+
+var (
+	ErrUnknownKind      = errors.New("unknown notification kind")
+	ErrPersonNotFound   = errors.New("person not found")
+	ErrInvalidEmail     = errors.New("invalid email")
+	ErrInvalidBirthDate = errors.New("birth date must be a past date in YYYY-MM-DD format")
+)
+
+// messageData — данные, доступные в шаблонах сообщений
+type messageData struct {
+	Target   dto.NotificationTarget
+	Org      config.Organization
+	DaysLeft int
+	Date     time.Time // Дата заморозки или разморозки
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("02.01.2006")
+	},
+}
+
+// New загружает шаблоны сообщений. Пустой список каналов допустим: рассылка будет только логироваться.
+func New(
+	log *slog.Logger,
+	notificationStorage NotificationStorage,
+	channels []notify.Channel,
+	cfg config.Notifications,
+	org config.Organization,
+) (*NotificationService, error) {
+	const op = "services.notification.New"
+
+	templates, err := template.New("notifications").Funcs(templateFuncs).ParseFiles(
+		filepath.Join(cfg.TemplatesDir, expiryTemplate),
+		filepath.Join(cfg.TemplatesDir, birthdayTemplate),
+		filepath.Join(cfg.TemplatesDir, freezeTemplate),
+		filepath.Join(cfg.TemplatesDir, unfreezeTemplate),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &NotificationService{
+		log:                 log,
+		notificationStorage: notificationStorage,
+		channels:            channels,
+		templates:           templates,
+		org:                 org,
+		remindBeforeDays:    cfg.RemindBeforeDays,
+	}, nil
+}
+
+// Run запускает рассылку указанного вида вне расписания
+func (n *NotificationService) Run(ctx context.Context, kind string) (dto.NotificationRun, error) {
+	const op = "services.notification.Run"
+
+	switch kind {
+	case dto.NotificationExpiry:
+		return n.SendExpiryReminders(ctx)
+	case dto.NotificationBirthday:
+		return n.SendBirthdayGreetings(ctx)
+	default:
+		return dto.NotificationRun{}, fmt.Errorf("%s: %w", op, ErrUnknownKind)
+	}
+}
+
+// SendExpiryReminders напоминает клиентам об окончании абонемента.
+// Каждому абонементу напоминание доставляется один раз; неудачные попытки повторяются при следующем запуске.
+func (n *NotificationService) SendExpiryReminders(ctx context.Context) (dto.NotificationRun, error) {
+	const op = "services.notification.SendExpiryReminders"
+
+	log := n.log.With(
+		slog.String("op", op),
+	)
+
+	targets, err := n.notificationStorage.FindExpiryTargets(ctx, n.remindBeforeDays)
+	if err != nil {
+		log.Error("failed to find expiring subscriptions", sl.Error(err))
+		return dto.NotificationRun{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	today := truncateToDate(time.Now())
+
+	run := dto.NotificationRun{Kind: dto.NotificationExpiry, Targets: len(targets)}
+	for _, target := range targets {
+		data := messageData{
+			Target:   target,
+			Org:      n.org,
+			DaysLeft: int(truncateToDate(target.EndDate).Sub(today).Hours() / 24),
+		}
+
+		n.deliver(ctx, log, &run, expiryTemplate, target.SubscriptionNumber, target, data)
+	}
+
+	log.Info("expiry reminders sent",
+		slog.Int("targets", run.Targets),
+		slog.Int("sent", run.Sent),
+		slog.Int("failed", run.Failed),
+		slog.Int("skipped", run.Skipped),
+	)
+
+	return run, nil
+}
+
+// SendBirthdayGreetings поздравляет именинников, не более одного раза в год
+func (n *NotificationService) SendBirthdayGreetings(ctx context.Context) (dto.NotificationRun, error) {
+	const op = "services.notification.SendBirthdayGreetings"
+
+	log := n.log.With(
+		slog.String("op", op),
+	)
+
+	today := time.Now()
+	ref := strconv.Itoa(today.Year())
+
+	targets, err := n.notificationStorage.FindBirthdayTargets(ctx, today, ref)
+	if err != nil {
+		log.Error("failed to find birthdays", sl.Error(err))
+		return dto.NotificationRun{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	run := dto.NotificationRun{Kind: dto.NotificationBirthday, Targets: len(targets)}
+	for _, target := range targets {
+		data := messageData{
+			Target: target,
+			Org:    n.org,
+		}
+
+		n.deliver(ctx, log, &run, birthdayTemplate, ref, target, data)
+	}
+
+	log.Info("birthday greetings sent",
+		slog.Int("targets", run.Targets),
+		slog.Int("sent", run.Sent),
+		slog.Int("failed", run.Failed),
+		slog.Int("skipped", run.Skipped),
+	)
+
+	return run, nil
+}
+
+// NotifyFreeze сообщает клиенту о заморозке или разморозке абонемента.
+// Отказавшимся от рассылки ничего не отправляется.
+func (n *NotificationService) NotifyFreeze(ctx context.Context, subscriptionNumber string, frozen bool, date time.Time) error {
+	const op = "services.notification.NotifyFreeze"
+
+	log := n.log.With(
+		slog.String("op", op),
+		slog.String("subscription_number", subscriptionNumber),
+	)
+
+	target, err := n.notificationStorage.FindSubscriptionTarget(ctx, subscriptionNumber)
+	if err != nil {
+		if errors.Is(err, storage.ErrPersonNotFound) {
+			return nil
+		}
+		log.Error("failed to find subscription owner", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	kind, templateName := dto.NotificationFreeze, freezeTemplate
+	if !frozen {
+		kind, templateName = dto.NotificationUnfreeze, unfreezeTemplate
+	}
+
+	data := messageData{
+		Target: target,
+		Org:    n.org,
+		Date:   date,
+	}
+
+	run := dto.NotificationRun{Kind: kind, Targets: 1}
+	ref := subscriptionNumber + ":" + date.Format(time.DateOnly)
+	n.deliver(ctx, log, &run, templateName, ref, target, data)
+
+	return nil
+}
+
+// deliver отправляет сообщение во все каналы, где у клиента есть адрес, и пишет каждую попытку в журнал
+func (n *NotificationService) deliver(
+	ctx context.Context,
+	log *slog.Logger,
+	run *dto.NotificationRun,
+	templateName string,
+	ref string,
+	target dto.NotificationTarget,
+	data messageData,
+) {
+	log = log.With(slog.Int("person_id", target.PersonID))
+
+	var text bytes.Buffer
+	if err := n.templates.ExecuteTemplate(&text, templateName, data); err != nil {
+		log.Error("failed to render message", sl.Error(err))
+		run.Failed++
+		return
+	}
+
+	kind := run.Kind
+	msg := notify.Message{
+		Subject: subjects[kind],
+		Text:    text.String(),
+	}
+
+	to := notify.Recipient{
+		Name:           target.PersonName,
+		Phone:          target.Phone,
+		Email:          target.Email,
+		TelegramChatID: target.TelegramChatID,
+	}
+
+	var attempted, delivered bool
+	for _, ch := range n.channels {
+		address, err := ch.Address(to)
+		if err != nil {
+			continue
+		}
+		attempted = true
+
+		entry := models.Notification{
+			PersonID:  target.PersonID,
+			Kind:      kind,
+			Ref:       ref,
+			Channel:   ch.Name(),
+			Recipient: address,
+			Message:   msg.Text,
+			Status:    dto.NotificationSent,
+		}
+
+		if err := ch.Send(ctx, to, msg); err != nil {
+			log.Warn("failed to send notification", slog.String("channel", ch.Name()), sl.Error(err))
+			entry.Status = dto.NotificationFailed
+			entry.Error = err.Error()
+		} else {
+			delivered = true
+		}
+
+		if err := n.notificationStorage.SaveNotification(ctx, entry); err != nil {
+			log.Error("failed to save notification log", sl.Error(err))
+		}
+	}
+
+	switch {
+	case delivered:
+		run.Sent++
+	case attempted:
+		run.Failed++
+	default:
+		run.Skipped++
+	}
+}
+
+// FindNotifications возвращает журнал доставки
+func (n *NotificationService) FindNotifications(ctx context.Context, filter dto.NotificationFilter) ([]models.Notification, error) {
+	const op = "services.notification.FindNotifications"
+
+	log := n.log.With(
+		slog.String("op", op),
+	)
+
+	if filter.Kind != "" && subjects[filter.Kind] == "" {
+		return nil, fmt.Errorf("%s: %w", op, ErrUnknownKind)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLogLimit
+	}
+	if filter.Limit > maxLogLimit {
+		filter.Limit = maxLogLimit
+	}
+
+	notifications, err := n.notificationStorage.FindNotifications(ctx, filter)
+	if err != nil {
+		log.Error("failed to find notifications", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+// GetContacts возвращает контакты клиента для уведомлений
+func (n *NotificationService) GetContacts(ctx context.Context, personID int) (models.PersonContacts, error) {
+	const op = "services.notification.GetContacts"
+
+	log := n.log.With(
+		slog.String("op", op),
+		slog.Int("person_id", personID),
+	)
+
+	contacts, err := n.notificationStorage.GetPersonContacts(ctx, personID)
+	if err != nil {
+		if errors.Is(err, storage.ErrPersonNotFound) {
+			return models.PersonContacts{}, fmt.Errorf("%s: %w", op, ErrPersonNotFound)
+		}
+		log.Error("failed to get contacts", sl.Error(err))
+		return models.PersonContacts{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return contacts, nil
+}
+
+// UpdateContacts перезаписывает контакты клиента, в том числе отказ от рассылки
+func (n *NotificationService) UpdateContacts(ctx context.Context, personID int, input dto.ContactsInput) (models.PersonContacts, error) {
+	const op = "services.notification.UpdateContacts"
+
+	log := n.log.With(
+		slog.String("op", op),
+		slog.Int("person_id", personID),
+	)
+
+	contacts := models.PersonContacts{
+		PersonID:       personID,
+		Email:          input.Email,
+		TelegramChatID: input.TelegramChatID,
+		OptedOut:       input.OptedOut,
+	}
+
+	if input.Email != "" {
+		addr, err := mail.ParseAddress(input.Email)
+		if err != nil || addr.Address != input.Email {
+			return models.PersonContacts{}, fmt.Errorf("%s: %w", op, ErrInvalidEmail)
+		}
+	}
+
+	if input.BirthDate != "" {
+		birthDate, err := time.Parse(time.DateOnly, input.BirthDate)
+		if err != nil || birthDate.After(time.Now()) {
+			return models.PersonContacts{}, fmt.Errorf("%s: %w", op, ErrInvalidBirthDate)
+		}
+		contacts.BirthDate = &birthDate
+	}
+
+	if err := n.notificationStorage.SavePersonContacts(ctx, contacts); err != nil {
+		if errors.Is(err, storage.ErrPersonNotFound) {
+			return models.PersonContacts{}, fmt.Errorf("%s: %w", op, ErrPersonNotFound)
+		}
+		log.Error("failed to save contacts", sl.Error(err))
+		return models.PersonContacts{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("contacts updated", slog.Bool("opted_out", contacts.OptedOut))
+
+	return contacts, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,144 @@
+package notificationService
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/gym_app/app/internal/lib/notify"
+	"github.com/Muaz717/gym_app/app/internal/services/notification/mocks"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newService(t *testing.T, st *mocks.NotificationStorage, channels ...notify.Channel) *NotificationService {
+	t.Helper()
+
+	srv, err := New(
+		slogdiscard.NewDiscardLogger(),
+		st,
+		channels,
+		config.Notifications{TemplatesDir: "../../../../templates/notifications", RemindBeforeDays: 3},
+		config.Organization{Name: "Фитнес-зал"},
+	)
+	require.NoError(t, err)
+
+	return srv
+}
+
+// saved собирает записи журнала доставки
+func saved(st *mocks.NotificationStorage) *[]models.Notification {
+	var log []models.Notification
+	st.EXPECT().SaveNotification(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, n models.Notification) { log = append(log, n) }).
+		Return(nil)
+	return &log
+}
+
+func TestSendExpiryReminders(t *testing.T) {
+	st := mocks.NewNotificationStorage(t)
+	st.EXPECT().FindExpiryTargets(mock.Anything, 3).Return([]dto.NotificationTarget{
+		{
+			PersonID:           1,
+			PersonName:         "Иван Иванов",
+			Phone:              "79990001122",
+			SubscriptionNumber: "A-1",
+			SubscriptionTitle:  "Месяц",
+			EndDate:            time.Now().AddDate(0, 0, 2),
+		},
+		{PersonID: 2, PersonName: "Без телефона"},
+	}, nil).Once()
+	log := saved(st)
+	ch := &notify.Fake{}
+
+	run, err := newService(t, st, ch).SendExpiryReminders(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, dto.NotificationRun{Kind: dto.NotificationExpiry, Targets: 2, Sent: 1, Skipped: 1}, run)
+
+	sent := ch.Sent()
+	require.Len(t, sent, 1)
+	require.Contains(t, sent[0].Msg.Text, "Иван Иванов")
+	require.Contains(t, sent[0].Msg.Text, "через 2 дн.")
+
+	require.Len(t, *log, 1)
+	require.Equal(t, "A-1", (*log)[0].Ref)
+	require.Equal(t, dto.NotificationSent, (*log)[0].Status)
+}
+
+func TestSendBirthdayGreetingsFailedChannel(t *testing.T) {
+	st := mocks.NewNotificationStorage(t)
+	st.EXPECT().FindBirthdayTargets(mock.Anything, mock.Anything, mock.Anything).Return([]dto.NotificationTarget{
+		{PersonID: 1, PersonName: "Мария", Phone: "79990001122"},
+	}, nil).Once()
+	log := saved(st)
+	ch := &notify.Fake{Err: errors.New("gateway is down")}
+
+	run, err := newService(t, st, ch).SendBirthdayGreetings(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, run.Failed)
+	require.Len(t, *log, 1)
+	require.Equal(t, dto.NotificationFailed, (*log)[0].Status)
+	require.Equal(t, "gateway is down", (*log)[0].Error)
+	require.True(t, strings.HasPrefix((*log)[0].Message, "Мария, с днем рождения!"))
+}
+
+func TestUpdateContactsValidation(t *testing.T) {
+	st := mocks.NewNotificationStorage(t)
+	srv := newService(t, st)
+
+	_, err := srv.UpdateContacts(context.Background(), 1, dto.ContactsInput{Email: "not an email"})
+	require.ErrorIs(t, err, ErrInvalidEmail)
+
+	_, err = srv.UpdateContacts(context.Background(), 1, dto.ContactsInput{BirthDate: "31.12.1990"})
+	require.ErrorIs(t, err, ErrInvalidBirthDate)
+
+	st.EXPECT().SavePersonContacts(mock.Anything, mock.MatchedBy(func(c models.PersonContacts) bool {
+		return c.PersonID == 1 && c.OptedOut
+	})).Return(nil).Once()
+
+	contacts, err := srv.UpdateContacts(context.Background(), 1, dto.ContactsInput{BirthDate: "1990-12-31", OptedOut: true})
+	require.NoError(t, err)
+	require.True(t, contacts.OptedOut)
+	require.Equal(t, 1990, contacts.BirthDate.Year())
+
+	st.EXPECT().SavePersonContacts(mock.Anything, mock.Anything).Return(storage.ErrPersonNotFound).Once()
+
+	_, err = srv.UpdateContacts(context.Background(), 2, dto.ContactsInput{})
+	require.ErrorIs(t, err, ErrPersonNotFound)
+}
+
+func TestNotifyFreeze(t *testing.T) {
+	st := mocks.NewNotificationStorage(t)
+	st.EXPECT().FindSubscriptionTarget(mock.Anything, "A-1").Return(dto.NotificationTarget{
+		PersonID: 1, PersonName: "Иван Иванов", Phone: "79990001122", SubscriptionNumber: "A-1", SubscriptionTitle: "Месяц",
+	}, nil).Twice()
+	// Отказавшийся от рассылки или неизвестный абонемент — не ошибка
+	st.EXPECT().FindSubscriptionTarget(mock.Anything, "B-2").Return(dto.NotificationTarget{}, storage.ErrPersonNotFound).Once()
+	log := saved(st)
+	ch := &notify.Fake{}
+	srv := newService(t, st, ch)
+
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	require.NoError(t, srv.NotifyFreeze(context.Background(), "A-1", true, date))
+	require.NoError(t, srv.NotifyFreeze(context.Background(), "A-1", false, date.AddDate(0, 0, 7)))
+	require.NoError(t, srv.NotifyFreeze(context.Background(), "B-2", true, date))
+
+	sent := ch.Sent()
+	require.Len(t, sent, 2)
+	require.Contains(t, sent[0].Msg.Text, "заморожен с 10.03.2025")
+	require.Contains(t, sent[1].Msg.Text, "разморожен 17.03.2025")
+
+	require.Len(t, *log, 2)
+	require.Equal(t, dto.NotificationFreeze, (*log)[0].Kind)
+	require.Equal(t, "A-1:2025-03-10", (*log)[0].Ref)
+	require.Equal(t, dto.NotificationUnfreeze, (*log)[1].Kind)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	"github.com/Muaz717/gym_app/app/internal/services/cache"
 	"github.com/Muaz717/gym_app/app/internal/storage"
@@ -18,9 +19,8 @@ type PersonCache interface {
 	cache.Cache
 }
 
-type StatCache interface {
-	cache.Cache
-	DelByPrefix(ctx context.Context, prefix string) error
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
 }
 
 type PersonStorage interface {
@@ -36,20 +36,20 @@ type PersonService struct {
 	log           *slog.Logger
 	personStorage PersonStorage
 	personCache   PersonCache
-	statCache     StatCache
+	publisher     Publisher
 }
 
 func New(
 	log *slog.Logger,
 	personStorage PersonStorage,
 	personCache PersonCache,
-	statCache StatCache,
+	publisher Publisher,
 ) *PersonService {
 	return &PersonService{
 		log:           log,
 		personStorage: personStorage,
 		personCache:   personCache,
-		statCache:     statCache,
+		publisher:     publisher,
 	}
 }
 
@@ -58,21 +58,6 @@ var (
 	ErrPersonNotFound = errors.New("person not found")
 )
 
-// Инвалидация кэша статистики (DelByPrefix для Redis)
-func (p *PersonService) invalidateStatisticsCache(ctx context.Context) {
-	_ = p.statCache.DelByPrefix(ctx, "stat:income:")
-	_ = p.statCache.DelByPrefix(ctx, "stat:sold_subs:")
-	_ = p.statCache.DelByPrefix(ctx, "stat:new_clients:")
-	_ = p.statCache.DelByPrefix(ctx, "stat:monthly_stats:")
-	_ = p.statCache.Delete(ctx, "stat:monthly_stats")
-	_ = p.statCache.Delete(ctx, "stat:income")
-	_ = p.statCache.Delete(ctx, "stat:total_clients")
-	_ = p.statCache.Delete(ctx, "stat:sold_subs")
-	_ = p.statCache.Delete(ctx, "stat:new_clients")
-	_ = p.statCache.Delete(ctx, "stat:total_sold_subscriptions")
-	_ = p.statCache.Delete(ctx, "stat:total_income")
-}
-
 func (p *PersonService) AddPerson(ctx context.Context, person models.Person) (int, error) {
 
 	const op = "services.person.addPerson"
@@ -94,13 +79,7 @@ func (p *PersonService) AddPerson(ctx context.Context, person models.Person) (in
 		return 0, err
 	}
 
-	// Инвалидируем кэш списка всех пользователей
-	if err := p.personCache.Delete(ctx, "people:all"); err != nil {
-		log.Warn("failed to invalidate cache", sl.Error(err))
-	}
-
-	// Инвалидируем статистику
-	p.invalidateStatisticsCache(ctx)
+	p.publisher.Publish(ctx, events.PersonCreated{PersonID: personId, Name: person.Name, Phone: person.Phone})
 
 	log.Info("person registered", "pid", personId)
 
@@ -133,16 +112,7 @@ func (p *PersonService) UpdatePerson(ctx context.Context, person models.Person,
 		return 0, err
 	}
 
-	cacheKey := fmt.Sprintf("person:name:%s", person.Name)
-	if err := p.personCache.Delete(ctx, cacheKey); err != nil {
-		log.Warn("failed to invalidate cache", sl.Error(err))
-	}
-	if err := p.personCache.Delete(ctx, "people:all"); err != nil {
-		log.Warn("failed to invalid cache", sl.Error(err))
-	}
-
-	// Инвалидируем статистику (если ФИО влияет на статистику новых клиентов)
-	p.invalidateStatisticsCache(ctx)
+	p.publisher.Publish(ctx, events.PersonUpdated{PersonID: personId, Name: person.Name, Phone: person.Phone})
 
 	log.Info("person updated", "pid", personId)
 
@@ -165,14 +135,12 @@ func (p *PersonService) DeletePerson(ctx context.Context, pID int) error {
 
 			return fmt.Errorf("%s: %w", op, ErrPersonNotFound)
 		}
-	}
 
-	if err := p.personCache.Delete(ctx, "people:all"); err != nil {
-		log.Warn("failed to invalid cache", sl.Error(err))
+		log.Error("failed to delete person", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Инвалидируем статистику (кол-во клиентов уменьшилось)
-	p.invalidateStatisticsCache(ctx)
+	p.publisher.Publish(ctx, events.PersonDeleted{PersonID: pID})
 
 	log.Info("person deleted")
 	return nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	"github.com/Muaz717/gym_app/app/internal/services/cache"
 	"github.com/Muaz717/gym_app/app/internal/storage"
@@ -29,41 +30,35 @@ type PersonSubStorage interface {
 	FindPersonSubByPersonName(ctx context.Context, name string) ([]dto.PersonSubResponse, error)
 	UpdatePersonSubStatus(ctx context.Context, number string, status string) error
 	FindPersonSubByPersonId(ctx context.Context, personID int) ([]dto.PersonSubResponse, error)
-}
-
-type PersonFinder interface {
-	FindPersonById(ctx context.Context, id int) (models.Person, error)
+	FindExpiringPersonSubs(ctx context.Context, filter dto.ExpiringFilter) ([]dto.ExpiringPersonSub, error)
 }
 
 type PersonSubCache interface {
 	cache.Cache
 }
 
-type StatCache interface {
-	cache.Cache
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
 }
 
 type PersonSubService struct {
 	log              *slog.Logger
 	personSubStorage PersonSubStorage
 	personSubCache   PersonSubCache
-	personFinder     PersonFinder
-	statCache        StatCache
+	publisher        Publisher
 }
 
 func New(
 	log *slog.Logger,
 	personSubStorage PersonSubStorage,
 	personSubCache PersonSubCache,
-	personFinder PersonFinder,
-	statCache StatCache,
+	publisher Publisher,
 ) *PersonSubService {
 	return &PersonSubService{
 		log:              log,
 		personSubStorage: personSubStorage,
 		personSubCache:   personSubCache,
-		personFinder:     personFinder,
-		statCache:        statCache,
+		publisher:        publisher,
 	}
 }
 
@@ -71,22 +66,12 @@ var (
 	ErrSubExists      = errors.New("subscription with that number already exists")
 	ErrSubNotFound    = errors.New("subscription not found")
 	ErrPersonNotFound = errors.New("person not found")
+	ErrInvalidKind    = errors.New("invalid report kind")
+	ErrInvalidDays    = errors.New("days must be between 1 and 365")
 )
 
-// Инвалидация статистического кэша с поддержкой DelByPrefix для Redis
-func (p *PersonSubService) invalidateStatisticsCache(ctx context.Context) {
-	_ = p.statCache.DelByPrefix(ctx, "stat:income:")
-	_ = p.statCache.DelByPrefix(ctx, "stat:sold_subs:")
-	_ = p.statCache.DelByPrefix(ctx, "stat:new_clients:")
-	_ = p.statCache.DelByPrefix(ctx, "stat:monthly_stats:")
-	_ = p.statCache.Delete(ctx, "stat:monthly_stats")
-	_ = p.statCache.Delete(ctx, "stat:income")
-	_ = p.statCache.Delete(ctx, "stat:total_clients")
-	_ = p.statCache.Delete(ctx, "stat:sold_subs")
-	_ = p.statCache.Delete(ctx, "stat:new_clients")
-	_ = p.statCache.Delete(ctx, "stat:total_sold_subscriptions")
-	_ = p.statCache.Delete(ctx, "stat:total_income")
-}
+// maxReportDays — максимальный горизонт отчета по заканчивающимся абонементам
+const maxReportDays = 365
 
 func (p *PersonSubService) AddPersonSub(ctx context.Context, input dto.PersonSubInput) (string, error) {
 	const op = "services.personSub.AddPersonSub"
@@ -146,24 +131,14 @@ func (p *PersonSubService) AddPersonSub(ctx context.Context, input dto.PersonSub
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Инвалидируем кэш подписок
-	if err := p.personSubCache.Delete(ctx, "person_subs:all"); err != nil {
-		log.Warn("failed to invalidate cache", sl.Error(err))
-	}
-
-	// Инвалидируем кэш по имени пользователя
-	person, err := p.personFinder.FindPersonById(ctx, personSub.PersonID)
-	if err != nil {
-		log.Warn("failed to get person name for cache invalidation", slog.Int("personID", personSub.PersonID), sl.Error(err))
-	} else {
-		cacheKey := fmt.Sprintf("person_sub:person:%s", person.Name)
-		if err := p.personSubCache.Delete(ctx, cacheKey); err != nil {
-			log.Warn("failed to invalidate cache", sl.Error(err))
-		}
-	}
-
-	// Инвалидируем статистику!
-	p.invalidateStatisticsCache(ctx)
+	p.publisher.Publish(ctx, events.SubscriptionSold{
+		Number:         personSubNumber,
+		PersonID:       personSub.PersonID,
+		SubscriptionID: personSub.SubscriptionID,
+		StartDate:      personSub.StartDate,
+		EndDate:        personSub.EndDate,
+		FinalPrice:     personSub.FinalPrice,
+	})
 
 	log.Info("person subscription added", "number", personSubNumber)
 
@@ -200,29 +175,7 @@ func (p *PersonSubService) DeletePersonSub(ctx context.Context, number string) e
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Инвалидируем кэш подписок
-	cacheKey := fmt.Sprintf("person_sub:number:%s", number)
-	if err := p.personSubCache.Delete(ctx, cacheKey); err != nil {
-		log.Warn("failed to invalidate cache", sl.Error(err))
-	}
-	if err := p.personSubCache.Delete(ctx, "person_subs:all"); err != nil {
-		log.Warn("failed to invalidate cache", sl.Error(err))
-	}
-	// Инвалидируем кэш по имени пользователя, если PersonID существует
-	if personSub.PersonID != 0 {
-		person, err := p.personFinder.FindPersonById(ctx, personSub.PersonID)
-		if err != nil {
-			log.Warn("failed to get person name for cache invalidation", slog.Int("personID", personSub.PersonID), sl.Error(err))
-		} else {
-			personCacheKey := fmt.Sprintf("person_sub:person:%s", person.Name)
-			if err := p.personSubCache.Delete(ctx, personCacheKey); err != nil {
-				log.Warn("failed to invalidate cache", sl.Error(err))
-			}
-		}
-	}
-
-	// Инвалидируем статистику!
-	p.invalidateStatisticsCache(ctx)
+	p.publisher.Publish(ctx, events.SubscriptionDeleted{Number: number, PersonID: personSub.PersonID})
 
 	log.Info("person subscription deleted", "number", number)
 
@@ -431,33 +384,45 @@ func (p *PersonSubService) UpdateStatuses(ctx context.Context) error {
 				return fmt.Errorf("%s: %w", op, err)
 			}
 
-			// Инвалидируем кэш для подписки
-			cacheKey := fmt.Sprintf("person_sub:number:%s", sub.Number)
-			if err := p.personSubCache.Delete(ctx, cacheKey); err != nil {
-				log.Warn("failed to invalidate cache", sl.Error(err))
-			}
-
-			// Инвалидируем кэш для пользователя
-			person, err := p.personFinder.FindPersonById(ctx, sub.PersonID)
-			if err != nil {
-				log.Warn("failed to get person name for cache invalidation", slog.Int("personID", sub.PersonID), sl.Error(err))
-			} else {
-				personCacheKey := fmt.Sprintf("person_sub:person:%s", person.Name)
-				if err := p.personSubCache.Delete(ctx, personCacheKey); err != nil {
-					log.Warn("failed to invalidate cache", sl.Error(err))
-				}
-			}
+			p.publisher.Publish(ctx, events.SubscriptionStatusChanged{
+				Number:   sub.Number,
+				PersonID: sub.PersonID,
+				From:     sub.Status,
+				To:       newStatus,
+			})
 		}
 	}
 
-	// Инвалидируем кэш всех подписок
-	if err := p.personSubCache.Delete(ctx, "person_subs:all"); err != nil {
-		log.Warn("failed to invalidate cache", sl.Error(err))
+	log.Info("person subscription statuses updated")
+	return nil
+}
+
+// FindExpiringPersonSubs возвращает список клиентов для обзвона: с заканчивающимися,
+// недавно закончившимися без продления или долго замороженными абонементами
+func (p *PersonSubService) FindExpiringPersonSubs(ctx context.Context, filter dto.ExpiringFilter) ([]dto.ExpiringPersonSub, error) {
+	const op = "services.personSub.FindExpiringPersonSubs"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.String("kind", filter.Kind),
+		slog.Int("days", filter.Days),
+	)
+
+	switch filter.Kind {
+	case dto.ExpiringKindExpiring, dto.ExpiringKindLapsed, dto.ExpiringKindFrozen:
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidKind)
+	}
+
+	if filter.Days <= 0 || filter.Days > maxReportDays {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidDays)
 	}
 
-	// И статистику!
-	p.invalidateStatisticsCache(ctx)
+	subs, err := p.personSubStorage.FindExpiringPersonSubs(ctx, filter)
+	if err != nil {
+		log.Error("failed to find expiring person subscriptions", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	log.Info("person subscription statuses updated")
-	return nil
+	return subs, nil
 }
@@ -3,17 +3,22 @@ package singleVisitService
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	"github.com/Muaz717/gym_app/app/internal/services/cache"
+	"github.com/Muaz717/gym_app/app/internal/storage"
 	"log/slog"
 	"time"
 )
 
+var ErrTariffNotFound = errors.New("single visit tariff not found")
+
 type SingleVisitStorage interface {
-	AddSingleVisit(ctx context.Context, singleVis models.SingleVisit) error
+	AddSingleVisit(ctx context.Context, singleVis models.SingleVisit) (int, error)
 	GetAllSingleVisits(ctx context.Context) ([]models.SingleVisit, error)
 	GetSingleVisitById(ctx context.Context, id int) (models.SingleVisit, error)
 	GetSingleVisitsByDay(ctx context.Context, date time.Time) ([]models.SingleVisit, error)
@@ -21,42 +26,42 @@ type SingleVisitStorage interface {
 	DeleteSingleVisit(ctx context.Context, id int) error
 }
 
+type TariffFinder interface {
+	FindSingleVisitTariffById(ctx context.Context, tariffID int) (models.SingleVisitTariff, error)
+}
+
 type SingleVisitCache interface {
 	cache.Cache
 }
 
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
+}
+
 type SingleVisitService struct {
 	log                *slog.Logger
 	singleVisitStorage SingleVisitStorage
+	tariffFinder       TariffFinder
 	singleVisitCache   SingleVisitCache
+	publisher          Publisher
 }
 
 func New(
 	log *slog.Logger,
 	singleVisitStorage SingleVisitStorage,
+	tariffFinder TariffFinder,
 	singleVisitCache SingleVisitCache,
+	publisher Publisher,
 ) *SingleVisitService {
 	return &SingleVisitService{
 		log:                log,
 		singleVisitStorage: singleVisitStorage,
+		tariffFinder:       tariffFinder,
 		singleVisitCache:   singleVisitCache,
+		publisher:          publisher,
 	}
 }
 
-func (p *SingleVisitService) invalidateStatisticsCache(ctx context.Context) {
-	_ = p.singleVisitCache.DelByPrefix(ctx, "stat:income:")
-	_ = p.singleVisitCache.DelByPrefix(ctx, "stat:sold_subs:")
-	_ = p.singleVisitCache.DelByPrefix(ctx, "stat:new_clients:")
-	_ = p.singleVisitCache.DelByPrefix(ctx, "stat:monthly_stats:")
-	_ = p.singleVisitCache.Delete(ctx, "stat:monthly_stats")
-	_ = p.singleVisitCache.Delete(ctx, "stat:income")
-	_ = p.singleVisitCache.Delete(ctx, "stat:total_clients")
-	_ = p.singleVisitCache.Delete(ctx, "stat:sold_subs")
-	_ = p.singleVisitCache.Delete(ctx, "stat:new_clients")
-	_ = p.singleVisitCache.Delete(ctx, "stat:total_sold_subscriptions")
-	_ = p.singleVisitCache.Delete(ctx, "stat:total_income")
-}
-
 func (s *SingleVisitService) AddSingleVisit(ctx context.Context, singleVisStrDate dto.SingleVisitInput) error {
 	const op = "services.single_visit.AddSingleVisit"
 	log := s.log.With(slog.String("op", op))
@@ -81,22 +86,35 @@ func (s *SingleVisitService) AddSingleVisit(ctx context.Context, singleVisStrDat
 		}
 	}
 
+	// Цена считается по тарифу и дате посещения, а не приходит с фронта
+	tariff, err := s.tariffFinder.FindSingleVisitTariffById(ctx, singleVisStrDate.TariffID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTariffNotFound) {
+			log.Warn("tariff not found", slog.Int("tariffID", singleVisStrDate.TariffID))
+			return fmt.Errorf("%s: %w", op, ErrTariffNotFound)
+		}
+		log.Error("failed to get tariff", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	singleVisit := models.SingleVisit{
 		VisitDate:  visitDate,
-		FinalPrice: singleVisStrDate.FinalPrice,
+		FinalPrice: tariff.PriceFor(visitDate),
+		TariffID:   &tariff.ID,
 	}
 
-	if err := s.singleVisitStorage.AddSingleVisit(ctx, singleVisit); err != nil {
+	id, err := s.singleVisitStorage.AddSingleVisit(ctx, singleVisit)
+	if err != nil {
 		log.Error("failed to add single visit", slog.Any("error", err))
 		return err
 	}
 
-	s.invalidateStatisticsCache(ctx)
-
-	cachePrefix := "single_visits:"
-	if err := s.singleVisitCache.DelByPrefix(ctx, cachePrefix); err != nil {
-		log.Error("failed to invalidate cache", slog.String("cacheKey", cachePrefix), slog.Any("error", err))
-	}
+	s.publisher.Publish(ctx, events.SingleVisitAdded{
+		ID:         id,
+		VisitDate:  visitDate,
+		TariffID:   tariff.ID,
+		FinalPrice: singleVisit.FinalPrice,
+	})
 
 	return nil
 }
@@ -270,12 +288,7 @@ func (s *SingleVisitService) DeleteSingleVisit(ctx context.Context, id int) erro
 		return err
 	}
 
-	s.invalidateStatisticsCache(ctx)
-
-	cachePrefix := "single_visits:"
-	if err := s.singleVisitCache.DelByPrefix(ctx, cachePrefix); err != nil {
-		log.Error("failed to invalidate cache after delete", slog.String("cacheKey", cachePrefix), slog.Any("error", err))
-	}
+	s.publisher.Publish(ctx, events.SingleVisitDeleted{ID: id})
 
 	return nil
 }
@@ -0,0 +1,158 @@
+package singleVisitTariffService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+
+	"log/slog"
+)
+
+type SingleVisitTariffStorage interface {
+	SaveSingleVisitTariff(ctx context.Context, tariff models.SingleVisitTariff) (int, error)
+	FindAllSingleVisitTariffs(ctx context.Context) ([]models.SingleVisitTariff, error)
+	UpdateSingleVisitTariff(ctx context.Context, tariff models.SingleVisitTariff, tariffID int) (int, error)
+	DeleteSingleVisitTariff(ctx context.Context, tariffID int) error
+}
+
+type SingleVisitTariffService struct {
+	log           *slog.Logger
+	tariffStorage SingleVisitTariffStorage
+}
+
+var (
+	ErrTariffExists   = errors.New("tariff with that title already exists")
+	ErrTariffNotFound = errors.New("tariff not found")
+	ErrTariffInUse    = errors.New("tariff is used by single visits")
+	ErrInvalidPrice   = errors.New("tariff price must be positive")
+)
+
+func New(
+	log *slog.Logger,
+	tariffStorage SingleVisitTariffStorage,
+) *SingleVisitTariffService {
+	return &SingleVisitTariffService{
+		log:           log,
+		tariffStorage: tariffStorage,
+	}
+}
+
+func validatePrices(tariff models.SingleVisitTariff) error {
+	if tariff.Price <= 0 {
+		return ErrInvalidPrice
+	}
+	if tariff.WeekendPrice != nil && *tariff.WeekendPrice <= 0 {
+		return ErrInvalidPrice
+	}
+
+	return nil
+}
+
+func (t *SingleVisitTariffService) AddTariff(ctx context.Context, tariff models.SingleVisitTariff) (int, error) {
+	const op = "services.single_visit_tariff.AddTariff"
+
+	log := t.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("Adding new single visit tariff")
+
+	if err := validatePrices(tariff); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tariffID, err := t.tariffStorage.SaveSingleVisitTariff(ctx, tariff)
+	if err != nil {
+		if errors.Is(err, storage.ErrTariffExists) {
+			log.Warn("tariff already exists", sl.Error(err))
+			return 0, fmt.Errorf("%s: %w", op, ErrTariffExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("tariff added", slog.Int("tariff_id", tariffID))
+
+	return tariffID, nil
+}
+
+func (t *SingleVisitTariffService) UpdateTariff(ctx context.Context, tariff models.SingleVisitTariff, tariffID int) (int, error) {
+	const op = "services.single_visit_tariff.UpdateTariff"
+
+	log := t.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("Updating single visit tariff")
+
+	if err := validatePrices(tariff); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := t.tariffStorage.UpdateSingleVisitTariff(ctx, tariff, tariffID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTariffNotFound) {
+			log.Warn("tariff not found", sl.Error(err))
+			return 0, fmt.Errorf("%s: %w", op, ErrTariffNotFound)
+		}
+		if errors.Is(err, storage.ErrTariffExists) {
+			log.Warn("tariff already exists", sl.Error(err))
+			return 0, fmt.Errorf("%s: %w", op, ErrTariffExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("tariff updated", slog.Int("tariff_id", id))
+
+	return id, nil
+}
+
+func (t *SingleVisitTariffService) DeleteTariff(ctx context.Context, tariffID int) error {
+	const op = "services.single_visit_tariff.DeleteTariff"
+
+	log := t.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("Deleting single visit tariff")
+
+	if err := t.tariffStorage.DeleteSingleVisitTariff(ctx, tariffID); err != nil {
+		if errors.Is(err, storage.ErrTariffNotFound) {
+			log.Warn("tariff not found", sl.Error(err))
+			return fmt.Errorf("%s: %w", op, ErrTariffNotFound)
+		}
+		if errors.Is(err, storage.ErrTariffInUse) {
+			log.Warn("tariff is in use", sl.Error(err))
+			return fmt.Errorf("%s: %w", op, ErrTariffInUse)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("tariff deleted")
+
+	return nil
+}
+
+func (t *SingleVisitTariffService) FindAllTariffs(ctx context.Context) ([]models.SingleVisitTariff, error) {
+	const op = "services.single_visit_tariff.FindAllTariffs"
+
+	log := t.log.With(
+		slog.String("op", op),
+	)
+
+	tariffs, err := t.tariffStorage.FindAllSingleVisitTariffs(ctx)
+	if err != nil {
+		log.Warn("error", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tariffs == nil {
+		tariffs = []models.SingleVisitTariff{}
+	}
+
+	log.Info("Tariffs are found")
+
+	return tariffs, nil
+}
@@ -0,0 +1,224 @@
+package staffService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/lib/grpcerrors"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidInput = errors.New("invalid input")
+	ErrNotFound     = errors.New("user, role or invite not found")
+	ErrLastAdmin    = errors.New("can't revoke the role of the last admin")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// SSOClient — управление ролями в SSO. token — access-токен администратора, от имени которого идет запрос
+type SSOClient interface {
+	ListUsers(ctx context.Context, token string, filter dto.StaffUsersFilter) (dto.StaffUsersPage, error)
+	ListRoles(ctx context.Context, token string) ([]dto.StaffRole, error)
+	AssignRole(ctx context.Context, token string, userID int64, role string) error
+	RevokeRole(ctx context.Context, token string, userID int64, role string) error
+	CheckPermission(ctx context.Context, token string, userID int64, permission string) (bool, error)
+	UnlockLogin(ctx context.Context, token, email, ip string) (bool, error)
+	CreateInvite(ctx context.Context, token string, input dto.InviteInput) (dto.CreatedInvite, error)
+	ListInvites(ctx context.Context, token string) ([]dto.StaffInvite, error)
+	RevokeInvite(ctx context.Context, token string, inviteID int64) error
+}
+
+// StaffService проксирует в SSO управление учетными записями сотрудников и их ролями
+type StaffService struct {
+	log       *slog.Logger
+	ssoClient SSOClient
+}
+
+func New(log *slog.Logger, ssoClient SSOClient) *StaffService {
+	return &StaffService{
+		log:       log,
+		ssoClient: ssoClient,
+	}
+}
+
+func (s *StaffService) FindUsers(ctx context.Context, token string, filter dto.StaffUsersFilter) (dto.StaffUsersPage, error) {
+	const op = "services.staff.FindUsers"
+
+	filter.Email = strings.TrimSpace(filter.Email)
+
+	page, err := s.ssoClient.ListUsers(ctx, token, filter)
+	if err != nil {
+		return dto.StaffUsersPage{}, s.ssoError(op, err)
+	}
+
+	return page, nil
+}
+
+func (s *StaffService) FindRoles(ctx context.Context, token string) ([]dto.StaffRole, error) {
+	const op = "services.staff.FindRoles"
+
+	roles, err := s.ssoClient.ListRoles(ctx, token)
+	if err != nil {
+		return nil, s.ssoError(op, err)
+	}
+
+	return roles, nil
+}
+
+func (s *StaffService) AssignRole(ctx context.Context, token string, userID int64, role string) error {
+	const op = "services.staff.AssignRole"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.String("role", role),
+	)
+
+	role = strings.TrimSpace(role)
+	if role == "" {
+		return fmt.Errorf("%w: role is required", ErrInvalidInput)
+	}
+
+	if err := s.ssoClient.AssignRole(ctx, token, userID, role); err != nil {
+		return s.ssoError(op, err)
+	}
+
+	log.Info("role assigned")
+	return nil
+}
+
+func (s *StaffService) RevokeRole(ctx context.Context, token string, userID int64, role string) error {
+	const op = "services.staff.RevokeRole"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.String("role", role),
+	)
+
+	if err := s.ssoClient.RevokeRole(ctx, token, userID, role); err != nil {
+		return s.ssoError(op, err)
+	}
+
+	log.Info("role revoked")
+	return nil
+}
+
+func (s *StaffService) CheckPermission(ctx context.Context, token string, userID int64, permission string) (bool, error) {
+	const op = "services.staff.CheckPermission"
+
+	permission = strings.TrimSpace(permission)
+	if permission == "" {
+		return false, fmt.Errorf("%w: permission is required", ErrInvalidInput)
+	}
+
+	allowed, err := s.ssoClient.CheckPermission(ctx, token, userID, permission)
+	if err != nil {
+		return false, s.ssoError(op, err)
+	}
+
+	return allowed, nil
+}
+
+// UnlockLogin снимает блокировку входа после серии неудачных попыток с email и/или IP.
+// Возвращает false, если снимать было нечего
+func (s *StaffService) UnlockLogin(ctx context.Context, token, email, ip string) (bool, error) {
+	const op = "services.staff.UnlockLogin"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("email", email),
+		slog.String("ip", ip),
+	)
+
+	email = strings.TrimSpace(email)
+	ip = strings.TrimSpace(ip)
+	if email == "" && ip == "" {
+		return false, fmt.Errorf("%w: email or ip is required", ErrInvalidInput)
+	}
+
+	unlocked, err := s.ssoClient.UnlockLogin(ctx, token, email, ip)
+	if err != nil {
+		return false, s.ssoError(op, err)
+	}
+
+	log.Info("login unlocked", slog.Bool("unlocked", unlocked))
+	return unlocked, nil
+}
+
+// CreateInvite выдает приглашение на регистрацию с ролями. Код показывается один раз
+func (s *StaffService) CreateInvite(ctx context.Context, token string, input dto.InviteInput) (dto.CreatedInvite, error) {
+	const op = "services.staff.CreateInvite"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Any("roles", input.Roles),
+	)
+
+	input.Email = strings.TrimSpace(input.Email)
+
+	roles := make([]string, 0, len(input.Roles))
+	for _, role := range input.Roles {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		return dto.CreatedInvite{}, fmt.Errorf("%w: at least one role is required", ErrInvalidInput)
+	}
+	input.Roles = roles
+
+	invite, err := s.ssoClient.CreateInvite(ctx, token, input)
+	if err != nil {
+		return dto.CreatedInvite{}, s.ssoError(op, err)
+	}
+
+	log.Info("invite created", slog.Int64("invite_id", invite.Invite.ID))
+	return invite, nil
+}
+
+func (s *StaffService) FindInvites(ctx context.Context, token string) ([]dto.StaffInvite, error) {
+	const op = "services.staff.FindInvites"
+
+	invites, err := s.ssoClient.ListInvites(ctx, token)
+	if err != nil {
+		return nil, s.ssoError(op, err)
+	}
+
+	return invites, nil
+}
+
+func (s *StaffService) RevokeInvite(ctx context.Context, token string, inviteID int64) error {
+	const op = "services.staff.RevokeInvite"
+
+	if err := s.ssoClient.RevokeInvite(ctx, token, inviteID); err != nil {
+		return s.ssoError(op, err)
+	}
+
+	s.log.Info("invite revoked", slog.String("op", op), slog.Int64("invite_id", inviteID))
+	return nil
+}
+
+// ssoError переводит gRPC-статус SSO в ошибки сервиса
+func (s *StaffService) ssoError(op string, err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		// Без op: текст ошибки валидации уходит клиенту как есть
+		return fmt.Errorf("%w: %s", ErrInvalidInput, grpcerrors.ParseValidationError(err))
+	case codes.NotFound:
+		return fmt.Errorf("%s: %w", op, ErrNotFound)
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%s: %w", op, ErrLastAdmin)
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return fmt.Errorf("%s: %w", op, ErrForbidden)
+	}
+
+	s.log.Error("sso request failed", slog.String("op", op), sl.Error(err))
+	return fmt.Errorf("%s: %w", op, err)
+}
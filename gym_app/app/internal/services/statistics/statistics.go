@@ -3,11 +3,13 @@ package statistics
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	"github.com/Muaz717/gym_app/app/internal/services/cache"
 	"log/slog"
+	"math"
 	"time"
 )
 
@@ -19,6 +21,7 @@ type StatStorage interface {
 	TotalSingleVisits(ctx context.Context) (int, error)
 	SingleVisits(ctx context.Context, from, to time.Time) (int, error)
 	SingleVisitsIncome(ctx context.Context) (float64, error)
+	SingleVisitsByTariff(ctx context.Context, from, to time.Time) ([]dto.SingleVisitTariffStat, error)
 
 	// Статистика по продажам абонементов
 	SoldSubscriptions(ctx context.Context, from, to time.Time) (int, error)
@@ -26,10 +29,31 @@ type StatStorage interface {
 
 	TotalIncome(ctx context.Context) (float64, error)
 	Income(ctx context.Context, from, to time.Time) (float64, error)
+	DailyIncome(ctx context.Context, day time.Time) (dto.DailyIncome, error)
 
 	MonthlyStatistics(ctx context.Context, from, to time.Time) ([]dto.MonthlyStat, error)
+
+	// Удержание и отток клиентов
+	Retention(ctx context.Context, from, to time.Time, windowDays int) (dto.RetentionStat, error)
+	MonthlyChurn(ctx context.Context, from, to time.Time, windowDays int) ([]dto.ChurnStat, error)
+	Cohorts(ctx context.Context, from, to time.Time) ([]dto.CohortStat, error)
+	LifetimeValue(ctx context.Context) (dto.LTVStat, error)
+
+	// Доход с разбивкой по периодам и абонементам
+	RevenueReport(ctx context.Context, from, to time.Time, granularity string, byPlan bool) ([]dto.RevenueStat, error)
+
+	// Загруженность зала
+	VisitsHeatmap(ctx context.Context, from, to time.Time) (dto.OccupancyHeatmap, error)
+	CurrentOccupancy(ctx context.Context, window time.Duration) (dto.Occupancy, error)
 }
 
+// singleVisitDuration — сколько времени считаем клиента с разовым посещением находящимся в зале
+const singleVisitDuration = 2 * time.Hour
+
+var (
+	ErrInvalidGranularity = errors.New("invalid granularity")
+)
+
 type StatCache interface {
 	cache.Cache
 }
@@ -189,6 +213,20 @@ func (s *StatService) Income(ctx context.Context, from, to time.Time) (float64,
 	return income, nil
 }
 
+// DailyIncome не кэшируется: счетчик на экране ресепшена должен меняться сразу после продажи
+func (s *StatService) DailyIncome(ctx context.Context, day time.Time) (dto.DailyIncome, error) {
+	const op = "services.statistics.dailyIncome"
+	log := s.log.With(slog.String("op", op))
+
+	income, err := s.statStorage.DailyIncome(ctx, day)
+	if err != nil {
+		log.Error("failed to get daily income", sl.Error(err))
+		return dto.DailyIncome{}, err
+	}
+
+	return income, nil
+}
+
 func (s *StatService) TotalSoldSubscriptions(ctx context.Context) (int, error) {
 	const op = "services.statistics.totalSoldSubscriptions"
 	log := s.log.With(slog.String("op", op))
@@ -281,3 +319,240 @@ func (s *StatService) SingleVisitsIncome(ctx context.Context) (float64, error) {
 
 	return income, nil
 }
+
+func (s *StatService) SingleVisitsByTariff(ctx context.Context, from, to time.Time) ([]dto.SingleVisitTariffStat, error) {
+	const op = "services.statistics.SingleVisitsByTariff"
+	log := s.log.With(slog.String("op", op))
+
+	cacheKey := fmt.Sprintf("stat:single_visits_tariff:%s:%s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var stats []dto.SingleVisitTariffStat
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return stats, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	stats, err := s.statStorage.SingleVisitsByTariff(ctx, from, to)
+	if err != nil {
+		log.Error("failed to get single visits by tariff", sl.Error(err))
+		return nil, err
+	}
+
+	if data, err := json.Marshal(stats); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return stats, nil
+}
+
+// ratio возвращает долю part от total, округленную до сотых процента
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(part)/float64(total)*10000) / 10000
+}
+
+func (s *StatService) Retention(ctx context.Context, from, to time.Time, windowDays int) (dto.RetentionStat, error) {
+	const op = "services.statistics.retention"
+	log := s.log.With(slog.String("op", op))
+
+	cacheKey := fmt.Sprintf("stat:retention:%s:%s:%d", from.Format("2006-01-02"), to.Format("2006-01-02"), windowDays)
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var stat dto.RetentionStat
+		if err := json.Unmarshal([]byte(cached), &stat); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return stat, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	stat, err := s.statStorage.Retention(ctx, from, to, windowDays)
+	if err != nil {
+		log.Error("failed to get retention", sl.Error(err))
+		return dto.RetentionStat{}, err
+	}
+
+	stat.RetentionRate = ratio(stat.Renewed, stat.Expired)
+
+	if data, err := json.Marshal(stat); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return stat, nil
+}
+
+func (s *StatService) MonthlyChurn(ctx context.Context, from, to time.Time, windowDays int) ([]dto.ChurnStat, error) {
+	const op = "services.statistics.monthlyChurn"
+	log := s.log.With(slog.String("op", op))
+
+	cacheKey := fmt.Sprintf("stat:churn:%s:%s:%d", from.Format("2006-01-02"), to.Format("2006-01-02"), windowDays)
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var stats []dto.ChurnStat
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return stats, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	stats, err := s.statStorage.MonthlyChurn(ctx, from, to, windowDays)
+	if err != nil {
+		log.Error("failed to get monthly churn", sl.Error(err))
+		return nil, err
+	}
+
+	for i := range stats {
+		stats[i].ChurnRate = ratio(stats[i].Churned, stats[i].ActiveAtStart)
+	}
+
+	if data, err := json.Marshal(stats); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return stats, nil
+}
+
+func (s *StatService) Cohorts(ctx context.Context, from, to time.Time) ([]dto.CohortStat, error) {
+	const op = "services.statistics.cohorts"
+	log := s.log.With(slog.String("op", op))
+
+	cacheKey := fmt.Sprintf("stat:cohorts:%s:%s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var cohorts []dto.CohortStat
+		if err := json.Unmarshal([]byte(cached), &cohorts); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return cohorts, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	cohorts, err := s.statStorage.Cohorts(ctx, from, to)
+	if err != nil {
+		log.Error("failed to get cohorts", sl.Error(err))
+		return nil, err
+	}
+
+	for i := range cohorts {
+		rates := make([]float64, len(cohorts[i].Active))
+		for j, active := range cohorts[i].Active {
+			rates[j] = ratio(active, cohorts[i].Size)
+		}
+		cohorts[i].RetentionRates = rates
+	}
+
+	if data, err := json.Marshal(cohorts); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return cohorts, nil
+}
+
+func (s *StatService) LifetimeValue(ctx context.Context) (dto.LTVStat, error) {
+	const op = "services.statistics.lifetimeValue"
+	log := s.log.With(slog.String("op", op))
+
+	cacheKey := "stat:ltv"
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var stat dto.LTVStat
+		if err := json.Unmarshal([]byte(cached), &stat); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return stat, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	stat, err := s.statStorage.LifetimeValue(ctx)
+	if err != nil {
+		log.Error("failed to get lifetime value", sl.Error(err))
+		return dto.LTVStat{}, err
+	}
+
+	if stat.Clients > 0 {
+		stat.AverageLTV = math.Round(stat.TotalRevenue/float64(stat.Clients)*100) / 100
+	}
+	stat.AverageLifetimeDays = math.Round(stat.AverageLifetimeDays*10) / 10
+
+	if data, err := json.Marshal(stat); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return stat, nil
+}
+
+func (s *StatService) RevenueReport(ctx context.Context, from, to time.Time, granularity string, byPlan bool) ([]dto.RevenueStat, error) {
+	const op = "services.statistics.revenueReport"
+	log := s.log.With(slog.String("op", op))
+
+	switch granularity {
+	case dto.GranularityDay, dto.GranularityWeek, dto.GranularityMonth, dto.GranularityYear:
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidGranularity)
+	}
+
+	cacheKey := fmt.Sprintf("stat:revenue:%s:%s:%s:%t", from.Format("2006-01-02"), to.Format("2006-01-02"), granularity, byPlan)
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var stats []dto.RevenueStat
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return stats, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	stats, err := s.statStorage.RevenueReport(ctx, from, to, granularity, byPlan)
+	if err != nil {
+		log.Error("failed to get revenue report", sl.Error(err))
+		return nil, err
+	}
+
+	if data, err := json.Marshal(stats); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return stats, nil
+}
+
+func (s *StatService) VisitsHeatmap(ctx context.Context, from, to time.Time) (dto.OccupancyHeatmap, error) {
+	const op = "services.statistics.visitsHeatmap"
+	log := s.log.With(slog.String("op", op))
+
+	cacheKey := fmt.Sprintf("stat:heatmap:%s:%s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if cached, err := s.statCache.Get(ctx, cacheKey); err == nil {
+		var heatmap dto.OccupancyHeatmap
+		if err := json.Unmarshal([]byte(cached), &heatmap); err == nil {
+			log.Info("cache hit", slog.String("key", cacheKey))
+			return heatmap, nil
+		}
+		log.Warn("failed to unmarshal cached data", sl.Error(err))
+	}
+
+	heatmap, err := s.statStorage.VisitsHeatmap(ctx, from, to)
+	if err != nil {
+		log.Error("failed to get visits heatmap", sl.Error(err))
+		return dto.OccupancyHeatmap{}, err
+	}
+
+	if data, err := json.Marshal(heatmap); err == nil {
+		_ = s.statCache.Set(ctx, cacheKey, data, 10*time.Minute)
+	}
+
+	return heatmap, nil
+}
+
+// CurrentOccupancy не кэшируется: значение должно быть актуальным на момент запроса
+func (s *StatService) CurrentOccupancy(ctx context.Context) (dto.Occupancy, error) {
+	const op = "services.statistics.currentOccupancy"
+	log := s.log.With(slog.String("op", op))
+
+	occ, err := s.statStorage.CurrentOccupancy(ctx, singleVisitDuration)
+	if err != nil {
+		log.Error("failed to get current occupancy", sl.Error(err))
+		return dto.Occupancy{}, err
+	}
+
+	return occ, nil
+}
@@ -3,9 +3,14 @@ package subFreezeService
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
 	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
 	"github.com/Muaz717/gym_app/app/internal/services/cache"
+	"github.com/Muaz717/gym_app/app/internal/storage"
 	"log/slog"
 	"time"
 )
@@ -14,27 +19,45 @@ type SubFreezeStorage interface {
 	FreezeSubscription(ctx context.Context, subscriptionNumber string, freezeStart time.Time) error
 	UnfreezeSubscription(ctx context.Context, subscriptionNumber string, unfreezeDate time.Time) error
 	GetAllActiveFreeze(ctx context.Context) ([]models.SubscriptionFreeze, error)
+	GetFreezesBySubscription(ctx context.Context, subscriptionNumber string) ([]models.SubscriptionFreeze, error)
+	SaveFreezeRequest(ctx context.Context, req models.FreezeRequest) (int, error)
+	GetFreezeRequest(ctx context.Context, id int) (models.FreezeRequest, error)
+	FindFreezeRequests(ctx context.Context, filter dto.FreezeRequestFilter) ([]models.FreezeRequest, error)
+	DecideFreezeRequest(ctx context.Context, id int, status string) error
 }
 
 type SubFreezeCache interface {
 	cache.Cache
 }
 
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
+}
+
 type SubFreezeService struct {
 	log              *slog.Logger
 	subFreezeStorage SubFreezeStorage
 	subFreezeCache   SubFreezeCache
+	publisher        Publisher
 }
 
+var (
+	ErrFreezeRequestExists   = errors.New("pending freeze request already exists")
+	ErrFreezeRequestNotFound = errors.New("pending freeze request not found")
+	ErrInvalidStatus         = errors.New("status must be one of pending, approved, rejected")
+)
+
 func New(
 	log *slog.Logger,
 	subFreezeStorage SubFreezeStorage,
 	subFreezeCache SubFreezeCache,
+	publisher Publisher,
 ) *SubFreezeService {
 	return &SubFreezeService{
 		log:              log,
 		subFreezeStorage: subFreezeStorage,
 		subFreezeCache:   subFreezeCache,
+		publisher:        publisher,
 	}
 }
 
@@ -49,17 +72,7 @@ func (s *SubFreezeService) FreezeSubscription(ctx context.Context, subscriptionN
 		return err
 	}
 
-	// Инвалидация кэша (не прерываем бизнес-логику, если кэш не удалился)
-	cacheKey := "sub_freezed:all"
-	if err := s.subFreezeCache.DelByPrefix(ctx, cacheKey); err != nil {
-		log.Error("failed to invalidate cache", slog.String("cacheKey", cacheKey), sl.Error(err))
-	}
-
-	// Инвалидация кеша абонементов клиентов
-	cacheKeySubs := "person_subs:all"
-	if err := s.subFreezeCache.Delete(ctx, cacheKeySubs); err != nil {
-		log.Error("failed to invalidate cache", slog.String("cacheKey", cacheKey), sl.Error(err))
-	}
+	s.publisher.Publish(ctx, events.SubscriptionFrozen{Number: subscriptionNumber, Date: freezeStart})
 
 	log.Info("subscription frozen successfully")
 	return nil
@@ -76,17 +89,7 @@ func (s *SubFreezeService) UnfreezeSubscription(ctx context.Context, subscriptio
 		return err
 	}
 
-	// Инвалидация кэша (не прерываем бизнес-логику)
-	cacheKey := "sub_freezed:all"
-	if err := s.subFreezeCache.DelByPrefix(ctx, cacheKey); err != nil {
-		log.Error("failed to invalidate cache", slog.String("cacheKey", cacheKey), sl.Error(err))
-	}
-
-	// Инвалидация кеша абонементов клиентов
-	cacheKeySubs := "person_subs:all"
-	if err := s.subFreezeCache.Delete(ctx, cacheKeySubs); err != nil {
-		log.Error("failed to invalidate cache", slog.String("cacheKey", cacheKey), sl.Error(err))
-	}
+	s.publisher.Publish(ctx, events.SubscriptionUnfrozen{Number: subscriptionNumber, Date: unfreezeDate})
 
 	log.Info("subscription unfrozen successfully")
 	return nil
@@ -122,3 +125,101 @@ func (s *SubFreezeService) GetAllActiveFreeze(ctx context.Context) ([]models.Sub
 
 	return freezes, nil
 }
+
+// GetFreezeHistory возвращает историю заморозок абонемента
+func (s *SubFreezeService) GetFreezeHistory(ctx context.Context, subscriptionNumber string) ([]models.SubscriptionFreeze, error) {
+	const op = "services.sub_freeze.GetFreezeHistory"
+	log := s.log.With(slog.String("op", op))
+
+	freezes, err := s.subFreezeStorage.GetFreezesBySubscription(ctx, subscriptionNumber)
+	if err != nil {
+		log.Error("failed to get freeze history", slog.String("subscriptionNumber", subscriptionNumber), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return freezes, nil
+}
+
+// RequestFreeze сохраняет заявку клиента на заморозку; саму заморозку оформляет администратор
+func (s *SubFreezeService) RequestFreeze(ctx context.Context, req models.FreezeRequest) (int, error) {
+	const op = "services.sub_freeze.RequestFreeze"
+	log := s.log.With(slog.String("op", op), slog.String("subscriptionNumber", req.SubscriptionNumber))
+
+	id, err := s.subFreezeStorage.SaveFreezeRequest(ctx, req)
+	if err != nil {
+		if errors.Is(err, storage.ErrFreezeRequestExists) {
+			return 0, fmt.Errorf("%s: %w", op, ErrFreezeRequestExists)
+		}
+		log.Error("failed to save freeze request", sl.Error(err))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("freeze request created", slog.Int("request_id", id))
+	return id, nil
+}
+
+// FindFreezeRequests возвращает заявки на заморозку
+func (s *SubFreezeService) FindFreezeRequests(ctx context.Context, filter dto.FreezeRequestFilter) ([]models.FreezeRequest, error) {
+	const op = "services.sub_freeze.FindFreezeRequests"
+	log := s.log.With(slog.String("op", op))
+
+	switch filter.Status {
+	case "", dto.FreezeRequestPending, dto.FreezeRequestApproved, dto.FreezeRequestRejected:
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidStatus)
+	}
+
+	requests, err := s.subFreezeStorage.FindFreezeRequests(ctx, filter)
+	if err != nil {
+		log.Error("failed to find freeze requests", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return requests, nil
+}
+
+// ApproveFreezeRequest замораживает абонемент с даты из заявки и закрывает заявку
+func (s *SubFreezeService) ApproveFreezeRequest(ctx context.Context, id int) error {
+	const op = "services.sub_freeze.ApproveFreezeRequest"
+	log := s.log.With(slog.String("op", op), slog.Int("request_id", id))
+
+	req, err := s.subFreezeStorage.GetFreezeRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrFreezeRequestNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrFreezeRequestNotFound)
+		}
+		log.Error("failed to get freeze request", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if req.Status != dto.FreezeRequestPending {
+		return fmt.Errorf("%s: %w", op, ErrFreezeRequestNotFound)
+	}
+
+	if err := s.FreezeSubscription(ctx, req.SubscriptionNumber, req.FreezeStart); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s.decide(ctx, op, id, dto.FreezeRequestApproved)
+}
+
+// RejectFreezeRequest отклоняет заявку
+func (s *SubFreezeService) RejectFreezeRequest(ctx context.Context, id int) error {
+	const op = "services.sub_freeze.RejectFreezeRequest"
+
+	return s.decide(ctx, op, id, dto.FreezeRequestRejected)
+}
+
+func (s *SubFreezeService) decide(ctx context.Context, op string, id int, status string) error {
+	log := s.log.With(slog.String("op", op), slog.Int("request_id", id))
+
+	if err := s.subFreezeStorage.DecideFreezeRequest(ctx, id, status); err != nil {
+		if errors.Is(err, storage.ErrFreezeRequestNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrFreezeRequestNotFound)
+		}
+		log.Error("failed to decide freeze request", sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("freeze request decided", slog.String("status", status))
+	return nil
+}
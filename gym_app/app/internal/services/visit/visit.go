@@ -0,0 +1,163 @@
+package visitService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"log/slog"
+	"time"
+)
+
+const activeStatus = "active"
+
+type VisitStorage interface {
+	CheckIn(ctx context.Context, subscriptionNumber string) (models.Visit, error)
+	CheckOut(ctx context.Context, subscriptionNumber string) (models.Visit, error)
+	GetOpenVisits(ctx context.Context) ([]models.Visit, error)
+}
+
+type PersonSubFinder interface {
+	GetPersonSubByNumber(ctx context.Context, number string) (dto.PersonSubResponse, error)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event)
+}
+
+type VisitService struct {
+	log             *slog.Logger
+	visitStorage    VisitStorage
+	personSubFinder PersonSubFinder
+	publisher       Publisher
+}
+
+var (
+	ErrSubNotFound       = errors.New("subscription not found")
+	ErrSubInactive       = errors.New("subscription is not active")
+	ErrAlreadyCheckedIn  = errors.New("subscription already checked in")
+	ErrOpenVisitNotFound = errors.New("open visit not found")
+)
+
+func New(
+	log *slog.Logger,
+	visitStorage VisitStorage,
+	personSubFinder PersonSubFinder,
+	publisher Publisher,
+) *VisitService {
+	return &VisitService{
+		log:             log,
+		visitStorage:    visitStorage,
+		personSubFinder: personSubFinder,
+		publisher:       publisher,
+	}
+}
+
+// CheckIn отмечает приход клиента. Абонемент должен быть активным и действовать на сегодня.
+func (v *VisitService) CheckIn(ctx context.Context, subscriptionNumber string) (models.Visit, error) {
+	const op = "services.visit.CheckIn"
+
+	log := v.log.With(
+		slog.String("op", op),
+		slog.String("subscription_number", subscriptionNumber),
+	)
+
+	personSub, err := v.personSubFinder.GetPersonSubByNumber(ctx, subscriptionNumber)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			return models.Visit{}, fmt.Errorf("%s: %w", op, ErrSubNotFound)
+		}
+		log.Error("failed to find subscription", sl.Error(err))
+		return models.Visit{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	today := dateOnly(time.Now())
+	if personSub.Status != activeStatus || personSub.StartDate.After(today) || personSub.EndDate.Before(today) {
+		log.Warn("subscription is not active", slog.String("status", personSub.Status))
+		return models.Visit{}, fmt.Errorf("%s: %w", op, ErrSubInactive)
+	}
+
+	visit, err := v.visitStorage.CheckIn(ctx, subscriptionNumber)
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyCheckedIn) {
+			return models.Visit{}, fmt.Errorf("%s: %w", op, ErrAlreadyCheckedIn)
+		}
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			return models.Visit{}, fmt.Errorf("%s: %w", op, ErrSubNotFound)
+		}
+		log.Error("failed to check in", sl.Error(err))
+		return models.Visit{}, fmt.Errorf("%s: %w", op, err)
+	}
+	visit.PersonID = personSub.PersonID
+	visit.PersonName = personSub.PersonName
+
+	v.publisher.Publish(ctx, events.VisitCheckedIn{
+		VisitID:            visit.ID,
+		SubscriptionNumber: subscriptionNumber,
+		PersonID:           visit.PersonID,
+		PersonName:         visit.PersonName,
+		At:                 visit.CheckedInAt,
+	})
+
+	log.Info("client checked in", slog.Int("visit_id", visit.ID))
+	return visit, nil
+}
+
+// CheckOut отмечает уход клиента
+func (v *VisitService) CheckOut(ctx context.Context, subscriptionNumber string) (models.Visit, error) {
+	const op = "services.visit.CheckOut"
+
+	log := v.log.With(
+		slog.String("op", op),
+		slog.String("subscription_number", subscriptionNumber),
+	)
+
+	visit, err := v.visitStorage.CheckOut(ctx, subscriptionNumber)
+	if err != nil {
+		if errors.Is(err, storage.ErrVisitNotFound) {
+			return models.Visit{}, fmt.Errorf("%s: %w", op, ErrOpenVisitNotFound)
+		}
+		log.Error("failed to check out", sl.Error(err))
+		return models.Visit{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	at := time.Now()
+	if visit.CheckedOutAt != nil {
+		at = *visit.CheckedOutAt
+	}
+	v.publisher.Publish(ctx, events.VisitCheckedOut{
+		VisitID:            visit.ID,
+		SubscriptionNumber: subscriptionNumber,
+		At:                 at,
+	})
+
+	log.Info("client checked out", slog.Int("visit_id", visit.ID))
+	return visit, nil
+}
+
+// GetOpenVisits возвращает клиентов по абонементам, которые сейчас в зале
+func (v *VisitService) GetOpenVisits(ctx context.Context) ([]models.Visit, error) {
+	const op = "services.visit.GetOpenVisits"
+
+	log := v.log.With(
+		slog.String("op", op),
+	)
+
+	visits, err := v.visitStorage.GetOpenVisits(ctx)
+	if err != nil {
+		log.Error("failed to get open visits", sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return visits, nil
+}
+
+// dateOnly возвращает полночь UTC локальной даты t — в таком виде из базы приходят поля типа date
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
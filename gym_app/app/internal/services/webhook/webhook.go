@@ -0,0 +1,278 @@
+package webhookService
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/lib/webhook"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+
+	// maxErrorLength — сколько символов ошибки храним в журнале доставок
+	maxErrorLength = 500
+)
+
+type WebhookStorage interface {
+	SaveWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error)
+	FindWebhooks(ctx context.Context) ([]models.Webhook, error)
+	SetWebhookActive(ctx context.Context, id int, active bool) error
+	DeleteWebhook(ctx context.Context, id int) error
+	FindDeliveries(ctx context.Context, webhookID int, limit int) ([]models.WebhookDelivery, error)
+	FanOutEvents(ctx context.Context, limit int) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.PendingDelivery, error)
+	SaveDeliveryResult(ctx context.Context, id int64, result dto.DeliveryResult) error
+}
+
+type Sender interface {
+	Send(ctx context.Context, r webhook.Request) (int, error)
+}
+
+type WebhookService struct {
+	log            *slog.Logger
+	webhookStorage WebhookStorage
+	sender         Sender
+	cfg            config.Webhooks
+	now            func() time.Time
+}
+
+var (
+	ErrInvalidURL      = errors.New("url must be an absolute http or https address")
+	ErrInvalidEvents   = errors.New("unknown or empty event list")
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+func New(
+	log *slog.Logger,
+	webhookStorage WebhookStorage,
+	sender Sender,
+	cfg config.Webhooks,
+) *WebhookService {
+	return &WebhookService{
+		log:            log,
+		webhookStorage: webhookStorage,
+		sender:         sender,
+		cfg:            cfg,
+		now:            time.Now,
+	}
+}
+
+// CreateWebhook регистрирует адрес. Секрет возвращается только здесь, в списке его нет.
+func (w *WebhookService) CreateWebhook(ctx context.Context, input dto.WebhookInput) (models.Webhook, error) {
+	const op = "services.webhook.CreateWebhook"
+
+	log := w.log.With(
+		slog.String("op", op),
+	)
+
+	u, err := url.Parse(input.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, ErrInvalidURL)
+	}
+
+	if len(input.Events) == 0 {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, ErrInvalidEvents)
+	}
+	events := make([]string, 0, len(input.Events))
+	for _, event := range input.Events {
+		if !slices.Contains(dto.WebhookEvents, event) {
+			return models.Webhook{}, fmt.Errorf("%s: %w: %s", op, ErrInvalidEvents, event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	webhook, err := w.webhookStorage.SaveWebhook(ctx, models.Webhook{
+		URL:    input.URL,
+		Secret: secret,
+		Events: events,
+	})
+	if err != nil {
+		log.Error("failed to save webhook", sl.Error(err))
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("webhook created", slog.Int("id", webhook.ID), slog.String("host", u.Host))
+
+	return webhook, nil
+}
+
+func (w *WebhookService) FindWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	const op = "services.webhook.FindWebhooks"
+
+	webhooks, err := w.webhookStorage.FindWebhooks(ctx)
+	if err != nil {
+		w.log.Error("failed to find webhooks", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+// SetActive включает или выключает вебхук. Пока вебхук выключен, новые события ему не назначаются.
+func (w *WebhookService) SetActive(ctx context.Context, id int, active bool) error {
+	const op = "services.webhook.SetActive"
+
+	if err := w.webhookStorage.SetWebhookActive(ctx, id, active); err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrWebhookNotFound)
+		}
+		w.log.Error("failed to update webhook", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (w *WebhookService) DeleteWebhook(ctx context.Context, id int) error {
+	const op = "services.webhook.DeleteWebhook"
+
+	if err := w.webhookStorage.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrWebhookNotFound)
+		}
+		w.log.Error("failed to delete webhook", slog.String("op", op), sl.Error(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (w *WebhookService) FindDeliveries(ctx context.Context, webhookID int, limit int) ([]models.WebhookDelivery, error) {
+	const op = "services.webhook.FindDeliveries"
+
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	limit = min(limit, maxDeliveriesLimit)
+
+	deliveries, err := w.webhookStorage.FindDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		w.log.Error("failed to find deliveries", slog.String("op", op), sl.Error(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// Dispatch — один проход диспетчера: раскладывает новые события outbox по вебхукам
+// и отправляет доставки, время которых подошло. Возвращает число отправленных попыток.
+func (w *WebhookService) Dispatch(ctx context.Context) (int, error) {
+	const op = "services.webhook.Dispatch"
+
+	log := w.log.With(
+		slog.String("op", op),
+	)
+
+	events, err := w.webhookStorage.FanOutEvents(ctx, w.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if events > 0 {
+		log.Debug("outbox events processed", slog.Int("events", events))
+	}
+
+	// Аренда с запасом на таймаут запроса: пока идет отправка, доставку не возьмет другой экземпляр
+	lease := w.cfg.Timeout*time.Duration(w.cfg.BatchSize) + time.Minute
+	deliveries, err := w.webhookStorage.ClaimDeliveries(ctx, w.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, d := range deliveries {
+		result := w.deliver(ctx, d)
+
+		if result.Error != "" {
+			log.Warn("webhook delivery failed",
+				slog.Int64("delivery_id", d.ID),
+				slog.String("event", d.Event.Type),
+				slog.Int("attempt", d.Attempts+1),
+				slog.Bool("final", result.Final),
+				slog.String("error", result.Error),
+			)
+		}
+
+		if err := w.webhookStorage.SaveDeliveryResult(ctx, d.ID, result); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (w *WebhookService) deliver(ctx context.Context, d models.PendingDelivery) dto.DeliveryResult {
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		// Повтор не поможет: событие битое
+		return dto.DeliveryResult{Error: err.Error(), Final: true}
+	}
+
+	code, err := w.sender.Send(ctx, webhook.Request{
+		URL:     d.URL,
+		Secret:  d.Secret,
+		Event:   d.Event.Type,
+		EventID: d.Event.ID,
+		Body:    body,
+	})
+	if err == nil {
+		return dto.DeliveryResult{StatusCode: code}
+	}
+
+	attempt := d.Attempts + 1
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = strings.ToValidUTF8(msg[:maxErrorLength], "")
+	}
+
+	return dto.DeliveryResult{
+		StatusCode:  code,
+		Error:       msg,
+		NextAttempt: w.now().Add(backoff(attempt, w.cfg.BaseBackoff, w.cfg.MaxBackoff)),
+		Final:       attempt >= w.cfg.MaxAttempts,
+	}
+}
+
+// backoff — пауза перед следующей попыткой: base, 2*base, 4*base... но не больше limit
+func backoff(attempt int, base, limit time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= limit {
+			return limit
+		}
+	}
+	return min(delay, limit)
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
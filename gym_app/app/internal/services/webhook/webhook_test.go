@@ -0,0 +1,117 @@
+package webhookService
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/config"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/gym_app/app/internal/lib/webhook"
+	"github.com/Muaz717/gym_app/app/internal/services/webhook/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newService(st *mocks.WebhookStorage, sender *mocks.Sender) *WebhookService {
+	return New(slogdiscard.NewDiscardLogger(), st, sender, config.Webhooks{
+		BatchSize:   10,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  time.Hour,
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	base, limit := 30*time.Second, 5*time.Minute
+
+	assert.Equal(t, 30*time.Second, backoff(1, base, limit))
+	assert.Equal(t, time.Minute, backoff(2, base, limit))
+	assert.Equal(t, 2*time.Minute, backoff(3, base, limit))
+	assert.Equal(t, limit, backoff(5, base, limit))
+	assert.Equal(t, limit, backoff(100, base, limit))
+}
+
+func TestCreateWebhook_Validation(t *testing.T) {
+	st := mocks.NewWebhookStorage(t)
+	srv := newService(st, mocks.NewSender(t))
+
+	_, err := srv.CreateWebhook(context.Background(), dto.WebhookInput{URL: "ftp://crm.local", Events: []string{dto.EventPersonCreated}})
+	assert.ErrorIs(t, err, ErrInvalidURL)
+
+	_, err = srv.CreateWebhook(context.Background(), dto.WebhookInput{URL: "https://crm.local/hook", Events: []string{"person.deleted"}})
+	assert.ErrorIs(t, err, ErrInvalidEvents)
+
+	st.EXPECT().SaveWebhook(mock.Anything, mock.MatchedBy(func(w models.Webhook) bool {
+		return w.URL == "https://crm.local/hook"
+	})).RunAndReturn(func(_ context.Context, w models.Webhook) (models.Webhook, error) {
+		w.ID = 1
+		return w, nil
+	}).Once()
+
+	created, err := srv.CreateWebhook(context.Background(), dto.WebhookInput{
+		URL:    "https://crm.local/hook",
+		Events: []string{dto.EventPersonCreated, dto.EventPersonCreated, dto.EventSubscriptionSold},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+	assert.Equal(t, []string{dto.EventPersonCreated, dto.EventSubscriptionSold}, created.Events)
+	assert.Len(t, created.Secret, 64)
+}
+
+func TestDispatch_RetriesAndGivesUp(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := models.OutboxEvent{ID: 7, Type: dto.EventSubscriptionSold, Payload: []byte(`{"number":"A-1"}`), CreatedAt: now}
+
+	st := mocks.NewWebhookStorage(t)
+	st.EXPECT().FanOutEvents(mock.Anything, 10).Return(1, nil).Once()
+	// Аренда: таймаут на каждую доставку пачки плюс минута запаса
+	st.EXPECT().ClaimDeliveries(mock.Anything, 10, 70*time.Second).Return([]models.PendingDelivery{
+		{ID: 1, URL: "https://ok.local", Secret: "s1", Event: event},
+		{ID: 2, URL: "https://down.local", Secret: "s2", Attempts: 0, Event: event},
+		{ID: 3, URL: "https://down.local", Secret: "s3", Attempts: 2, Event: event},
+	}, nil).Once()
+
+	results := map[int64]dto.DeliveryResult{}
+	st.EXPECT().SaveDeliveryResult(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, id int64, result dto.DeliveryResult) { results[id] = result }).
+		Return(nil).Times(3)
+
+	var sent []webhook.Request
+	sender := mocks.NewSender(t)
+	sender.EXPECT().Send(mock.Anything, mock.MatchedBy(func(r webhook.Request) bool { return r.URL == "https://ok.local" })).
+		Run(func(_ context.Context, r webhook.Request) { sent = append(sent, r) }).
+		Return(http.StatusOK, nil).Once()
+	sender.EXPECT().Send(mock.Anything, mock.MatchedBy(func(r webhook.Request) bool { return r.URL == "https://down.local" })).
+		Return(http.StatusServiceUnavailable, errors.New("unexpected status 503")).Twice()
+
+	srv := newService(st, sender)
+	srv.now = func() time.Time { return now }
+
+	n, err := srv.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	assert.Empty(t, results[1].Error)
+	assert.Equal(t, http.StatusOK, results[1].StatusCode)
+
+	assert.NotEmpty(t, results[2].Error)
+	assert.False(t, results[2].Final)
+	assert.Equal(t, now.Add(30*time.Second), results[2].NextAttempt)
+
+	assert.True(t, results[3].Final)
+
+	require.Len(t, sent, 1)
+	assert.Equal(t, "s1", sent[0].Secret)
+	assert.Equal(t, dto.EventSubscriptionSold, sent[0].Event)
+	assert.JSONEq(t,
+		`{"id":7,"type":"subscription.sold","data":{"number":"A-1"},"created_at":"2025-03-01T12:00:00Z"}`,
+		string(sent[0].Body),
+	)
+}
@@ -0,0 +1,378 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (s *Storage) SaveAccessDevice(ctx context.Context, name, tokenHash string) (models.AccessDevice, error) {
+	const op = "storage.postgres.SaveAccessDevice"
+
+	const query = `
+		INSERT INTO access_devices (name, token_hash)
+		VALUES ($1, $2)
+		RETURNING id, active, created_at
+	`
+
+	device := models.AccessDevice{Name: name}
+	err := s.db.QueryRow(ctx, query, name, tokenHash).Scan(&device.ID, &device.Active, &device.CreatedAt)
+	if err != nil {
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return device, nil
+}
+
+func (s *Storage) FindAccessDevices(ctx context.Context) ([]models.AccessDevice, error) {
+	const op = "storage.postgres.FindAccessDevices"
+
+	const query = `SELECT id, name, active, created_at, last_seen_at FROM access_devices ORDER BY id`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	devices := make([]models.AccessDevice, 0)
+	for rows.Next() {
+		var d models.AccessDevice
+		if err := rows.Scan(&d.ID, &d.Name, &d.Active, &d.CreatedAt, &d.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		devices = append(devices, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return devices, nil
+}
+
+func (s *Storage) SetAccessDeviceActive(ctx context.Context, id int, active bool) error {
+	const op = "storage.postgres.SetAccessDeviceActive"
+
+	result, err := s.db.Exec(ctx, `UPDATE access_devices SET active = $1 WHERE id = $2`, active, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAccessDeviceNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteAccessDevice(ctx context.Context, id int) error {
+	const op = "storage.postgres.DeleteAccessDevice"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM access_devices WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAccessDeviceNotFound)
+	}
+
+	return nil
+}
+
+// AccessDeviceByToken находит активный контроллер по хэшу токена и отмечает время обращения
+func (s *Storage) AccessDeviceByToken(ctx context.Context, tokenHash string) (models.AccessDevice, error) {
+	const op = "storage.postgres.AccessDeviceByToken"
+
+	const query = `
+		UPDATE access_devices
+		SET last_seen_at = NOW()
+		WHERE token_hash = $1 AND active
+		RETURNING id, name, active, created_at, last_seen_at
+	`
+
+	var d models.AccessDevice
+	err := s.db.QueryRow(ctx, query, tokenHash).Scan(&d.ID, &d.Name, &d.Active, &d.CreatedAt, &d.LastSeenAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.AccessDevice{}, fmt.Errorf("%s: %w", op, storage.ErrAccessDeviceNotFound)
+		}
+		return models.AccessDevice{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return d, nil
+}
+
+func (s *Storage) SaveAccessCard(ctx context.Context, card models.AccessCard) (models.AccessCard, error) {
+	const op = "storage.postgres.SaveAccessCard"
+
+	const query = `
+		INSERT INTO access_cards (card_number, person_id)
+		VALUES ($1, $2)
+		RETURNING created_at
+	`
+
+	err := s.db.QueryRow(ctx, query, card.CardNumber, card.PersonID).Scan(&card.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return models.AccessCard{}, fmt.Errorf("%s: %w", op, storage.ErrAccessCardExists)
+			case "23503":
+				return models.AccessCard{}, fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+			}
+		}
+		return models.AccessCard{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return card, nil
+}
+
+func (s *Storage) FindAccessCards(ctx context.Context, personID int) ([]models.AccessCard, error) {
+	const op = "storage.postgres.FindAccessCards"
+
+	const query = `
+		SELECT card_number, person_id, created_at
+		FROM access_cards
+		WHERE person_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.Query(ctx, query, personID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	cards := make([]models.AccessCard, 0)
+	for rows.Next() {
+		var c models.AccessCard
+		if err := rows.Scan(&c.CardNumber, &c.PersonID, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		cards = append(cards, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cards, nil
+}
+
+func (s *Storage) DeleteAccessCard(ctx context.Context, cardNumber string) error {
+	const op = "storage.postgres.DeleteAccessCard"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM access_cards WHERE card_number = $1`, cardNumber)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAccessCardNotFound)
+	}
+
+	return nil
+}
+
+// FindAccessCredential ищет абонемент по номеру абонемента или по карте клиента.
+// У клиента с картой берем абонемент, действующий сегодня, иначе — с самым поздним окончанием.
+func (s *Storage) FindAccessCredential(ctx context.Context, credential string) (dto.AccessCredential, error) {
+	const op = "storage.postgres.FindAccessCredential"
+
+	const query = `
+		SELECT ps.number, p.id, p.full_name, ps.status, ps.start_date, ps.end_date
+		FROM person_subscriptions ps
+		JOIN person p ON p.id = ps.person_id
+		WHERE ps.number = $1
+		   OR ps.person_id = (SELECT person_id FROM access_cards WHERE card_number = $1)
+		ORDER BY
+			ps.number = $1 DESC,
+			(ps.status = 'active' AND ps.start_date <= CURRENT_DATE AND ps.end_date >= CURRENT_DATE) DESC,
+			ps.end_date DESC
+		LIMIT 1
+	`
+
+	var c dto.AccessCredential
+	err := s.db.QueryRow(ctx, query, credential).Scan(
+		&c.SubscriptionNumber,
+		&c.PersonID,
+		&c.PersonName,
+		&c.Status,
+		&c.StartDate,
+		&c.EndDate,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.AccessCredential{}, fmt.Errorf("%s: %w", op, storage.ErrCredentialNotFound)
+		}
+		return dto.AccessCredential{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return c, nil
+}
+
+// EnterVisit отмечает вход через турникет. Незакрытые посещения прошлых дней закрываются концом
+// того же дня: через турникет без считывателя на выходе клиенты уход не отмечают.
+// Если клиент уже вошел сегодня, возвращается открытое посещение и created = false,
+// а при antiPassback — ErrAlreadyCheckedIn.
+func (s *Storage) EnterVisit(ctx context.Context, subscriptionNumber string, antiPassback bool) (visit models.Visit, created bool, err error) {
+	const op = "storage.postgres.EnterVisit"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Visit{}, false, fmt.Errorf("%s: begin: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, closeStaleVisitsQuery, subscriptionNumber); err != nil {
+		return models.Visit{}, false, fmt.Errorf("%s: close stale: %w", op, err)
+	}
+
+	const insert = `
+		INSERT INTO visits (subscription_number, checked_in_at)
+		VALUES ($1, NOW())
+		ON CONFLICT (subscription_number) WHERE checked_out_at IS NULL DO NOTHING
+		RETURNING id, checked_in_at
+	`
+
+	visit.SubscriptionNumber = subscriptionNumber
+	err = tx.QueryRow(ctx, insert, subscriptionNumber).Scan(&visit.ID, &visit.CheckedInAt)
+	switch {
+	case err == nil:
+		created = true
+	case errors.Is(err, pgx.ErrNoRows):
+		if antiPassback {
+			return models.Visit{}, false, fmt.Errorf("%s: %w", op, storage.ErrAlreadyCheckedIn)
+		}
+		const open = `
+			SELECT id, checked_in_at
+			FROM visits
+			WHERE subscription_number = $1 AND checked_out_at IS NULL
+		`
+		if err := tx.QueryRow(ctx, open, subscriptionNumber).Scan(&visit.ID, &visit.CheckedInAt); err != nil {
+			return models.Visit{}, false, fmt.Errorf("%s: open visit: %w", op, err)
+		}
+	default:
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.Visit{}, false, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionNotFound)
+		}
+		return models.Visit{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Visit{}, false, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return visit, created, nil
+}
+
+func (s *Storage) SaveAccessLog(ctx context.Context, entry models.AccessLogEntry) error {
+	const op = "storage.postgres.SaveAccessLog"
+
+	const query = `
+		INSERT INTO access_log (device_id, credential, direction, allowed, reason, subscription_number, visit_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+	`
+
+	_, err := s.db.Exec(ctx, query,
+		entry.DeviceID,
+		entry.Credential,
+		entry.Direction,
+		entry.Allowed,
+		entry.Reason,
+		entry.SubscriptionNumber,
+		entry.VisitID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindAccessLog возвращает последние попытки прохода, новые первыми
+func (s *Storage) FindAccessLog(ctx context.Context, limit int) ([]models.AccessLogEntry, error) {
+	const op = "storage.postgres.FindAccessLog"
+
+	const query = `
+		SELECT id, device_id, credential, direction, allowed, reason,
+		       COALESCE(subscription_number, ''), visit_id, created_at
+		FROM access_log
+		ORDER BY id DESC
+		LIMIT $1
+	`
+
+	rows, err := s.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.AccessLogEntry, 0)
+	for rows.Next() {
+		var e models.AccessLogEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.DeviceID,
+			&e.Credential,
+			&e.Direction,
+			&e.Allowed,
+			&e.Reason,
+			&e.SubscriptionNumber,
+			&e.VisitID,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// AllowList возвращает номера абонементов и карт, по которым можно пройти сегодня или позже.
+// У карты может быть несколько строк: по одной на каждый действующий или будущий абонемент.
+func (s *Storage) AllowList(ctx context.Context) ([]dto.AllowListEntry, error) {
+	const op = "storage.postgres.AllowList"
+
+	const query = `
+		SELECT ps.number AS credential, ps.number, ps.start_date, ps.end_date
+		FROM person_subscriptions ps
+		WHERE ps.status = 'active' AND ps.end_date >= CURRENT_DATE
+		UNION ALL
+		SELECT c.card_number, ps.number, ps.start_date, ps.end_date
+		FROM access_cards c
+		JOIN person_subscriptions ps ON ps.person_id = c.person_id
+		WHERE ps.status = 'active' AND ps.end_date >= CURRENT_DATE
+		ORDER BY 1, 3
+	`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := make([]dto.AllowListEntry, 0)
+	for rows.Next() {
+		var e dto.AllowListEntry
+		if err := rows.Scan(&e.Credential, &e.SubscriptionNumber, &e.ValidFrom, &e.ValidUntil); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+)
+
+// FindExpiringPersonSubs возвращает абонементы для обзвона вместе с контактами клиента:
+//   - expiring: активные абонементы, заканчивающиеся в ближайшие Days дней;
+//   - lapsed: абонементы, закончившиеся за последние Days дней, после которых клиент не купил новый;
+//   - frozen: абонементы, замороженные не меньше Days дней.
+func (s *Storage) FindExpiringPersonSubs(ctx context.Context, filter dto.ExpiringFilter) ([]dto.ExpiringPersonSub, error) {
+	const op = "storage.postgres.FindExpiringPersonSubs"
+
+	var condition string
+	switch filter.Kind {
+	case dto.ExpiringKindExpiring:
+		condition = `ps.status = 'active'
+			AND ps.end_date >= CURRENT_DATE
+			AND ps.end_date <= CURRENT_DATE + $1::int`
+	case dto.ExpiringKindLapsed:
+		condition = `f.freeze_start IS NULL
+			AND ps.end_date < CURRENT_DATE
+			AND ps.end_date >= CURRENT_DATE - $1::int
+			AND NOT EXISTS (
+				SELECT 1 FROM person_subscriptions next
+				WHERE next.person_id = ps.person_id
+				  AND next.number <> ps.number
+				  AND next.end_date > ps.end_date
+			)`
+	case dto.ExpiringKindFrozen:
+		// Заморозку определяем по незакрытой записи в subscription_freeze, а не по статусу
+		condition = `f.freeze_start <= NOW() - make_interval(days => $1::int)`
+	default:
+		return nil, fmt.Errorf("%s: unknown report kind %q", op, filter.Kind)
+	}
+
+	query := `
+		SELECT
+			ps.number,
+			p.id,
+			p.full_name,
+			p.phone,
+			s.id,
+			s.title,
+			ps.start_date,
+			ps.end_date,
+			ps.status,
+			(ps.end_date - CURRENT_DATE) as days_left,
+			f.freeze_start,
+			COALESCE(EXTRACT(DAY FROM NOW() - f.freeze_start)::int, 0) as frozen_days
+		FROM person_subscriptions ps
+		JOIN person p ON p.id = ps.person_id
+		JOIN subscriptions s ON s.id = ps.subscription_id
+		LEFT JOIN LATERAL (
+			SELECT freeze_start
+			FROM subscription_freeze
+			WHERE subscription_number = ps.number AND freeze_end IS NULL
+			ORDER BY freeze_start DESC
+			LIMIT 1
+		) f ON true
+		WHERE ` + condition + `
+		  AND ($2::int = 0 OR ps.subscription_id = $2::int)
+		ORDER BY ps.end_date, p.full_name
+	`
+
+	rows, err := s.db.Query(ctx, query, filter.Days, filter.SubscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	subs := make([]dto.ExpiringPersonSub, 0)
+	for rows.Next() {
+		var sub dto.ExpiringPersonSub
+		err := rows.Scan(
+			&sub.Number,
+			&sub.PersonID,
+			&sub.PersonName,
+			&sub.Phone,
+			&sub.SubscriptionID,
+			&sub.SubscriptionTitle,
+			&sub.StartDate,
+			&sub.EndDate,
+			&sub.Status,
+			&sub.DaysLeft,
+			&sub.FrozenSince,
+			&sub.FrozenDays,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
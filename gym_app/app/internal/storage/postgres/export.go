@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"time"
+)
+
+// Методы Stream* читают строки по одной и передают их в fn, не собирая результат в память.
+// Если fn вернула ошибку, чтение прерывается.
+
+// StreamPeople выгружает клиентов
+func (s *Storage) StreamPeople(ctx context.Context, filter dto.PeopleFilter, fn func(models.Person) error) error {
+	const op = "storage.postgres.StreamPeople"
+
+	const query = `
+		SELECT id, full_name, phone
+		FROM person
+		WHERE $1 = '' OR full_name ILIKE '%' || $1 || '%'
+		ORDER BY full_name
+	`
+
+	rows, err := s.db.Query(ctx, query, filter.Name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.Person
+		if err := rows.Scan(&p.Id, &p.Name, &p.Phone); err != nil {
+			return fmt.Errorf("%s: scan: %w", op, err)
+		}
+		if err := fn(p); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// StreamPersonSubs выгружает абонементы клиентов
+func (s *Storage) StreamPersonSubs(ctx context.Context, filter dto.PersonSubFilter, fn func(dto.PersonSubResponse) error) error {
+	const op = "storage.postgres.StreamPersonSubs"
+
+	const query = `
+		SELECT
+			ps.number,
+			ps.person_id,
+			ps.subscription_id,
+			s.title AS subscription_title,
+			ps.subscription_price,
+			ps.start_date,
+			ps.end_date,
+			ps.status,
+			p.full_name AS person_name,
+			ps.discount,
+			ps.final_price,
+			s.freeze_days,
+			COALESCE((
+				SELECT SUM(EXTRACT(DAY FROM (COALESCE(freeze_end, NOW()) - freeze_start)))
+				FROM subscription_freeze
+				WHERE subscription_number = ps.number
+			), 0) as used_freeze_days
+		FROM person_subscriptions ps
+		JOIN person p ON ps.person_id = p.id
+		JOIN subscriptions s ON ps.subscription_id = s.id
+		WHERE ($1::bigint = 0 OR ps.person_id = $1)
+		  AND ($2 = '' OR p.full_name = $2)
+		  AND ($3 = '' OR ps.status = $3)
+		ORDER BY
+			ps.number ~ '[^0-9]',
+			CASE WHEN ps.number ~ '^[0-9]+$' THEN CAST(ps.number AS INTEGER) END DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, filter.PersonID, filter.PersonName, filter.Status)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sub dto.PersonSubResponse
+		err := rows.Scan(
+			&sub.Number,
+			&sub.PersonID,
+			&sub.SubscriptionID,
+			&sub.SubscriptionTitle,
+			&sub.SubscriptionPrice,
+			&sub.StartDate,
+			&sub.EndDate,
+			&sub.Status,
+			&sub.PersonName,
+			&sub.Discount,
+			&sub.FinalPrice,
+			&sub.FreezeDays,
+			&sub.UsedFreezeDays,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: scan: %w", op, err)
+		}
+		if err := fn(sub); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// StreamSingleVisits выгружает разовые посещения
+func (s *Storage) StreamSingleVisits(ctx context.Context, filter dto.SingleVisitFilter, fn func(models.SingleVisit) error) error {
+	const op = "storage.postgres.StreamSingleVisits"
+
+	const query = `
+		SELECT sv.id, sv.visit_date, sv.visited_at, sv.final_price, sv.tariff_id, COALESCE(t.title, '')
+		FROM single_visits sv
+		LEFT JOIN single_visit_tariffs t ON t.id = sv.tariff_id
+		WHERE ($1::date IS NULL OR sv.visit_date >= $1)
+		  AND ($2::date IS NULL OR sv.visit_date <= $2)
+		ORDER BY sv.visit_date DESC, sv.id DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, nullDate(filter.From), nullDate(filter.To))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.SingleVisit
+		if err := rows.Scan(&v.Id, &v.VisitDate, &v.VisitedAt, &v.FinalPrice, &v.TariffID, &v.TariffTitle); err != nil {
+			return fmt.Errorf("%s: scan: %w", op, err)
+		}
+		if err := fn(v); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// StreamFreezes выгружает заморозки абонементов
+func (s *Storage) StreamFreezes(ctx context.Context, filter dto.FreezeFilter, fn func(models.SubscriptionFreeze) error) error {
+	const op = "storage.postgres.StreamFreezes"
+
+	const query = `
+		SELECT sf.id, sf.subscription_number, sf.freeze_start, sf.freeze_end, COALESCE(sf.days_used, 0), sf.created_at
+		FROM subscription_freeze sf
+		JOIN person_subscriptions ps ON ps.number = sf.subscription_number
+		WHERE NOT $1 OR ps.status = 'frozen'
+		ORDER BY sf.created_at DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, filter.ActiveOnly)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var f models.SubscriptionFreeze
+		var freezeEnd *time.Time
+		if err := rows.Scan(&f.ID, &f.SubscriptionNumber, &f.FreezeStart, &freezeEnd, &f.DaysUsed, &f.CreatedAt); err != nil {
+			return fmt.Errorf("%s: scan: %w", op, err)
+		}
+		if freezeEnd != nil {
+			f.FreezeEnd = *freezeEnd
+		}
+		if err := fn(f); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// nullDate превращает нулевую дату в NULL для необязательных фильтров
+func nullDate(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const freezeRequestColumns = `
+	fr.id, fr.subscription_number, p.id, p.full_name, fr.freeze_start,
+	COALESCE(fr.comment, ''), fr.status, fr.created_at, fr.decided_at
+`
+
+func scanFreezeRequest(row pgx.Row) (models.FreezeRequest, error) {
+	var r models.FreezeRequest
+	err := row.Scan(
+		&r.ID,
+		&r.SubscriptionNumber,
+		&r.PersonID,
+		&r.PersonName,
+		&r.FreezeStart,
+		&r.Comment,
+		&r.Status,
+		&r.CreatedAt,
+		&r.DecidedAt,
+	)
+	return r, err
+}
+
+// SaveFreezeRequest создает заявку на заморозку. На абонемент допускается одна необработанная заявка.
+func (s *Storage) SaveFreezeRequest(ctx context.Context, req models.FreezeRequest) (int, error) {
+	const op = "storage.postgres.SaveFreezeRequest"
+
+	query := `
+		INSERT INTO freeze_requests (subscription_number, freeze_start, comment)
+		VALUES ($1, $2, NULLIF($3, ''))
+		RETURNING id
+	`
+
+	var id int
+	if err := s.db.QueryRow(ctx, query, req.SubscriptionNumber, req.FreezeStart, req.Comment).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrFreezeRequestExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GetFreezeRequest возвращает заявку по id
+func (s *Storage) GetFreezeRequest(ctx context.Context, id int) (models.FreezeRequest, error) {
+	const op = "storage.postgres.GetFreezeRequest"
+
+	query := `
+		SELECT ` + freezeRequestColumns + `
+		FROM freeze_requests fr
+		JOIN person_subscriptions ps ON ps.number = fr.subscription_number
+		JOIN person p ON p.id = ps.person_id
+		WHERE fr.id = $1
+	`
+
+	req, err := scanFreezeRequest(s.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FreezeRequest{}, fmt.Errorf("%s: %w", op, storage.ErrFreezeRequestNotFound)
+		}
+		return models.FreezeRequest{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return req, nil
+}
+
+// FindFreezeRequests возвращает заявки, новые первыми
+func (s *Storage) FindFreezeRequests(ctx context.Context, filter dto.FreezeRequestFilter) ([]models.FreezeRequest, error) {
+	const op = "storage.postgres.FindFreezeRequests"
+
+	query := `
+		SELECT ` + freezeRequestColumns + `
+		FROM freeze_requests fr
+		JOIN person_subscriptions ps ON ps.number = fr.subscription_number
+		JOIN person p ON p.id = ps.person_id
+		WHERE ($1::int = 0 OR p.id = $1::int)
+		  AND ($2 = '' OR fr.status = $2)
+		ORDER BY fr.created_at DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, filter.PersonID, filter.Status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	requests := make([]models.FreezeRequest, 0)
+	for rows.Next() {
+		req, err := scanFreezeRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		requests = append(requests, req)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return requests, nil
+}
+
+// DecideFreezeRequest фиксирует решение по заявке; обработанные заявки не меняются
+func (s *Storage) DecideFreezeRequest(ctx context.Context, id int, status string) error {
+	const op = "storage.postgres.DecideFreezeRequest"
+
+	query := `
+		UPDATE freeze_requests
+		SET status = $2, decided_at = NOW()
+		WHERE id = $1 AND status = $3
+	`
+
+	result, err := s.db.Exec(ctx, query, id, status, dto.FreezeRequestPending)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrFreezeRequestNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// FindPeopleByNameAndPhone возвращает уже существующих клиентов с такими же парами (ФИО, телефон).
+// ФИО сравниваются без учета регистра и пробелов по краям, как при поиске дублей внутри файла
+func (s *Storage) FindPeopleByNameAndPhone(ctx context.Context, people []models.Person) ([]models.Person, error) {
+	const op = "storage.postgres.FindPeopleByNameAndPhone"
+
+	names := make([]string, 0, len(people))
+	phones := make([]string, 0, len(people))
+	for _, p := range people {
+		names = append(names, p.Name)
+		phones = append(phones, p.Phone)
+	}
+
+	const query = `
+		SELECT p.id, p.full_name, p.phone
+		FROM person p
+		JOIN unnest($1::text[], $2::text[]) AS k(full_name, phone)
+		  ON lower(btrim(p.full_name)) = lower(btrim(k.full_name)) AND p.phone = k.phone
+	`
+
+	rows, err := s.db.Query(ctx, query, names, phones)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var found []models.Person
+	for rows.Next() {
+		var p models.Person
+		if err := rows.Scan(&p.Id, &p.Name, &p.Phone); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		found = append(found, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return found, nil
+}
+
+// ExistingPersonIDs возвращает те id из списка, для которых есть клиент
+func (s *Storage) ExistingPersonIDs(ctx context.Context, ids []int) ([]int, error) {
+	const op = "storage.postgres.ExistingPersonIDs"
+
+	const query = `SELECT id FROM person WHERE id = ANY($1)`
+
+	rows, err := s.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var existing []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		existing = append(existing, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return existing, nil
+}
+
+// ExistingPersonSubNumbers возвращает номера абонементов из списка, которые уже заняты
+func (s *Storage) ExistingPersonSubNumbers(ctx context.Context, numbers []string) ([]string, error) {
+	const op = "storage.postgres.ExistingPersonSubNumbers"
+
+	const query = `SELECT number FROM person_subscriptions WHERE number = ANY($1)`
+
+	rows, err := s.db.Query(ctx, query, numbers)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var existing []string
+	for rows.Next() {
+		var number string
+		if err := rows.Scan(&number); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		existing = append(existing, number)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return existing, nil
+}
+
+// ImportPeople сохраняет клиентов в одной транзакции: либо все, либо ни одного.
+// Событий для вебхуков импорт не пишет: это перенос исторических данных, а не новые клиенты.
+func (s *Storage) ImportPeople(ctx context.Context, people []models.Person) (int, error) {
+	const op = "storage.postgres.ImportPeople"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	const query = `INSERT INTO person (full_name, phone) VALUES ($1, $2)`
+
+	for _, p := range people {
+		if _, err := tx.Exec(ctx, query, p.Name, p.Phone); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return 0, fmt.Errorf("%s: %w", op, storage.ErrUserExists)
+			}
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return len(people), nil
+}
+
+// ImportPersonSubs сохраняет абонементы клиентов в одной транзакции: либо все, либо ни одного.
+// Как и ImportPeople, событий для вебхуков не пишет.
+func (s *Storage) ImportPersonSubs(ctx context.Context, subs []models.PersonSubscription) (int, error) {
+	const op = "storage.postgres.ImportPersonSubs"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	const query = `
+		INSERT INTO person_subscriptions (
+			number, person_id, subscription_id, subscription_price, start_date, end_date, status, discount, final_price
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for _, sub := range subs {
+		_, err := tx.Exec(ctx, query,
+			sub.Number,
+			sub.PersonID,
+			sub.SubscriptionID,
+			sub.SubscriptionPrice,
+			sub.StartDate,
+			sub.EndDate,
+			sub.Status,
+			sub.Discount,
+			sub.FinalPrice,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				switch pgErr.Code {
+				case "23505":
+					return 0, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+				case "23503":
+					return 0, fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+				}
+			}
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return len(subs), nil
+}
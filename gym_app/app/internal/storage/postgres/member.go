@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// GetMemberPersonID возвращает клиента, к которому привязан аккаунт SSO
+func (s *Storage) GetMemberPersonID(ctx context.Context, userID int64) (int, error) {
+	const op = "storage.postgres.GetMemberPersonID"
+
+	var personID int
+	err := s.db.QueryRow(ctx, `SELECT person_id FROM member_accounts WHERE user_id = $1`, userID).Scan(&personID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrMemberNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return personID, nil
+}
+
+// LinkMemberAccount привязывает аккаунт SSO к клиенту. У клиента может быть только один аккаунт.
+func (s *Storage) LinkMemberAccount(ctx context.Context, userID int64, personID int) error {
+	const op = "storage.postgres.LinkMemberAccount"
+
+	_, err := s.db.Exec(ctx, `INSERT INTO member_accounts (user_id, person_id) VALUES ($1, $2)`, userID, personID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return fmt.Errorf("%s: %w", op, storage.ErrMemberExists)
+			case "23503":
+				return fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReserveMemberRegistration занимает карточку на время регистрации в портале.
+// Просроченную бронь (регистрация оборвалась, не сняв ее) можно занять снова.
+func (s *Storage) ReserveMemberRegistration(ctx context.Context, personID int, ttl time.Duration) error {
+	const op = "storage.postgres.ReserveMemberRegistration"
+
+	query := `
+		INSERT INTO member_registrations (person_id, expires_at)
+		VALUES ($1, NOW() + make_interval(secs => $2))
+		ON CONFLICT (person_id) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE member_registrations.expires_at < NOW()
+	`
+
+	result, err := s.db.Exec(ctx, query, personID, ttl.Seconds())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrRegistrationPending)
+	}
+
+	return nil
+}
+
+// ReleaseMemberRegistration снимает бронь карточки после регистрации, удачной или нет
+func (s *Storage) ReleaseMemberRegistration(ctx context.Context, personID int) error {
+	const op = "storage.postgres.ReleaseMemberRegistration"
+
+	if _, err := s.db.Exec(ctx, `DELETE FROM member_registrations WHERE person_id = $1`, personID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UnlinkMemberAccount снимает привязку аккаунта
+func (s *Storage) UnlinkMemberAccount(ctx context.Context, userID int64) error {
+	const op = "storage.postgres.UnlinkMemberAccount"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM member_accounts WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrMemberNotFound)
+	}
+
+	return nil
+}
+
+// FindVisitsByPerson возвращает посещения клиента по всем его абонементам за период, новые первыми
+func (s *Storage) FindVisitsByPerson(ctx context.Context, personID int, from, to time.Time) ([]models.Visit, error) {
+	const op = "storage.postgres.FindVisitsByPerson"
+
+	query := `
+		SELECT v.id, v.subscription_number, p.id, p.full_name, v.checked_in_at, v.checked_out_at
+		FROM visits v
+		JOIN person_subscriptions ps ON ps.number = v.subscription_number
+		JOIN person p ON p.id = ps.person_id
+		WHERE ps.person_id = $1
+		  AND v.checked_in_at >= $2
+		  AND v.checked_in_at < $3
+		ORDER BY v.checked_in_at DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, personID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	visits := make([]models.Visit, 0)
+	for rows.Next() {
+		var v models.Visit
+		if err := rows.Scan(&v.ID, &v.SubscriptionNumber, &v.PersonID, &v.PersonName, &v.CheckedInAt, &v.CheckedOutAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		visits = append(visits, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return visits, nil
+}
+
+// PersonHasMemberAccount проверяет, есть ли у клиента привязанный аккаунт
+func (s *Storage) PersonHasMemberAccount(ctx context.Context, personID int) (bool, error) {
+	const op = "storage.postgres.PersonHasMemberAccount"
+
+	var exists bool
+	err := s.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM member_accounts WHERE person_id = $1)`, personID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
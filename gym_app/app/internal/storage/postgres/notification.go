@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// FindExpiryTargets возвращает клиентов, чей активный абонемент заканчивается в ближайшие days дней.
+// Пропускаются отказавшиеся от рассылки, уже продлившие абонемент, замороженные
+// и те, кому напоминание по этому абонементу уже доставлено.
+func (s *Storage) FindExpiryTargets(ctx context.Context, days int) ([]dto.NotificationTarget, error) {
+	const op = "storage.postgres.FindExpiryTargets"
+
+	query := `
+		SELECT
+			p.id,
+			p.full_name,
+			p.phone,
+			COALESCE(pc.email, ''),
+			pc.telegram_chat_id,
+			ps.number,
+			s.title,
+			ps.end_date
+		FROM person_subscriptions ps
+		JOIN person p ON p.id = ps.person_id
+		JOIN subscriptions s ON s.id = ps.subscription_id
+		LEFT JOIN person_contacts pc ON pc.person_id = p.id
+		WHERE ps.status = 'active'
+		  AND ps.end_date >= CURRENT_DATE
+		  AND ps.end_date <= CURRENT_DATE + $1::int
+		  AND NOT COALESCE(pc.opted_out, FALSE)
+		  AND NOT EXISTS (
+			SELECT 1 FROM subscription_freeze f
+			WHERE f.subscription_number = ps.number AND f.freeze_end IS NULL
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM person_subscriptions next
+			WHERE next.person_id = ps.person_id
+			  AND next.number <> ps.number
+			  AND next.end_date > ps.end_date
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM notification_log nl
+			WHERE nl.person_id = p.id
+			  AND nl.kind = $2
+			  AND nl.ref = ps.number
+			  AND nl.status = $3
+		  )
+		ORDER BY ps.end_date, p.full_name
+	`
+
+	rows, err := s.db.Query(ctx, query, days, dto.NotificationExpiry, dto.NotificationSent)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	targets := make([]dto.NotificationTarget, 0)
+	for rows.Next() {
+		var t dto.NotificationTarget
+		err := rows.Scan(
+			&t.PersonID,
+			&t.PersonName,
+			&t.Phone,
+			&t.Email,
+			&t.TelegramChatID,
+			&t.SubscriptionNumber,
+			&t.SubscriptionTitle,
+			&t.EndDate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		targets = append(targets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return targets, nil
+}
+
+// FindBirthdayTargets возвращает именинников на дату day, которых еще не поздравили с ref.
+// В невисокосный год родившихся 29 февраля поздравляем 28-го.
+func (s *Storage) FindBirthdayTargets(ctx context.Context, day time.Time, ref string) ([]dto.NotificationTarget, error) {
+	const op = "storage.postgres.FindBirthdayTargets"
+
+	year := day.Year()
+	isLeap := year%4 == 0 && (year%100 != 0 || year%400 == 0)
+	includeLeapDay := !isLeap && day.Month() == time.February && day.Day() == 28
+
+	query := `
+		SELECT
+			p.id,
+			p.full_name,
+			p.phone,
+			COALESCE(pc.email, ''),
+			pc.telegram_chat_id
+		FROM person_contacts pc
+		JOIN person p ON p.id = pc.person_id
+		WHERE pc.birth_date IS NOT NULL
+		  AND NOT pc.opted_out
+		  AND (
+			(EXTRACT(MONTH FROM pc.birth_date) = $1 AND EXTRACT(DAY FROM pc.birth_date) = $2)
+			OR ($3::bool AND EXTRACT(MONTH FROM pc.birth_date) = 2 AND EXTRACT(DAY FROM pc.birth_date) = 29)
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM notification_log nl
+			WHERE nl.person_id = p.id
+			  AND nl.kind = $4
+			  AND nl.ref = $5
+			  AND nl.status = $6
+		  )
+		ORDER BY p.full_name
+	`
+
+	rows, err := s.db.Query(ctx, query,
+		int(day.Month()), day.Day(), includeLeapDay,
+		dto.NotificationBirthday, ref, dto.NotificationSent,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	targets := make([]dto.NotificationTarget, 0)
+	for rows.Next() {
+		var t dto.NotificationTarget
+		if err := rows.Scan(&t.PersonID, &t.PersonName, &t.Phone, &t.Email, &t.TelegramChatID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		targets = append(targets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return targets, nil
+}
+
+// FindSubscriptionTarget возвращает владельца абонемента с контактами.
+// Если клиент отказался от рассылки, возвращает storage.ErrPersonNotFound.
+func (s *Storage) FindSubscriptionTarget(ctx context.Context, number string) (dto.NotificationTarget, error) {
+	const op = "storage.postgres.FindSubscriptionTarget"
+
+	query := `
+		SELECT
+			p.id,
+			p.full_name,
+			p.phone,
+			COALESCE(pc.email, ''),
+			pc.telegram_chat_id,
+			ps.number,
+			s.title,
+			ps.end_date
+		FROM person_subscriptions ps
+		JOIN person p ON p.id = ps.person_id
+		JOIN subscriptions s ON s.id = ps.subscription_id
+		LEFT JOIN person_contacts pc ON pc.person_id = p.id
+		WHERE ps.number = $1
+		  AND NOT COALESCE(pc.opted_out, FALSE)
+	`
+
+	var t dto.NotificationTarget
+	err := s.db.QueryRow(ctx, query, number).Scan(
+		&t.PersonID,
+		&t.PersonName,
+		&t.Phone,
+		&t.Email,
+		&t.TelegramChatID,
+		&t.SubscriptionNumber,
+		&t.SubscriptionTitle,
+		&t.EndDate,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.NotificationTarget{}, fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+		}
+		return dto.NotificationTarget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return t, nil
+}
+
+// SaveNotification записывает попытку доставки в журнал
+func (s *Storage) SaveNotification(ctx context.Context, n models.Notification) error {
+	const op = "storage.postgres.SaveNotification"
+
+	query := `
+		INSERT INTO notification_log (person_id, kind, ref, channel, recipient, message, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+	`
+
+	_, err := s.db.Exec(ctx, query, n.PersonID, n.Kind, n.Ref, n.Channel, n.Recipient, n.Message, n.Status, n.Error)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindNotifications возвращает журнал доставки, новые записи первыми
+func (s *Storage) FindNotifications(ctx context.Context, filter dto.NotificationFilter) ([]models.Notification, error) {
+	const op = "storage.postgres.FindNotifications"
+
+	query := `
+		SELECT id, person_id, kind, ref, channel, recipient, message, status, COALESCE(error, ''), created_at
+		FROM notification_log
+		WHERE ($1::int = 0 OR person_id = $1::int)
+		  AND ($2 = '' OR kind = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(ctx, query, filter.PersonID, filter.Kind, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	notifications := make([]models.Notification, 0)
+	for rows.Next() {
+		var n models.Notification
+		err := rows.Scan(
+			&n.ID,
+			&n.PersonID,
+			&n.Kind,
+			&n.Ref,
+			&n.Channel,
+			&n.Recipient,
+			&n.Message,
+			&n.Status,
+			&n.Error,
+			&n.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+// GetPersonContacts возвращает контакты клиента; если они не заполнены — пустые значения
+func (s *Storage) GetPersonContacts(ctx context.Context, personID int) (models.PersonContacts, error) {
+	const op = "storage.postgres.GetPersonContacts"
+
+	query := `
+		SELECT p.id, COALESCE(pc.email, ''), pc.telegram_chat_id, pc.birth_date, COALESCE(pc.opted_out, FALSE)
+		FROM person p
+		LEFT JOIN person_contacts pc ON pc.person_id = p.id
+		WHERE p.id = $1
+	`
+
+	var contacts models.PersonContacts
+	err := s.db.QueryRow(ctx, query, personID).Scan(
+		&contacts.PersonID,
+		&contacts.Email,
+		&contacts.TelegramChatID,
+		&contacts.BirthDate,
+		&contacts.OptedOut,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PersonContacts{}, fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+		}
+		return models.PersonContacts{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return contacts, nil
+}
+
+// SavePersonContacts создает или полностью перезаписывает контакты клиента
+func (s *Storage) SavePersonContacts(ctx context.Context, contacts models.PersonContacts) error {
+	const op = "storage.postgres.SavePersonContacts"
+
+	query := `
+		INSERT INTO person_contacts (person_id, email, telegram_chat_id, birth_date, opted_out)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5)
+		ON CONFLICT (person_id) DO UPDATE SET
+			email = EXCLUDED.email,
+			telegram_chat_id = EXCLUDED.telegram_chat_id,
+			birth_date = EXCLUDED.birth_date,
+			opted_out = EXCLUDED.opted_out
+	`
+
+	_, err := s.db.Exec(ctx, query,
+		contacts.PersonID,
+		contacts.Email,
+		contacts.TelegramChatID,
+		contacts.BirthDate,
+		contacts.OptedOut,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, storage.ErrPersonNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
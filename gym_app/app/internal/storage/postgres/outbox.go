@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// insertOutboxEvent записывает событие в outbox в транзакции изменения:
+// событие появляется только если изменение закоммичено.
+func insertOutboxEvent(ctx context.Context, tx pgx.Tx, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", eventType, err)
+	}
+
+	const query = `INSERT INTO outbox_events (event_type, payload) VALUES ($1, $2)`
+	if _, err := tx.Exec(ctx, query, eventType, data); err != nil {
+		return fmt.Errorf("insert %s event: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
 	"github.com/Muaz717/gym_app/app/internal/storage"
 	"github.com/jackc/pgx/v5"
@@ -16,8 +17,16 @@ func (s *Storage) SavePerson(
 ) (int, error) {
 	const op = "postgres.savePerson"
 
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
 	query := `INSERT INTO person(full_name, phone) VALUES($1, $2) RETURNING id`
-	row := s.db.QueryRow(ctx, query, person.Name, person.Phone)
+	row := tx.QueryRow(ctx, query, person.Name, person.Phone)
 
 	var personId int
 	if err := row.Scan(&personId); err != nil {
@@ -32,6 +41,15 @@ func (s *Storage) SavePerson(
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
+	event := dto.PersonCreatedEvent{PersonID: personId, Name: person.Name, Phone: person.Phone}
+	if err := insertOutboxEvent(ctx, tx, dto.EventPersonCreated, event); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
 	return personId, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/Muaz717/gym_app/app/internal/storage"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"time"
 )
 
 // Добавляем поля subscription_price, final_price, freeze_days и used_freeze_days в запросы/запись
@@ -24,8 +25,16 @@ func (s *Storage) AddPersonSub(ctx context.Context, personSub models.PersonSubsc
 		RETURNING number
 	`
 
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
 	var number string
-	err := s.db.QueryRow(ctx, query,
+	err = tx.QueryRow(ctx, query,
 		personSub.Number,
 		personSub.PersonID,
 		personSub.SubscriptionID,
@@ -50,6 +59,24 @@ func (s *Storage) AddPersonSub(ctx context.Context, personSub models.PersonSubsc
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
+	event := dto.SubscriptionSoldEvent{
+		Number:         number,
+		PersonID:       personSub.PersonID,
+		SubscriptionID: personSub.SubscriptionID,
+		StartDate:      personSub.StartDate,
+		EndDate:        personSub.EndDate,
+		Price:          personSub.SubscriptionPrice,
+		Discount:       personSub.Discount,
+		FinalPrice:     personSub.FinalPrice,
+	}
+	if err := insertOutboxEvent(ctx, tx, dto.EventSubscriptionSold, event); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("%s: commit: %w", op, err)
+	}
+
 	return number, nil
 }
 
@@ -343,15 +370,38 @@ func (s *Storage) FindPersonSubByPersonId(ctx context.Context, personId int) ([]
 func (s *Storage) UpdatePersonSubStatus(ctx context.Context, number string, status string) error {
 	const op = "storage.postgres.UpdatePersonSubStatus"
 
-	query := `UPDATE person_subscriptions SET status = $1 WHERE number = $2`
-
-	result, err := s.db.Exec(ctx, query, status, number)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Событие об окончании пишем только при переходе в expired, а не при повторной установке
+	var previous string
+	const selectStatus = `SELECT status FROM person_subscriptions WHERE number = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, selectStatus, number).Scan(&previous); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrSubscriptionNotFound)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("%s: %w", op, storage.ErrSubscriptionNotFound)
+	const updateStatus = `UPDATE person_subscriptions SET status = $1 WHERE number = $2`
+	if _, err := tx.Exec(ctx, updateStatus, status, number); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if status == "expired" && previous != "expired" {
+		event := dto.SubscriptionStatusEvent{Number: number, Date: time.Now()}
+		if err := insertOutboxEvent(ctx, tx, dto.EventSubscriptionExpired, event); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: commit: %w", op, err)
 	}
 
 	return nil
@@ -3,27 +3,56 @@ package postgres
 import (
 	"context"
 	"errors"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
 	"github.com/jackc/pgx/v5"
 	"time"
 )
 
-// AddSingleVisit inserts a new single visit into the database.
-func (s *Storage) AddSingleVisit(ctx context.Context, singleVis models.SingleVisit) error {
+// AddSingleVisit inserts a new single visit into the database and returns its ID.
+func (s *Storage) AddSingleVisit(ctx context.Context, singleVis models.SingleVisit) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
 	const query = `
-		INSERT INTO single_visits (visit_date, final_price)
-		VALUES ($1, $2)
+		INSERT INTO single_visits (visit_date, final_price, tariff_id, visited_at)
+		VALUES ($1, $2, $3, CASE WHEN $1::date = CURRENT_DATE THEN NOW() END)
+		RETURNING id
 	`
-	_, err := s.db.Exec(ctx, query, singleVis.VisitDate, singleVis.FinalPrice)
-	return err
+	var id int
+	if err := tx.QueryRow(ctx, query, singleVis.VisitDate, singleVis.FinalPrice, singleVis.TariffID).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	event := dto.SingleVisitAddedEvent{
+		ID:         id,
+		VisitDate:  singleVis.VisitDate,
+		TariffID:   singleVis.TariffID,
+		FinalPrice: singleVis.FinalPrice,
+	}
+	if err := insertOutboxEvent(ctx, tx, dto.EventSingleVisitAdded, event); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return id, nil
 }
 
 // GetAllSingleVisits retrieves all single visits from the database.
 func (s *Storage) GetAllSingleVisits(ctx context.Context) ([]models.SingleVisit, error) {
 	const query = `
-		SELECT id, visit_date, final_price
-		FROM single_visits
-		ORDER BY visit_date DESC, id DESC
+		SELECT sv.id, sv.visit_date, sv.visited_at, sv.final_price, sv.tariff_id, COALESCE(t.title, '')
+		FROM single_visits sv
+		LEFT JOIN single_visit_tariffs t ON t.id = sv.tariff_id
+		ORDER BY sv.visit_date DESC, sv.id DESC
 	`
 	rows, err := s.db.Query(ctx, query)
 	if err != nil {
@@ -34,7 +63,7 @@ func (s *Storage) GetAllSingleVisits(ctx context.Context) ([]models.SingleVisit,
 	var visits []models.SingleVisit
 	for rows.Next() {
 		var v models.SingleVisit
-		err := rows.Scan(&v.Id, &v.VisitDate, &v.FinalPrice)
+		err := rows.Scan(&v.Id, &v.VisitDate, &v.VisitedAt, &v.FinalPrice, &v.TariffID, &v.TariffTitle)
 		if err != nil {
 			return nil, err
 		}
@@ -46,14 +75,15 @@ func (s *Storage) GetAllSingleVisits(ctx context.Context) ([]models.SingleVisit,
 // GetSingleVisitById retrieves a single visit by its ID.
 func (s *Storage) GetSingleVisitById(ctx context.Context, id int) (models.SingleVisit, error) {
 	const query = `
-		SELECT id, visit_date, final_price
-		FROM single_visits
-		WHERE id = $1
+		SELECT sv.id, sv.visit_date, sv.visited_at, sv.final_price, sv.tariff_id, COALESCE(t.title, '')
+		FROM single_visits sv
+		LEFT JOIN single_visit_tariffs t ON t.id = sv.tariff_id
+		WHERE sv.id = $1
 	`
 	row := s.db.QueryRow(ctx, query, id)
 
 	var v models.SingleVisit
-	err := row.Scan(&v.Id, &v.VisitDate, &v.FinalPrice)
+	err := row.Scan(&v.Id, &v.VisitDate, &v.VisitedAt, &v.FinalPrice, &v.TariffID, &v.TariffTitle)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.SingleVisit{}, nil // No visit found with the given ID
@@ -66,10 +96,11 @@ func (s *Storage) GetSingleVisitById(ctx context.Context, id int) (models.Single
 // GetSingleVisitsByDay retrieves all single visits for the specified date.
 func (s *Storage) GetSingleVisitsByDay(ctx context.Context, date time.Time) ([]models.SingleVisit, error) {
 	const query = `
-		SELECT id, visit_date, final_price
-		FROM single_visits
-		WHERE visit_date = $1
-		ORDER BY id DESC
+		SELECT sv.id, sv.visit_date, sv.visited_at, sv.final_price, sv.tariff_id, COALESCE(t.title, '')
+		FROM single_visits sv
+		LEFT JOIN single_visit_tariffs t ON t.id = sv.tariff_id
+		WHERE sv.visit_date = $1
+		ORDER BY sv.id DESC
 	`
 	rows, err := s.db.Query(ctx, query, date.Format("2006-01-02"))
 	if err != nil {
@@ -80,7 +111,7 @@ func (s *Storage) GetSingleVisitsByDay(ctx context.Context, date time.Time) ([]m
 	var visits []models.SingleVisit
 	for rows.Next() {
 		var v models.SingleVisit
-		err := rows.Scan(&v.Id, &v.VisitDate, &v.FinalPrice)
+		err := rows.Scan(&v.Id, &v.VisitDate, &v.VisitedAt, &v.FinalPrice, &v.TariffID, &v.TariffTitle)
 		if err != nil {
 			return nil, err
 		}
@@ -92,10 +123,11 @@ func (s *Storage) GetSingleVisitsByDay(ctx context.Context, date time.Time) ([]m
 // GetSingleVisitsByPeriod retrieves all single visits within the specified period (inclusive).
 func (s *Storage) GetSingleVisitsByPeriod(ctx context.Context, from, to time.Time) ([]models.SingleVisit, error) {
 	const query = `
-		SELECT id, visit_date, final_price
-		FROM single_visits
-		WHERE visit_date >= $1 AND visit_date <= $2
-		ORDER BY visit_date DESC, id DESC
+		SELECT sv.id, sv.visit_date, sv.visited_at, sv.final_price, sv.tariff_id, COALESCE(t.title, '')
+		FROM single_visits sv
+		LEFT JOIN single_visit_tariffs t ON t.id = sv.tariff_id
+		WHERE sv.visit_date >= $1 AND sv.visit_date <= $2
+		ORDER BY sv.visit_date DESC, sv.id DESC
 	`
 	rows, err := s.db.Query(ctx, query, from.Format("2006-01-02"), to.Format("2006-01-02"))
 	if err != nil {
@@ -106,7 +138,7 @@ func (s *Storage) GetSingleVisitsByPeriod(ctx context.Context, from, to time.Tim
 	var visits []models.SingleVisit
 	for rows.Next() {
 		var v models.SingleVisit
-		err := rows.Scan(&v.Id, &v.VisitDate, &v.FinalPrice)
+		err := rows.Scan(&v.Id, &v.VisitDate, &v.VisitedAt, &v.FinalPrice, &v.TariffID, &v.TariffTitle)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (s *Storage) SaveSingleVisitTariff(ctx context.Context, tariff models.SingleVisitTariff) (int, error) {
+	const op = "postgres.SaveSingleVisitTariff"
+
+	query := `INSERT INTO single_visit_tariffs(title, price, weekend_price) VALUES($1, $2, $3) RETURNING id`
+
+	var id int
+	err := s.db.QueryRow(ctx, query, tariff.Title, tariff.Price, tariff.WeekendPrice).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTariffExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) UpdateSingleVisitTariff(ctx context.Context, tariff models.SingleVisitTariff, tariffID int) (int, error) {
+	const op = "postgres.UpdateSingleVisitTariff"
+
+	query := `UPDATE single_visit_tariffs SET title = $1, price = $2, weekend_price = $3 WHERE id = $4 RETURNING id`
+
+	var id int
+	err := s.db.QueryRow(ctx, query, tariff.Title, tariff.Price, tariff.WeekendPrice, tariffID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTariffNotFound)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTariffExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) DeleteSingleVisitTariff(ctx context.Context, tariffID int) error {
+	const op = "postgres.DeleteSingleVisitTariff"
+
+	query := `DELETE FROM single_visit_tariffs WHERE id = $1`
+
+	result, err := s.db.Exec(ctx, query, tariffID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, storage.ErrTariffInUse)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTariffNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) FindAllSingleVisitTariffs(ctx context.Context) ([]models.SingleVisitTariff, error) {
+	const op = "postgres.FindAllSingleVisitTariffs"
+
+	query := `SELECT id, title, price, weekend_price FROM single_visit_tariffs ORDER BY id`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tariffs []models.SingleVisitTariff
+	for rows.Next() {
+		var t models.SingleVisitTariff
+		if err := rows.Scan(&t.ID, &t.Title, &t.Price, &t.WeekendPrice); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tariffs = append(tariffs, t)
+	}
+
+	return tariffs, rows.Err()
+}
+
+func (s *Storage) FindSingleVisitTariffById(ctx context.Context, tariffID int) (models.SingleVisitTariff, error) {
+	const op = "postgres.FindSingleVisitTariffById"
+
+	query := `SELECT id, title, price, weekend_price FROM single_visit_tariffs WHERE id = $1`
+
+	var t models.SingleVisitTariff
+	err := s.db.QueryRow(ctx, query, tariffID).Scan(&t.ID, &t.Title, &t.Price, &t.WeekendPrice)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.SingleVisitTariff{}, fmt.Errorf("%s: %w", op, storage.ErrTariffNotFound)
+		}
+		return models.SingleVisitTariff{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return t, nil
+}
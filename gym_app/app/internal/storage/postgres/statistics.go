@@ -235,7 +235,7 @@ func (s *Storage) SingleVisits(ctx context.Context, from, to time.Time) (int, er
 	`
 
 	var count int
-	err := s.db.QueryRow(ctx, query, from, to)
+	err := s.db.QueryRow(ctx, query, from, to).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("SingleVisits: %w", err)
 	}
@@ -255,3 +255,363 @@ func (s *Storage) SingleVisitsIncome(ctx context.Context) (float64, error) {
 	}
 	return income, nil
 }
+
+// DailyIncome возвращает выручку за день. Абонемент относится ко дню начала, как и в Income,
+// а сумма считается по цене на момент продажи, как и в RevenueReport.
+func (s *Storage) DailyIncome(ctx context.Context, day time.Time) (dto.DailyIncome, error) {
+	const query = `
+		SELECT
+			(SELECT COUNT(*) FROM person_subscriptions WHERE start_date = $1::date),
+			(SELECT COALESCE(SUM(subscription_price - discount), 0)
+			 FROM person_subscriptions
+			 WHERE start_date = $1::date),
+			(SELECT COUNT(*) FROM single_visits WHERE visit_date = $1::date),
+			(SELECT COALESCE(SUM(final_price), 0) FROM single_visits WHERE visit_date = $1::date)
+	`
+
+	income := dto.DailyIncome{Date: day}
+	err := s.db.QueryRow(ctx, query, day).Scan(
+		&income.SubscriptionsSold,
+		&income.SubscriptionsIncome,
+		&income.SingleVisitsSold,
+		&income.SingleVisitsIncome,
+	)
+	if err != nil {
+		return dto.DailyIncome{}, fmt.Errorf("DailyIncome: %w", err)
+	}
+	income.Total = income.SubscriptionsIncome + income.SingleVisitsIncome
+
+	return income, nil
+}
+
+// SingleVisitsByTariff возвращает количество и доход разовых посещений за период с разбивкой по тарифам
+func (s *Storage) SingleVisitsByTariff(ctx context.Context, from, to time.Time) ([]dto.SingleVisitTariffStat, error) {
+	const query = `
+		SELECT
+			sv.tariff_id,
+			COALESCE(t.title, 'Без тарифа') as tariff_title,
+			COUNT(*) as visits_count,
+			COALESCE(SUM(sv.final_price), 0) as income
+		FROM single_visits sv
+		LEFT JOIN single_visit_tariffs t ON t.id = sv.tariff_id
+		WHERE sv.visit_date >= $1 AND sv.visit_date <= $2
+		GROUP BY sv.tariff_id, t.title
+		ORDER BY sv.tariff_id NULLS LAST
+	`
+
+	rows, err := s.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("SingleVisitsByTariff: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]dto.SingleVisitTariffStat, 0)
+	for rows.Next() {
+		var stat dto.SingleVisitTariffStat
+		if err := rows.Scan(&stat.TariffID, &stat.TariffTitle, &stat.Count, &stat.Income); err != nil {
+			return nil, fmt.Errorf("SingleVisitsByTariff rows.Scan: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SingleVisitsByTariff rows.Err: %w", err)
+	}
+
+	return stats, nil
+}
+
+// renewedCondition — абонемент ps продлен: у клиента есть абонемент, начатый позже ps и не позднее
+// windowDays ($3) дней после его окончания. Общее определение для Retention и MonthlyChurn
+const renewedCondition = `EXISTS (
+	SELECT 1
+	FROM person_subscriptions nx
+	WHERE nx.person_id = ps.person_id
+	  AND nx.number <> ps.number
+	  AND nx.start_date > ps.start_date
+	  AND nx.start_date <= ps.end_date + $3 * INTERVAL '1 day'
+)`
+
+// Retention возвращает количество абонементов, закончившихся за период, и сколько из них продлено
+// (см. renewedCondition). Абонементы, у которых окно продления еще не закрылось, не учитываются
+func (s *Storage) Retention(ctx context.Context, from, to time.Time, windowDays int) (dto.RetentionStat, error) {
+	const query = `
+		SELECT
+			COUNT(*) as expired,
+			COUNT(*) FILTER (WHERE ` + renewedCondition + `) as renewed
+		FROM person_subscriptions ps
+		WHERE ps.end_date >= $1 AND ps.end_date <= $2
+		  AND ps.end_date + $3 * INTERVAL '1 day' < CURRENT_DATE
+	`
+
+	stat := dto.RetentionStat{From: from, To: to, WindowDays: windowDays}
+	err := s.db.QueryRow(ctx, query, from, to, windowDays).Scan(&stat.Expired, &stat.Renewed)
+	if err != nil {
+		return dto.RetentionStat{}, fmt.Errorf("Retention: %w", err)
+	}
+
+	return stat, nil
+}
+
+// MonthlyChurn возвращает по месяцам количество активных клиентов на начало месяца и ушедших за месяц.
+// Клиент считается ушедшим, если его абонемент закончился в этом месяце и не был продлен в течение windowDays дней.
+// Пока окно продления не закрылось, клиент ушедшим не считается
+func (s *Storage) MonthlyChurn(ctx context.Context, from, to time.Time, windowDays int) ([]dto.ChurnStat, error) {
+	const query = `
+		WITH months AS (
+			SELECT generate_series(
+				DATE_TRUNC('month', $1::date),
+				DATE_TRUNC('month', $2::date),
+				INTERVAL '1 month'
+			)::date as month
+		)
+		SELECT
+			m.month,
+			(
+				SELECT COUNT(DISTINCT ps.person_id)
+				FROM person_subscriptions ps
+				WHERE ps.start_date <= m.month AND ps.end_date >= m.month
+			) as active_at_start,
+			(
+				SELECT COUNT(DISTINCT ps.person_id)
+				FROM person_subscriptions ps
+				WHERE ps.end_date >= m.month
+				  AND ps.end_date < m.month + INTERVAL '1 month'
+				  AND ps.end_date + $3 * INTERVAL '1 day' < CURRENT_DATE
+				  AND NOT ` + renewedCondition + `
+			) as churned
+		FROM months m
+		ORDER BY m.month
+	`
+
+	rows, err := s.db.Query(ctx, query, from, to, windowDays)
+	if err != nil {
+		return nil, fmt.Errorf("MonthlyChurn: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]dto.ChurnStat, 0)
+	for rows.Next() {
+		var stat dto.ChurnStat
+		if err := rows.Scan(&stat.Month, &stat.ActiveAtStart, &stat.Churned); err != nil {
+			return nil, fmt.Errorf("MonthlyChurn rows.Scan: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("MonthlyChurn rows.Err: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Cohorts возвращает когорты клиентов по месяцу первой покупки за период.
+// Для каждой когорты считается количество клиентов с действующим абонементом в каждом следующем месяце до to
+func (s *Storage) Cohorts(ctx context.Context, from, to time.Time) ([]dto.CohortStat, error) {
+	const query = `
+		WITH first_purchase AS (
+			SELECT person_id, DATE_TRUNC('month', MIN(start_date))::date as cohort
+			FROM person_subscriptions
+			GROUP BY person_id
+		),
+		activity AS (
+			SELECT DISTINCT ps.person_id, gs::date as month
+			FROM person_subscriptions ps,
+			     generate_series(DATE_TRUNC('month', ps.start_date), DATE_TRUNC('month', ps.end_date), INTERVAL '1 month') gs
+		)
+		SELECT
+			f.cohort,
+			(EXTRACT(YEAR FROM AGE(a.month, f.cohort)) * 12 + EXTRACT(MONTH FROM AGE(a.month, f.cohort)))::int as month_offset,
+			COUNT(DISTINCT a.person_id) as active
+		FROM first_purchase f
+		JOIN activity a ON a.person_id = f.person_id
+		WHERE f.cohort >= DATE_TRUNC('month', $1::date)
+		  AND f.cohort <= DATE_TRUNC('month', $2::date)
+		  AND a.month >= f.cohort
+		  AND a.month <= DATE_TRUNC('month', $2::date)
+		GROUP BY f.cohort, month_offset
+		ORDER BY f.cohort, month_offset
+	`
+
+	rows, err := s.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("Cohorts: %w", err)
+	}
+	defer rows.Close()
+
+	lastMonth := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	cohorts := make([]dto.CohortStat, 0)
+	index := make(map[string]int)
+	for rows.Next() {
+		var cohort time.Time
+		var offset, active int
+		if err := rows.Scan(&cohort, &offset, &active); err != nil {
+			return nil, fmt.Errorf("Cohorts rows.Scan: %w", err)
+		}
+
+		key := cohort.Format("2006-01")
+		i, ok := index[key]
+		if !ok {
+			// Длина строки когорты — число месяцев от когорты до конца периода включительно
+			months := (lastMonth.Year()-cohort.Year())*12 + int(lastMonth.Month()-cohort.Month()) + 1
+			cohorts = append(cohorts, dto.CohortStat{Cohort: cohort, Active: make([]int, months)})
+			i = len(cohorts) - 1
+			index[key] = i
+		}
+
+		if offset >= 0 && offset < len(cohorts[i].Active) {
+			cohorts[i].Active[offset] = active
+		}
+		if offset == 0 {
+			cohorts[i].Size = active
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Cohorts rows.Err: %w", err)
+	}
+
+	return cohorts, nil
+}
+
+// LifetimeValue возвращает суммарный доход по клиентам и среднюю длительность жизни клиента в днях
+func (s *Storage) LifetimeValue(ctx context.Context) (dto.LTVStat, error) {
+	const query = `
+		WITH per_person AS (
+			SELECT
+				ps.person_id,
+				SUM(ps.subscription_price - ps.discount) as revenue,
+				MAX(ps.end_date) - MIN(ps.start_date) as lifetime_days
+			FROM person_subscriptions ps
+			GROUP BY ps.person_id
+		)
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(revenue), 0),
+			COALESCE(AVG(lifetime_days), 0)
+		FROM per_person
+	`
+
+	var stat dto.LTVStat
+	err := s.db.QueryRow(ctx, query).Scan(&stat.Clients, &stat.TotalRevenue, &stat.AverageLifetimeDays)
+	if err != nil {
+		return dto.LTVStat{}, fmt.Errorf("LifetimeValue: %w", err)
+	}
+
+	return stat, nil
+}
+
+// RevenueReport возвращает доход от продажи абонементов за период, сгруппированный по
+// granularity (day, week, month, year) и, если byPlan, по абонементам. Суммы берутся по цене
+// на момент продажи, поэтому изменение цены тарифа не переписывает прошлые периоды
+func (s *Storage) RevenueReport(ctx context.Context, from, to time.Time, granularity string, byPlan bool) ([]dto.RevenueStat, error) {
+	const query = `
+		SELECT
+			DATE_TRUNC($3::text, ps.start_date) as period,
+			CASE WHEN $4::bool THEN s.id END as subscription_id,
+			CASE WHEN $4::bool THEN s.title ELSE 'Все абонементы' END as subscription_title,
+			COUNT(*) as sold,
+			COALESCE(SUM(ps.subscription_price), 0) as gross,
+			COALESCE(SUM(ps.discount), 0) as discount,
+			COALESCE(SUM(ps.subscription_price - ps.discount), 0) as net
+		FROM person_subscriptions ps
+		JOIN subscriptions s ON ps.subscription_id = s.id
+		WHERE ps.start_date >= $1 AND ps.start_date <= $2
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2
+	`
+
+	rows, err := s.db.Query(ctx, query, from, to, granularity, byPlan)
+	if err != nil {
+		return nil, fmt.Errorf("RevenueReport: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]dto.RevenueStat, 0)
+	for rows.Next() {
+		var stat dto.RevenueStat
+		err := rows.Scan(
+			&stat.Period,
+			&stat.SubscriptionID,
+			&stat.SubscriptionTitle,
+			&stat.Sold,
+			&stat.Gross,
+			&stat.Discount,
+			&stat.Net,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("RevenueReport rows.Scan: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("RevenueReport rows.Err: %w", err)
+	}
+
+	return stats, nil
+}
+
+// VisitsHeatmap возвращает количество приходов (отметки по абонементам и разовые посещения)
+// за период с разбивкой по дню недели и часу
+func (s *Storage) VisitsHeatmap(ctx context.Context, from, to time.Time) (dto.OccupancyHeatmap, error) {
+	const query = `
+		SELECT
+			EXTRACT(ISODOW FROM visited_at)::int as weekday,
+			EXTRACT(HOUR FROM visited_at)::int as hour,
+			COUNT(*) as visits
+		FROM (
+			SELECT checked_in_at as visited_at
+			FROM visits
+			WHERE checked_in_at >= $1 AND checked_in_at <= $2
+			UNION ALL
+			SELECT visited_at
+			FROM single_visits
+			WHERE visited_at IS NOT NULL AND visited_at >= $1 AND visited_at <= $2
+		) all_visits
+		GROUP BY weekday, hour
+	`
+
+	heatmap := dto.OccupancyHeatmap{From: from, To: to}
+
+	rows, err := s.db.Query(ctx, query, from, to)
+	if err != nil {
+		return heatmap, fmt.Errorf("VisitsHeatmap: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var weekday, hour, count int
+		if err := rows.Scan(&weekday, &hour, &count); err != nil {
+			return heatmap, fmt.Errorf("VisitsHeatmap rows.Scan: %w", err)
+		}
+		// ISODOW: 1 — понедельник, 7 — воскресенье
+		heatmap.Matrix[weekday-1][hour] = count
+		heatmap.Total += count
+	}
+	if err := rows.Err(); err != nil {
+		return heatmap, fmt.Errorf("VisitsHeatmap rows.Err: %w", err)
+	}
+
+	return heatmap, nil
+}
+
+// CurrentOccupancy возвращает количество клиентов в зале: незакрытые сегодняшние отметки по абонементам
+// и разовые посещения, оформленные за последние window
+func (s *Storage) CurrentOccupancy(ctx context.Context, window time.Duration) (dto.Occupancy, error) {
+	const query = `
+		SELECT
+			(SELECT COUNT(*) FROM visits
+			 WHERE checked_out_at IS NULL AND checked_in_at >= CURRENT_DATE) as members,
+			(SELECT COUNT(*) FROM single_visits
+			 WHERE visited_at >= NOW() - make_interval(secs => $1)) as single_visits,
+			NOW()::timestamp as at
+	`
+
+	var occ dto.Occupancy
+	err := s.db.QueryRow(ctx, query, window.Seconds()).Scan(&occ.Members, &occ.SingleVisits, &occ.At)
+	if err != nil {
+		return dto.Occupancy{}, fmt.Errorf("CurrentOccupancy: %w", err)
+	}
+	occ.Total = occ.Members + occ.SingleVisits
+
+	return occ, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
 	"github.com/Muaz717/gym_app/app/internal/domain/models"
 	"github.com/jackc/pgx/v5"
 	"time"
@@ -91,6 +92,11 @@ func (s *Storage) FreezeSubscription(ctx context.Context, subscriptionNumber str
 		return err
 	}
 
+	event := dto.SubscriptionStatusEvent{Number: subscriptionNumber, Date: freezeStart}
+	if err := insertOutboxEvent(ctx, tx, dto.EventSubscriptionFrozen, event); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -127,6 +133,11 @@ func (s *Storage) UnfreezeSubscription(ctx context.Context, subscriptionNumber s
 		return err
 	}
 
+	event := dto.SubscriptionStatusEvent{Number: subscriptionNumber, Date: unfreezeDate}
+	if err := insertOutboxEvent(ctx, tx, dto.EventSubscriptionUnfrozen, event); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
@@ -163,3 +174,38 @@ func (s *Storage) GetAllActiveFreeze(ctx context.Context) ([]models.Subscription
 	}
 	return freezes, nil
 }
+
+// GetFreezesBySubscription возвращает все заморозки абонемента, последние первыми
+func (s *Storage) GetFreezesBySubscription(ctx context.Context, subscriptionNumber string) ([]models.SubscriptionFreeze, error) {
+	const op = "storage.postgres.GetFreezesBySubscription"
+
+	const query = `
+		SELECT id, subscription_number, freeze_start, freeze_end, COALESCE(days_used, 0), created_at
+		FROM subscription_freeze
+		WHERE subscription_number = $1
+		ORDER BY freeze_start DESC
+	`
+	rows, err := s.db.Query(ctx, query, subscriptionNumber)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	freezes := make([]models.SubscriptionFreeze, 0)
+	for rows.Next() {
+		var f models.SubscriptionFreeze
+		var freezeEnd *time.Time
+		if err := rows.Scan(&f.ID, &f.SubscriptionNumber, &f.FreezeStart, &freezeEnd, &f.DaysUsed, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if freezeEnd != nil {
+			f.FreezeEnd = *freezeEnd
+		}
+		freezes = append(freezes, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return freezes, nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+)
+
+// FindPeopleByPhones ищет клиентов по любому из вариантов номера; в базе номер сравнивается только по цифрам
+func (s *Storage) FindPeopleByPhones(ctx context.Context, phones []string) ([]models.Person, error) {
+	const op = "storage.postgres.FindPeopleByPhones"
+
+	query := `
+		SELECT id, full_name, phone
+		FROM person
+		WHERE regexp_replace(phone, '\D', '', 'g') = ANY($1)
+		ORDER BY id
+	`
+
+	return s.queryPeople(ctx, op, query, phones)
+}
+
+// FindPeopleByTelegramChat возвращает клиентов, привязанных к чату
+func (s *Storage) FindPeopleByTelegramChat(ctx context.Context, chatID int64) ([]models.Person, error) {
+	const op = "storage.postgres.FindPeopleByTelegramChat"
+
+	query := `
+		SELECT p.id, p.full_name, p.phone
+		FROM person p
+		JOIN person_contacts pc ON pc.person_id = p.id
+		WHERE pc.telegram_chat_id = $1
+		ORDER BY p.id
+	`
+
+	return s.queryPeople(ctx, op, query, chatID)
+}
+
+// LinkTelegramChat привязывает чат к клиентам. Прежние привязки этого чата снимаются.
+func (s *Storage) LinkTelegramChat(ctx context.Context, personIDs []int, chatID int64) error {
+	const op = "storage.postgres.LinkTelegramChat"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE person_contacts SET telegram_chat_id = NULL WHERE telegram_chat_id = $1`, chatID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+		INSERT INTO person_contacts (person_id, telegram_chat_id)
+		SELECT unnest($1::bigint[]), $2
+		ON CONFLICT (person_id) DO UPDATE SET telegram_chat_id = EXCLUDED.telegram_chat_id
+	`
+	if _, err := tx.Exec(ctx, query, personIDs, chatID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UnlinkTelegramChat снимает привязку чата со всех клиентов
+func (s *Storage) UnlinkTelegramChat(ctx context.Context, chatID int64) error {
+	const op = "storage.postgres.UnlinkTelegramChat"
+
+	if _, err := s.db.Exec(ctx, `UPDATE person_contacts SET telegram_chat_id = NULL WHERE telegram_chat_id = $1`, chatID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) queryPeople(ctx context.Context, op, query string, args ...any) ([]models.Person, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	people := make([]models.Person, 0)
+	for rows.Next() {
+		var person models.Person
+		if err := rows.Scan(&person.Id, &person.Name, &person.Phone); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		people = append(people, person)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return people, nil
+}
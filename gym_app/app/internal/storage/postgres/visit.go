@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// closeStaleVisitsQuery закрывает незакрытые посещения прошлых дней концом того же дня,
+// иначе забытый уход навсегда блокирует новую отметку прихода
+const closeStaleVisitsQuery = `
+	UPDATE visits
+	SET checked_out_at = date_trunc('day', checked_in_at) + INTERVAL '1 day' - INTERVAL '1 second'
+	WHERE subscription_number = $1 AND checked_out_at IS NULL AND checked_in_at < CURRENT_DATE
+`
+
+// CheckIn отмечает приход клиента по абонементу. Незакрытые посещения прошлых дней
+// закрываются так же, как при входе через турникет
+func (s *Storage) CheckIn(ctx context.Context, subscriptionNumber string) (models.Visit, error) {
+	const op = "storage.postgres.CheckIn"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Visit{}, fmt.Errorf("%s: begin: %w", op, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, closeStaleVisitsQuery, subscriptionNumber); err != nil {
+		return models.Visit{}, fmt.Errorf("%s: close stale: %w", op, err)
+	}
+
+	const query = `
+		INSERT INTO visits (subscription_number, checked_in_at)
+		VALUES ($1, NOW())
+		RETURNING id, checked_in_at
+	`
+
+	visit := models.Visit{SubscriptionNumber: subscriptionNumber}
+	err = tx.QueryRow(ctx, query, subscriptionNumber).Scan(&visit.ID, &visit.CheckedInAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return models.Visit{}, fmt.Errorf("%s: %w", op, storage.ErrAlreadyCheckedIn)
+			case "23503":
+				return models.Visit{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionNotFound)
+			}
+		}
+		return models.Visit{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Visit{}, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return visit, nil
+}
+
+// CheckOut закрывает незавершенное посещение по абонементу
+func (s *Storage) CheckOut(ctx context.Context, subscriptionNumber string) (models.Visit, error) {
+	const op = "storage.postgres.CheckOut"
+
+	const query = `
+		UPDATE visits
+		SET checked_out_at = NOW()
+		WHERE subscription_number = $1 AND checked_out_at IS NULL
+		RETURNING id, subscription_number, checked_in_at, checked_out_at
+	`
+
+	var visit models.Visit
+	err := s.db.QueryRow(ctx, query, subscriptionNumber).Scan(
+		&visit.ID,
+		&visit.SubscriptionNumber,
+		&visit.CheckedInAt,
+		&visit.CheckedOutAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Visit{}, fmt.Errorf("%s: %w", op, storage.ErrVisitNotFound)
+		}
+		return models.Visit{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return visit, nil
+}
+
+// GetOpenVisits возвращает посещения за сегодня, по которым клиент еще не отметил уход
+func (s *Storage) GetOpenVisits(ctx context.Context) ([]models.Visit, error) {
+	const op = "storage.postgres.GetOpenVisits"
+
+	const query = `
+		SELECT v.id, v.subscription_number, p.id, p.full_name, v.checked_in_at, v.checked_out_at
+		FROM visits v
+		JOIN person_subscriptions ps ON ps.number = v.subscription_number
+		JOIN person p ON p.id = ps.person_id
+		WHERE v.checked_out_at IS NULL AND v.checked_in_at >= CURRENT_DATE
+		ORDER BY v.checked_in_at DESC
+	`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	visits := make([]models.Visit, 0)
+	for rows.Next() {
+		var v models.Visit
+		err := rows.Scan(&v.ID, &v.SubscriptionNumber, &v.PersonID, &v.PersonName, &v.CheckedInAt, &v.CheckedOutAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		visits = append(visits, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return visits, nil
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/domain/dto"
+	"github.com/Muaz717/gym_app/app/internal/domain/models"
+	"github.com/Muaz717/gym_app/app/internal/storage"
+)
+
+func (s *Storage) SaveWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	const op = "storage.postgres.SaveWebhook"
+
+	const query = `
+		INSERT INTO webhooks (url, secret, events)
+		VALUES ($1, $2, $3)
+		RETURNING id, active, created_at
+	`
+
+	err := s.db.QueryRow(ctx, query, webhook.URL, webhook.Secret, webhook.Events).
+		Scan(&webhook.ID, &webhook.Active, &webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// FindWebhooks возвращает вебхуки без секретов
+func (s *Storage) FindWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	const op = "storage.postgres.FindWebhooks"
+
+	const query = `SELECT id, url, events, active, created_at FROM webhooks ORDER BY id`
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		var w models.Webhook
+		if err := rows.Scan(&w.ID, &w.URL, &w.Events, &w.Active, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Storage) SetWebhookActive(ctx context.Context, id int, active bool) error {
+	const op = "storage.postgres.SetWebhookActive"
+
+	result, err := s.db.Exec(ctx, `UPDATE webhooks SET active = $1 WHERE id = $2`, active, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id int) error {
+	const op = "storage.postgres.DeleteWebhook"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// FanOutEvents создает доставки по необработанным событиям outbox для активных вебхуков,
+// подписанных на их тип, и помечает события обработанными. Возвращает число событий.
+func (s *Storage) FanOutEvents(ctx context.Context, limit int) (int, error) {
+	const op = "storage.postgres.FanOutEvents"
+
+	// Один запрос — одна транзакция: SKIP LOCKED не дает двум экземплярам взять одни события
+	const query = `
+		WITH ev AS (
+			SELECT id, event_type
+			FROM outbox_events
+			WHERE processed_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), ins AS (
+			INSERT INTO webhook_deliveries (webhook_id, event_id)
+			SELECT w.id, ev.id
+			FROM ev
+			JOIN webhooks w ON w.active AND ev.event_type = ANY(w.events)
+			ON CONFLICT (webhook_id, event_id) DO NOTHING
+		)
+		UPDATE outbox_events SET processed_at = NOW()
+		WHERE id IN (SELECT id FROM ev)
+	`
+
+	result, err := s.db.Exec(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// ClaimDeliveries берет в работу доставки, время которых подошло. Следующая попытка
+// сдвигается на lease, чтобы доставку не взял другой экземпляр, пока идет отправка.
+func (s *Storage) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.PendingDelivery, error) {
+	const op = "storage.postgres.ClaimDeliveries"
+
+	const query = `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d
+			SET next_attempt_at = NOW() + make_interval(secs => $2)
+			FROM due
+			WHERE d.id = due.id
+			RETURNING d.id, d.attempts, d.webhook_id, d.event_id
+		)
+		SELECT c.id, c.attempts, w.url, w.secret, e.id, e.event_type, e.payload, e.created_at
+		FROM claimed c
+		JOIN webhooks w ON w.id = c.webhook_id
+		JOIN outbox_events e ON e.id = c.event_id
+		ORDER BY e.id
+	`
+
+	rows, err := s.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.PendingDelivery, 0)
+	for rows.Next() {
+		var d models.PendingDelivery
+		err := rows.Scan(
+			&d.ID,
+			&d.Attempts,
+			&d.URL,
+			&d.Secret,
+			&d.Event.ID,
+			&d.Event.Type,
+			&d.Event.Payload,
+			&d.Event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// SaveDeliveryResult записывает итог попытки доставки
+func (s *Storage) SaveDeliveryResult(ctx context.Context, id int64, result dto.DeliveryResult) error {
+	const op = "storage.postgres.SaveDeliveryResult"
+
+	var statusCode *int
+	if result.StatusCode != 0 {
+		statusCode = &result.StatusCode
+	}
+
+	var query string
+	var args []any
+	switch {
+	case result.Error == "":
+		query = `
+			UPDATE webhook_deliveries
+			SET status = 'delivered', attempts = attempts + 1, last_status_code = $2,
+				last_error = NULL, delivered_at = NOW()
+			WHERE id = $1
+		`
+		args = []any{id, statusCode}
+	default:
+		status := dto.DeliveryPending
+		if result.Final {
+			status = dto.DeliveryFailed
+		}
+		query = `
+			UPDATE webhook_deliveries
+			SET status = $2, attempts = attempts + 1, last_status_code = $3,
+				last_error = $4, next_attempt_at = $5
+			WHERE id = $1
+		`
+		args = []any{id, status, statusCode, result.Error, result.NextAttempt}
+	}
+
+	if _, err := s.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindDeliveries возвращает последние доставки вебхука
+func (s *Storage) FindDeliveries(ctx context.Context, webhookID int, limit int) ([]models.WebhookDelivery, error) {
+	const op = "storage.postgres.FindDeliveries"
+
+	const query = `
+		SELECT d.id, d.webhook_id, d.event_id, e.event_type, d.status, d.attempts, d.next_attempt_at,
+			d.last_status_code, COALESCE(d.last_error, ''), d.created_at, d.delivered_at
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.webhook_id = $1
+		ORDER BY d.id DESC
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		var d models.WebhookDelivery
+		err := rows.Scan(
+			&d.ID,
+			&d.WebhookID,
+			&d.EventID,
+			&d.EventType,
+			&d.Status,
+			&d.Attempts,
+			&d.NextAttemptAt,
+			&d.LastStatusCode,
+			&d.LastError,
+			&d.CreatedAt,
+			&d.DeliveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
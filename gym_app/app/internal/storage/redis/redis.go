@@ -65,6 +65,18 @@ func (r *Redis) DelByPrefix(ctx context.Context, prefix string) error {
 	return nil
 }
 
+// Incr увеличивает счетчик key и возвращает новое значение.
+// Срок window ставится при первом увеличении, поэтому окно не продлевается каждым запросом.
+func (r *Redis) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 func (r *Redis) Close() error {
 	return r.client.Close()
 }
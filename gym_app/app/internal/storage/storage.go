@@ -3,9 +3,24 @@ package storage
 import "errors"
 
 var (
-	ErrUserExists           = errors.New("person already exists")
-	ErrSubscriptionExists   = errors.New("subscription with that number already exists")
-	ErrPersonNotFound       = errors.New("person not found")
-	ErrSubscriptionNotFound = errors.New("subscription not found")
-	ErrAppNotFound          = errors.New("app not found")
+	ErrUserExists            = errors.New("person already exists")
+	ErrSubscriptionExists    = errors.New("subscription with that number already exists")
+	ErrPersonNotFound        = errors.New("person not found")
+	ErrSubscriptionNotFound  = errors.New("subscription not found")
+	ErrAppNotFound           = errors.New("app not found")
+	ErrTariffExists          = errors.New("tariff with that title already exists")
+	ErrTariffNotFound        = errors.New("tariff not found")
+	ErrTariffInUse           = errors.New("tariff is used by single visits")
+	ErrAlreadyCheckedIn      = errors.New("subscription already checked in")
+	ErrVisitNotFound         = errors.New("open visit not found")
+	ErrMemberNotFound        = errors.New("member account not found")
+	ErrMemberExists          = errors.New("member account already linked")
+	ErrRegistrationPending   = errors.New("member registration is already in progress")
+	ErrFreezeRequestExists   = errors.New("pending freeze request already exists")
+	ErrFreezeRequestNotFound = errors.New("pending freeze request not found")
+	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrAccessDeviceNotFound  = errors.New("access device not found")
+	ErrAccessCardExists      = errors.New("access card already exists")
+	ErrAccessCardNotFound    = errors.New("access card not found")
+	ErrCredentialNotFound    = errors.New("card or subscription not found")
 )
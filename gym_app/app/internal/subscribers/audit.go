@@ -0,0 +1,31 @@
+package subscribers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Muaz717/gym_app/app/internal/events"
+)
+
+// Audit пишет каждое доменное событие в лог отдельной записью с пометкой audit,
+// чтобы изменения данных можно было выбрать из общего лога одним фильтром.
+type Audit struct {
+	log *slog.Logger
+}
+
+func NewAudit(log *slog.Logger) *Audit {
+	return &Audit{
+		log: log.With(slog.Bool("audit", true)),
+	}
+}
+
+func (a *Audit) Register(bus *events.Bus) {
+	bus.Subscribe(a.handle)
+}
+
+func (a *Audit) handle(ctx context.Context, event events.Event) {
+	a.log.InfoContext(ctx, "domain event",
+		slog.String("event", event.EventName()),
+		slog.Any("data", event),
+	)
+}
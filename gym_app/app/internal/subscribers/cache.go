@@ -0,0 +1,103 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+	"github.com/Muaz717/gym_app/app/internal/services/cache"
+)
+
+// Ключи и префиксы кэша, которые заполняют сервисы чтения
+const (
+	keyPeopleAll        = "people:all"
+	prefixPersonName    = "person:name:"
+	keyPersonID         = "person:id:%d"
+	keyPersonSubsAll    = "person_subs:all"
+	keyPersonSubNumber  = "person_sub:number:%s"
+	prefixPersonSubs    = "person_sub:person:" // По ФИО и по ID клиента
+	prefixPersonSubAny  = "person_sub:"        // По номеру и по клиенту
+	keyActiveFreezes    = "sub_freezed:all"
+	prefixSingleVisits  = "single_visits:"
+	keySingleVisit      = "single_visit:%d"
+	prefixStatistics    = "stat:"
+	prefixVisitsHeatmap = "stat:heatmap:"
+)
+
+// CacheInvalidator сбрасывает кэш по доменным событиям. Вся логика «что сбросить после
+// какого изменения» собрана здесь, а не в каждом методе сервисов.
+type CacheInvalidator struct {
+	log   *slog.Logger
+	cache cache.Cache
+}
+
+func NewCacheInvalidator(log *slog.Logger, cache cache.Cache) *CacheInvalidator {
+	return &CacheInvalidator{
+		log:   log,
+		cache: cache,
+	}
+}
+
+// Register подписывает инвалидацию на шину. Обработчики синхронные: после ответа
+// клиенту чтение уже не вернет устаревшие данные.
+func (c *CacheInvalidator) Register(bus *events.Bus) {
+	bus.Subscribe(c.handle)
+}
+
+func (c *CacheInvalidator) handle(ctx context.Context, event events.Event) {
+	var keys, prefixes []string
+
+	switch e := event.(type) {
+	case events.PersonCreated:
+		keys = []string{keyPeopleAll}
+		prefixes = []string{prefixPersonName, prefixStatistics}
+	case events.PersonUpdated:
+		// ФИО и телефон попадают и в ответы по абонементам
+		keys = []string{keyPeopleAll, fmt.Sprintf(keyPersonID, e.PersonID), keyPersonSubsAll, keyActiveFreezes}
+		prefixes = []string{prefixPersonName, prefixPersonSubAny, prefixStatistics}
+	case events.PersonDeleted:
+		keys = []string{keyPeopleAll, fmt.Sprintf(keyPersonID, e.PersonID), keyPersonSubsAll, keyActiveFreezes}
+		prefixes = []string{prefixPersonName, prefixPersonSubAny, prefixStatistics}
+	case events.SubscriptionSold:
+		keys = []string{keyPersonSubsAll, fmt.Sprintf(keyPersonSubNumber, e.Number)}
+		prefixes = []string{prefixPersonSubs, prefixStatistics}
+	case events.SubscriptionDeleted:
+		keys = []string{keyPersonSubsAll, fmt.Sprintf(keyPersonSubNumber, e.Number), keyActiveFreezes}
+		prefixes = []string{prefixPersonSubs, prefixStatistics}
+	case events.SubscriptionStatusChanged:
+		keys = []string{keyPersonSubsAll, fmt.Sprintf(keyPersonSubNumber, e.Number)}
+		prefixes = []string{prefixPersonSubs, prefixStatistics}
+	case events.SubscriptionFrozen:
+		keys = []string{keyActiveFreezes, keyPersonSubsAll, fmt.Sprintf(keyPersonSubNumber, e.Number)}
+		prefixes = []string{prefixPersonSubs}
+	case events.SubscriptionUnfrozen:
+		keys = []string{keyActiveFreezes, keyPersonSubsAll, fmt.Sprintf(keyPersonSubNumber, e.Number)}
+		prefixes = []string{prefixPersonSubs}
+	case events.SingleVisitAdded:
+		prefixes = []string{prefixSingleVisits, prefixStatistics}
+	case events.SingleVisitDeleted:
+		keys = []string{fmt.Sprintf(keySingleVisit, e.ID)}
+		prefixes = []string{prefixSingleVisits, prefixStatistics}
+	case events.VisitCheckedIn:
+		prefixes = []string{prefixVisitsHeatmap}
+	case events.DataImported:
+		keys = []string{keyPeopleAll, keyPersonSubsAll}
+		prefixes = []string{prefixPersonName, prefixPersonSubs, prefixStatistics}
+	default:
+		return
+	}
+
+	// Ошибка кэша не отменяет изменение: пишем в лог, ключи истекут по TTL
+	for _, key := range keys {
+		if err := c.cache.Delete(ctx, key); err != nil {
+			c.log.Warn("failed to invalidate cache", slog.String("event", event.EventName()), slog.String("key", key), sl.Error(err))
+		}
+	}
+	for _, prefix := range prefixes {
+		if err := c.cache.DelByPrefix(ctx, prefix); err != nil {
+			c.log.Warn("failed to invalidate cache", slog.String("event", event.EventName()), slog.String("prefix", prefix), sl.Error(err))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package subscribers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCache struct {
+	deleted  []string
+	prefixes []string
+}
+
+func (f *fakeCache) Set(context.Context, string, []byte, time.Duration) error { return nil }
+func (f *fakeCache) Get(context.Context, string) (string, error)              { return "", nil }
+
+func (f *fakeCache) Delete(_ context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func (f *fakeCache) DelByPrefix(_ context.Context, prefix string) error {
+	f.prefixes = append(f.prefixes, prefix)
+	return nil
+}
+
+func TestCacheInvalidator(t *testing.T) {
+	c := &fakeCache{}
+	bus := events.NewBus(slogdiscard.NewDiscardLogger())
+	NewCacheInvalidator(slogdiscard.NewDiscardLogger(), c).Register(bus)
+
+	bus.Publish(context.Background(), events.SubscriptionFrozen{Number: "A-1"})
+
+	assert.ElementsMatch(t, []string{"sub_freezed:all", "person_subs:all", "person_sub:number:A-1"}, c.deleted)
+	assert.Equal(t, []string{"person_sub:person:"}, c.prefixes)
+
+	*c = fakeCache{}
+	bus.Publish(context.Background(), events.SingleVisitAdded{ID: 5})
+
+	assert.Empty(t, c.deleted)
+	assert.ElementsMatch(t, []string{"single_visits:", "stat:"}, c.prefixes)
+}
@@ -0,0 +1,43 @@
+package subscribers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Muaz717/gym_app/app/internal/events"
+	"github.com/Muaz717/gym_app/app/internal/lib/logger/sl"
+)
+
+type FreezeNotifier interface {
+	NotifyFreeze(ctx context.Context, subscriptionNumber string, frozen bool, date time.Time) error
+}
+
+// Notifications сообщает клиенту о заморозке и разморозке абонемента
+type Notifications struct {
+	log      *slog.Logger
+	notifier FreezeNotifier
+}
+
+func NewNotifications(log *slog.Logger, notifier FreezeNotifier) *Notifications {
+	return &Notifications{
+		log:      log,
+		notifier: notifier,
+	}
+}
+
+// Register подписывает уведомления асинхронно: отправка через SMS или почту не должна задерживать ответ
+func (n *Notifications) Register(bus *events.Bus) {
+	bus.SubscribeAsync(events.On(func(ctx context.Context, e events.SubscriptionFrozen) {
+		n.notify(ctx, e.Number, true, e.Date)
+	}))
+	bus.SubscribeAsync(events.On(func(ctx context.Context, e events.SubscriptionUnfrozen) {
+		n.notify(ctx, e.Number, false, e.Date)
+	}))
+}
+
+func (n *Notifications) notify(ctx context.Context, number string, frozen bool, date time.Time) {
+	if err := n.notifier.NotifyFreeze(ctx, number, frozen, date); err != nil {
+		n.log.Error("failed to send freeze notification", slog.String("subscription_number", number), sl.Error(err))
+	}
+}
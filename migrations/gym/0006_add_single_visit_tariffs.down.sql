@@ -0,0 +1,3 @@
+ALTER TABLE single_visits DROP COLUMN IF EXISTS tariff_id;
+
+DROP TABLE IF EXISTS single_visit_tariffs;
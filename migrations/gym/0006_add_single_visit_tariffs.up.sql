@@ -0,0 +1,18 @@
+-- Тарифы разовых посещений (взрослый, студенческий, детский, вечерний)
+CREATE TABLE IF NOT EXISTS single_visit_tariffs (
+    id SERIAL PRIMARY KEY,
+    title TEXT NOT NULL UNIQUE,           -- Название тарифа
+    price NUMERIC(10, 2) NOT NULL,        -- Цена в будние дни
+    weekend_price NUMERIC(10, 2)          -- Цена в выходные (NULL — как в будни)
+);
+
+INSERT INTO single_visit_tariffs (title, price) VALUES
+    ('Взрослый', 500),
+    ('Студенческий', 350),
+    ('Детский', 250),
+    ('Вечерний', 400)
+ON CONFLICT (title) DO NOTHING;
+
+-- Старые посещения остаются без тарифа
+ALTER TABLE single_visits
+    ADD COLUMN tariff_id INT REFERENCES single_visit_tariffs(id) ON DELETE RESTRICT;
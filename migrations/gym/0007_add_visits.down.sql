@@ -0,0 +1,4 @@
+ALTER TABLE single_visits
+    DROP COLUMN IF EXISTS visited_at;
+
+DROP TABLE IF EXISTS visits;
@@ -0,0 +1,18 @@
+-- Отметки посещений по абонементам (вход / выход)
+CREATE TABLE IF NOT EXISTS visits (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_number VARCHAR(32) NOT NULL REFERENCES person_subscriptions(number) ON DELETE CASCADE,
+    checked_in_at TIMESTAMP NOT NULL DEFAULT now(),
+    checked_out_at TIMESTAMP                  -- NULL, пока клиент в зале
+);
+
+CREATE INDEX IF NOT EXISTS idx_visits_checked_in_at ON visits (checked_in_at);
+
+-- У абонемента может быть только одно незакрытое посещение
+CREATE UNIQUE INDEX IF NOT EXISTS idx_visits_open
+    ON visits (subscription_number)
+    WHERE checked_out_at IS NULL;
+
+-- Время разового посещения; у старых записей остается NULL
+ALTER TABLE single_visits
+    ADD COLUMN visited_at TIMESTAMP;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_log;
+
+DROP TABLE IF EXISTS person_contacts;
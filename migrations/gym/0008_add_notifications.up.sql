@@ -0,0 +1,28 @@
+-- Контакты клиента для уведомлений и согласие на рассылку
+CREATE TABLE IF NOT EXISTS person_contacts (
+    person_id BIGINT PRIMARY KEY REFERENCES person(id) ON DELETE CASCADE,
+    email TEXT,
+    telegram_chat_id BIGINT,
+    birth_date DATE,
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE -- клиент отказался от уведомлений
+);
+
+CREATE INDEX IF NOT EXISTS idx_person_contacts_birthday
+    ON person_contacts (EXTRACT(MONTH FROM birth_date), EXTRACT(DAY FROM birth_date));
+
+-- Журнал доставки уведомлений
+CREATE TABLE IF NOT EXISTS notification_log (
+    id BIGSERIAL PRIMARY KEY,
+    person_id BIGINT NOT NULL REFERENCES person(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,      -- expiry / birthday
+    ref VARCHAR(64) NOT NULL,       -- номер абонемента или год поздравления
+    channel VARCHAR(20) NOT NULL,   -- sms / email / telegram
+    recipient TEXT NOT NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL,    -- sent / failed
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_log_person ON notification_log (person_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notification_log_ref ON notification_log (person_id, kind, ref);
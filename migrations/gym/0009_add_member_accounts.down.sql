@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS freeze_requests;
+
+DROP TABLE IF EXISTS member_accounts;
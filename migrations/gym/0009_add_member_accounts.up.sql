@@ -0,0 +1,22 @@
+-- Привязка аккаунта SSO к карточке клиента: такой аккаунт видит только свои данные
+CREATE TABLE IF NOT EXISTS member_accounts (
+    user_id BIGINT PRIMARY KEY,     -- id пользователя в SSO
+    person_id BIGINT NOT NULL UNIQUE REFERENCES person(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Заявки клиентов на заморозку, решение принимает администратор
+CREATE TABLE IF NOT EXISTS freeze_requests (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_number VARCHAR(32) NOT NULL REFERENCES person_subscriptions(number) ON DELETE CASCADE,
+    freeze_start DATE NOT NULL,
+    comment TEXT,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending', -- pending / approved / rejected
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    decided_at TIMESTAMP
+);
+
+-- Одна необработанная заявка на абонемент
+CREATE UNIQUE INDEX IF NOT EXISTS idx_freeze_requests_pending
+    ON freeze_requests (subscription_number)
+    WHERE status = 'pending';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS access_log;
+
+DROP TABLE IF EXISTS access_cards;
+
+DROP TABLE IF EXISTS access_devices;
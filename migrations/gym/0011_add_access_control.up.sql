@@ -0,0 +1,33 @@
+-- Контроллеры турникетов. Токен выдается один раз, в базе хранится только его sha256
+CREATE TABLE IF NOT EXISTS access_devices (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    last_seen_at TIMESTAMP
+);
+
+-- Карты (браслеты) клиентов. Проход по карте идет по действующему абонементу клиента
+CREATE TABLE IF NOT EXISTS access_cards (
+    card_number VARCHAR(64) PRIMARY KEY,
+    person_id BIGINT NOT NULL REFERENCES person(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_cards_person_id ON access_cards (person_id);
+
+-- Журнал проходов: и разрешенные, и запрещенные попытки
+CREATE TABLE IF NOT EXISTS access_log (
+    id BIGSERIAL PRIMARY KEY,
+    device_id INT REFERENCES access_devices(id) ON DELETE SET NULL,
+    credential VARCHAR(64) NOT NULL,            -- Номер карты или абонемента, как его прислал контроллер
+    direction VARCHAR(3) NOT NULL,              -- in / out
+    allowed BOOLEAN NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    subscription_number VARCHAR(32),
+    visit_id BIGINT REFERENCES visits(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_log_created_at ON access_log (created_at);
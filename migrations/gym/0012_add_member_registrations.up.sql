@@ -0,0 +1,6 @@
+-- Карточка, для которой идет самостоятельная регистрация в портале.
+-- Занимается до создания аккаунта в SSO, чтобы параллельные регистрации не оставляли лишних аккаунтов
+CREATE TABLE IF NOT EXISTS member_registrations (
+    person_id BIGINT PRIMARY KEY REFERENCES person(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL -- после этого брошенную бронь можно занять снова
+);
@@ -0,0 +1,14 @@
+-- Invitations to register with preset roles, issued by administrators.
+-- Only the hash of the code is stored, the code itself is given to the invitee.
+CREATE TABLE IF NOT EXISTS invitations
+(
+    id         SERIAL PRIMARY KEY,
+    code_hash  CHAR(64) NOT NULL UNIQUE,
+    email      TEXT, -- if set, only this email can register with the invitation
+    roles      VARCHAR(50)[] NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_by    INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    used_at    TIMESTAMPTZ
+);
@@ -0,0 +1,5 @@
+ALTER TABLE apps
+    DROP COLUMN IF EXISTS previous_secret,
+    DROP COLUMN IF EXISTS previous_secret_expires_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS disabled_at;
@@ -0,0 +1,10 @@
+-- Apps are managed through the admin RPCs and the apps CLI instead of being seeded.
+-- After a secret rotation the previous secret keeps validating until previous_secret_expires_at.
+ALTER TABLE apps
+    ADD COLUMN IF NOT EXISTS previous_secret            TEXT,
+    ADD COLUMN IF NOT EXISTS previous_secret_expires_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS created_at                 TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS disabled_at                TIMESTAMPTZ;
+
+-- Seeded apps were inserted with explicit ids, move the identity past them
+SELECT setval(pg_get_serial_sequence('apps', 'id'), COALESCE((SELECT MAX(id) FROM apps), 0) + 1, false);
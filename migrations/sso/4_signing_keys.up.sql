@@ -0,0 +1,17 @@
+-- Asymmetric keys used to sign access tokens of an app. The key with retired_at IS NULL signs new tokens,
+-- retired keys stay published in JWKS until the tokens signed with them expire.
+CREATE TABLE IF NOT EXISTS signing_keys
+(
+    kid         TEXT PRIMARY KEY,
+    app_id      INTEGER NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    algorithm   TEXT NOT NULL,
+    private_key BYTEA NOT NULL, -- PKCS #8, DER
+    public_key  BYTEA NOT NULL, -- PKIX, DER
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retired_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_app_id ON signing_keys(app_id);
+
+-- At most one active key per app
+CREATE UNIQUE INDEX IF NOT EXISTS idx_signing_keys_active ON signing_keys(app_id) WHERE retired_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS role_permissions;
+
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS fk_user_roles_role;
+
+DROP TABLE IF EXISTS roles;
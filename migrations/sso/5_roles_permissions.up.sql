@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS roles
+(
+    name        VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO roles(name, description)
+VALUES ('user', 'Gym staff member'),
+       ('admin', 'Administrator with full access')
+ON CONFLICT (name) DO NOTHING;
+
+-- Roles assigned by hand before this migration
+INSERT INTO roles(name)
+SELECT DISTINCT role FROM user_roles
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE user_roles
+    ADD CONSTRAINT fk_user_roles_role FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE;
+
+-- "*" grants every permission
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+    role       VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', '*')
+ON CONFLICT DO NOTHING;
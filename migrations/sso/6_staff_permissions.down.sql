@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE role = 'user';
+
+-- Cascades to user_roles and role_permissions
+DELETE FROM roles WHERE name IN ('receptionist', 'accountant', 'trainer');
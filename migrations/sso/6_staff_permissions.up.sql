@@ -0,0 +1,46 @@
+INSERT INTO roles(name, description)
+VALUES ('receptionist', 'Front desk: sells subscriptions and single visits, freezes, checks clients in'),
+       ('accountant', 'Reads statistics only'),
+       ('trainer', 'Sees clients, their subscriptions and visits')
+ON CONFLICT (name) DO NOTHING;
+
+-- "user" keeps what every staff account could do before permissions were introduced
+INSERT INTO role_permissions(role, permission)
+VALUES ('user', 'people:read'),
+       ('user', 'subscriptions:read'),
+       ('user', 'person_sub:read'),
+       ('user', 'freeze:read'),
+       ('user', 'single_visit:read'),
+       ('user', 'tariffs:read'),
+       ('user', 'visits:read'),
+       ('user', 'visits:write'),
+       ('user', 'documents:read'),
+       ('user', 'notifications:read'),
+       ('user', 'statistics:read'),
+       ('user', 'access:read'),
+
+       ('receptionist', 'people:read'),
+       ('receptionist', 'people:write'),
+       ('receptionist', 'subscriptions:read'),
+       ('receptionist', 'person_sub:read'),
+       ('receptionist', 'person_sub:create'),
+       ('receptionist', 'freeze:read'),
+       ('receptionist', 'freeze:write'),
+       ('receptionist', 'single_visit:read'),
+       ('receptionist', 'single_visit:create'),
+       ('receptionist', 'tariffs:read'),
+       ('receptionist', 'visits:read'),
+       ('receptionist', 'visits:write'),
+       ('receptionist', 'documents:read'),
+       ('receptionist', 'notifications:read'),
+       ('receptionist', 'access:read'),
+
+       ('accountant', 'statistics:read'),
+
+       ('trainer', 'people:read'),
+       ('trainer', 'person_sub:read'),
+       ('trainer', 'visits:read')
+ON CONFLICT DO NOTHING;
+
+-- Tokens issued before carry no permissions: bumping the version makes clients refresh them
+UPDATE users SET token_version = token_version + 1;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS one_time_tokens;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
@@ -0,0 +1,23 @@
+-- Failed login counters, one row per "email:<email>" or "ip:<address>" key.
+-- Failures older than the configured window are forgotten on the next failure.
+CREATE TABLE IF NOT EXISTS login_failures
+(
+    key             TEXT PRIMARY KEY,
+    failures        INTEGER NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until    TIMESTAMPTZ
+);
+
+-- Audit of failed and blocked logins. user_id is NULL for unknown emails.
+CREATE TABLE IF NOT EXISTS login_audit
+(
+    id         BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    email      TEXT NOT NULL,
+    ip         TEXT NOT NULL DEFAULT '',
+    user_id    INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reason     VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_audit_email ON login_audit(email, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_audit_created_at ON login_audit(created_at);
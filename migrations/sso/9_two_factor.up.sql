@@ -0,0 +1,22 @@
+-- TOTP secrets. A secret is stored unconfirmed on enrollment and enabled once the user
+-- enters a valid code. last_step is the time step of the last accepted code,
+-- a code can't be used twice.
+CREATE TABLE IF NOT EXISTS user_totp
+(
+    user_id    INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret     TEXT NOT NULL,
+    enabled    BOOLEAN NOT NULL DEFAULT FALSE,
+    last_step  BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    enabled_at TIMESTAMPTZ
+);
+
+-- Single-use recovery codes for a lost authenticator, only hashes are stored
+CREATE TABLE IF NOT EXISTS recovery_codes
+(
+    code_hash  CHAR(64) PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes(user_id);
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/services/apps"
+	"github.com/Muaz717/sso/app/internal/storage/postgres"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// Manages apps directly in the database, e.g. to register the first app before any admin can log in:
+//
+//	CONFIG_PATH=../config/local.yaml go run ./cmd/apps --command=create --name=gym_app
+//	go run ./cmd/apps --config=../config/local.yaml --command=rotate --app-id=1 --grace=48h
+func main() {
+	var command, name string
+	var appID int64
+	var grace time.Duration
+
+	flag.StringVar(&command, "command", "", "command (create, list, rotate, disable, enable)")
+	flag.StringVar(&name, "name", "", "app name, for create")
+	flag.Int64Var(&appID, "app-id", 0, "app id, for rotate, disable and enable")
+	flag.DurationVar(&grace, "grace", 0, "how long the previous secret stays valid after rotate, defaults to apps.secret_grace_period")
+
+	// parses the flags above together with --config
+	cfg := config.MustLoad()
+
+	if command == "" {
+		panic("command is required")
+	}
+
+	ctx := context.Background()
+
+	storage, err := postgres.New(ctx, cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	// only warnings and errors, the result goes to stdout
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	service := apps.New(log, storage, cfg.Apps.SecretGracePeriod)
+
+	switch command {
+	case "create":
+		if name == "" {
+			panic("name is required")
+		}
+
+		app, err := service.Create(ctx, name)
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("app %q created\nid:     %d\nsecret: %s\n", app.Name, app.ID, app.Secret)
+	case "list":
+		list, err := service.List(ctx)
+		if err != nil {
+			panic(err)
+		}
+
+		printApps(os.Stdout, list, time.Now())
+	case "rotate":
+		requireAppID(appID)
+
+		app, err := service.RotateSecret(ctx, appID, grace)
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("secret of app %q rotated\nsecret: %s\nprevious secret valid until %s\n",
+			app.Name, app.Secret, app.PreviousSecretExpiresAt.Format(time.RFC3339))
+	case "disable":
+		requireAppID(appID)
+
+		if err := service.Disable(ctx, appID); err != nil {
+			panic(err)
+		}
+
+		fmt.Println("app disabled")
+	case "enable":
+		requireAppID(appID)
+
+		if err := service.Enable(ctx, appID); err != nil {
+			panic(err)
+		}
+
+		fmt.Println("app enabled")
+	default:
+		panic("Unknown command. Command must be create, list, rotate, disable or enable")
+	}
+}
+
+func requireAppID(appID int64) {
+	if appID <= 0 {
+		panic("app-id is required")
+	}
+}
+
+// printApps writes the apps as a table. The previous secret is shown only while it is still accepted.
+func printApps(out io.Writer, list []models.App, now time.Time) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "ID\tNAME\tCREATED\tPREVIOUS SECRET UNTIL\tDISABLED")
+
+	for _, app := range list {
+		previous, disabled := "-", "-"
+		if app.PreviousSecret != "" && app.PreviousSecretExpiresAt.After(now) {
+			previous = app.PreviousSecretExpiresAt.Format(time.RFC3339)
+		}
+		if !app.DisabledAt.IsZero() {
+			disabled = app.DisabledAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", app.ID, app.Name, app.CreatedAt.Format(time.RFC3339), previous, disabled)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintApps(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	list := []models.App{
+		{ID: 1, Name: "gym_app", Secret: "s1", CreatedAt: now.Add(-48 * time.Hour)},
+		{
+			ID: 2, Name: "bot", Secret: "s2", CreatedAt: now.Add(-48 * time.Hour),
+			PreviousSecret: "old", PreviousSecretExpiresAt: now.Add(time.Hour),
+		},
+		{
+			ID: 3, Name: "portal", Secret: "s3", CreatedAt: now.Add(-48 * time.Hour),
+			PreviousSecret: "old", PreviousSecretExpiresAt: now.Add(-time.Hour),
+			DisabledAt: now.Add(-time.Hour),
+		},
+	}
+
+	var out bytes.Buffer
+	printApps(&out, list, now)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if !assert.Len(t, lines, 4) {
+		return
+	}
+
+	assert.Equal(t, []string{"ID", "NAME", "CREATED", "PREVIOUS", "SECRET", "UNTIL", "DISABLED"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"1", "gym_app", "2026-02-27T12:00:00Z", "-", "-"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"2", "bot", "2026-02-27T12:00:00Z", "2026-03-01T13:00:00Z", "-"}, strings.Fields(lines[2]),
+		"previous secret is still accepted")
+	assert.Equal(t, []string{"3", "portal", "2026-02-27T12:00:00Z", "-", "2026-03-01T11:00:00Z"}, strings.Fields(lines[3]),
+		"expired previous secret is hidden")
+
+	// secrets are never printed by list
+	assert.NotContains(t, out.String(), "old")
+}
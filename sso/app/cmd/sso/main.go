@@ -27,12 +27,28 @@ func main() {
 
 	log := setupLogger(cfg.Env)
 
-	application := app.New(log, cfg.GRPC.Port, cfg.GRPC.Host, cfg.DB, cfg.TokenTTL)
+	application := app.New(
+		log,
+		cfg.GRPC.Port,
+		cfg.GRPC.Host,
+		cfg.DB,
+		cfg.JWT,
+		cfg.Mail,
+		cfg.LoginProtection,
+		cfg.TwoFactor,
+		cfg.Registration,
+		cfg.Apps,
+		cfg.TokenTTL,
+		cfg.RefreshTokenTTL,
+		cfg.TokenCleanupInterval,
+	)
 
 	go func() {
 		application.GRPCSrv.MustRun()
 	}()
 
+	go application.Cleanup.Run()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
@@ -40,6 +56,7 @@ func main() {
 	log.Info("stopping application", slog.String("signal", sign.String()))
 
 	application.GRPCSrv.Stop()
+	application.Cleanup.Stop()
 
 	log.Info("application stopped")
 }
@@ -2,9 +2,18 @@ package app
 
 import (
 	"context"
+	cleanupapp "github.com/Muaz717/sso/app/internal/app/cleanup"
 	grpcapp "github.com/Muaz717/sso/app/internal/app/grpc"
 	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/lib/mail"
+	"github.com/Muaz717/sso/app/internal/services/account"
+	"github.com/Muaz717/sso/app/internal/services/apps"
 	"github.com/Muaz717/sso/app/internal/services/auth"
+	"github.com/Muaz717/sso/app/internal/services/invites"
+	"github.com/Muaz717/sso/app/internal/services/keys"
+	"github.com/Muaz717/sso/app/internal/services/loginguard"
+	"github.com/Muaz717/sso/app/internal/services/roles"
+	"github.com/Muaz717/sso/app/internal/services/twofactor"
 	"github.com/Muaz717/sso/app/internal/storage/postgres"
 	"log/slog"
 
@@ -13,6 +22,7 @@ import (
 
 type App struct {
 	GRPCSrv *grpcapp.App
+	Cleanup *cleanupapp.App
 }
 
 func New(
@@ -20,18 +30,55 @@ func New(
 	grpcPort string,
 	grpcHost string,
 	db config.DBConfig,
+	jwtCfg config.JWTConfig,
+	mailCfg config.MailConfig,
+	loginCfg config.LoginProtectionConfig,
+	twoFactorCfg config.TwoFactorConfig,
+	registrationCfg config.RegistrationConfig,
+	appsCfg config.AppsConfig,
 	tokenTTL time.Duration,
+	refreshTTL time.Duration,
+	cleanupInterval time.Duration,
 ) *App {
 	storage, err := postgres.New(context.Background(), db)
 	if err != nil {
 		panic(err)
 	}
 
-	authService := auth.New(log, storage, storage, storage, tokenTTL)
+	// retired keys stay published while tokens signed with them may be alive
+	keysService, err := keys.New(log, storage, jwtCfg.Algorithm, jwtCfg.KeyRotationInterval, tokenTTL)
+	if err != nil {
+		panic(err)
+	}
+
+	loginGuard := loginguard.New(log, storage, loginCfg)
+
+	twoFactorService := twofactor.New(log, storage, storage, twoFactorCfg)
+
+	authService := auth.New(
+		log, storage, storage, storage, storage, keysService, loginGuard, twoFactorService,
+		tokenTTL, refreshTTL, registrationCfg.Open, jwtCfg.LegacyHMACTokens,
+	)
+
+	rolesService := roles.New(log, storage)
+
+	mailer := mail.NewLogSender(log)
+
+	accountService := account.New(log, storage, storage, mailer, mailCfg)
+
+	invitesService := invites.New(log, storage, mailer, mailCfg, registrationCfg.InviteTTL)
+
+	appsService := apps.New(log, storage, appsCfg.SecretGracePeriod)
+
+	grpcApp := grpcapp.New(
+		log, grpcPort, grpcHost,
+		authService, rolesService, accountService, twoFactorService, invitesService, appsService,
+	)
 
-	grpcApp := grpcapp.New(log, grpcPort, grpcHost, authService)
+	cleanupApp := cleanupapp.New(log, authService, cleanupInterval)
 
 	return &App{
 		GRPCSrv: grpcApp,
+		Cleanup: cleanupApp,
 	}
 }
@@ -0,0 +1,65 @@
+package cleanupapp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TokenCleaner interface {
+	CleanupExpiredTokens(ctx context.Context) error
+}
+
+// App periodically deletes revocations and refresh tokens that have already expired.
+type App struct {
+	log      *slog.Logger
+	cleaner  TokenCleaner
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func New(log *slog.Logger, cleaner TokenCleaner, interval time.Duration) *App {
+	return &App{
+		log:      log,
+		cleaner:  cleaner,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run blocks until Stop is called.
+func (a *App) Run() {
+	const op = "cleanupapp.Run"
+
+	defer close(a.done)
+
+	a.log.With(slog.String("op", op)).
+		Info("expired tokens cleanup is running", slog.Duration("interval", a.interval))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), a.interval)
+			// errors are logged by the service, the next tick will retry
+			_ = a.cleaner.CleanupExpiredTokens(ctx)
+			cancel()
+		}
+	}
+}
+
+func (a *App) Stop() {
+	const op = "cleanupapp.Stop"
+
+	a.log.With(slog.String("op", op)).
+		Info("stopping expired tokens cleanup")
+
+	close(a.stop)
+	<-a.done
+}
@@ -17,10 +17,20 @@ type App struct {
 	host        string
 }
 
-func New(log *slog.Logger, port string, host string, authService authgrpc.AuthSrv) *App {
+func New(
+	log *slog.Logger,
+	port string,
+	host string,
+	authService authgrpc.AuthSrv,
+	rolesService authgrpc.RolesSrv,
+	accountService authgrpc.AccountSrv,
+	twoFactorService authgrpc.TwoFactorSrv,
+	invitesService authgrpc.InvitesSrv,
+	appsService authgrpc.AppsSrv,
+) *App {
 	gRPCServer := grpc.NewServer()
 
-	authgrpc.Reg(gRPCServer, authService)
+	authgrpc.Reg(gRPCServer, authService, rolesService, accountService, twoFactorService, invitesService, appsService)
 
 	return &App{
 		log:         log,
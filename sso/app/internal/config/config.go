@@ -8,10 +8,79 @@ import (
 )
 
 type Config struct {
-	Env      string        `yaml:"env" env-default:"local"`
-	TokenTTL time.Duration `yaml:"token_ttl" env-required:"true"`
-	DB       DBConfig      `yaml:"db"`
-	GRPC     GRPCConfig    `yaml:"grpc"`
+	Env string `yaml:"env" env-default:"local"`
+	// TokenTTL is the lifetime of an access token, keep it short: it is renewed with the refresh token
+	TokenTTL        time.Duration `yaml:"token_ttl" env-required:"true"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env-default:"720h"`
+	// TokenCleanupInterval is how often expired revocations and refresh tokens are deleted
+	TokenCleanupInterval time.Duration         `yaml:"token_cleanup_interval" env-default:"1h"`
+	JWT                  JWTConfig             `yaml:"jwt"`
+	Mail                 MailConfig            `yaml:"mail"`
+	LoginProtection      LoginProtectionConfig `yaml:"login_protection"`
+	TwoFactor            TwoFactorConfig       `yaml:"two_factor"`
+	Registration         RegistrationConfig    `yaml:"registration"`
+	Apps                 AppsConfig            `yaml:"apps"`
+	DB                   DBConfig              `yaml:"db"`
+	GRPC                 GRPCConfig            `yaml:"grpc"`
+}
+
+// JWTConfig configures asymmetric signing of access tokens
+type JWTConfig struct {
+	// Algorithm is EdDSA or RS256
+	Algorithm           string        `yaml:"algorithm" env-default:"EdDSA"`
+	KeyRotationInterval time.Duration `yaml:"key_rotation_interval" env-default:"720h"`
+	// LegacyHMACTokens accepts tokens without "kid" signed with the app secret (HS256), as issued
+	// before asymmetric signing. Anyone holding an app secret can forge them, so enable it only
+	// for one token TTL after upgrading an installation that issued such tokens.
+	LegacyHMACTokens bool `yaml:"legacy_hmac_tokens" env-default:"false"`
+}
+
+// MailConfig configures password reset and email verification emails
+type MailConfig struct {
+	// PasswordResetURL and EmailVerificationURL are the links sent by email, %s is replaced with the token
+	PasswordResetURL     string `yaml:"password_reset_url" env-required:"true"`
+	EmailVerificationURL string `yaml:"email_verification_url" env-required:"true"`
+	// InviteURL is the registration link sent with an invite, %s is replaced with the invite code
+	InviteURL            string        `yaml:"invite_url" env-required:"true"`
+	PasswordResetTTL     time.Duration `yaml:"password_reset_ttl" env-default:"1h"`
+	EmailVerificationTTL time.Duration `yaml:"email_verification_ttl" env-default:"72h"`
+}
+
+// LoginProtectionConfig limits password guessing
+type LoginProtectionConfig struct {
+	// EmailAttempts and IPAttempts are the failures allowed within Window before logins are locked
+	EmailAttempts int           `yaml:"email_attempts" env-default:"5"`
+	IPAttempts    int           `yaml:"ip_attempts" env-default:"20"`
+	Window        time.Duration `yaml:"window" env-default:"1h"`
+	// BaseDelay is the first lock, every next failure doubles it up to MaxLockout
+	BaseDelay      time.Duration `yaml:"base_delay" env-default:"1s"`
+	MaxLockout     time.Duration `yaml:"max_lockout" env-default:"15m"`
+	AuditRetention time.Duration `yaml:"audit_retention" env-default:"2160h"`
+}
+
+// TwoFactorConfig configures TOTP two-factor authentication
+type TwoFactorConfig struct {
+	// Issuer is the account name prefix shown in authenticator apps
+	Issuer string `yaml:"issuer" env-default:"Gym"`
+	// RequiredRoles must use 2FA: until they enroll, their tokens carry no permissions
+	RequiredRoles []string      `yaml:"required_roles"`
+	ChallengeTTL  time.Duration `yaml:"challenge_ttl" env-default:"5m"`
+	RecoveryCodes int           `yaml:"recovery_codes" env-default:"10"`
+}
+
+// RegistrationConfig configures who may register
+type RegistrationConfig struct {
+	// Open gives self-registered users the default "user" role with staff permissions, so it is off
+	// unless set: only invites grant roles and an account registered without one has none,
+	// which is enough for the member portal only.
+	Open      bool          `yaml:"open" env-default:"false"`
+	InviteTTL time.Duration `yaml:"invite_ttl" env-default:"168h"`
+}
+
+// AppsConfig configures app management
+type AppsConfig struct {
+	// SecretGracePeriod is how long the previous secret stays valid after a rotation, unless the rotation sets it
+	SecretGracePeriod time.Duration `yaml:"secret_grace_period" env-default:"24h"`
 }
 
 type DBConfig struct {
@@ -1,7 +1,26 @@
 package models
 
+import "time"
+
+// App is a client of the SSO that tokens are issued for, e.g. gym_app, the member portal or the bot.
 type App struct {
 	ID     int64
 	Name   string
 	Secret string
+	// PreviousSecret is the secret replaced by the last rotation, it stays valid until PreviousSecretExpiresAt
+	PreviousSecret          string
+	PreviousSecretExpiresAt time.Time
+	CreatedAt               time.Time
+	// DisabledAt is zero while the app is enabled
+	DisabledAt time.Time
+}
+
+// Secrets returns the secrets the app backend may authenticate with at the moment.
+// Legacy HS256 tokens without "kid" are verified with them as well.
+func (a App) Secrets(now time.Time) []string {
+	secrets := []string{a.Secret}
+	if a.PreviousSecret != "" && now.Before(a.PreviousSecretExpiresAt) {
+		secrets = append(secrets, a.PreviousSecret)
+	}
+	return secrets
 }
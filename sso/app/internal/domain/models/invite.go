@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Invite lets a person register with preset roles. Only the hash of its code is stored.
+type Invite struct {
+	ID int64
+	// Email is empty if anyone with the code may register
+	Email     string
+	Roles     []string
+	CreatedBy int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// UsedBy and UsedAt are zero until someone registers with the invite
+	UsedBy int64
+	UsedAt time.Time
+}
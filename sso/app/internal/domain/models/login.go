@@ -0,0 +1,18 @@
+package models
+
+// Reasons of failed logins in the audit
+const (
+	LoginReasonUnknownEmail    = "unknown_email"
+	LoginReasonInvalidPassword = "invalid_password"
+	LoginReasonLocked          = "locked"
+	LoginReasonInvalidCode     = "invalid_2fa_code"
+)
+
+// LoginAttempt is a failed or blocked login recorded in the audit.
+type LoginAttempt struct {
+	Email string
+	IP    string
+	// UserID is 0 when the email is not registered
+	UserID int64
+	Reason string
+}
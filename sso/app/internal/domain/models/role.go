@@ -0,0 +1,34 @@
+package models
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+
+	// PermissionAll grants every permission
+	PermissionAll = "*"
+
+	PermissionUsersRead   = "users:read"
+	PermissionRolesManage = "roles:manage"
+	PermissionUsersUnlock = "users:unlock"
+	PermissionAppsManage  = "apps:manage"
+)
+
+type Role struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// UserInfo is a user as shown to administrators, without credentials.
+type UserInfo struct {
+	ID    int64
+	Email string
+	Roles []string
+}
+
+type UserFilter struct {
+	// Email is a case-insensitive substring
+	Email  string
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"crypto"
+	"time"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmRS256 = "RS256"
+)
+
+// SigningKey is an asymmetric key pair used to sign access tokens of an app.
+// ID is published as the "kid" token header.
+type SigningKey struct {
+	ID         string
+	AppID      int64
+	Algorithm  string
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+	CreatedAt  time.Time
+	// RetiredAt is set when the key was replaced by a newer one
+	RetiredAt *time.Time
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// TokenPair is the result of a login or a refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	AccessTTL    time.Duration
+	RefreshTTL   time.Duration
+}
+
+// RefreshToken is a stored refresh token. Only the hash of the token is kept.
+type RefreshToken struct {
+	ID        int64
+	SessionID string
+	UserID    int64
+	AppID     int64
+	TokenHash string
+	Device    string
+	ExpiresAt time.Time
+}
+
+// Purposes of one-time tokens
+const (
+	PurposePasswordReset     = "password_reset"
+	PurposeEmailVerification = "email_verification"
+	// PurposeLoginChallenge is the second step of a login with 2FA, it is returned by Login instead of tokens
+	PurposeLoginChallenge = "login_challenge"
+)
+
+// OneTimeToken is a single-use token delivered by email. Only the hash of the token is kept.
+type OneTimeToken struct {
+	UserID    int64
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,11 @@
+package models
+
+// TOTP is the authenticator secret of a user. Enabled is false until the user confirms
+// the enrollment with a valid code.
+type TOTP struct {
+	UserID  int64
+	Secret  string
+	Enabled bool
+	// LastStep is the time step of the last accepted code
+	LastStep int64
+}
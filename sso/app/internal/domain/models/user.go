@@ -8,6 +8,9 @@ type User struct {
 	ID       int64
 	Email    string `validate:"required,email"`
 	PassHash []byte `validate:"required"`
+	// TokenVersion is incremented on logout from all sessions
+	TokenVersion  int
+	EmailVerified bool
 }
 
 func (u *User) Validate() map[string]string {
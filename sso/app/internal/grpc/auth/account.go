@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/lib/validation"
+	"github.com/Muaz717/sso/app/internal/services/account"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountSrv interface {
+	ChangePassword(ctx context.Context, userID int64, currentPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	SendVerificationEmail(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+func (s *serverApi) ChangePassword(ctx context.Context, req *ssov1.ChangePasswordRequest) (*ssov1.ChangePasswordResponse, error) {
+
+	if err := validation.ValidateChangePasswordInput(req); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.auth.CheckToken(ctx, req.GetToken(), req.GetAppId())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	if err := s.account.ChangePassword(ctx, claims.UserId, req.GetCurrentPassword(), req.GetNewPassword()); err != nil {
+		return nil, accountError(err)
+	}
+
+	return &ssov1.ChangePasswordResponse{}, nil
+}
+
+func (s *serverApi) RequestPasswordReset(
+	ctx context.Context,
+	req *ssov1.RequestPasswordResetRequest,
+) (*ssov1.RequestPasswordResetResponse, error) {
+
+	if err := validation.ValidateEmailInput(req.GetEmail()); err != nil {
+		return nil, err
+	}
+
+	if err := s.account.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		return nil, accountError(err)
+	}
+
+	return &ssov1.RequestPasswordResetResponse{}, nil
+}
+
+func (s *serverApi) ResetPassword(ctx context.Context, req *ssov1.ResetPasswordRequest) (*ssov1.ResetPasswordResponse, error) {
+
+	if err := validation.ValidateResetPasswordInput(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.account.ResetPassword(ctx, req.GetToken(), req.GetNewPassword()); err != nil {
+		return nil, accountError(err)
+	}
+
+	return &ssov1.ResetPasswordResponse{}, nil
+}
+
+func (s *serverApi) VerifyEmail(ctx context.Context, req *ssov1.VerifyEmailRequest) (*ssov1.VerifyEmailResponse, error) {
+
+	if err := validation.ValidateVerifyEmailInput(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.account.VerifyEmail(ctx, req.GetToken()); err != nil {
+		return nil, accountError(err)
+	}
+
+	return &ssov1.VerifyEmailResponse{}, nil
+}
+
+func (s *serverApi) ResendVerificationEmail(
+	ctx context.Context,
+	req *ssov1.ResendVerificationEmailRequest,
+) (*ssov1.ResendVerificationEmailResponse, error) {
+
+	if err := validation.ValidateEmailInput(req.GetEmail()); err != nil {
+		return nil, err
+	}
+
+	if err := s.account.SendVerificationEmail(ctx, req.GetEmail()); err != nil {
+		return nil, accountError(err)
+	}
+
+	return &ssov1.ResendVerificationEmailResponse{}, nil
+}
+
+// accountError maps account errors to statuses. Wrong passwords and tokens are
+// reported as validation errors of the field, so clients can show them next to it.
+func accountError(err error) error {
+	switch {
+	case errors.Is(err, account.ErrInvalidCredentials):
+		return validation.NewValidationError(map[string]string{
+			"current_password": "Неверный текущий пароль",
+		})
+	case errors.Is(err, account.ErrInvalidToken):
+		return validation.NewValidationError(map[string]string{
+			"token": "Ссылка недействительна или устарела",
+		})
+	case errors.Is(err, account.ErrUserNotFound):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/validation"
+	"github.com/Muaz717/sso/app/internal/services/apps"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type AppsSrv interface {
+	Create(ctx context.Context, name string) (models.App, error)
+	List(ctx context.Context) ([]models.App, error)
+	RotateSecret(ctx context.Context, appID int64, gracePeriod time.Duration) (models.App, error)
+	Disable(ctx context.Context, appID int64) error
+	Enable(ctx context.Context, appID int64) error
+}
+
+func (s *serverApi) CreateApp(ctx context.Context, req *ssov1.CreateAppRequest) (*ssov1.CreateAppResponse, error) {
+
+	if err := validation.ValidateCreateAppInput(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionAppsManage); err != nil {
+		return nil, err
+	}
+
+	app, err := s.apps.Create(ctx, req.GetName())
+	if err != nil {
+		return nil, appsError(err)
+	}
+
+	return &ssov1.CreateAppResponse{App: appToProto(app), Secret: app.Secret}, nil
+}
+
+func (s *serverApi) ListApps(ctx context.Context, _ *ssov1.ListAppsRequest) (*ssov1.ListAppsResponse, error) {
+
+	if _, err := s.authorize(ctx, models.PermissionAppsManage); err != nil {
+		return nil, err
+	}
+
+	list, err := s.apps.List(ctx)
+	if err != nil {
+		return nil, appsError(err)
+	}
+
+	resp := &ssov1.ListAppsResponse{Apps: make([]*ssov1.App, 0, len(list))}
+	for _, app := range list {
+		resp.Apps = append(resp.Apps, appToProto(app))
+	}
+
+	return resp, nil
+}
+
+func (s *serverApi) RotateAppSecret(ctx context.Context, req *ssov1.RotateAppSecretRequest) (*ssov1.RotateAppSecretResponse, error) {
+
+	if err := validation.ValidateRotateAppSecretInput(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionAppsManage); err != nil {
+		return nil, err
+	}
+
+	gracePeriod := time.Duration(req.GetGracePeriodSeconds()) * time.Second
+
+	app, err := s.apps.RotateSecret(ctx, int64(req.GetAppId()), gracePeriod)
+	if err != nil {
+		return nil, appsError(err)
+	}
+
+	return &ssov1.RotateAppSecretResponse{App: appToProto(app), Secret: app.Secret}, nil
+}
+
+func (s *serverApi) DisableApp(ctx context.Context, req *ssov1.DisableAppRequest) (*ssov1.DisableAppResponse, error) {
+
+	if err := validation.ValidateAppID(req.GetAppId()); err != nil {
+		return nil, err
+	}
+
+	caller, err := s.authorize(ctx, models.PermissionAppsManage)
+	if err != nil {
+		return nil, err
+	}
+
+	// otherwise the administrator locks themselves out and only the CLI can undo it
+	if caller.AppID == int(req.GetAppId()) {
+		return nil, status.Error(codes.FailedPrecondition, "can't disable the app of the current session")
+	}
+
+	if err := s.apps.Disable(ctx, int64(req.GetAppId())); err != nil {
+		return nil, appsError(err)
+	}
+
+	return &ssov1.DisableAppResponse{}, nil
+}
+
+func (s *serverApi) EnableApp(ctx context.Context, req *ssov1.EnableAppRequest) (*ssov1.EnableAppResponse, error) {
+
+	if err := validation.ValidateAppID(req.GetAppId()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionAppsManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.apps.Enable(ctx, int64(req.GetAppId())); err != nil {
+		return nil, appsError(err)
+	}
+
+	return &ssov1.EnableAppResponse{}, nil
+}
+
+// appToProto converts an app without its secrets
+func appToProto(app models.App) *ssov1.App {
+	resp := &ssov1.App{
+		Id:        int32(app.ID),
+		Name:      app.Name,
+		CreatedAt: app.CreatedAt.Unix(),
+	}
+	if app.PreviousSecret != "" && app.PreviousSecretExpiresAt.After(time.Now()) {
+		resp.PreviousSecretExpiresAt = app.PreviousSecretExpiresAt.Unix()
+	}
+	if !app.DisabledAt.IsZero() {
+		resp.DisabledAt = app.DisabledAt.Unix()
+	}
+	return resp
+}
+
+func appsError(err error) error {
+	switch {
+	case errors.Is(err, apps.ErrAppNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, apps.ErrAppExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
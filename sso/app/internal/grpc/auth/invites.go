@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/validation"
+	"github.com/Muaz717/sso/app/internal/services/invites"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type InvitesSrv interface {
+	Create(ctx context.Context, createdBy int64, email string, roles []string) (models.Invite, string, error)
+	List(ctx context.Context) ([]models.Invite, error)
+	Revoke(ctx context.Context, id int64) error
+}
+
+func (s *serverApi) CreateInvite(ctx context.Context, req *ssov1.CreateInviteRequest) (*ssov1.CreateInviteResponse, error) {
+
+	if err := validation.ValidateCreateInviteInput(req); err != nil {
+		return nil, err
+	}
+
+	// the invitee gets the roles, so issuing invites takes the same permission as assigning roles
+	caller, err := s.authorize(ctx, models.PermissionRolesManage)
+	if err != nil {
+		return nil, err
+	}
+
+	invite, code, err := s.invites.Create(ctx, caller.UserId, req.GetEmail(), req.GetRoles())
+	if err != nil {
+		return nil, invitesError(err)
+	}
+
+	return &ssov1.CreateInviteResponse{Invite: inviteToProto(invite), Code: code}, nil
+}
+
+func (s *serverApi) ListInvites(ctx context.Context, _ *ssov1.ListInvitesRequest) (*ssov1.ListInvitesResponse, error) {
+
+	if _, err := s.authorize(ctx, models.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	list, err := s.invites.List(ctx)
+	if err != nil {
+		return nil, invitesError(err)
+	}
+
+	resp := &ssov1.ListInvitesResponse{Invites: make([]*ssov1.Invite, 0, len(list))}
+	for _, invite := range list {
+		resp.Invites = append(resp.Invites, inviteToProto(invite))
+	}
+
+	return resp, nil
+}
+
+func (s *serverApi) RevokeInvite(ctx context.Context, req *ssov1.RevokeInviteRequest) (*ssov1.RevokeInviteResponse, error) {
+
+	if err := validation.ValidateRevokeInviteInput(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.invites.Revoke(ctx, req.GetInviteId()); err != nil {
+		return nil, invitesError(err)
+	}
+
+	return &ssov1.RevokeInviteResponse{}, nil
+}
+
+func inviteToProto(invite models.Invite) *ssov1.Invite {
+	resp := &ssov1.Invite{
+		Id:        invite.ID,
+		Email:     invite.Email,
+		Roles:     invite.Roles,
+		CreatedBy: invite.CreatedBy,
+		CreatedAt: invite.CreatedAt.Unix(),
+		ExpiresAt: invite.ExpiresAt.Unix(),
+		UsedBy:    invite.UsedBy,
+	}
+	if !invite.UsedAt.IsZero() {
+		resp.UsedAt = invite.UsedAt.Unix()
+	}
+	return resp
+}
+
+func invitesError(err error) error {
+	switch {
+	case errors.Is(err, invites.ErrRoleNotFound), errors.Is(err, invites.ErrInviteNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
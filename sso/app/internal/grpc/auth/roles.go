@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/jwt"
+	"github.com/Muaz717/sso/app/internal/lib/validation"
+	"github.com/Muaz717/sso/app/internal/services/roles"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+)
+
+type RolesSrv interface {
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.UserInfo, int64, error)
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	AssignRole(ctx context.Context, userID int64, role string) error
+	RevokeRole(ctx context.Context, userID int64, role string) error
+	HasPermission(ctx context.Context, userID int64, permission string) (bool, error)
+}
+
+func (s *serverApi) ListUsers(ctx context.Context, req *ssov1.ListUsersRequest) (*ssov1.ListUsersResponse, error) {
+
+	if err := validation.ValidateListUsersInput(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.roles.ListUsers(ctx, models.UserFilter{
+		Email:  req.GetEmail(),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &ssov1.ListUsersResponse{
+		Users: make([]*ssov1.UserInfo, 0, len(users)),
+		Total: total,
+	}
+	for _, user := range users {
+		resp.Users = append(resp.Users, &ssov1.UserInfo{
+			UserId: user.ID,
+			Email:  user.Email,
+			Roles:  user.Roles,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *serverApi) ListRoles(ctx context.Context, _ *ssov1.ListRolesRequest) (*ssov1.ListRolesResponse, error) {
+
+	if _, err := s.authorize(ctx, models.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	list, err := s.roles.ListRoles(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &ssov1.ListRolesResponse{Roles: make([]*ssov1.Role, 0, len(list))}
+	for _, role := range list {
+		resp.Roles = append(resp.Roles, &ssov1.Role{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *serverApi) AssignRole(ctx context.Context, req *ssov1.AssignRoleRequest) (*ssov1.AssignRoleResponse, error) {
+
+	if err := validation.ValidateRoleInput(req.GetUserId(), req.GetRole()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.roles.AssignRole(ctx, req.GetUserId(), req.GetRole()); err != nil {
+		return nil, rolesError(err)
+	}
+
+	return &ssov1.AssignRoleResponse{}, nil
+}
+
+func (s *serverApi) RevokeRole(ctx context.Context, req *ssov1.RevokeRoleRequest) (*ssov1.RevokeRoleResponse, error) {
+
+	if err := validation.ValidateRoleInput(req.GetUserId(), req.GetRole()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.roles.RevokeRole(ctx, req.GetUserId(), req.GetRole()); err != nil {
+		return nil, rolesError(err)
+	}
+
+	return &ssov1.RevokeRoleResponse{}, nil
+}
+
+func (s *serverApi) CheckPermission(
+	ctx context.Context,
+	req *ssov1.CheckPermissionRequest,
+) (*ssov1.CheckPermissionResponse, error) {
+
+	if err := validation.ValidateCheckPermissionInput(req); err != nil {
+		return nil, err
+	}
+
+	// anyone may check their own permissions, checking others' requires users:read
+	caller, err := s.authorize(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if caller.UserId != req.GetUserId() {
+		if _, err := s.authorize(ctx, models.PermissionUsersRead); err != nil {
+			return nil, err
+		}
+	}
+
+	allowed, err := s.roles.HasPermission(ctx, req.GetUserId(), req.GetPermission())
+	if err != nil {
+		return nil, rolesError(err)
+	}
+
+	return &ssov1.CheckPermissionResponse{Allowed: allowed}, nil
+}
+
+func (s *serverApi) UnlockLogin(ctx context.Context, req *ssov1.UnlockLoginRequest) (*ssov1.UnlockLoginResponse, error) {
+
+	if err := validation.ValidateUnlockLoginInput(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.authorize(ctx, models.PermissionUsersUnlock); err != nil {
+		return nil, err
+	}
+
+	unlocked, err := s.auth.UnlockLogin(ctx, req.GetEmail(), req.GetIp())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &ssov1.UnlockLoginResponse{Unlocked: unlocked}, nil
+}
+
+// authorize checks the caller's access token from the "authorization" metadata
+// and, if permission is not empty, that the caller has it.
+func (s *serverApi) authorize(ctx context.Context, permission string) (*jwt.Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization format")
+	}
+
+	appID, err := jwt.AppIDUnverified(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	claims, err := s.auth.CheckToken(ctx, token, appID)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	if permission == "" {
+		return claims, nil
+	}
+
+	// The token must carry the permission as well: tokens of users who haven't enabled
+	// required 2FA are issued without permissions
+	if !slices.Contains(claims.Permissions, permission) && !slices.Contains(claims.Permissions, models.PermissionAll) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s permission required", permission)
+	}
+
+	allowed, err := s.roles.HasPermission(ctx, claims.UserId, permission)
+	if err != nil {
+		if errors.Is(err, roles.ErrUserNotFound) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "%s permission required", permission)
+	}
+
+	return claims, nil
+}
+
+func rolesError(err error) error {
+	switch {
+	case errors.Is(err, roles.ErrUserNotFound), errors.Is(err, roles.ErrRoleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, roles.ErrLastAdmin):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
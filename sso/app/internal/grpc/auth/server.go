@@ -3,40 +3,82 @@ package auth
 import (
 	"context"
 	"errors"
+	"github.com/Muaz717/sso/app/internal/domain/models"
 	"github.com/Muaz717/sso/app/internal/lib/jwt"
 	"github.com/Muaz717/sso/app/internal/lib/validation"
 	"github.com/Muaz717/sso/app/internal/services/auth"
-	"github.com/Muaz717/sso/app/internal/storage"
 	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// appSecretMetadata carries the secret an app backend authenticates with on CheckToken and GetJWKS
+const appSecretMetadata = "x-app-secret"
+
 type AuthSrv interface {
 	Login(
 		ctx context.Context,
 		email string,
 		password string,
 		appId int,
-	) (token string, err error)
+		device string,
+		ip string,
+	) (models.TokenPair, error)
+	LoginTwoFactor(
+		ctx context.Context,
+		challenge string,
+		code string,
+		appID int,
+		device string,
+		ip string,
+	) (models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string, appID int32) (models.TokenPair, error)
 	RegisterNewUser(
 		ctx context.Context,
 		email string,
 		password string,
+		inviteCode string,
+		withoutRoles bool,
 	) (userID int64, err error)
 	IsAdmin(ctx context.Context, userID int64) (bool, error)
-	Logout(ctx context.Context, token string, appID int32) error
+	Logout(ctx context.Context, token string, appID int32, all bool) error
 	CheckToken(ctx context.Context, token string, appID int32) (*jwt.Claims, error)
+	PublicKeys(ctx context.Context, appID int32) ([]models.SigningKey, error)
+	AuthenticateApp(ctx context.Context, appID int32, secret string) error
+	UnlockLogin(ctx context.Context, email, ip string) (bool, error)
 }
 
 type serverApi struct {
 	ssov1.UnimplementedAuthServer
-	auth AuthSrv
+	auth      AuthSrv
+	roles     RolesSrv
+	account   AccountSrv
+	twoFactor TwoFactorSrv
+	invites   InvitesSrv
+	apps      AppsSrv
 }
 
-func Reg(gRPC *grpc.Server, auth AuthSrv) {
-	ssov1.RegisterAuthServer(gRPC, &serverApi{auth: auth})
+func Reg(
+	gRPC *grpc.Server,
+	auth AuthSrv,
+	roles RolesSrv,
+	account AccountSrv,
+	twoFactor TwoFactorSrv,
+	invites InvitesSrv,
+	apps AppsSrv,
+) {
+	ssov1.RegisterAuthServer(gRPC, &serverApi{
+		auth:      auth,
+		roles:     roles,
+		account:   account,
+		twoFactor: twoFactor,
+		invites:   invites,
+		apps:      apps,
+	})
 }
 
 const (
@@ -52,16 +94,73 @@ func (s *serverApi) Login(
 		return nil, err
 	}
 
-	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword(), int(req.GetAppId()))
+	tokens, err := s.auth.Login(
+		ctx, req.GetEmail(), req.GetPassword(), int(req.GetAppId()), req.GetDevice(), req.GetIp(),
+	)
 	if err != nil {
-		if errors.Is(err, auth.ErrInvalidCredentials) {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+		var required *auth.TwoFactorRequiredError
+		if errors.As(err, &required) {
+			return &ssov1.LoginResponse{
+				TwoFactorRequired: true,
+				Challenge:         required.Challenge,
+			}, nil
 		}
 
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, loginError(err)
+	}
+
+	return &ssov1.LoginResponse{
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresIn:        int64(tokens.AccessTTL.Seconds()),
+		RefreshExpiresIn: int64(tokens.RefreshTTL.Seconds()),
+	}, nil
+}
+
+func (s *serverApi) LoginTwoFactor(
+	ctx context.Context,
+	req *ssov1.LoginTwoFactorRequest,
+) (*ssov1.LoginResponse, error) {
+
+	if err := validation.ValidateLoginTwoFactorInput(req); err != nil {
+		return nil, err
 	}
 
-	return &ssov1.LoginResponse{Token: token}, nil
+	tokens, err := s.auth.LoginTwoFactor(
+		ctx, req.GetChallenge(), req.GetCode(), int(req.GetAppId()), req.GetDevice(), req.GetIp(),
+	)
+	if err != nil {
+		return nil, loginError(err)
+	}
+
+	return &ssov1.LoginResponse{
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresIn:        int64(tokens.AccessTTL.Seconds()),
+		RefreshExpiresIn: int64(tokens.RefreshTTL.Seconds()),
+	}, nil
+}
+
+func (s *serverApi) Refresh(
+	ctx context.Context,
+	req *ssov1.RefreshRequest,
+) (*ssov1.RefreshResponse, error) {
+
+	if err := validation.ValidateRefreshInput(req); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.auth.Refresh(ctx, req.GetRefreshToken(), req.GetAppId())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	return &ssov1.RefreshResponse{
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		ExpiresIn:        int64(tokens.AccessTTL.Seconds()),
+		RefreshExpiresIn: int64(tokens.RefreshTTL.Seconds()),
+	}, nil
 }
 
 func (s *serverApi) Register(
@@ -73,15 +172,28 @@ func (s *serverApi) Register(
 		return nil, err
 	}
 
-	userID, err := s.auth.RegisterNewUser(ctx, req.GetEmail(), req.GetPassword())
+	userID, err := s.auth.RegisterNewUser(ctx, req.GetEmail(), req.GetPassword(), req.GetInviteCode(), req.GetWithoutRoles())
 	if err != nil {
-		if errors.Is(err, auth.ErrUserExists) {
+		switch {
+		case errors.Is(err, auth.ErrUserExists):
 			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, auth.ErrInvalidInvite):
+			return nil, validation.NewValidationError(map[string]string{
+				"invite_code": "Приглашение недействительно или устарело",
+			})
+		case errors.Is(err, auth.ErrInviteEmail):
+			return nil, validation.NewValidationError(map[string]string{
+				"email": "Приглашение выдано на другой email",
+			})
 		}
 
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	// The user is registered even if the email could not be sent: it can be requested again
+	// with ResendVerificationEmail. The account service logs the failure.
+	_ = s.account.SendVerificationEmail(ctx, req.GetEmail())
+
 	return &ssov1.RegisterResponse{UserId: userID}, nil
 }
 
@@ -96,7 +208,7 @@ func (s *serverApi) IsAdmin(
 
 	isAdmin, err := s.auth.IsAdmin(ctx, req.GetUserId())
 	if err != nil {
-		if errors.Is(err, storage.ErrUserNotFound) {
+		if errors.Is(err, auth.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
 		return nil, status.Error(codes.Internal, err.Error())
@@ -111,11 +223,8 @@ func (s *serverApi) Logout(ctx context.Context, req *ssov1.LogoutRequest) (*ssov
 		return nil, err
 	}
 
-	if err := s.auth.Logout(ctx, req.GetToken(), req.GetAppId()); err != nil {
-		//if errors.Is(err, auth.ErrInvalidToken) {
-		//	return nil, status.Error(codes.InvalidArgument, err.Error())
-		//}
-		return nil, status.Error(codes.Internal, err.Error())
+	if err := s.auth.Logout(ctx, req.GetToken(), req.GetAppId(), req.GetAllSessions()); err != nil {
+		return nil, tokenError(err)
 	}
 
 	return &ssov1.LogoutResponse{Success: true}, nil
@@ -127,19 +236,106 @@ func (s *serverApi) CheckToken(ctx context.Context, req *ssov1.CheckTokenRequest
 		return nil, err
 	}
 
+	if err := s.authenticateApp(ctx, req.GetAppId()); err != nil {
+		return nil, err
+	}
+
 	claims, err := s.auth.CheckToken(ctx, req.GetToken(), req.GetAppId())
 	if err != nil {
-		//if errors.Is(err, auth.ErrInvalidToken) {
-		//	return nil, status.Error(codes.InvalidArgument, err.Error())
-		//}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, tokenError(err)
 	}
 
 	return &ssov1.CheckTokenResponse{
-		UserId:  claims.UserId,
-		IsValid: true,
-		Roles:   claims.Roles,
-		Email:   claims.Email,
+		UserId:      claims.UserId,
+		IsValid:     true,
+		Roles:       claims.Roles,
+		Email:       claims.Email,
+		Permissions: claims.Permissions,
 	}, nil
 
 }
+
+func (s *serverApi) GetJWKS(ctx context.Context, req *ssov1.GetJWKSRequest) (*ssov1.GetJWKSResponse, error) {
+
+	if err := validation.ValidateGetJWKSInput(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.authenticateApp(ctx, req.GetAppId()); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.auth.PublicKeys(ctx, req.GetAppId())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	resp := &ssov1.GetJWKSResponse{Keys: make([]*ssov1.JWK, 0, len(keys))}
+	for _, key := range keys {
+		jwk, err := jwt.JWK(key)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Keys = append(resp.Keys, jwk)
+	}
+
+	return resp, nil
+}
+
+// authenticateApp checks the secret of the app backend in the "x-app-secret" metadata
+func (s *serverApi) authenticateApp(ctx context.Context, appID int32) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var secret string
+	if values := md.Get(appSecretMetadata); len(values) > 0 {
+		secret = values[0]
+	}
+
+	if err := s.auth.AuthenticateApp(ctx, appID, secret); err != nil {
+		return tokenError(err)
+	}
+
+	return nil
+}
+
+func tokenError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrTokenRevoked),
+		errors.Is(err, auth.ErrInvalidRefreshToken),
+		errors.Is(err, auth.ErrRefreshTokenReused),
+		errors.Is(err, auth.ErrInvalidAppSecret):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, auth.ErrInvalidAppID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func loginError(err error) error {
+	var lockout *auth.LockoutError
+	if errors.As(err, &lockout) {
+		return lockoutError(lockout)
+	}
+
+	if errors.Is(err, auth.ErrInvalidCredentials) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// lockoutError returns ResourceExhausted with RetryInfo telling the client when to try again.
+func lockoutError(lockout *auth.LockoutError) error {
+	st := status.New(codes.ResourceExhausted, lockout.Error())
+
+	stWithDetails, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(lockout.RetryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
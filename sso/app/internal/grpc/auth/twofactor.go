@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/lib/validation"
+	"github.com/Muaz717/sso/app/internal/services/twofactor"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TwoFactorSrv interface {
+	Enroll(ctx context.Context, userID int64) (secret string, uri string, err error)
+	Confirm(ctx context.Context, userID int64, code string) ([]string, error)
+	Disable(ctx context.Context, userID int64, code string) error
+}
+
+func (s *serverApi) EnrollTOTP(ctx context.Context, req *ssov1.EnrollTOTPRequest) (*ssov1.EnrollTOTPResponse, error) {
+
+	if err := validation.ValidateTOTPInput(req.GetToken(), req.GetAppId(), "", false); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.auth.CheckToken(ctx, req.GetToken(), req.GetAppId())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	secret, uri, err := s.twoFactor.Enroll(ctx, claims.UserId)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &ssov1.EnrollTOTPResponse{Secret: secret, OtpauthUri: uri}, nil
+}
+
+func (s *serverApi) ConfirmTOTP(ctx context.Context, req *ssov1.ConfirmTOTPRequest) (*ssov1.ConfirmTOTPResponse, error) {
+
+	if err := validation.ValidateTOTPInput(req.GetToken(), req.GetAppId(), req.GetCode(), true); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.auth.CheckToken(ctx, req.GetToken(), req.GetAppId())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	recoveryCodes, err := s.twoFactor.Confirm(ctx, claims.UserId, req.GetCode())
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &ssov1.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *serverApi) DisableTOTP(ctx context.Context, req *ssov1.DisableTOTPRequest) (*ssov1.DisableTOTPResponse, error) {
+
+	if err := validation.ValidateTOTPInput(req.GetToken(), req.GetAppId(), req.GetCode(), true); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.auth.CheckToken(ctx, req.GetToken(), req.GetAppId())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	if err := s.twoFactor.Disable(ctx, claims.UserId, req.GetCode()); err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &ssov1.DisableTOTPResponse{}, nil
+}
+
+// twoFactorError maps 2FA errors to statuses. A wrong code is a validation error of the field.
+func twoFactorError(err error) error {
+	switch {
+	case errors.Is(err, twofactor.ErrInvalidCode):
+		return validation.NewValidationError(map[string]string{
+			"code": "Неверный код",
+		})
+	case errors.Is(err, twofactor.ErrAlreadyEnabled),
+		errors.Is(err, twofactor.ErrNotEnabled),
+		errors.Is(err, twofactor.ErrNotEnrolled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, twofactor.ErrUserNotFound):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,35 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
+	"math/big"
+)
+
+// JWK converts the public part of a signing key to the JSON Web Key form.
+func JWK(key models.SigningKey) (*ssov1.JWK, error) {
+	jwk := &ssov1.JWK{
+		Kid: key.ID,
+		Alg: key.Algorithm,
+		Use: "sig",
+	}
+
+	switch public := key.PublicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	default:
+		return nil, fmt.Errorf("%w: public key %T", ErrUnsupportedAlgorithm, key.PublicKey)
+	}
+
+	return jwk, nil
+}
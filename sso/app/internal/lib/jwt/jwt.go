@@ -1,25 +1,64 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"github.com/Muaz717/sso/app/internal/domain/models"
 	"github.com/golang-jwt/jwt/v5"
 	"time"
 )
 
-func NewToken(user models.User, app models.App, duration time.Duration, roles []string) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
+// NewToken issues a token signed with the app key; the key id is put into the "kid" header.
+// jti identifies the token for a single logout, ver is the user's token version,
+// bumped by logout from all sessions, sid is the refresh session the token was issued for.
+func NewToken(
+	user models.User,
+	app models.App,
+	key models.SigningKey,
+	sessionID string,
+	duration time.Duration,
+	roles []string,
+	permissions []string,
+) (string, error) {
+	method, err := SigningMethod(key.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	token := jwt.New(method)
+	token.Header["kid"] = key.ID
 
 	claims := token.Claims.(jwt.MapClaims)
+	claims["jti"] = jti
+	claims["sid"] = sessionID
 	claims["uid"] = user.ID
 	claims["email"] = user.Email
 	claims["roles"] = roles
-	claims["exp"] = time.Now().Add(duration).Unix()
+	claims["perms"] = permissions
+	claims["ver"] = user.TokenVersion
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(duration).Unix()
 	claims["app_id"] = app.ID
 
-	tokenString, err := token.SignedString([]byte(app.Secret))
+	tokenString, err := token.SignedString(key.PrivateKey)
 	if err != nil {
 		return "", err
 	}
 
 	return tokenString, nil
 }
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
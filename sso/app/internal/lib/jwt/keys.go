@@ -0,0 +1,62 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+const rsaKeyBits = 2048
+
+var ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+
+// SigningMethod returns the jwt signing method for the algorithm of a signing key.
+func SigningMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case models.AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	case models.AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
+
+// GenerateKey creates a new signing key for the app.
+func GenerateKey(appID int64, algorithm string) (models.SigningKey, error) {
+	kid, err := newTokenID()
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+
+	key := models.SigningKey{
+		ID:        kid,
+		AppID:     appID,
+		Algorithm: algorithm,
+		CreatedAt: time.Now(),
+	}
+
+	switch algorithm {
+	case models.AlgorithmEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return models.SigningKey{}, err
+		}
+		key.PrivateKey, key.PublicKey = private, public
+	case models.AlgorithmRS256:
+		private, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return models.SigningKey{}, err
+		}
+		key.PrivateKey, key.PublicKey = private, &private.PublicKey
+	default:
+		return models.SigningKey{}, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	return key, nil
+}
@@ -18,34 +18,76 @@ type Claims struct {
 	AppID  int      `json:"app_id"`
 	Email  string   `json:"email"`
 	Roles  []string `json:"roles"`
+	// Permissions granted by the roles when the token was issued
+	Permissions []string `json:"perms"`
+	// TokenVersion must match users.token_version, otherwise the token was revoked by logout from all sessions
+	TokenVersion int `json:"ver"`
+	// SessionID is the refresh session, revoked together with the token on logout
+	SessionID string `json:"sid"`
 	//Exp  int64  `json:"exp"`
 	jwt.RegisteredClaims
 }
 
-func ParseToken(tokenStr string, app models.App) (*Claims, error) {
+// KeyFunc returns the app key with the given id.
+type KeyFunc func(kid string) (models.SigningKey, error)
+
+// ParseToken verifies a token of the app. Tokens with a "kid" header are checked with the public key
+// returned by keyByID. Tokens without it were issued before asymmetric signing and use the app secret,
+// or the previous one during the grace period after a rotation; they are rejected unless acceptLegacy is set.
+func ParseToken(tokenStr string, app models.App, keyByID KeyFunc, acceptLegacy bool) (*Claims, error) {
 
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if !acceptLegacy {
+				return nil, ErrInvalidToken
+			}
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, ErrInvalidToken
+			}
+
+			var keys jwt.VerificationKeySet
+			for _, secret := range app.Secrets(time.Now()) {
+				keys.Keys = append(keys.Keys, []byte(secret))
+			}
+			return keys, nil
+		}
+
+		key, err := keyByID(kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Algorithm {
 			return nil, ErrInvalidToken
 		}
-		return []byte(app.Secret), nil
+		return key.PublicKey, nil
 	})
 
 	if err != nil {
-		if errors.Is(err, jwt.ErrSignatureInvalid) {
-			return nil, ErrInvalidToken
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
 		}
-		return nil, err
+		return nil, ErrInvalidToken
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || int64(claims.AppID) != app.ID {
 		return nil, ErrInvalidToken
 	}
 
-	if claims.ExpiresAt.Time.Before(time.Now()) {
+	if claims.ExpiresAt == nil || claims.ExpiresAt.Time.Before(time.Now()) {
 		return nil, ErrTokenExpired
 	}
 
 	return claims, nil
 }
+
+// AppIDUnverified reads the app_id claim without checking the signature,
+// to know which app keys the token has to be verified with.
+func AppIDUnverified(tokenStr string) (int32, error) {
+	claims := &Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenStr, claims); err != nil {
+		return 0, ErrInvalidToken
+	}
+	return int32(claims.AppID), nil
+}
@@ -0,0 +1,40 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// LogSender writes emails to the log instead of sending them.
+// It is meant for local development and for deployments without an SMTP relay.
+type LogSender struct {
+	log *slog.Logger
+}
+
+func NewLogSender(log *slog.Logger) *LogSender {
+	return &LogSender{log: log}
+}
+
+func (s *LogSender) Send(_ context.Context, msg Message) error {
+	s.log.Info("email",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body),
+	)
+
+	return nil
+}
+
+// Link puts the token into a link template configured with a "%s" placeholder.
+// A template without the placeholder is returned as is instead of a garbled fmt output.
+func Link(urlTemplate, token string) string {
+	return strings.Replace(urlTemplate, "%s", token, 1)
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"net"
 	"strings"
 
 	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
@@ -40,6 +41,10 @@ func ValidateRegisterInput(req *ssov1.RegisterRequest) error {
 		errors["password"] = "Пароль должен содержать минимум 6 символов"
 	}
 
+	if req.GetWithoutRoles() && req.GetInviteCode() != "" {
+		errors["invite_code"] = "Приглашение нельзя использовать при регистрации без ролей"
+	}
+
 	if len(errors) > 0 {
 		return NewValidationError(errors)
 	}
@@ -61,6 +66,31 @@ func ValidateLoginInput(req *ssov1.LoginRequest) error {
 		errors["app_id"] = "App ID обязателен"
 	}
 
+	if len([]rune(req.GetDevice())) > 255 {
+		errors["device"] = "Название устройства не должно превышать 255 символов"
+	}
+
+	if req.GetIp() != "" && net.ParseIP(req.GetIp()) == nil {
+		errors["ip"] = "Неверный формат IP"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateRefreshInput(req *ssov1.RefreshRequest) error {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(req.GetRefreshToken()) == "" {
+		errors["refresh_token"] = "Refresh token обязателен"
+	}
+
+	if req.GetAppId() == 0 {
+		errors["app_id"] = "App ID обязателен"
+	}
+
 	if len(errors) > 0 {
 		return NewValidationError(errors)
 	}
@@ -109,3 +139,271 @@ func ValidateCheckTokenInput(req *ssov1.CheckTokenRequest) error {
 	}
 	return nil
 }
+
+func ValidateGetJWKSInput(req *ssov1.GetJWKSRequest) error {
+	if req.GetAppId() == 0 {
+		return NewValidationError(map[string]string{
+			"app_id": "App ID обязателен",
+		})
+	}
+	return nil
+}
+
+func ValidateListUsersInput(req *ssov1.ListUsersRequest) error {
+	errors := make(map[string]string)
+
+	if req.GetLimit() < 0 || req.GetLimit() > 500 {
+		errors["limit"] = "Limit должен быть от 0 до 500"
+	}
+
+	if req.GetOffset() < 0 {
+		errors["offset"] = "Offset не может быть отрицательным"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateRoleInput(userID int64, role string) error {
+	errors := make(map[string]string)
+
+	if userID <= 0 {
+		errors["user_id"] = "User ID обязателен"
+	}
+
+	if strings.TrimSpace(role) == "" {
+		errors["role"] = "Роль обязательна"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateCheckPermissionInput(req *ssov1.CheckPermissionRequest) error {
+	errors := make(map[string]string)
+
+	if req.GetUserId() <= 0 {
+		errors["user_id"] = "User ID обязателен"
+	}
+
+	if strings.TrimSpace(req.GetPermission()) == "" {
+		errors["permission"] = "Permission обязателен"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateChangePasswordInput(req *ssov1.ChangePasswordRequest) error {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(req.GetToken()) == "" {
+		errors["token"] = "Token обязателен"
+	}
+
+	if req.GetAppId() == 0 {
+		errors["app_id"] = "App ID обязателен"
+	}
+
+	if len([]rune(req.GetCurrentPassword())) == 0 {
+		errors["current_password"] = "Текущий пароль не должен быть пустым"
+	}
+
+	if len([]rune(req.GetNewPassword())) < 6 {
+		errors["new_password"] = "Пароль должен содержать минимум 6 символов"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateResetPasswordInput(req *ssov1.ResetPasswordRequest) error {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(req.GetToken()) == "" {
+		errors["token"] = "Token обязателен"
+	}
+
+	if len([]rune(req.GetNewPassword())) < 6 {
+		errors["new_password"] = "Пароль должен содержать минимум 6 символов"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateEmailInput(email string) error {
+	if !strings.Contains(email, "@") {
+		return NewValidationError(map[string]string{
+			"email": "Неверный формат email",
+		})
+	}
+	return nil
+}
+
+func ValidateVerifyEmailInput(req *ssov1.VerifyEmailRequest) error {
+	if strings.TrimSpace(req.GetToken()) == "" {
+		return NewValidationError(map[string]string{
+			"token": "Token обязателен",
+		})
+	}
+	return nil
+}
+
+func ValidateUnlockLoginInput(req *ssov1.UnlockLoginRequest) error {
+	errors := make(map[string]string)
+
+	if req.GetEmail() == "" && req.GetIp() == "" {
+		errors["email"] = "Укажите email или IP"
+	}
+
+	if req.GetEmail() != "" && !strings.Contains(req.GetEmail(), "@") {
+		errors["email"] = "Неверный формат email"
+	}
+
+	if req.GetIp() != "" && net.ParseIP(req.GetIp()) == nil {
+		errors["ip"] = "Неверный формат IP"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateLoginTwoFactorInput(req *ssov1.LoginTwoFactorRequest) error {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(req.GetChallenge()) == "" {
+		errors["challenge"] = "Challenge обязателен"
+	}
+
+	if strings.TrimSpace(req.GetCode()) == "" {
+		errors["code"] = "Код не должен быть пустым"
+	}
+
+	if req.GetAppId() == 0 {
+		errors["app_id"] = "App ID обязателен"
+	}
+
+	if len([]rune(req.GetDevice())) > 255 {
+		errors["device"] = "Название устройства не должно превышать 255 символов"
+	}
+
+	if req.GetIp() != "" && net.ParseIP(req.GetIp()) == nil {
+		errors["ip"] = "Неверный формат IP"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+// ValidateTOTPInput checks the requests of the 2FA RPCs. EnrollTOTP has no code, so codeRequired is false for it.
+func ValidateTOTPInput(token string, appID int32, code string, codeRequired bool) error {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(token) == "" {
+		errors["token"] = "Token обязателен"
+	}
+
+	if appID == 0 {
+		errors["app_id"] = "App ID обязателен"
+	}
+
+	if codeRequired && strings.TrimSpace(code) == "" {
+		errors["code"] = "Код не должен быть пустым"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateCreateInviteInput(req *ssov1.CreateInviteRequest) error {
+	errors := make(map[string]string)
+
+	if req.GetEmail() != "" && !strings.Contains(req.GetEmail(), "@") {
+		errors["email"] = "Неверный формат email"
+	}
+
+	if len(req.GetRoles()) == 0 {
+		errors["roles"] = "Укажите хотя бы одну роль"
+	}
+
+	for _, role := range req.GetRoles() {
+		if strings.TrimSpace(role) == "" {
+			errors["roles"] = "Роль не должна быть пустой"
+		}
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+func ValidateRevokeInviteInput(req *ssov1.RevokeInviteRequest) error {
+	if req.GetInviteId() <= 0 {
+		return NewValidationError(map[string]string{
+			"invite_id": "Invite ID обязателен",
+		})
+	}
+	return nil
+}
+
+func ValidateCreateAppInput(req *ssov1.CreateAppRequest) error {
+	name := strings.TrimSpace(req.GetName())
+
+	if name == "" {
+		return NewValidationError(map[string]string{
+			"name": "Название приложения обязательно",
+		})
+	}
+
+	if len(name) > 100 {
+		return NewValidationError(map[string]string{
+			"name": "Название приложения не должно превышать 100 символов",
+		})
+	}
+	return nil
+}
+
+func ValidateRotateAppSecretInput(req *ssov1.RotateAppSecretRequest) error {
+	errors := make(map[string]string)
+
+	if req.GetAppId() <= 0 {
+		errors["app_id"] = "App ID обязателен"
+	}
+
+	if req.GetGracePeriodSeconds() < 0 {
+		errors["grace_period_seconds"] = "Период действия старого секрета не может быть отрицательным"
+	}
+
+	if len(errors) > 0 {
+		return NewValidationError(errors)
+	}
+	return nil
+}
+
+// ValidateAppID checks the app id of DisableApp and EnableApp
+func ValidateAppID(appID int32) error {
+	if appID <= 0 {
+		return NewValidationError(map[string]string{
+			"app_id": "App ID обязателен",
+		})
+	}
+	return nil
+}
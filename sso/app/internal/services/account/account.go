@@ -0,0 +1,292 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/lib/mail"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+)
+
+type UserProvider interface {
+	User(ctx context.Context, email string) (models.User, []string, error)
+	UserByID(ctx context.Context, userID int64) (models.User, []string, error)
+}
+
+type AccountStorage interface {
+	SaveOneTimeToken(ctx context.Context, token models.OneTimeToken) error
+	UseOneTimeToken(ctx context.Context, hash string, purpose string) (models.OneTimeToken, error)
+	// SetPassword also increments the user's token_version, so access tokens issued before stop passing CheckToken.
+	SetPassword(ctx context.Context, userID int64, passHash []byte) error
+	SetEmailVerified(ctx context.Context, userID int64) error
+}
+
+// Mailer delivers emails with reset and verification links.
+type Mailer interface {
+	Send(ctx context.Context, msg mail.Message) error
+}
+
+// Account changes and resets passwords and verifies emails.
+type Account struct {
+	log     *slog.Logger
+	users   UserProvider
+	storage AccountStorage
+	mailer  Mailer
+	cfg     config.MailConfig
+}
+
+// New creates a new Account service
+func New(
+	log *slog.Logger,
+	users UserProvider,
+	storage AccountStorage,
+	mailer Mailer,
+	cfg config.MailConfig,
+) *Account {
+	return &Account{
+		log:     log,
+		users:   users,
+		storage: storage,
+		mailer:  mailer,
+		cfg:     cfg,
+	}
+}
+
+// ChangePassword replaces the password after checking the current one. All sessions of the user end.
+func (a *Account) ChangePassword(ctx context.Context, userID int64, currentPassword, newPassword string) error {
+	const op = "account.ChangePassword"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+	)
+
+	user, _, err := a.users.UserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return fmt.Errorf("%s : %w", op, ErrUserNotFound)
+		}
+
+		log.Error("failed to get user", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.PassHash, []byte(currentPassword)); err != nil {
+		log.Warn("invalid current password")
+
+		return fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+	}
+
+	if err := a.setPassword(ctx, userID, newPassword); err != nil {
+		log.Error("failed to set password", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("password changed")
+
+	return nil
+}
+
+// RequestPasswordReset emails a reset link. Unknown emails and delivery failures are not reported
+// to the caller, otherwise the RPC would tell which emails are registered.
+func (a *Account) RequestPasswordReset(ctx context.Context, email string) error {
+	const op = "account.RequestPasswordReset"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, _, err := a.users.User(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("password reset requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	link, err := a.newLink(ctx, user.ID, models.PurposePasswordReset, a.cfg.PasswordResetTTL, a.cfg.PasswordResetURL)
+	if err != nil {
+		log.Error("failed to create reset token", sl.Error(err))
+
+		return nil
+	}
+
+	err = a.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Восстановление пароля",
+		Body: fmt.Sprintf("Чтобы задать новый пароль, перейдите по ссылке: %s\n"+
+			"Ссылка действует %s. Если вы не запрашивали восстановление, просто проигнорируйте это письмо.",
+			link, a.cfg.PasswordResetTTL),
+	})
+	if err != nil {
+		log.Error("failed to send reset email", sl.Error(err))
+
+		return nil
+	}
+
+	log.Info("password reset email sent", slog.Int64("userID", user.ID))
+
+	return nil
+}
+
+// ResetPassword sets a new password by a token from the reset email. All sessions of the user end.
+func (a *Account) ResetPassword(ctx context.Context, token, newPassword string) error {
+	const op = "account.ResetPassword"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	used, err := a.storage.UseOneTimeToken(ctx, refresh.Hash(token), models.PurposePasswordReset)
+	if err != nil {
+		if errors.Is(err, storage.ErrOneTimeTokenNotFound) {
+			log.Warn("invalid reset token")
+
+			return fmt.Errorf("%s : %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to use reset token", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	if err := a.setPassword(ctx, used.UserID, newPassword); err != nil {
+		log.Error("failed to set password", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("password reset", slog.Int64("userID", used.UserID))
+
+	return nil
+}
+
+// SendVerificationEmail emails an email verification link, unless the email is already verified.
+// Like RequestPasswordReset, it doesn't report unknown emails or delivery failures.
+func (a *Account) SendVerificationEmail(ctx context.Context, email string) error {
+	const op = "account.SendVerificationEmail"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	user, _, err := a.users.User(ctx, email)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Info("verification requested for unknown email")
+
+			return nil
+		}
+
+		log.Error("failed to get user", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	link, err := a.newLink(ctx, user.ID, models.PurposeEmailVerification, a.cfg.EmailVerificationTTL, a.cfg.EmailVerificationURL)
+	if err != nil {
+		log.Error("failed to create verification token", sl.Error(err))
+
+		return nil
+	}
+
+	err = a.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Подтверждение email",
+		Body:    fmt.Sprintf("Чтобы подтвердить адрес, перейдите по ссылке: %s\nСсылка действует %s.", link, a.cfg.EmailVerificationTTL),
+	})
+	if err != nil {
+		log.Error("failed to send verification email", sl.Error(err))
+
+		return nil
+	}
+
+	log.Info("verification email sent", slog.Int64("userID", user.ID))
+
+	return nil
+}
+
+// VerifyEmail marks the email as verified by a token from the verification email.
+func (a *Account) VerifyEmail(ctx context.Context, token string) error {
+	const op = "account.VerifyEmail"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	used, err := a.storage.UseOneTimeToken(ctx, refresh.Hash(token), models.PurposeEmailVerification)
+	if err != nil {
+		if errors.Is(err, storage.ErrOneTimeTokenNotFound) {
+			log.Warn("invalid verification token")
+
+			return fmt.Errorf("%s : %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to use verification token", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	if err := a.storage.SetEmailVerified(ctx, used.UserID); err != nil {
+		log.Error("failed to verify email", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("email verified", slog.Int64("userID", used.UserID))
+
+	return nil
+}
+
+func (a *Account) setPassword(ctx context.Context, userID int64, password string) error {
+	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return a.storage.SetPassword(ctx, userID, passHash)
+}
+
+// newLink stores a new one-time token and returns the link with it.
+func (a *Account) newLink(ctx context.Context, userID int64, purpose string, ttl time.Duration, urlTemplate string) (string, error) {
+	token, hash, err := refresh.NewToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = a.storage.SaveOneTimeToken(ctx, models.OneTimeToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return mail.Link(urlTemplate, token), nil
+}
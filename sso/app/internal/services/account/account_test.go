@@ -0,0 +1,170 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/sso/app/internal/lib/mail"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/services/account/mocks"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+	"time"
+)
+
+const resetURL = "https://gym.local/reset?token=%s"
+
+type testAccount struct {
+	*Account
+	users   *mocks.UserProvider
+	storage *mocks.AccountStorage
+	mailer  *mocks.Mailer
+	user    models.User
+}
+
+func newTestAccount(t *testing.T) *testAccount {
+	users := mocks.NewUserProvider(t)
+	st := mocks.NewAccountStorage(t)
+	mailer := mocks.NewMailer(t)
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	cfg := config.MailConfig{
+		PasswordResetURL:     resetURL,
+		EmailVerificationURL: "https://gym.local/verify?token=%s",
+		PasswordResetTTL:     time.Hour,
+		EmailVerificationTTL: 72 * time.Hour,
+	}
+
+	return &testAccount{
+		Account: New(slogdiscard.NewDiscardLogger(), users, st, mailer, cfg),
+		users:   users,
+		storage: st,
+		mailer:  mailer,
+		user:    models.User{ID: 1, Email: "user@gym.local", PassHash: passHash},
+	}
+}
+
+// hashOf matches a bcrypt hash of the password.
+func hashOf(password string) interface{} {
+	return mock.MatchedBy(func(hash []byte) bool {
+		return bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil
+	})
+}
+
+func TestPasswordReset_TokenIsSingleUse(t *testing.T) {
+	a := newTestAccount(t)
+
+	var (
+		saved models.OneTimeToken
+		msg   mail.Message
+	)
+
+	a.users.EXPECT().User(mock.Anything, a.user.Email).Return(a.user, nil, nil).Once()
+	a.storage.EXPECT().SaveOneTimeToken(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, token models.OneTimeToken) { saved = token }).
+		Return(nil).Once()
+	a.mailer.EXPECT().Send(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, m mail.Message) { msg = m }).
+		Return(nil).Once()
+
+	require.NoError(t, a.RequestPasswordReset(context.Background(), a.user.Email))
+
+	_, rest, ok := strings.Cut(msg.Body, strings.TrimSuffix(resetURL, "%s"))
+	require.True(t, ok, "email has no reset link: %q", msg.Body)
+	token := strings.Fields(rest)[0]
+
+	assert.Equal(t, a.user.Email, msg.To)
+	assert.Equal(t, a.user.ID, saved.UserID)
+	assert.Equal(t, models.PurposePasswordReset, saved.Purpose)
+	assert.Equal(t, refresh.Hash(token), saved.TokenHash, "only the token hash is stored")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), saved.ExpiresAt, time.Second)
+
+	// Storage consumes the token on first use, the second lookup finds nothing
+	a.storage.EXPECT().UseOneTimeToken(mock.Anything, refresh.Hash(token), models.PurposePasswordReset).
+		Return(saved, nil).Once()
+	a.storage.EXPECT().UseOneTimeToken(mock.Anything, refresh.Hash(token), models.PurposePasswordReset).
+		Return(models.OneTimeToken{}, storage.ErrOneTimeTokenNotFound).Once()
+	a.storage.EXPECT().SetPassword(mock.Anything, a.user.ID, hashOf("new-password")).Return(nil).Once()
+
+	require.NoError(t, a.ResetPassword(context.Background(), token, "new-password"))
+
+	err := a.ResetPassword(context.Background(), token, "another-password")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestRequestPasswordReset_HidesFailures(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(a *testAccount)
+	}{
+		{
+			name: "unknown email",
+			setup: func(a *testAccount) {
+				a.users.EXPECT().User(mock.Anything, a.user.Email).Return(models.User{}, nil, storage.ErrUserNotFound).Once()
+			},
+		},
+		{
+			name: "token not saved",
+			setup: func(a *testAccount) {
+				a.users.EXPECT().User(mock.Anything, a.user.Email).Return(a.user, nil, nil).Once()
+				a.storage.EXPECT().SaveOneTimeToken(mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+			},
+		},
+		{
+			name: "mail not sent",
+			setup: func(a *testAccount) {
+				a.users.EXPECT().User(mock.Anything, a.user.Email).Return(a.user, nil, nil).Once()
+				a.storage.EXPECT().SaveOneTimeToken(mock.Anything, mock.Anything).Return(nil).Once()
+				a.mailer.EXPECT().Send(mock.Anything, mock.Anything).Return(errors.New("smtp: 421")).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAccount(t)
+			tt.setup(a)
+
+			assert.NoError(t, a.RequestPasswordReset(context.Background(), a.user.Email))
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	a := newTestAccount(t)
+
+	a.users.EXPECT().UserByID(mock.Anything, a.user.ID).Return(a.user, nil, nil).Twice()
+
+	err := a.ChangePassword(context.Background(), a.user.ID, "wrong-password", "new-password")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// SetPassword bumps token_version, which ends the other sessions of the user
+	a.storage.EXPECT().SetPassword(mock.Anything, a.user.ID, hashOf("new-password")).Return(nil).Once()
+
+	require.NoError(t, a.ChangePassword(context.Background(), a.user.ID, "old-password", "new-password"))
+}
+
+func TestVerifyEmail(t *testing.T) {
+	a := newTestAccount(t)
+
+	a.storage.EXPECT().UseOneTimeToken(mock.Anything, refresh.Hash("expired"), models.PurposeEmailVerification).
+		Return(models.OneTimeToken{}, storage.ErrOneTimeTokenNotFound).Once()
+
+	err := a.VerifyEmail(context.Background(), "expired")
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	a.storage.EXPECT().UseOneTimeToken(mock.Anything, refresh.Hash("valid"), models.PurposeEmailVerification).
+		Return(models.OneTimeToken{UserID: a.user.ID, Purpose: models.PurposeEmailVerification}, nil).Once()
+	a.storage.EXPECT().SetEmailVerified(mock.Anything, a.user.ID).Return(nil).Once()
+
+	require.NoError(t, a.VerifyEmail(context.Background(), "valid"))
+}
@@ -0,0 +1,176 @@
+package apps
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// secretSize is the number of random bytes in an app secret
+const secretSize = 32
+
+var (
+	ErrAppNotFound = errors.New("app not found")
+	ErrAppExists   = errors.New("app already exists")
+)
+
+type AppStorage interface {
+	Apps(ctx context.Context) ([]models.App, error)
+	SaveApp(ctx context.Context, name, secret string) (models.App, error)
+	RotateAppSecret(ctx context.Context, appID int64, secret string, previousExpiresAt time.Time) (models.App, error)
+	SetAppDisabled(ctx context.Context, appID int64, disabled bool) error
+}
+
+// Apps manages the clients of the SSO and their secrets.
+type Apps struct {
+	log         *slog.Logger
+	storage     AppStorage
+	gracePeriod time.Duration
+}
+
+// New creates a new Apps service. gracePeriod is the default time the previous secret
+// stays valid after a rotation.
+func New(log *slog.Logger, storage AppStorage, gracePeriod time.Duration) *Apps {
+	return &Apps{
+		log:         log,
+		storage:     storage,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// Create registers an app with a new secret. The secret is returned in the app.
+func (a *Apps) Create(ctx context.Context, name string) (models.App, error) {
+	const op = "apps.Create"
+
+	name = strings.TrimSpace(name)
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("name", name),
+	)
+
+	secret, err := newSecret()
+	if err != nil {
+		log.Error("failed to generate secret", sl.Error(err))
+
+		return models.App{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	app, err := a.storage.SaveApp(ctx, name, secret)
+	if err != nil {
+		if errors.Is(err, storage.ErrAppExists) {
+			log.Warn("app already exists", sl.Error(err))
+
+			return models.App{}, fmt.Errorf("%s : %w", op, ErrAppExists)
+		}
+
+		log.Error("failed to save app", sl.Error(err))
+
+		return models.App{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("app created", slog.Int64("appID", app.ID))
+
+	return app, nil
+}
+
+func (a *Apps) List(ctx context.Context) ([]models.App, error) {
+	const op = "apps.List"
+
+	apps, err := a.storage.Apps(ctx)
+	if err != nil {
+		a.log.Error("failed to list apps", slog.String("op", op), sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return apps, nil
+}
+
+// RotateSecret issues a new secret for the app. The current secret keeps authenticating
+// the app for gracePeriod, or for the configured default if gracePeriod is zero.
+func (a *Apps) RotateSecret(ctx context.Context, appID int64, gracePeriod time.Duration) (models.App, error) {
+	const op = "apps.RotateSecret"
+
+	if gracePeriod == 0 {
+		gracePeriod = a.gracePeriod
+	}
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("appID", appID),
+		slog.Duration("gracePeriod", gracePeriod),
+	)
+
+	secret, err := newSecret()
+	if err != nil {
+		log.Error("failed to generate secret", sl.Error(err))
+
+		return models.App{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	app, err := a.storage.RotateAppSecret(ctx, appID, secret, time.Now().Add(gracePeriod))
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found", sl.Error(err))
+
+			return models.App{}, fmt.Errorf("%s : %w", op, ErrAppNotFound)
+		}
+
+		log.Error("failed to rotate secret", sl.Error(err))
+
+		return models.App{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("app secret rotated")
+
+	return app, nil
+}
+
+// Disable stops the app from logging users in, refreshing and checking tokens.
+func (a *Apps) Disable(ctx context.Context, appID int64) error {
+	return a.setDisabled(ctx, "apps.Disable", appID, true)
+}
+
+func (a *Apps) Enable(ctx context.Context, appID int64) error {
+	return a.setDisabled(ctx, "apps.Enable", appID, false)
+}
+
+func (a *Apps) setDisabled(ctx context.Context, op string, appID int64, disabled bool) error {
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int64("appID", appID),
+	)
+
+	if err := a.storage.SetAppDisabled(ctx, appID, disabled); err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrAppNotFound)
+		}
+
+		log.Error("failed to update app", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("app updated", slog.Bool("disabled", disabled))
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
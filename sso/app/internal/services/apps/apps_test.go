@@ -0,0 +1,112 @@
+package apps
+
+import (
+	"context"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/sso/app/internal/services/apps/mocks"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testGracePeriod = 24 * time.Hour
+
+func newTestApps(t *testing.T) (*Apps, *mocks.AppStorage) {
+	st := mocks.NewAppStorage(t)
+	return New(slogdiscard.NewDiscardLogger(), st, testGracePeriod), st
+}
+
+// about matches a time within a second of now + d
+func about(d time.Duration) interface{} {
+	return mock.MatchedBy(func(at time.Time) bool {
+		diff := time.Until(at) - d
+		return diff > -time.Second && diff < time.Second
+	})
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	a, st := newTestApps(t)
+
+	var secrets []string
+
+	st.EXPECT().SaveApp(mock.Anything, "gym_app", mock.Anything).
+		RunAndReturn(func(_ context.Context, name, secret string) (models.App, error) {
+			secrets = append(secrets, secret)
+			return models.App{ID: int64(len(secrets)), Name: name, Secret: secret}, nil
+		}).Twice()
+
+	gym, err := a.Create(ctx, "  gym_app ")
+	require.NoError(t, err)
+	assert.Equal(t, "gym_app", gym.Name)
+	assert.Len(t, gym.Secret, 43, "32 random bytes in base64url")
+
+	again, err := a.Create(ctx, "gym_app")
+	require.NoError(t, err)
+	assert.NotEqual(t, gym.Secret, again.Secret)
+
+	st.EXPECT().SaveApp(mock.Anything, "bot", mock.Anything).Return(models.App{}, storage.ErrAppExists).Once()
+
+	_, err = a.Create(ctx, "bot")
+	assert.ErrorIs(t, err, ErrAppExists)
+}
+
+func TestRotateSecret(t *testing.T) {
+	ctx := context.Background()
+	a, st := newTestApps(t)
+
+	var secret string
+
+	st.EXPECT().RotateAppSecret(mock.Anything, int64(1), mock.Anything, about(testGracePeriod)).
+		Run(func(_ context.Context, _ int64, s string, _ time.Time) { secret = s }).
+		Return(models.App{ID: 1}, nil).Once()
+
+	_, err := a.RotateSecret(ctx, 1, 0)
+	require.NoError(t, err)
+	assert.Len(t, secret, 43)
+
+	st.EXPECT().RotateAppSecret(mock.Anything, int64(1), mock.Anything, about(time.Hour)).
+		Return(models.App{ID: 1}, nil).Once()
+
+	_, err = a.RotateSecret(ctx, 1, time.Hour)
+	require.NoError(t, err)
+
+	st.EXPECT().RotateAppSecret(mock.Anything, int64(42), mock.Anything, mock.Anything).
+		Return(models.App{}, storage.ErrAppNotFound).Once()
+
+	_, err = a.RotateSecret(ctx, 42, 0)
+	assert.ErrorIs(t, err, ErrAppNotFound)
+}
+
+func TestSecrets(t *testing.T) {
+	now := time.Now()
+	app := models.App{
+		Secret:                  "new",
+		PreviousSecret:          "old",
+		PreviousSecretExpiresAt: now.Add(testGracePeriod),
+	}
+
+	// both secrets are accepted until the grace period ends, then only the new one
+	assert.Equal(t, []string{"new", "old"}, app.Secrets(now))
+	assert.Equal(t, []string{"new"}, app.Secrets(now.Add(testGracePeriod+time.Second)))
+}
+
+func TestDisableEnable(t *testing.T) {
+	ctx := context.Background()
+	a, st := newTestApps(t)
+
+	st.EXPECT().SetAppDisabled(mock.Anything, int64(1), true).Return(nil).Once()
+	st.EXPECT().SetAppDisabled(mock.Anything, int64(1), false).Return(nil).Once()
+
+	require.NoError(t, a.Disable(ctx, 1))
+	require.NoError(t, a.Enable(ctx, 1))
+
+	st.EXPECT().SetAppDisabled(mock.Anything, int64(42), mock.Anything).Return(storage.ErrAppNotFound).Twice()
+
+	assert.ErrorIs(t, a.Disable(ctx, 42), ErrAppNotFound)
+	assert.ErrorIs(t, a.Enable(ctx, 42), ErrAppNotFound)
+}
@@ -2,11 +2,13 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"github.com/Muaz717/sso/app/internal/domain/models"
 	"github.com/Muaz717/sso/app/internal/lib/jwt"
 	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
 	"github.com/Muaz717/sso/app/internal/storage"
 	"golang.org/x/crypto/bcrypt"
 	"log/slog"
@@ -18,7 +20,16 @@ type Auth struct {
 	userSaver    UserSaver
 	userProvider UserProvider
 	appProvider  AppProvider
+	tokenStore   TokenStore
+	keys         KeyProvider
+	loginGuard   LoginGuard
+	twoFactor    TwoFactor
 	tokenTTL     time.Duration
+	refreshTTL   time.Duration
+	// openRegistration gives users registered without an invite the default role
+	openRegistration bool
+	// legacyTokens accepts HS256 tokens signed with the app secret, see config.JWTConfig
+	legacyTokens bool
 }
 
 type UserSaver interface {
@@ -28,49 +39,154 @@ type UserSaver interface {
 		passHash []byte,
 		role string,
 	) (uid int64, err error)
+	SaveInvitedUser(ctx context.Context, email string, passHash []byte, codeHash string) (uid int64, err error)
 }
 
 type UserProvider interface {
 	User(ctx context.Context, email string) (models.User, []string, error)
+	UserByID(ctx context.Context, userID int64) (models.User, []string, error)
 	IsAdmin(ctx context.Context, userID int64) (bool, error)
-	Logout(ctx context.Context, email string) error
+	Permissions(ctx context.Context, userID int64) ([]string, error)
 }
 
 type AppProvider interface {
 	App(ctx context.Context, appID int) (models.App, error)
 }
 
+type TokenStore interface {
+	RevokeToken(ctx context.Context, jti string, userID int64, expiresAt time.Time) error
+	RevokeAllTokens(ctx context.Context, userID int64) error
+	TokenState(ctx context.Context, userID int64, jti string, sessionID string) (version int, revoked bool, err error)
+	DeleteExpiredTokens(ctx context.Context) (int64, error)
+	SaveRefreshToken(ctx context.Context, token models.RefreshToken) error
+	RotateRefreshToken(
+		ctx context.Context,
+		hash string,
+		appID int64,
+		next models.RefreshToken,
+		reuseInterval time.Duration,
+	) (models.RefreshToken, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+}
+
+type KeyProvider interface {
+	SigningKey(ctx context.Context, appID int64) (models.SigningKey, error)
+	VerificationKey(ctx context.Context, appID int64, kid string) (models.SigningKey, error)
+	PublicKeys(ctx context.Context, appID int64) ([]models.SigningKey, error)
+	DeleteRetired(ctx context.Context) error
+}
+
+type LoginGuard interface {
+	Check(ctx context.Context, email, ip string) (time.Duration, error)
+	Failed(ctx context.Context, attempt models.LoginAttempt) error
+	Succeeded(ctx context.Context, email string) error
+	Unlock(ctx context.Context, email, ip string) (bool, error)
+	Cleanup(ctx context.Context) error
+}
+
+type TwoFactor interface {
+	Enabled(ctx context.Context, userID int64) (bool, error)
+	Required(roles []string) bool
+	Verify(ctx context.Context, userID int64, code string) (bool, error)
+	NewChallenge(ctx context.Context, userID int64) (string, error)
+	UseChallenge(ctx context.Context, challenge string) (int64, error)
+}
+
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidAppID       = errors.New("invalid app id")
+	ErrInvalidAppSecret   = errors.New("invalid app secret")
 	ErrUserExists         = errors.New("user already exists")
+	ErrInvalidInvite      = errors.New("invalid or expired invite")
+	ErrInviteEmail        = errors.New("invite was issued for another email")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrTokenRevoked       = errors.New("token revoked")
+
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+
+	ErrTooManyAttempts = errors.New("too many login attempts")
+
+	ErrTwoFactorRequired = errors.New("two-factor authentication required")
 )
 
+// LockoutError is returned by Login while the email or the client IP is locked.
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// TwoFactorRequiredError is returned by Login instead of tokens when the user has 2FA enabled.
+// The challenge and a code are exchanged for tokens with LoginTwoFactor.
+type TwoFactorRequiredError struct {
+	Challenge string
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return ErrTwoFactorRequired.Error()
+}
+
+func (e *TwoFactorRequiredError) Unwrap() error {
+	return ErrTwoFactorRequired
+}
+
 const userRole = "user"
 
+// refreshReuseInterval is how long a used refresh token may be presented again. Requests that were
+// sent before the client stored the rotated token are not a theft and must not end the session.
+const refreshReuseInterval = 10 * time.Second
+
 // New creates a new Auth service
 func New(
 	log *slog.Logger,
 	userSaver UserSaver,
 	userProvider UserProvider,
 	appProvider AppProvider,
+	tokenStore TokenStore,
+	keys KeyProvider,
+	loginGuard LoginGuard,
+	twoFactor TwoFactor,
 	tokenTTL time.Duration,
+	refreshTTL time.Duration,
+	openRegistration bool,
+	legacyTokens bool,
 ) *Auth {
 	return &Auth{
 		log:          log,
 		userSaver:    userSaver,
 		userProvider: userProvider,
 		appProvider:  appProvider,
+		tokenStore:   tokenStore,
+		keys:         keys,
+		loginGuard:   loginGuard,
+		twoFactor:    twoFactor,
 		tokenTTL:     tokenTTL,
+		refreshTTL:   refreshTTL,
+
+		openRegistration: openRegistration,
+		legacyTokens:     legacyTokens,
 	}
 }
 
+// Login checks the credentials and starts a new session: an access token and a refresh token.
+// device is a free-form session label, e.g. the User-Agent, ip is the client address
+// that failed logins are limited by along with the email.
 func (a *Auth) Login(
 	ctx context.Context,
 	email string,
 	password string,
 	appID int,
-) (token string, err error) {
+	device string,
+	ip string,
+) (models.TokenPair, error) {
 
 	const op = "auth.Login"
 
@@ -81,23 +197,40 @@ func (a *Auth) Login(
 
 	log.Info("attempting to login")
 
+	retryAfter, err := a.loginGuard.Check(ctx, email, ip)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+	if retryAfter > 0 {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, &LockoutError{RetryAfter: retryAfter})
+	}
+
 	user, roles, err := a.userProvider.User(ctx, email)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			log.Warn("user not found", sl.Error(err))
 
-			return "", fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+			return models.TokenPair{}, a.loginFailed(ctx, op, models.LoginAttempt{
+				Email:  email,
+				IP:     ip,
+				Reason: models.LoginReasonUnknownEmail,
+			})
 		}
 
 		log.Error("failed to get user", sl.Error(err))
 
-		return "", fmt.Errorf("%s : %w", op, err)
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
 	}
 
 	if err := bcrypt.CompareHashAndPassword(user.PassHash, []byte(password)); err != nil {
 		log.Warn("invalid password", sl.Error(err))
 
-		return "", fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+		return models.TokenPair{}, a.loginFailed(ctx, op, models.LoginAttempt{
+			Email:  email,
+			IP:     ip,
+			UserID: user.ID,
+			Reason: models.LoginReasonInvalidPassword,
+		})
 	}
 
 	app, err := a.appProvider.App(ctx, appID)
@@ -105,30 +238,248 @@ func (a *Auth) Login(
 		if errors.Is(err, storage.ErrAppNotFound) {
 			log.Warn("app not found", sl.Error(err))
 
-			return "", fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
 		}
 
 		log.Error("failed to get app", sl.Error(err))
 
-		return "", fmt.Errorf("%s : %w", op, err)
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	enabled, err := a.twoFactor.Enabled(ctx, user.ID)
+	if err != nil {
+		log.Error("failed to check 2fa", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	// The password is right, but the failures of the email are kept until the code is checked too:
+	// otherwise each correct password would give a fresh set of attempts to guess the code.
+	if enabled {
+		challenge, err := a.twoFactor.NewChallenge(ctx, user.ID)
+		if err != nil {
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+		}
+
+		log.Info("second factor required")
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, &TwoFactorRequiredError{Challenge: challenge})
+	}
+
+	if err := a.loginGuard.Succeeded(ctx, email); err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	pair, err := a.startSession(ctx, user, roles, app, device)
+	if err != nil {
+		log.Error("failed to start session", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
 	}
 
 	log.Info("user logged in successfully")
 
-	token, err = jwt.NewToken(user, app, a.tokenTTL, roles)
+	return pair, nil
+}
+
+// LoginTwoFactor is the second step of a login with 2FA: it exchanges the challenge returned
+// by Login and a TOTP or recovery code for a token pair.
+func (a *Auth) LoginTwoFactor(
+	ctx context.Context,
+	challenge string,
+	code string,
+	appID int,
+	device string,
+	ip string,
+) (models.TokenPair, error) {
+
+	const op = "auth.LoginTwoFactor"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	userID, err := a.twoFactor.UseChallenge(ctx, challenge)
+	if err != nil {
+		log.Warn("invalid challenge", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+	}
+
+	user, roles, err := a.userProvider.UserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+		}
+
+		log.Error("failed to get user", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log = log.With(slog.String("email", user.Email))
+
+	retryAfter, err := a.loginGuard.Check(ctx, user.Email, ip)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+	if retryAfter > 0 {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, &LockoutError{RetryAfter: retryAfter})
+	}
+
+	ok, err := a.twoFactor.Verify(ctx, user.ID, code)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+	if !ok {
+		log.Warn("invalid 2fa code")
+
+		return models.TokenPair{}, a.loginFailed(ctx, op, models.LoginAttempt{
+			Email:  user.Email,
+			IP:     ip,
+			UserID: user.ID,
+			Reason: models.LoginReasonInvalidCode,
+		})
+	}
+
+	if err := a.loginGuard.Succeeded(ctx, user.Email); err != nil {
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	app, err := a.appProvider.App(ctx, appID)
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found", sl.Error(err))
+
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+		}
+
+		log.Error("failed to get app", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	pair, err := a.startSession(ctx, user, roles, app, device)
+	if err != nil {
+		log.Error("failed to start session", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("user logged in with 2fa")
+
+	return pair, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. The presented refresh token
+// can be used only once; presenting it again revokes the whole session, unless it happens
+// within refreshReuseInterval, which parallel requests of one client hitting an expired token do.
+func (a *Auth) Refresh(ctx context.Context, refreshToken string, appID int32) (models.TokenPair, error) {
+	const op = "auth.Refresh"
+
+	log := a.log.With(
+		slog.String("op", op),
+	)
+
+	log.Info("refreshing token")
+
+	app, err := a.appProvider.App(ctx, int(appID))
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found", sl.Error(err))
+
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidAppID)
+		}
+
+		log.Error("failed to get app", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	nextToken, nextHash, err := refresh.NewToken()
+	if err != nil {
+		log.Error("failed to generate refresh token", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	current, err := a.tokenStore.RotateRefreshToken(ctx, refresh.Hash(refreshToken), app.ID, models.RefreshToken{
+		TokenHash: nextHash,
+		ExpiresAt: time.Now().Add(a.refreshTTL),
+	}, refreshReuseInterval)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrRefreshTokenNotFound):
+			log.Warn("refresh token not found", sl.Error(err))
+
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidRefreshToken)
+		case errors.Is(err, storage.ErrRefreshTokenReused):
+			log.Warn("refresh token reuse detected, session revoked",
+				slog.Int64("userID", current.UserID),
+				slog.String("sessionID", current.SessionID),
+			)
+
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrRefreshTokenReused)
+		}
+
+		log.Error("failed to rotate refresh token", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	user, roles, err := a.userProvider.UserByID(ctx, current.UserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+
+			return models.TokenPair{}, fmt.Errorf("%s : %w", op, ErrInvalidRefreshToken)
+		}
+
+		log.Error("failed to get user", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	key, err := a.keys.SigningKey(ctx, app.ID)
+	if err != nil {
+		log.Error("failed to get signing key", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	permissions, err := a.permissions(ctx, user, roles)
+	if err != nil {
+		log.Error("failed to get permissions", sl.Error(err))
+
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	token, err := jwt.NewToken(user, app, key, current.SessionID, a.tokenTTL, roles, permissions)
 	if err != nil {
 		log.Error("failed to generate token", sl.Error(err))
 
-		return "", fmt.Errorf("%s : %w", op, err)
+		return models.TokenPair{}, fmt.Errorf("%s : %w", op, err)
 	}
 
-	return token, nil
+	log.Info("token refreshed", slog.Int64("userID", user.ID))
+
+	return models.TokenPair{
+		AccessToken:  token,
+		RefreshToken: nextToken,
+		AccessTTL:    a.tokenTTL,
+		RefreshTTL:   a.refreshTTL,
+	}, nil
 }
 
+// RegisterNewUser creates a user. With an invite code the user gets the roles of the invite,
+// otherwise the default role if registration is open and no role at all if it is not.
+// withoutRoles skips the default role, for accounts that get access elsewhere, like gym members.
 func (a *Auth) RegisterNewUser(
 	ctx context.Context,
 	email string,
 	password string,
+	inviteCode string,
+	withoutRoles bool,
 ) (userID int64, err error) {
 	const op = "auth.RegisterNewUser"
 
@@ -146,12 +497,35 @@ func (a *Auth) RegisterNewUser(
 		return 0, fmt.Errorf("%s : %w", op, err)
 	}
 
-	id, err := a.userSaver.SaveUser(ctx, email, passHash, userRole)
+	var id int64
+	if inviteCode != "" {
+		id, err = a.userSaver.SaveInvitedUser(ctx, email, passHash, refresh.Hash(inviteCode))
+	} else {
+		role := userRole
+		switch {
+		case withoutRoles:
+			role = ""
+		case !a.openRegistration:
+			log.Info("open registration is disabled, registering without roles")
+
+			role = ""
+		}
+		id, err = a.userSaver.SaveUser(ctx, email, passHash, role)
+	}
 	if err != nil {
-		if errors.Is(err, storage.ErrUserExists) {
+		switch {
+		case errors.Is(err, storage.ErrUserExists):
 			log.Warn("user already exists", sl.Error(err))
 
 			return 0, fmt.Errorf("%s : %w", op, ErrUserExists)
+		case errors.Is(err, storage.ErrInviteNotFound), errors.Is(err, storage.ErrRoleNotFound):
+			log.Warn("invalid invite", sl.Error(err))
+
+			return 0, fmt.Errorf("%s : %w", op, ErrInvalidInvite)
+		case errors.Is(err, storage.ErrInviteEmailMismatch):
+			log.Warn("invite email mismatch", sl.Error(err))
+
+			return 0, fmt.Errorf("%s : %w", op, ErrInviteEmail)
 		}
 
 		log.Error("failed to save user", sl.Error(err))
@@ -176,10 +550,10 @@ func (a *Auth) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 
 	isAdmin, err := a.userProvider.IsAdmin(ctx, userID)
 	if err != nil {
-		if errors.Is(err, storage.ErrAppNotFound) {
+		if errors.Is(err, storage.ErrUserNotFound) {
 			log.Warn("user not found", sl.Error(err))
 
-			return false, fmt.Errorf("%s : %w", op, ErrInvalidAppID)
+			return false, fmt.Errorf("%s : %w", op, ErrUserNotFound)
 		}
 
 		log.Error("failed to check if user is admin", sl.Error(err))
@@ -192,37 +566,43 @@ func (a *Auth) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 	return isAdmin, nil
 }
 
-func (a *Auth) Logout(ctx context.Context, token string, appID int32) error {
+// Logout revokes the given token. With all set, every token of the user is revoked.
+func (a *Auth) Logout(ctx context.Context, token string, appID int32, all bool) error {
 	const op = "auth.Logout"
 
 	log := a.log.With(
 		slog.String("op", op),
-		slog.String("token", token),
+		slog.Bool("all", all),
 	)
 
 	log.Info("logging out user")
 
-	app, err := a.appProvider.App(ctx, int(appID))
+	claims, err := a.parseToken(ctx, token, appID)
 	if err != nil {
-		if errors.Is(err, storage.ErrAppNotFound) {
-			log.Warn("app not found", sl.Error(err))
-
-			return fmt.Errorf("%s : %w", op, ErrInvalidAppID)
-		}
-
-		log.Error("failed to get app", sl.Error(err))
+		log.Warn("failed to parse token", sl.Error(err))
 
 		return fmt.Errorf("%s : %w", op, err)
 	}
 
-	claims, err := jwt.ParseToken(token, app)
-	if err != nil {
-		return fmt.Errorf("%s : %w", op, err)
-	}
+	log = log.With(slog.Int64("userID", claims.UserId))
 
-	err = a.userProvider.Logout(ctx, claims.Email)
+	// tokens issued before jti was introduced can only be revoked together with all others
+	if all || claims.ID == "" {
+		err = a.tokenStore.RevokeAllTokens(ctx, claims.UserId)
+	} else {
+		err = a.tokenStore.RevokeToken(ctx, claims.ID, claims.UserId, claims.ExpiresAt.Time)
+		if err == nil && claims.SessionID != "" {
+			err = a.tokenStore.RevokeSession(ctx, claims.SessionID)
+		}
+	}
 	if err != nil {
-		log.Error("failed to logout user", sl.Error(err))
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrInvalidToken)
+		}
+
+		log.Error("failed to revoke token", sl.Error(err))
 
 		return fmt.Errorf("%s : %w", op, err)
 	}
@@ -237,11 +617,176 @@ func (a *Auth) CheckToken(ctx context.Context, token string, appID int32) (*jwt.
 
 	log := a.log.With(
 		slog.String("op", op),
-		slog.String("token", token),
 	)
 
 	log.Info("checking token")
 
+	claims, err := a.parseToken(ctx, token, appID)
+	if err != nil {
+		log.Warn("failed to parse token", sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	version, revoked, err := a.tokenStore.TokenState(ctx, claims.UserId, claims.ID, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user of token not found", sl.Error(err))
+
+			return nil, fmt.Errorf("%s : %w", op, ErrTokenRevoked)
+		}
+
+		log.Error("failed to get token state", sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if revoked || version != claims.TokenVersion {
+		log.Info("token is revoked", slog.Int64("userID", claims.UserId))
+
+		return nil, fmt.Errorf("%s : %w", op, ErrTokenRevoked)
+	}
+
+	log.Info("token is valid")
+
+	return claims, nil
+}
+
+// CleanupExpiredTokens deletes revocations, refresh tokens and signing keys that have already expired.
+func (a *Auth) CleanupExpiredTokens(ctx context.Context) error {
+	const op = "auth.CleanupExpiredTokens"
+
+	log := a.log.With(slog.String("op", op))
+
+	deleted, err := a.tokenStore.DeleteExpiredTokens(ctx)
+	if err != nil {
+		log.Error("failed to delete expired tokens", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Debug("expired tokens deleted", slog.Int64("deleted", deleted))
+
+	if err := a.keys.DeleteRetired(ctx); err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	if err := a.loginGuard.Cleanup(ctx); err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	return nil
+}
+
+// UnlockLogin lifts the lockout of the email and/or the ip, see LoginGuard.
+func (a *Auth) UnlockLogin(ctx context.Context, email, ip string) (bool, error) {
+	const op = "auth.UnlockLogin"
+
+	unlocked, err := a.loginGuard.Unlock(ctx, email, ip)
+	if err != nil {
+		return false, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return unlocked, nil
+}
+
+// startSession issues the first token pair of a new session.
+func (a *Auth) startSession(
+	ctx context.Context,
+	user models.User,
+	roles []string,
+	app models.App,
+	device string,
+) (models.TokenPair, error) {
+	sessionID, err := refresh.NewSessionID()
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("generate session id: %w", err)
+	}
+
+	refreshToken, refreshHash, err := refresh.NewToken()
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	err = a.tokenStore.SaveRefreshToken(ctx, models.RefreshToken{
+		SessionID: sessionID,
+		UserID:    user.ID,
+		AppID:     app.ID,
+		TokenHash: refreshHash,
+		Device:    device,
+		ExpiresAt: time.Now().Add(a.refreshTTL),
+	})
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("save refresh token: %w", err)
+	}
+
+	key, err := a.keys.SigningKey(ctx, app.ID)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("get signing key: %w", err)
+	}
+
+	permissions, err := a.permissions(ctx, user, roles)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	token, err := jwt.NewToken(user, app, key, sessionID, a.tokenTTL, roles, permissions)
+	if err != nil {
+		return models.TokenPair{}, fmt.Errorf("generate token: %w", err)
+	}
+
+	return models.TokenPair{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		AccessTTL:    a.tokenTTL,
+		RefreshTTL:   a.refreshTTL,
+	}, nil
+}
+
+// permissions returns the permissions to put into a token of the user. Users whose role
+// requires 2FA get none until they enable it: the token is then only good for the enrollment.
+func (a *Auth) permissions(ctx context.Context, user models.User, roles []string) ([]string, error) {
+	permissions, err := a.userProvider.Permissions(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get permissions: %w", err)
+	}
+
+	if !a.twoFactor.Required(roles) {
+		return permissions, nil
+	}
+
+	enabled, err := a.twoFactor.Enabled(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("check 2fa: %w", err)
+	}
+	if !enabled {
+		a.log.Warn("2fa required but not enabled, permissions withheld", slog.Int64("userID", user.ID))
+
+		return nil, nil
+	}
+
+	return permissions, nil
+}
+
+// loginFailed counts the failed attempt and returns ErrInvalidCredentials,
+// or the error of the guard if the attempt could not be counted.
+func (a *Auth) loginFailed(ctx context.Context, op string, attempt models.LoginAttempt) error {
+	if err := a.loginGuard.Failed(ctx, attempt); err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	return fmt.Errorf("%s : %w", op, ErrInvalidCredentials)
+}
+
+// PublicKeys returns the keys that tokens of the app are verified with, for GetJWKS.
+func (a *Auth) PublicKeys(ctx context.Context, appID int32) ([]models.SigningKey, error) {
+	const op = "auth.PublicKeys"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int("appID", int(appID)),
+	)
+
 	app, err := a.appProvider.App(ctx, int(appID))
 	if err != nil {
 		if errors.Is(err, storage.ErrAppNotFound) {
@@ -255,12 +800,68 @@ func (a *Auth) CheckToken(ctx context.Context, token string, appID int32) (*jwt.
 		return nil, fmt.Errorf("%s : %w", op, err)
 	}
 
-	claims, err := jwt.ParseToken(token, app)
+	keys, err := a.keys.PublicKeys(ctx, app.ID)
 	if err != nil {
+		log.Error("failed to get public keys", sl.Error(err))
+
 		return nil, fmt.Errorf("%s : %w", op, err)
 	}
 
-	log.Info("token is valid")
+	return keys, nil
+}
+
+// AuthenticateApp checks the secret an app backend presents for CheckToken and GetJWKS.
+// After a rotation the previous secret is accepted until its grace period ends.
+func (a *Auth) AuthenticateApp(ctx context.Context, appID int32, secret string) error {
+	const op = "auth.AuthenticateApp"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int("appID", int(appID)),
+	)
+
+	app, err := a.appProvider.App(ctx, int(appID))
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			log.Warn("app not found", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrInvalidAppID)
+		}
+
+		log.Error("failed to get app", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	if secret != "" {
+		for _, valid := range app.Secrets(time.Now()) {
+			if subtle.ConstantTimeCompare([]byte(secret), []byte(valid)) == 1 {
+				return nil
+			}
+		}
+	}
+
+	log.Warn("invalid app secret")
+
+	return fmt.Errorf("%s : %w", op, ErrInvalidAppSecret)
+}
+
+func (a *Auth) parseToken(ctx context.Context, token string, appID int32) (*jwt.Claims, error) {
+	app, err := a.appProvider.App(ctx, int(appID))
+	if err != nil {
+		if errors.Is(err, storage.ErrAppNotFound) {
+			return nil, ErrInvalidAppID
+		}
+
+		return nil, err
+	}
+
+	claims, err := jwt.ParseToken(token, app, func(kid string) (models.SigningKey, error) {
+		return a.keys.VerificationKey(ctx, app.ID, kid)
+	}, a.legacyTokens)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
 
 	return claims, nil
 }
@@ -0,0 +1,474 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/jwt"
+	"github.com/Muaz717/sso/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/services/auth/mocks"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+const (
+	testAppID      = 1
+	testUserID     = 7
+	testEmail      = "admin@gym.local"
+	testPassword   = "password"
+	testIP         = "127.0.0.1"
+	testTokenTTL   = 15 * time.Minute
+	testRefreshTTL = time.Hour
+)
+
+type testAuth struct {
+	*Auth
+	saver     *mocks.UserSaver
+	users     *mocks.UserProvider
+	apps      *mocks.AppProvider
+	tokens    *mocks.TokenStore
+	keys      *mocks.KeyProvider
+	guard     *mocks.LoginGuard
+	twoFactor *mocks.TwoFactor
+
+	app  models.App
+	user models.User
+	key  models.SigningKey
+}
+
+func newTestAuth(t *testing.T) *testAuth {
+	t.Helper()
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	key, err := jwt.GenerateKey(testAppID, models.AlgorithmEdDSA)
+	require.NoError(t, err)
+
+	a := &testAuth{
+		saver:     mocks.NewUserSaver(t),
+		users:     mocks.NewUserProvider(t),
+		apps:      mocks.NewAppProvider(t),
+		tokens:    mocks.NewTokenStore(t),
+		keys:      mocks.NewKeyProvider(t),
+		guard:     mocks.NewLoginGuard(t),
+		twoFactor: mocks.NewTwoFactor(t),
+		app:       models.App{ID: testAppID, Name: "gym_app", Secret: "gym-secret"},
+		user:      models.User{ID: testUserID, Email: testEmail, PassHash: passHash},
+		key:       key,
+	}
+	a.Auth = New(slogdiscard.NewDiscardLogger(), a.saver, a.users, a.apps, a.tokens, a.keys, a.guard, a.twoFactor,
+		testTokenTTL, testRefreshTTL, false, false)
+
+	return a
+}
+
+func (a *testAuth) expectApp() {
+	a.apps.EXPECT().App(mock.Anything, testAppID).Return(a.app, nil).Once()
+}
+
+// expectToken sets up issuing a token: the signing key and the permissions of the user
+func (a *testAuth) expectToken(permissions []string) {
+	a.keys.EXPECT().SigningKey(mock.Anything, int64(testAppID)).Return(a.key, nil).Once()
+	a.users.EXPECT().Permissions(mock.Anything, int64(testUserID)).Return(permissions, nil).Once()
+	a.twoFactor.EXPECT().Required([]string{models.RoleAdmin}).Return(false).Once()
+}
+
+// login logs in with the right password and returns the token pair and the stored refresh token
+func (a *testAuth) login(t *testing.T) (models.TokenPair, models.RefreshToken) {
+	t.Helper()
+
+	var saved models.RefreshToken
+
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(0, nil).Once()
+	a.users.EXPECT().User(mock.Anything, testEmail).Return(a.user, []string{models.RoleAdmin}, nil).Once()
+	a.expectApp()
+	a.twoFactor.EXPECT().Enabled(mock.Anything, int64(testUserID)).Return(false, nil).Once()
+	a.guard.EXPECT().Succeeded(mock.Anything, testEmail).Return(nil).Once()
+	a.tokens.EXPECT().SaveRefreshToken(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, token models.RefreshToken) { saved = token }).
+		Return(nil).Once()
+	a.expectToken([]string{models.PermissionAll})
+
+	pair, err := a.Login(context.Background(), testEmail, testPassword, testAppID, "test", testIP)
+	require.NoError(t, err)
+
+	return pair, saved
+}
+
+// claims checks the token with the given state in storage
+func (a *testAuth) claims(t *testing.T, token string) *jwt.Claims {
+	t.Helper()
+
+	a.expectApp()
+	a.keys.EXPECT().VerificationKey(mock.Anything, int64(testAppID), a.key.ID).Return(a.key, nil).Once()
+	a.tokens.EXPECT().TokenState(mock.Anything, int64(testUserID), mock.Anything, mock.Anything).
+		Return(0, false, nil).Once()
+
+	claims, err := a.CheckToken(context.Background(), token, testAppID)
+	require.NoError(t, err)
+
+	return claims
+}
+
+func TestLogin_StartsSession(t *testing.T) {
+	a := newTestAuth(t)
+
+	pair, saved := a.login(t)
+
+	// only the hash of the refresh token is stored
+	assert.Equal(t, refresh.Hash(pair.RefreshToken), saved.TokenHash)
+	assert.Equal(t, int64(testUserID), saved.UserID)
+	assert.Equal(t, int64(testAppID), saved.AppID)
+	assert.Equal(t, "test", saved.Device)
+	assert.WithinDuration(t, time.Now().Add(testRefreshTTL), saved.ExpiresAt, time.Second)
+
+	claims := a.claims(t, pair.AccessToken)
+	assert.Equal(t, saved.SessionID, claims.SessionID)
+	assert.NotEmpty(t, claims.ID)
+	assert.Equal(t, []string{models.PermissionAll}, claims.Permissions)
+}
+
+func TestLogin_InvalidPassword(t *testing.T) {
+	a := newTestAuth(t)
+
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(0, nil).Once()
+	a.users.EXPECT().User(mock.Anything, testEmail).Return(a.user, nil, nil).Once()
+	a.guard.EXPECT().Failed(mock.Anything, models.LoginAttempt{
+		Email:  testEmail,
+		IP:     testIP,
+		UserID: testUserID,
+		Reason: models.LoginReasonInvalidPassword,
+	}).Return(nil).Once()
+
+	_, err := a.Login(context.Background(), testEmail, "wrong", testAppID, "test", testIP)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLogin_Locked(t *testing.T) {
+	a := newTestAuth(t)
+
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(5*time.Second, nil).Once()
+
+	_, err := a.Login(context.Background(), testEmail, testPassword, testAppID, "test", testIP)
+
+	var lockout *LockoutError
+	require.ErrorAs(t, err, &lockout)
+	assert.Equal(t, 5*time.Second, lockout.RetryAfter)
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func TestLogin_TwoFactorEnabledReturnsChallenge(t *testing.T) {
+	a := newTestAuth(t)
+
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(0, nil).Once()
+	a.users.EXPECT().User(mock.Anything, testEmail).Return(a.user, []string{models.RoleAdmin}, nil).Once()
+	a.expectApp()
+	a.twoFactor.EXPECT().Enabled(mock.Anything, int64(testUserID)).Return(true, nil).Once()
+	a.twoFactor.EXPECT().NewChallenge(mock.Anything, int64(testUserID)).Return("challenge", nil).Once()
+
+	// no session is started and the failures of the email are kept until the code is checked
+	_, err := a.Login(context.Background(), testEmail, testPassword, testAppID, "test", testIP)
+
+	var required *TwoFactorRequiredError
+	require.ErrorAs(t, err, &required)
+	assert.Equal(t, "challenge", required.Challenge)
+}
+
+func TestLogin_TwoFactorRequiredWithholdsPermissions(t *testing.T) {
+	a := newTestAuth(t)
+
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(0, nil).Once()
+	a.users.EXPECT().User(mock.Anything, testEmail).Return(a.user, []string{models.RoleAdmin}, nil).Once()
+	a.expectApp()
+	a.twoFactor.EXPECT().Enabled(mock.Anything, int64(testUserID)).Return(false, nil).Twice()
+	a.guard.EXPECT().Succeeded(mock.Anything, testEmail).Return(nil).Once()
+	a.tokens.EXPECT().SaveRefreshToken(mock.Anything, mock.Anything).Return(nil).Once()
+	a.keys.EXPECT().SigningKey(mock.Anything, int64(testAppID)).Return(a.key, nil).Once()
+	a.users.EXPECT().Permissions(mock.Anything, int64(testUserID)).Return([]string{models.PermissionAll}, nil).Once()
+	a.twoFactor.EXPECT().Required([]string{models.RoleAdmin}).Return(true).Once()
+
+	pair, err := a.Login(context.Background(), testEmail, testPassword, testAppID, "test", testIP)
+	require.NoError(t, err)
+
+	claims := a.claims(t, pair.AccessToken)
+	assert.Empty(t, claims.Permissions)
+	assert.Equal(t, []string{models.RoleAdmin}, claims.Roles)
+}
+
+func TestLogout_RevokesOnlyThisToken(t *testing.T) {
+	a := newTestAuth(t)
+	ctx := context.Background()
+
+	pair, saved := a.login(t)
+	claims := a.claims(t, pair.AccessToken)
+
+	a.expectApp()
+	a.keys.EXPECT().VerificationKey(mock.Anything, int64(testAppID), a.key.ID).Return(a.key, nil).Once()
+	a.tokens.EXPECT().RevokeToken(mock.Anything, claims.ID, int64(testUserID), claims.ExpiresAt.Time).Return(nil).Once()
+	a.tokens.EXPECT().RevokeSession(mock.Anything, saved.SessionID).Return(nil).Once()
+
+	require.NoError(t, a.Logout(ctx, pair.AccessToken, testAppID, false))
+}
+
+func TestLogout_AllRevokesEveryToken(t *testing.T) {
+	a := newTestAuth(t)
+
+	pair, _ := a.login(t)
+
+	a.expectApp()
+	a.keys.EXPECT().VerificationKey(mock.Anything, int64(testAppID), a.key.ID).Return(a.key, nil).Once()
+	a.tokens.EXPECT().RevokeAllTokens(mock.Anything, int64(testUserID)).Return(nil).Once()
+
+	require.NoError(t, a.Logout(context.Background(), pair.AccessToken, testAppID, true))
+}
+
+func TestCheckToken_Revoked(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		revoked bool
+		err     error
+	}{
+		{name: "token or session revoked", revoked: true},
+		{name: "all tokens revoked", version: 1},
+		{name: "user deleted", err: storage.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			pair, saved := a.login(t)
+
+			a.expectApp()
+			a.keys.EXPECT().VerificationKey(mock.Anything, int64(testAppID), a.key.ID).Return(a.key, nil).Once()
+			a.tokens.EXPECT().TokenState(mock.Anything, int64(testUserID), mock.Anything, saved.SessionID).
+				Return(tt.version, tt.revoked, tt.err).Once()
+
+			_, err := a.CheckToken(context.Background(), pair.AccessToken, testAppID)
+			assert.ErrorIs(t, err, ErrTokenRevoked)
+		})
+	}
+}
+
+func TestCleanupExpiredTokens(t *testing.T) {
+	a := newTestAuth(t)
+
+	a.tokens.EXPECT().DeleteExpiredTokens(mock.Anything).Return(1, nil).Once()
+	a.keys.EXPECT().DeleteRetired(mock.Anything).Return(nil).Once()
+	a.guard.EXPECT().Cleanup(mock.Anything).Return(nil).Once()
+
+	require.NoError(t, a.CleanupExpiredTokens(context.Background()))
+}
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	a := newTestAuth(t)
+
+	pair, saved := a.login(t)
+
+	var next models.RefreshToken
+
+	a.expectApp()
+	a.tokens.EXPECT().
+		RotateRefreshToken(mock.Anything, refresh.Hash(pair.RefreshToken), int64(testAppID), mock.Anything, refreshReuseInterval).
+		Run(func(_ context.Context, _ string, _ int64, token models.RefreshToken, _ time.Duration) { next = token }).
+		Return(saved, nil).Once()
+	a.users.EXPECT().UserByID(mock.Anything, int64(testUserID)).Return(a.user, []string{models.RoleAdmin}, nil).Once()
+	a.expectToken([]string{models.PermissionAll})
+
+	refreshed, err := a.Refresh(context.Background(), pair.RefreshToken, testAppID)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, pair.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, refresh.Hash(refreshed.RefreshToken), next.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(testRefreshTTL), next.ExpiresAt, time.Second)
+
+	// the new access token belongs to the same session
+	assert.Equal(t, saved.SessionID, a.claims(t, refreshed.AccessToken).SessionID)
+}
+
+func TestRefresh_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		appErr   error
+		rotate   error
+		userErr  error
+		expected error
+	}{
+		{name: "unknown or disabled app", appErr: storage.ErrAppNotFound, expected: ErrInvalidAppID},
+		{name: "unknown, expired or revoked token", rotate: storage.ErrRefreshTokenNotFound, expected: ErrInvalidRefreshToken},
+		{name: "reused token", rotate: storage.ErrRefreshTokenReused, expected: ErrRefreshTokenReused},
+		{name: "deleted user", userErr: storage.ErrUserNotFound, expected: ErrInvalidRefreshToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+
+			if tt.appErr != nil {
+				a.apps.EXPECT().App(mock.Anything, testAppID).Return(models.App{}, tt.appErr).Once()
+			} else {
+				a.expectApp()
+				a.tokens.EXPECT().RotateRefreshToken(mock.Anything, refresh.Hash("token"), int64(testAppID), mock.Anything, refreshReuseInterval).
+					Return(models.RefreshToken{UserID: testUserID}, tt.rotate).Once()
+			}
+			if tt.userErr != nil {
+				a.users.EXPECT().UserByID(mock.Anything, int64(testUserID)).Return(models.User{}, nil, tt.userErr).Once()
+			}
+
+			_, err := a.Refresh(context.Background(), "token", testAppID)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestRegisterNewUser_Roles(t *testing.T) {
+	tests := []struct {
+		name         string
+		open         bool
+		withoutRoles bool
+		role         string
+	}{
+		{name: "registration is closed"},
+		{name: "open registration", open: true, role: userRole},
+		{name: "member account", open: true, withoutRoles: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			a.openRegistration = tt.open
+
+			passHash := mock.MatchedBy(func(hash []byte) bool {
+				return bcrypt.CompareHashAndPassword(hash, []byte(testPassword)) == nil
+			})
+			a.saver.EXPECT().SaveUser(mock.Anything, "new@gym.local", passHash, tt.role).Return(8, nil).Once()
+
+			id, err := a.RegisterNewUser(context.Background(), "new@gym.local", testPassword, "", tt.withoutRoles)
+			require.NoError(t, err)
+			assert.Equal(t, int64(8), id)
+		})
+	}
+}
+
+func TestRegisterNewUser_UserExists(t *testing.T) {
+	a := newTestAuth(t)
+
+	a.saver.EXPECT().SaveUser(mock.Anything, testEmail, mock.Anything, "").Return(0, storage.ErrUserExists).Once()
+
+	_, err := a.RegisterNewUser(context.Background(), testEmail, testPassword, "", false)
+	assert.ErrorIs(t, err, ErrUserExists)
+}
+
+func TestRegisterNewUser_Invite(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "valid invite"},
+		{name: "unknown, used or expired invite", err: storage.ErrInviteNotFound, expected: ErrInvalidInvite},
+		{name: "role of the invite deleted", err: storage.ErrRoleNotFound, expected: ErrInvalidInvite},
+		{name: "invite for another email", err: storage.ErrInviteEmailMismatch, expected: ErrInviteEmail},
+		{name: "user exists", err: storage.ErrUserExists, expected: ErrUserExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			// an invalid invite doesn't fall back to open registration: SaveUser is not expected
+			a.openRegistration = true
+
+			a.saver.EXPECT().SaveInvitedUser(mock.Anything, "trainer@gym.local", mock.Anything, refresh.Hash("code")).
+				Return(8, tt.err).Once()
+
+			_, err := a.RegisterNewUser(context.Background(), "trainer@gym.local", testPassword, "code", false)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestAuthenticateApp(t *testing.T) {
+	ctx := context.Background()
+
+	app := models.App{
+		ID:                      testAppID,
+		Secret:                  "gym-secret",
+		PreviousSecret:          "old-secret",
+		PreviousSecretExpiresAt: time.Now().Add(time.Hour),
+	}
+	expired := app
+	expired.PreviousSecretExpiresAt = time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name     string
+		app      models.App
+		secret   string
+		expected error
+	}{
+		{name: "current secret", app: app, secret: "gym-secret"},
+		{name: "previous secret within the grace period", app: app, secret: "old-secret"},
+		{name: "previous secret after the grace period", app: expired, secret: "old-secret", expected: ErrInvalidAppSecret},
+		{name: "secret of another app", app: app, secret: "bot-secret", expected: ErrInvalidAppSecret},
+		{name: "no secret", app: app, expected: ErrInvalidAppSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			a.apps.EXPECT().App(mock.Anything, testAppID).Return(tt.app, nil).Once()
+
+			err := a.AuthenticateApp(ctx, testAppID, tt.secret)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDisabledAppIsTreatedAsMissing(t *testing.T) {
+	a := newTestAuth(t)
+	ctx := context.Background()
+
+	// the storage hides disabled apps
+	a.apps.EXPECT().App(mock.Anything, testAppID).Return(models.App{}, storage.ErrAppNotFound)
+
+	_, err := a.CheckToken(ctx, "token", testAppID)
+	assert.ErrorIs(t, err, ErrInvalidAppID)
+
+	_, err = a.PublicKeys(ctx, testAppID)
+	assert.ErrorIs(t, err, ErrInvalidAppID)
+
+	assert.ErrorIs(t, a.AuthenticateApp(ctx, testAppID, "gym-secret"), ErrInvalidAppID)
+
+	// Login doesn't tell a missing app from wrong credentials
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(0, nil).Once()
+	a.users.EXPECT().User(mock.Anything, testEmail).Return(a.user, nil, nil).Once()
+
+	_, err = a.Login(ctx, testEmail, testPassword, testAppID, "test", testIP)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLogin_StorageError(t *testing.T) {
+	a := newTestAuth(t)
+	failure := errors.New("connection refused")
+
+	a.guard.EXPECT().Check(mock.Anything, testEmail, testIP).Return(0, nil).Once()
+	a.users.EXPECT().User(mock.Anything, testEmail).Return(models.User{}, nil, failure).Once()
+
+	_, err := a.Login(context.Background(), testEmail, testPassword, testAppID, "test", testIP)
+	assert.ErrorIs(t, err, failure)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+}
@@ -0,0 +1,144 @@
+package invites
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/lib/mail"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRoleNotFound   = errors.New("role not found")
+	ErrInviteNotFound = errors.New("invite not found or already used")
+)
+
+type Storage interface {
+	SaveInvite(ctx context.Context, invite models.Invite, codeHash string) (models.Invite, error)
+	Invites(ctx context.Context) ([]models.Invite, error)
+	DeleteInvite(ctx context.Context, id int64) error
+}
+
+// Mailer delivers invites to the invitees.
+type Mailer interface {
+	Send(ctx context.Context, msg mail.Message) error
+}
+
+// Invites issues invitations to register with preset roles.
+type Invites struct {
+	log     *slog.Logger
+	storage Storage
+	mailer  Mailer
+	mailCfg config.MailConfig
+	ttl     time.Duration
+}
+
+// New creates a new Invites service
+func New(log *slog.Logger, storage Storage, mailer Mailer, mailCfg config.MailConfig, ttl time.Duration) *Invites {
+	return &Invites{
+		log:     log,
+		storage: storage,
+		mailer:  mailer,
+		mailCfg: mailCfg,
+		ttl:     ttl,
+	}
+}
+
+// Create issues an invite and returns it with its code. The code is shown only now.
+// If email is set, only that email can register with the invite and the link is mailed to it.
+func (i *Invites) Create(ctx context.Context, createdBy int64, email string, roles []string) (models.Invite, string, error) {
+	const op = "invites.Create"
+
+	email = strings.TrimSpace(email)
+
+	log := i.log.With(
+		slog.String("op", op),
+		slog.Int64("createdBy", createdBy),
+		slog.String("email", email),
+		slog.Any("roles", roles),
+	)
+
+	code, hash, err := refresh.NewToken()
+	if err != nil {
+		log.Error("failed to generate invite code", sl.Error(err))
+
+		return models.Invite{}, "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	invite, err := i.storage.SaveInvite(ctx, models.Invite{
+		Email:     email,
+		Roles:     roles,
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(i.ttl),
+	}, hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrRoleNotFound) {
+			return models.Invite{}, "", fmt.Errorf("%s : %w", op, ErrRoleNotFound)
+		}
+
+		log.Error("failed to save invite", sl.Error(err))
+
+		return models.Invite{}, "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("invite created", slog.Int64("inviteID", invite.ID))
+
+	if email != "" {
+		// The invite works without the email: the administrator gets the code in the response
+		err := i.mailer.Send(ctx, mail.Message{
+			To:      email,
+			Subject: "Приглашение",
+			Body: fmt.Sprintf("Вас пригласили зарегистрироваться. Перейдите по ссылке: %s\n"+
+				"Приглашение действует %s.", mail.Link(i.mailCfg.InviteURL, code), i.ttl),
+		})
+		if err != nil {
+			log.Error("failed to send invite email", sl.Error(err))
+		}
+	}
+
+	return invite, code, nil
+}
+
+func (i *Invites) List(ctx context.Context) ([]models.Invite, error) {
+	const op = "invites.List"
+
+	invites, err := i.storage.Invites(ctx)
+	if err != nil {
+		i.log.Error("failed to list invites", slog.String("op", op), sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return invites, nil
+}
+
+// Revoke deletes an invite that hasn't been used yet.
+func (i *Invites) Revoke(ctx context.Context, id int64) error {
+	const op = "invites.Revoke"
+
+	log := i.log.With(
+		slog.String("op", op),
+		slog.Int64("inviteID", id),
+	)
+
+	if err := i.storage.DeleteInvite(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrInviteNotFound) {
+			return fmt.Errorf("%s : %w", op, ErrInviteNotFound)
+		}
+
+		log.Error("failed to delete invite", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("invite revoked")
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package invites
+
+import (
+	"context"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/sso/app/internal/lib/mail"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/services/invites/mocks"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testTTL = 48 * time.Hour
+
+func newTestInvites(t *testing.T) (*Invites, *mocks.Storage, *mocks.Mailer) {
+	st := mocks.NewStorage(t)
+	mailer := mocks.NewMailer(t)
+	cfg := config.MailConfig{InviteURL: "https://gym.local/register?invite=%s"}
+	return New(slogdiscard.NewDiscardLogger(), st, mailer, cfg, testTTL), st, mailer
+}
+
+func TestCreate_StoresOnlyCodeHash(t *testing.T) {
+	i, st, _ := newTestInvites(t)
+
+	var (
+		saved     models.Invite
+		savedHash string
+	)
+
+	st.EXPECT().SaveInvite(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, invite models.Invite, codeHash string) (models.Invite, error) {
+			saved, savedHash = invite, codeHash
+			invite.ID = 1
+			return invite, nil
+		}).Once()
+
+	// invites without email are handed over by the administrator: nothing is mailed
+	invite, code, err := i.Create(context.Background(), 1, "", []string{models.RoleUser})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), invite.ID)
+	assert.Equal(t, refresh.Hash(code), savedHash)
+	assert.Equal(t, []string{models.RoleUser}, saved.Roles)
+	assert.Equal(t, int64(1), saved.CreatedBy)
+	assert.WithinDuration(t, time.Now().Add(testTTL), saved.ExpiresAt, time.Second)
+}
+
+func TestCreate_MailsInvitee(t *testing.T) {
+	i, st, mailer := newTestInvites(t)
+
+	var sent mail.Message
+
+	st.EXPECT().SaveInvite(mock.Anything, mock.MatchedBy(func(invite models.Invite) bool {
+		return invite.Email == "trainer@gym.local"
+	}), mock.Anything).Return(models.Invite{ID: 1, Email: "trainer@gym.local"}, nil).Once()
+	mailer.EXPECT().Send(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, msg mail.Message) { sent = msg }).
+		Return(nil).Once()
+
+	_, code, err := i.Create(context.Background(), 1, " trainer@gym.local ", []string{models.RoleUser})
+	require.NoError(t, err)
+
+	assert.Equal(t, "trainer@gym.local", sent.To)
+	assert.Contains(t, sent.Body, "https://gym.local/register?invite="+code)
+}
+
+func TestCreate_UnknownRole(t *testing.T) {
+	i, st, _ := newTestInvites(t)
+
+	st.EXPECT().SaveInvite(mock.Anything, mock.Anything, mock.Anything).Return(models.Invite{}, storage.ErrRoleNotFound).Once()
+
+	_, _, err := i.Create(context.Background(), 1, "trainer@gym.local", []string{"owner"})
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := context.Background()
+	i, st, _ := newTestInvites(t)
+
+	st.EXPECT().DeleteInvite(mock.Anything, int64(1)).Return(nil).Once()
+	require.NoError(t, i.Revoke(ctx, 1))
+
+	// used and already revoked invites are not found
+	st.EXPECT().DeleteInvite(mock.Anything, int64(1)).Return(storage.ErrInviteNotFound).Once()
+	assert.ErrorIs(t, i.Revoke(ctx, 1), ErrInviteNotFound)
+}
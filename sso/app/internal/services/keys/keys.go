@@ -0,0 +1,232 @@
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/jwt"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// cacheTTL bounds how long another SSO instance may keep using a key after rotation
+// before it picks up the new one.
+const cacheTTL = time.Minute
+
+var ErrKeyNotFound = errors.New("signing key not found")
+
+type KeyStorage interface {
+	SigningKeys(ctx context.Context, appID int64, retiredSince time.Time) ([]models.SigningKey, error)
+	SaveSigningKey(ctx context.Context, key models.SigningKey) error
+	DeleteRetiredSigningKeys(ctx context.Context, retiredBefore time.Time) (int64, error)
+}
+
+// Keys manages per-app signing keys. A key is created on first use and replaced once it is older
+// than the rotation interval. Retired keys are kept for verification for retention,
+// which must be at least the access token TTL.
+type Keys struct {
+	log              *slog.Logger
+	storage          KeyStorage
+	algorithm        string
+	rotationInterval time.Duration
+	retention        time.Duration
+
+	mu    sync.Mutex
+	cache map[int64]cachedKeys
+}
+
+type cachedKeys struct {
+	keys     []models.SigningKey
+	loadedAt time.Time
+}
+
+// New creates a new Keys service
+func New(
+	log *slog.Logger,
+	storage KeyStorage,
+	algorithm string,
+	rotationInterval time.Duration,
+	retention time.Duration,
+) (*Keys, error) {
+	if _, err := jwt.SigningMethod(algorithm); err != nil {
+		return nil, err
+	}
+
+	return &Keys{
+		log:              log,
+		storage:          storage,
+		algorithm:        algorithm,
+		rotationInterval: rotationInterval,
+		retention:        retention,
+		cache:            make(map[int64]cachedKeys),
+	}, nil
+}
+
+// SigningKey returns the active key of the app, rotating it when it is missing, expired
+// or uses another algorithm than configured.
+func (k *Keys) SigningKey(ctx context.Context, appID int64) (models.SigningKey, error) {
+	const op = "keys.SigningKey"
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	keys, err := k.load(ctx, appID, false)
+	if err != nil {
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if active, ok := activeKey(keys); ok &&
+		active.Algorithm == k.algorithm &&
+		time.Since(active.CreatedAt) < k.rotationInterval {
+		return active, nil
+	}
+
+	return k.rotate(ctx, appID)
+}
+
+// VerificationKey returns the key with the given id if it is still published.
+func (k *Keys) VerificationKey(ctx context.Context, appID int64, kid string) (models.SigningKey, error) {
+	const op = "keys.VerificationKey"
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	keys, err := k.load(ctx, appID, false)
+	if err != nil {
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if key, ok := findKey(keys, kid); ok {
+		return key, nil
+	}
+
+	// the key may have been created by another instance after the cache was loaded
+	keys, err = k.load(ctx, appID, true)
+	if err != nil {
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if key, ok := findKey(keys, kid); ok {
+		return key, nil
+	}
+
+	return models.SigningKey{}, fmt.Errorf("%s : %w", op, ErrKeyNotFound)
+}
+
+// PublicKeys returns all keys of the app that tokens may still be signed with.
+func (k *Keys) PublicKeys(ctx context.Context, appID int64) ([]models.SigningKey, error) {
+	const op = "keys.PublicKeys"
+
+	// makes sure a freshly registered app already has a key to publish
+	if _, err := k.SigningKey(ctx, appID); err != nil {
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	keys, err := k.load(ctx, appID, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// DeleteRetired removes keys that no unexpired token can be signed with.
+func (k *Keys) DeleteRetired(ctx context.Context) error {
+	const op = "keys.DeleteRetired"
+
+	log := k.log.With(slog.String("op", op))
+
+	deleted, err := k.storage.DeleteRetiredSigningKeys(ctx, time.Now().Add(-k.retention))
+	if err != nil {
+		log.Error("failed to delete retired keys", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Debug("retired keys deleted", slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+// rotate creates a new active key. Must be called with k.mu held.
+func (k *Keys) rotate(ctx context.Context, appID int64) (models.SigningKey, error) {
+	const op = "keys.rotate"
+
+	log := k.log.With(
+		slog.String("op", op),
+		slog.Int64("appID", appID),
+	)
+
+	key, err := jwt.GenerateKey(appID, k.algorithm)
+	if err != nil {
+		log.Error("failed to generate key", sl.Error(err))
+
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	err = k.storage.SaveSigningKey(ctx, key)
+	if err != nil && !errors.Is(err, storage.ErrSigningKeyConflict) {
+		log.Error("failed to save key", sl.Error(err))
+
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, err)
+	}
+
+	keys, loadErr := k.load(ctx, appID, true)
+	if loadErr != nil {
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, loadErr)
+	}
+
+	active, ok := activeKey(keys)
+	if !ok {
+		return models.SigningKey{}, fmt.Errorf("%s : %w", op, ErrKeyNotFound)
+	}
+
+	if err != nil {
+		log.Info("key was rotated by another instance", slog.String("kid", active.ID))
+	} else {
+		log.Info("signing key rotated", slog.String("kid", key.ID), slog.String("algorithm", key.Algorithm))
+	}
+
+	return active, nil
+}
+
+// load returns the published keys of the app from cache or storage. Must be called with k.mu held.
+func (k *Keys) load(ctx context.Context, appID int64, force bool) ([]models.SigningKey, error) {
+	if cached, ok := k.cache[appID]; ok && !force && time.Since(cached.loadedAt) < cacheTTL {
+		return cached.keys, nil
+	}
+
+	keys, err := k.storage.SigningKeys(ctx, appID, time.Now().Add(-k.retention))
+	if err != nil {
+		return nil, err
+	}
+
+	k.cache[appID] = cachedKeys{keys: keys, loadedAt: time.Now()}
+
+	return keys, nil
+}
+
+func activeKey(keys []models.SigningKey) (models.SigningKey, bool) {
+	for _, key := range keys {
+		if key.RetiredAt == nil {
+			return key, true
+		}
+	}
+	return models.SigningKey{}, false
+}
+
+func findKey(keys []models.SigningKey, kid string) (models.SigningKey, bool) {
+	for _, key := range keys {
+		if key.ID == kid {
+			return key, true
+		}
+	}
+	return models.SigningKey{}, false
+}
@@ -0,0 +1,215 @@
+package loginguard
+
+import (
+	"context"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type Storage interface {
+	LoginLockedUntil(ctx context.Context, keys []string) (time.Time, error)
+	RegisterLoginFailure(ctx context.Context, key string, since time.Time) (int, error)
+	LockLogin(ctx context.Context, key string, until time.Time) error
+	ResetLoginFailures(ctx context.Context, keys []string) (int64, error)
+	SaveLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error
+	DeleteStaleLoginData(ctx context.Context, failuresBefore, auditBefore time.Time) (int64, error)
+}
+
+// Guard limits password guessing. Failed logins are counted per email and per client IP;
+// once a key runs out of free attempts, every next failure locks it for twice as long,
+// up to the configured lockout.
+type Guard struct {
+	log     *slog.Logger
+	storage Storage
+	cfg     config.LoginProtectionConfig
+}
+
+// New creates a new Guard
+func New(log *slog.Logger, storage Storage, cfg config.LoginProtectionConfig) *Guard {
+	return &Guard{
+		log:     log,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// Check returns how long logins for the email or from the ip stay locked, zero if they are allowed.
+// Blocked attempts are recorded in the audit but not counted as failures.
+func (g *Guard) Check(ctx context.Context, email, ip string) (time.Duration, error) {
+	const op = "loginguard.Check"
+
+	log := g.log.With(
+		slog.String("op", op),
+		slog.String("email", email),
+		slog.String("ip", ip),
+	)
+
+	until, err := g.storage.LoginLockedUntil(ctx, keys(email, ip))
+	if err != nil {
+		log.Error("failed to check login lock", sl.Error(err))
+
+		return 0, fmt.Errorf("%s : %w", op, err)
+	}
+
+	retryAfter := time.Until(until)
+	if retryAfter <= 0 {
+		return 0, nil
+	}
+
+	log.Warn("login is locked", slog.Duration("retryAfter", retryAfter))
+
+	err = g.storage.SaveLoginAttempt(ctx, models.LoginAttempt{
+		Email:  email,
+		IP:     ip,
+		Reason: models.LoginReasonLocked,
+	})
+	if err != nil {
+		log.Error("failed to audit blocked login", sl.Error(err))
+	}
+
+	return retryAfter, nil
+}
+
+// Failed records a failed login and locks the email or the ip when they run out of attempts.
+func (g *Guard) Failed(ctx context.Context, attempt models.LoginAttempt) error {
+	const op = "loginguard.Failed"
+
+	log := g.log.With(
+		slog.String("op", op),
+		slog.String("email", attempt.Email),
+		slog.String("ip", attempt.IP),
+		slog.String("reason", attempt.Reason),
+	)
+
+	log.Warn("failed login")
+
+	if err := g.storage.SaveLoginAttempt(ctx, attempt); err != nil {
+		log.Error("failed to audit failed login", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	limits := map[string]int{emailKey(attempt.Email): g.cfg.EmailAttempts}
+	if attempt.IP != "" {
+		limits[ipKey(attempt.IP)] = g.cfg.IPAttempts
+	}
+
+	since := time.Now().Add(-g.cfg.Window)
+	for key, free := range limits {
+		failures, err := g.storage.RegisterLoginFailure(ctx, key, since)
+		if err != nil {
+			log.Error("failed to count failed login", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, err)
+		}
+
+		if failures <= free {
+			continue
+		}
+
+		lock := g.backoff(failures - free)
+		if err := g.storage.LockLogin(ctx, key, time.Now().Add(lock)); err != nil {
+			log.Error("failed to lock login", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, err)
+		}
+
+		log.Warn("login locked", slog.String("key", key), slog.Int("failures", failures), slog.Duration("lock", lock))
+	}
+
+	return nil
+}
+
+// Succeeded forgets the failures of the email. The ip keeps its failures:
+// an attacker with one valid account must not be able to reset them.
+func (g *Guard) Succeeded(ctx context.Context, email string) error {
+	const op = "loginguard.Succeeded"
+
+	if _, err := g.storage.ResetLoginFailures(ctx, []string{emailKey(email)}); err != nil {
+		g.log.Error("failed to reset login failures", slog.String("op", op), sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	return nil
+}
+
+// Unlock removes the locks and failures of the email and, if given, of the ip.
+// It reports whether there was anything to unlock.
+func (g *Guard) Unlock(ctx context.Context, email, ip string) (bool, error) {
+	const op = "loginguard.Unlock"
+
+	log := g.log.With(
+		slog.String("op", op),
+		slog.String("email", email),
+		slog.String("ip", ip),
+	)
+
+	var lockKeys []string
+	if email != "" {
+		lockKeys = append(lockKeys, emailKey(email))
+	}
+	if ip != "" {
+		lockKeys = append(lockKeys, ipKey(ip))
+	}
+
+	reset, err := g.storage.ResetLoginFailures(ctx, lockKeys)
+	if err != nil {
+		log.Error("failed to unlock login", sl.Error(err))
+
+		return false, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("login unlocked", slog.Int64("keys", reset))
+
+	return reset > 0, nil
+}
+
+// Cleanup deletes counters without recent failures and audit records past the retention.
+func (g *Guard) Cleanup(ctx context.Context) error {
+	const op = "loginguard.Cleanup"
+
+	log := g.log.With(slog.String("op", op))
+
+	now := time.Now()
+	deleted, err := g.storage.DeleteStaleLoginData(ctx, now.Add(-g.cfg.Window), now.Add(-g.cfg.AuditRetention))
+	if err != nil {
+		log.Error("failed to delete stale login data", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Debug("stale login data deleted", slog.Int64("deleted", deleted))
+
+	return nil
+}
+
+// backoff returns the lock after the n-th failure past the free attempts: BaseDelay, doubled
+// with every failure and capped by MaxLockout.
+func (g *Guard) backoff(n int) time.Duration {
+	lock := g.cfg.BaseDelay
+	for i := 1; i < n && lock < g.cfg.MaxLockout; i++ {
+		lock *= 2
+	}
+	return min(lock, g.cfg.MaxLockout)
+}
+
+func keys(email, ip string) []string {
+	if ip == "" {
+		return []string{emailKey(email)}
+	}
+	return []string{emailKey(email), ipKey(ip)}
+}
+
+func emailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,168 @@
+package loginguard
+
+import (
+	"context"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/sso/app/internal/services/loginguard/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	testEmail = "Admin@Gym.local"
+	testIP    = "203.0.113.7"
+)
+
+var testConfig = config.LoginProtectionConfig{
+	EmailAttempts:  3,
+	IPAttempts:     5,
+	Window:         time.Hour,
+	BaseDelay:      time.Second,
+	MaxLockout:     10 * time.Second,
+	AuditRetention: 24 * time.Hour,
+}
+
+func newTestGuard(t *testing.T) (*Guard, *mocks.Storage) {
+	storage := mocks.NewStorage(t)
+	return New(slogdiscard.NewDiscardLogger(), storage, testConfig), storage
+}
+
+// about matches a time within a second of now + d
+func about(d time.Duration) interface{} {
+	return mock.MatchedBy(func(at time.Time) bool {
+		diff := time.Until(at) - d
+		return diff > -time.Second && diff < time.Second
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	g, _ := newTestGuard(t)
+
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{n: 1, want: time.Second},
+		{n: 2, want: 2 * time.Second},
+		{n: 3, want: 4 * time.Second},
+		{n: 4, want: 8 * time.Second},
+		{n: 5, want: 10 * time.Second},
+		{n: 50, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, g.backoff(tt.n), "n = %d", tt.n)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	g, storage := newTestGuard(t)
+
+	// the email is normalized, so its case and spaces don't give fresh attempts
+	lockKeys := []string{"email:admin@gym.local", "ip:" + testIP}
+
+	storage.EXPECT().LoginLockedUntil(mock.Anything, lockKeys).Return(time.Time{}, nil).Once()
+
+	retryAfter, err := g.Check(ctx, " "+testEmail, testIP)
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	// blocked attempts go to the audit
+	storage.EXPECT().LoginLockedUntil(mock.Anything, lockKeys).Return(time.Now().Add(2*time.Second), nil).Once()
+	storage.EXPECT().SaveLoginAttempt(mock.Anything, models.LoginAttempt{
+		Email:  testEmail,
+		IP:     testIP,
+		Reason: models.LoginReasonLocked,
+	}).Return(nil).Once()
+
+	retryAfter, err = g.Check(ctx, testEmail, testIP)
+	require.NoError(t, err)
+	assert.InDelta(t, 2*time.Second, retryAfter, float64(100*time.Millisecond))
+}
+
+func TestFailed(t *testing.T) {
+	tests := []struct {
+		name          string
+		emailFailures int
+		ipFailures    int
+		emailLock     time.Duration
+		ipLock        time.Duration
+	}{
+		{name: "free attempts", emailFailures: 3, ipFailures: 3},
+		{name: "email runs out of attempts", emailFailures: 4, ipFailures: 4, emailLock: time.Second},
+		{name: "lock doubles", emailFailures: 5, ipFailures: 5, emailLock: 2 * time.Second},
+		{name: "ip runs out of attempts across emails", emailFailures: 1, ipFailures: 7, ipLock: 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, storage := newTestGuard(t)
+
+			attempt := models.LoginAttempt{Email: testEmail, IP: testIP, Reason: models.LoginReasonInvalidPassword}
+
+			since := about(-testConfig.Window)
+			storage.EXPECT().SaveLoginAttempt(mock.Anything, attempt).Return(nil).Once()
+			storage.EXPECT().RegisterLoginFailure(mock.Anything, "email:admin@gym.local", since).Return(tt.emailFailures, nil).Once()
+			storage.EXPECT().RegisterLoginFailure(mock.Anything, "ip:"+testIP, since).Return(tt.ipFailures, nil).Once()
+			if tt.emailLock > 0 {
+				storage.EXPECT().LockLogin(mock.Anything, "email:admin@gym.local", about(tt.emailLock)).Return(nil).Once()
+			}
+			if tt.ipLock > 0 {
+				storage.EXPECT().LockLogin(mock.Anything, "ip:"+testIP, about(tt.ipLock)).Return(nil).Once()
+			}
+
+			require.NoError(t, g.Failed(context.Background(), attempt))
+		})
+	}
+}
+
+func TestFailed_WithoutIP(t *testing.T) {
+	g, storage := newTestGuard(t)
+
+	attempt := models.LoginAttempt{Email: testEmail, Reason: models.LoginReasonUnknownEmail}
+
+	storage.EXPECT().SaveLoginAttempt(mock.Anything, attempt).Return(nil).Once()
+	storage.EXPECT().RegisterLoginFailure(mock.Anything, "email:admin@gym.local", mock.Anything).Return(1, nil).Once()
+
+	require.NoError(t, g.Failed(context.Background(), attempt))
+}
+
+func TestSucceeded_KeepsIPFailures(t *testing.T) {
+	g, storage := newTestGuard(t)
+
+	storage.EXPECT().ResetLoginFailures(mock.Anything, []string{"email:admin@gym.local"}).Return(1, nil).Once()
+
+	require.NoError(t, g.Succeeded(context.Background(), testEmail))
+}
+
+func TestUnlock(t *testing.T) {
+	ctx := context.Background()
+	g, storage := newTestGuard(t)
+
+	storage.EXPECT().ResetLoginFailures(mock.Anything, []string{"email:admin@gym.local", "ip:" + testIP}).Return(2, nil).Once()
+
+	unlocked, err := g.Unlock(ctx, testEmail, testIP)
+	require.NoError(t, err)
+	assert.True(t, unlocked)
+
+	storage.EXPECT().ResetLoginFailures(mock.Anything, []string{"ip:" + testIP}).Return(0, nil).Once()
+
+	unlocked, err = g.Unlock(ctx, "", testIP)
+	require.NoError(t, err)
+	assert.False(t, unlocked)
+}
+
+func TestCleanup(t *testing.T) {
+	g, storage := newTestGuard(t)
+
+	storage.EXPECT().DeleteStaleLoginData(mock.Anything, about(-testConfig.Window), about(-testConfig.AuditRetention)).
+		Return(3, nil).Once()
+
+	require.NoError(t, g.Cleanup(context.Background()))
+}
@@ -0,0 +1,161 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"log/slog"
+)
+
+const (
+	defaultUsersLimit = 50
+	maxUsersLimit     = 500
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrRoleNotFound = errors.New("role not found")
+	ErrLastAdmin    = errors.New("can't revoke the role of the last admin")
+)
+
+type RoleStorage interface {
+	Users(ctx context.Context, filter models.UserFilter) ([]models.UserInfo, int64, error)
+	Roles(ctx context.Context) ([]models.Role, error)
+	AssignRole(ctx context.Context, userID int64, role string) error
+	RevokeRole(ctx context.Context, userID int64, role string, keepHolder bool) error
+	HasPermission(ctx context.Context, userID int64, permission string) (bool, error)
+}
+
+// Roles manages user roles and the permissions they grant.
+type Roles struct {
+	log     *slog.Logger
+	storage RoleStorage
+}
+
+// New creates a new Roles service
+func New(log *slog.Logger, storage RoleStorage) *Roles {
+	return &Roles{
+		log:     log,
+		storage: storage,
+	}
+}
+
+func (r *Roles) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.UserInfo, int64, error) {
+	const op = "roles.ListUsers"
+
+	log := r.log.With(slog.String("op", op))
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUsersLimit
+	}
+	filter.Limit = min(filter.Limit, maxUsersLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	users, total, err := r.storage.Users(ctx, filter)
+	if err != nil {
+		log.Error("failed to list users", sl.Error(err))
+
+		return nil, 0, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return users, total, nil
+}
+
+func (r *Roles) ListRoles(ctx context.Context) ([]models.Role, error) {
+	const op = "roles.ListRoles"
+
+	roles, err := r.storage.Roles(ctx)
+	if err != nil {
+		r.log.Error("failed to list roles", slog.String("op", op), sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return roles, nil
+}
+
+func (r *Roles) AssignRole(ctx context.Context, userID int64, role string) error {
+	const op = "roles.AssignRole"
+
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+		slog.String("role", role),
+	)
+
+	log.Info("assigning role")
+
+	if err := r.storage.AssignRole(ctx, userID, role); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			log.Warn("user not found", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrUserNotFound)
+		case errors.Is(err, storage.ErrRoleNotFound):
+			log.Warn("role not found", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrRoleNotFound)
+		}
+
+		log.Error("failed to assign role", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("role assigned")
+
+	return nil
+}
+
+func (r *Roles) RevokeRole(ctx context.Context, userID int64, role string) error {
+	const op = "roles.RevokeRole"
+
+	log := r.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+		slog.String("role", role),
+	)
+
+	log.Info("revoking role")
+
+	if err := r.storage.RevokeRole(ctx, userID, role, role == models.RoleAdmin); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrUserNotFound):
+			log.Warn("user not found", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrUserNotFound)
+		case errors.Is(err, storage.ErrLastRoleHolder):
+			log.Warn("refusing to revoke the last admin", sl.Error(err))
+
+			return fmt.Errorf("%s : %w", op, ErrLastAdmin)
+		}
+
+		log.Error("failed to revoke role", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("role revoked")
+
+	return nil
+}
+
+func (r *Roles) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	const op = "roles.HasPermission"
+
+	allowed, err := r.storage.HasPermission(ctx, userID, permission)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return false, fmt.Errorf("%s : %w", op, ErrUserNotFound)
+		}
+
+		r.log.Error("failed to check permission", slog.String("op", op), sl.Error(err))
+
+		return false, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return allowed, nil
+}
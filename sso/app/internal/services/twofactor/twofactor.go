@@ -0,0 +1,347 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/sl"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/lib/totp"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrNotEnrolled      = errors.New("two-factor enrollment not started")
+	ErrInvalidCode      = errors.New("invalid code")
+	ErrInvalidChallenge = errors.New("invalid or expired challenge")
+)
+
+// codeSkew is the number of time steps before and after the current one a code is accepted in
+const codeSkew = 1
+
+type UserProvider interface {
+	UserByID(ctx context.Context, userID int64) (models.User, []string, error)
+}
+
+type Storage interface {
+	TOTP(ctx context.Context, userID int64) (models.TOTP, error)
+	SaveTOTPSecret(ctx context.Context, userID int64, secret string) error
+	EnableTOTP(ctx context.Context, userID int64, step int64, recoveryHashes []string) error
+	UseTOTPStep(ctx context.Context, userID int64, step int64) error
+	UseRecoveryCode(ctx context.Context, userID int64, hash string) error
+	DeleteTOTP(ctx context.Context, userID int64) error
+	SaveOneTimeToken(ctx context.Context, token models.OneTimeToken) error
+	UseOneTimeToken(ctx context.Context, hash string, purpose string) (models.OneTimeToken, error)
+}
+
+// TwoFactor enrolls users in TOTP two-factor authentication and verifies their codes.
+type TwoFactor struct {
+	log     *slog.Logger
+	users   UserProvider
+	storage Storage
+	cfg     config.TwoFactorConfig
+}
+
+// New creates a new TwoFactor service
+func New(log *slog.Logger, users UserProvider, storage Storage, cfg config.TwoFactorConfig) *TwoFactor {
+	return &TwoFactor{
+		log:     log,
+		users:   users,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// Enroll generates a new secret for the user. 2FA is not enabled until Confirm,
+// so an abandoned enrollment doesn't lock the user out.
+func (t *TwoFactor) Enroll(ctx context.Context, userID int64) (secret string, uri string, err error) {
+	const op = "twofactor.Enroll"
+
+	log := t.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+	)
+
+	user, _, err := t.users.UserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return "", "", fmt.Errorf("%s : %w", op, ErrUserNotFound)
+		}
+
+		log.Error("failed to get user", sl.Error(err))
+
+		return "", "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	secret, err = totp.NewSecret()
+	if err != nil {
+		log.Error("failed to generate secret", sl.Error(err))
+
+		return "", "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	if err := t.storage.SaveTOTPSecret(ctx, userID, secret); err != nil {
+		if errors.Is(err, storage.ErrTOTPNotFound) {
+			return "", "", fmt.Errorf("%s : %w", op, ErrAlreadyEnabled)
+		}
+
+		log.Error("failed to save secret", sl.Error(err))
+
+		return "", "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("two-factor enrollment started")
+
+	return secret, totp.URI(t.cfg.Issuer, user.Email, secret), nil
+}
+
+// Confirm enables 2FA once the user proves the authenticator works, and returns recovery codes.
+// The codes are shown only now, just their hashes are kept.
+func (t *TwoFactor) Confirm(ctx context.Context, userID int64, code string) ([]string, error) {
+	const op = "twofactor.Confirm"
+
+	log := t.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+	)
+
+	secret, err := t.storage.TOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTOTPNotFound) {
+			return nil, fmt.Errorf("%s : %w", op, ErrNotEnrolled)
+		}
+
+		log.Error("failed to get secret", sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if secret.Enabled {
+		return nil, fmt.Errorf("%s : %w", op, ErrAlreadyEnabled)
+	}
+
+	step, ok := totp.Validate(secret.Secret, strings.TrimSpace(code), time.Now(), codeSkew)
+	if !ok {
+		log.Warn("invalid confirmation code")
+
+		return nil, fmt.Errorf("%s : %w", op, ErrInvalidCode)
+	}
+
+	codes, hashes, err := t.newRecoveryCodes()
+	if err != nil {
+		log.Error("failed to generate recovery codes", sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if err := t.storage.EnableTOTP(ctx, userID, step, hashes); err != nil {
+		if errors.Is(err, storage.ErrTOTPNotFound) {
+			// enabled concurrently or the code was already used
+			return nil, fmt.Errorf("%s : %w", op, ErrInvalidCode)
+		}
+
+		log.Error("failed to enable 2fa", sl.Error(err))
+
+		return nil, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("two-factor authentication enabled")
+
+	return codes, nil
+}
+
+// Disable turns 2FA off after checking a current code or a recovery code.
+func (t *TwoFactor) Disable(ctx context.Context, userID int64, code string) error {
+	const op = "twofactor.Disable"
+
+	log := t.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+	)
+
+	ok, err := t.Verify(ctx, userID, code)
+	if err != nil {
+		return fmt.Errorf("%s : %w", op, err)
+	}
+	if !ok {
+		log.Warn("invalid code")
+
+		return fmt.Errorf("%s : %w", op, ErrInvalidCode)
+	}
+
+	if err := t.storage.DeleteTOTP(ctx, userID); err != nil {
+		log.Error("failed to disable 2fa", sl.Error(err))
+
+		return fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Info("two-factor authentication disabled")
+
+	return nil
+}
+
+// Enabled reports whether the user has confirmed 2FA.
+func (t *TwoFactor) Enabled(ctx context.Context, userID int64) (bool, error) {
+	const op = "twofactor.Enabled"
+
+	secret, err := t.storage.TOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTOTPNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return secret.Enabled, nil
+}
+
+// Required reports whether one of the roles must use 2FA.
+func (t *TwoFactor) Required(roles []string) bool {
+	for _, role := range roles {
+		if slices.Contains(t.cfg.RequiredRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify checks a TOTP code or a recovery code of a user with enabled 2FA.
+// Each code is accepted once: a TOTP code can't be replayed, a recovery code is consumed.
+func (t *TwoFactor) Verify(ctx context.Context, userID int64, code string) (bool, error) {
+	const op = "twofactor.Verify"
+
+	log := t.log.With(
+		slog.String("op", op),
+		slog.Int64("userID", userID),
+	)
+
+	secret, err := t.storage.TOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTOTPNotFound) {
+			return false, fmt.Errorf("%s : %w", op, ErrNotEnabled)
+		}
+
+		log.Error("failed to get secret", sl.Error(err))
+
+		return false, fmt.Errorf("%s : %w", op, err)
+	}
+
+	if !secret.Enabled {
+		return false, fmt.Errorf("%s : %w", op, ErrNotEnabled)
+	}
+
+	code = strings.TrimSpace(code)
+
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(secret.Secret, code, time.Now(), codeSkew)
+		if !ok {
+			return false, nil
+		}
+
+		if err := t.storage.UseTOTPStep(ctx, userID, step); err != nil {
+			if errors.Is(err, storage.ErrTOTPCodeUsed) {
+				log.Warn("totp code replayed")
+
+				return false, nil
+			}
+
+			log.Error("failed to save totp step", sl.Error(err))
+
+			return false, fmt.Errorf("%s : %w", op, err)
+		}
+
+		return true, nil
+	}
+
+	if err := t.storage.UseRecoveryCode(ctx, userID, refresh.Hash(normalizeRecoveryCode(code))); err != nil {
+		if errors.Is(err, storage.ErrRecoveryCodeNotFound) {
+			return false, nil
+		}
+
+		log.Error("failed to use recovery code", sl.Error(err))
+
+		return false, fmt.Errorf("%s : %w", op, err)
+	}
+
+	log.Warn("recovery code used")
+
+	return true, nil
+}
+
+// NewChallenge starts the second step of a login: the challenge is exchanged
+// for tokens together with a valid code within ChallengeTTL.
+func (t *TwoFactor) NewChallenge(ctx context.Context, userID int64) (string, error) {
+	const op = "twofactor.NewChallenge"
+
+	challenge, hash, err := refresh.NewToken()
+	if err != nil {
+		return "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	err = t.storage.SaveOneTimeToken(ctx, models.OneTimeToken{
+		UserID:    userID,
+		Purpose:   models.PurposeLoginChallenge,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(t.cfg.ChallengeTTL),
+	})
+	if err != nil {
+		t.log.Error("failed to save login challenge", slog.String("op", op), sl.Error(err))
+
+		return "", fmt.Errorf("%s : %w", op, err)
+	}
+
+	return challenge, nil
+}
+
+// UseChallenge consumes the challenge and returns the user it was issued to.
+// A challenge is single-use, after a wrong code the login starts over with the password.
+func (t *TwoFactor) UseChallenge(ctx context.Context, challenge string) (int64, error) {
+	const op = "twofactor.UseChallenge"
+
+	used, err := t.storage.UseOneTimeToken(ctx, refresh.Hash(challenge), models.PurposeLoginChallenge)
+	if err != nil {
+		if errors.Is(err, storage.ErrOneTimeTokenNotFound) {
+			return 0, fmt.Errorf("%s : %w", op, ErrInvalidChallenge)
+		}
+
+		t.log.Error("failed to use login challenge", slog.String("op", op), sl.Error(err))
+
+		return 0, fmt.Errorf("%s : %w", op, err)
+	}
+
+	return used.UserID, nil
+}
+
+// newRecoveryCodes returns codes like "k3x7-7qpm-d2fa" and their hashes.
+func (t *TwoFactor) newRecoveryCodes() (codes []string, hashes []string, err error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	for range t.cfg.RecoveryCodes {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+
+		raw := strings.ToLower(encoding.EncodeToString(buf))[:12]
+		codes = append(codes, raw[0:4]+"-"+raw[4:8]+"-"+raw[8:12])
+		hashes = append(hashes, refresh.Hash(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
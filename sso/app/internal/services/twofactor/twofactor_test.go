@@ -0,0 +1,248 @@
+package twofactor
+
+import (
+	"context"
+	"github.com/Muaz717/sso/app/internal/config"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/lib/logger/handlers/slogdiscard"
+	"github.com/Muaz717/sso/app/internal/lib/refresh"
+	"github.com/Muaz717/sso/app/internal/lib/totp"
+	"github.com/Muaz717/sso/app/internal/services/twofactor/mocks"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testUserID = 7
+	testSecret = "JBSWY3DPEHPK3PXP"
+)
+
+func newTestTwoFactor(t *testing.T) (*TwoFactor, *mocks.UserProvider, *mocks.Storage) {
+	users := mocks.NewUserProvider(t)
+	st := mocks.NewStorage(t)
+	cfg := config.TwoFactorConfig{
+		Issuer:        "Gym",
+		RequiredRoles: []string{models.RoleAdmin},
+		RecoveryCodes: 3,
+		ChallengeTTL:  5 * time.Minute,
+	}
+	return New(slogdiscard.NewDiscardLogger(), users, st, cfg), users, st
+}
+
+func code(t *testing.T, at time.Time) (string, int64) {
+	t.Helper()
+
+	step := totp.Step(at)
+	c, err := totp.Code(testSecret, step)
+	require.NoError(t, err)
+	return c, step
+}
+
+func TestEnroll(t *testing.T) {
+	ctx := context.Background()
+	tf, users, st := newTestTwoFactor(t)
+
+	var saved string
+
+	users.EXPECT().UserByID(mock.Anything, int64(testUserID)).
+		Return(models.User{ID: testUserID, Email: "admin@gym.local"}, nil, nil).Once()
+	st.EXPECT().SaveTOTPSecret(mock.Anything, int64(testUserID), mock.Anything).
+		Run(func(_ context.Context, _ int64, secret string) { saved = secret }).
+		Return(nil).Once()
+
+	secret, uri, err := tf.Enroll(ctx, testUserID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, secret)
+	assert.Contains(t, uri, "secret="+secret)
+
+	// the storage refuses to replace the secret of enabled 2FA
+	users.EXPECT().UserByID(mock.Anything, int64(testUserID)).Return(models.User{ID: testUserID}, nil, nil).Once()
+	st.EXPECT().SaveTOTPSecret(mock.Anything, int64(testUserID), mock.Anything).Return(storage.ErrTOTPNotFound).Once()
+
+	_, _, err = tf.Enroll(ctx, testUserID)
+	assert.ErrorIs(t, err, ErrAlreadyEnabled)
+}
+
+func TestConfirm(t *testing.T) {
+	tf, _, st := newTestTwoFactor(t)
+
+	current, step := code(t, time.Now())
+
+	var hashes []string
+
+	st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{UserID: testUserID, Secret: testSecret}, nil).Once()
+	st.EXPECT().EnableTOTP(mock.Anything, int64(testUserID), step, mock.Anything).
+		Run(func(_ context.Context, _ int64, _ int64, recoveryHashes []string) { hashes = recoveryHashes }).
+		Return(nil).Once()
+
+	recovery, err := tf.Confirm(context.Background(), testUserID, " "+current+" ")
+	require.NoError(t, err)
+	require.Len(t, recovery, 3)
+
+	// only the hashes of normalized codes are kept
+	for i, c := range recovery {
+		assert.Equal(t, refresh.Hash(normalizeRecoveryCode(c)), hashes[i])
+	}
+}
+
+func TestConfirm_Rejected(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid code", func(t *testing.T) {
+		tf, _, st := newTestTwoFactor(t)
+		st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret}, nil).Once()
+
+		_, err := tf.Confirm(ctx, testUserID, "000000")
+		assert.ErrorIs(t, err, ErrInvalidCode)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		tf, _, st := newTestTwoFactor(t)
+		st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret, Enabled: true}, nil).Once()
+
+		_, err := tf.Confirm(ctx, testUserID, "000000")
+		assert.ErrorIs(t, err, ErrAlreadyEnabled)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		tf, _, st := newTestTwoFactor(t)
+		st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{}, storage.ErrTOTPNotFound).Once()
+
+		_, err := tf.Confirm(ctx, testUserID, "000000")
+		assert.ErrorIs(t, err, ErrNotEnrolled)
+	})
+
+	t.Run("enabled concurrently", func(t *testing.T) {
+		tf, _, st := newTestTwoFactor(t)
+		current, _ := code(t, time.Now())
+
+		st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret}, nil).Once()
+		st.EXPECT().EnableTOTP(mock.Anything, int64(testUserID), mock.Anything, mock.Anything).Return(storage.ErrTOTPNotFound).Once()
+
+		_, err := tf.Confirm(ctx, testUserID, current)
+		assert.ErrorIs(t, err, ErrInvalidCode)
+	})
+}
+
+func TestVerify_TOTPCodeIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	tf, _, st := newTestTwoFactor(t)
+
+	current, step := code(t, time.Now())
+
+	st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret, Enabled: true}, nil).Twice()
+	st.EXPECT().UseTOTPStep(mock.Anything, int64(testUserID), step).Return(nil).Once()
+
+	ok, err := tf.Verify(ctx, testUserID, current)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the storage refuses a step that isn't past the last used one
+	st.EXPECT().UseTOTPStep(mock.Anything, int64(testUserID), step).Return(storage.ErrTOTPCodeUsed).Once()
+
+	ok, err = tf.Verify(ctx, testUserID, current)
+	require.NoError(t, err)
+	assert.False(t, ok, "replayed code must be rejected")
+}
+
+func TestVerify_RecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	tf, _, st := newTestTwoFactor(t)
+
+	hash := refresh.Hash("k3x77qpmd2fa")
+
+	st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret, Enabled: true}, nil)
+	st.EXPECT().UseRecoveryCode(mock.Anything, int64(testUserID), hash).Return(nil).Once()
+
+	// codes are accepted regardless of case, spaces and dashes
+	ok, err := tf.Verify(ctx, testUserID, " K3X7-7QPM-D2FA ")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	st.EXPECT().UseRecoveryCode(mock.Anything, int64(testUserID), hash).Return(storage.ErrRecoveryCodeNotFound).Once()
+
+	ok, err = tf.Verify(ctx, testUserID, "k3x77qpmd2fa")
+	require.NoError(t, err)
+	assert.False(t, ok, "used recovery code must be rejected")
+}
+
+func TestVerify_NotEnabled(t *testing.T) {
+	tf, _, st := newTestTwoFactor(t)
+
+	st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret}, nil).Once()
+
+	_, err := tf.Verify(context.Background(), testUserID, "123456")
+	assert.ErrorIs(t, err, ErrNotEnabled)
+}
+
+func TestDisable(t *testing.T) {
+	ctx := context.Background()
+	tf, _, st := newTestTwoFactor(t)
+
+	st.EXPECT().TOTP(mock.Anything, int64(testUserID)).Return(models.TOTP{Secret: testSecret, Enabled: true}, nil)
+
+	assert.ErrorIs(t, tf.Disable(ctx, testUserID, "000000"), ErrInvalidCode)
+
+	current, step := code(t, time.Now())
+	st.EXPECT().UseTOTPStep(mock.Anything, int64(testUserID), step).Return(nil).Once()
+	st.EXPECT().DeleteTOTP(mock.Anything, int64(testUserID)).Return(nil).Once()
+
+	require.NoError(t, tf.Disable(ctx, testUserID, current))
+}
+
+func TestChallenge(t *testing.T) {
+	ctx := context.Background()
+	tf, _, st := newTestTwoFactor(t)
+
+	var saved models.OneTimeToken
+
+	st.EXPECT().SaveOneTimeToken(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, token models.OneTimeToken) { saved = token }).
+		Return(nil).Once()
+
+	challenge, err := tf.NewChallenge(ctx, testUserID)
+	require.NoError(t, err)
+	assert.Equal(t, refresh.Hash(challenge), saved.TokenHash)
+	assert.Equal(t, models.PurposeLoginChallenge, saved.Purpose)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), saved.ExpiresAt, time.Second)
+
+	st.EXPECT().UseOneTimeToken(mock.Anything, saved.TokenHash, models.PurposeLoginChallenge).
+		Return(models.OneTimeToken{UserID: testUserID}, nil).Once()
+
+	userID, err := tf.UseChallenge(ctx, challenge)
+	require.NoError(t, err)
+	assert.Equal(t, int64(testUserID), userID)
+
+	st.EXPECT().UseOneTimeToken(mock.Anything, saved.TokenHash, models.PurposeLoginChallenge).
+		Return(models.OneTimeToken{}, storage.ErrOneTimeTokenNotFound).Once()
+
+	_, err = tf.UseChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, ErrInvalidChallenge)
+}
+
+func TestRequired(t *testing.T) {
+	tf, _, _ := newTestTwoFactor(t)
+
+	assert.True(t, tf.Required([]string{"trainer", models.RoleAdmin}))
+	assert.False(t, tf.Required([]string{"trainer"}))
+	assert.False(t, tf.Required(nil))
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	tf, _, _ := newTestTwoFactor(t)
+
+	codes, hashes, err := tf.newRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+
+	for i, c := range codes {
+		assert.Len(t, c, 14)
+		assert.Equal(t, 2, strings.Count(c, "-"))
+		assert.Equal(t, refresh.Hash(normalizeRecoveryCode(c)), hashes[i])
+	}
+}
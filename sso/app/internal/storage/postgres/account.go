@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SaveOneTimeToken stores a password reset or email verification token.
+func (s *Storage) SaveOneTimeToken(ctx context.Context, token models.OneTimeToken) error {
+	const op = "postgres.SaveOneTimeToken"
+
+	query := `INSERT INTO one_time_tokens(token_hash, user_id, purpose, expires_at) VALUES($1, $2, $3, $4)`
+	_, err := s.db.Exec(ctx, query, token.TokenHash, token.UserID, token.Purpose, token.ExpiresAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UseOneTimeToken consumes an unexpired token and returns it. Other tokens of the user
+// with the same purpose are deleted too: only the latest email sent matters.
+func (s *Storage) UseOneTimeToken(ctx context.Context, hash string, purpose string) (models.OneTimeToken, error) {
+	const op = "postgres.UseOneTimeToken"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	token := models.OneTimeToken{TokenHash: hash, Purpose: purpose}
+
+	query := `DELETE FROM one_time_tokens
+		WHERE token_hash = $1 AND purpose = $2 AND expires_at > NOW()
+		RETURNING user_id, expires_at`
+	err = tx.QueryRow(ctx, query, hash, purpose).Scan(&token.UserID, &token.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, storage.ErrOneTimeTokenNotFound)
+		}
+		return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deleteQuery := `DELETE FROM one_time_tokens WHERE user_id = $1 AND purpose = $2`
+	if _, err = tx.Exec(ctx, deleteQuery, token.UserID, purpose); err != nil {
+		return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.OneTimeToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// SetPassword replaces the password hash and ends every session of the user,
+// so a stolen session doesn't survive a password change.
+func (s *Storage) SetPassword(ctx context.Context, userID int64, passHash []byte) error {
+	const op = "postgres.SetPassword"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users SET passhash = $2, token_version = token_version + 1 WHERE id = $1`
+	result, err := tx.Exec(ctx, query, userID, passHash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+	}
+
+	revokeQuery := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err = tx.Exec(ctx, revokeQuery, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SetEmailVerified(ctx context.Context, userID int64) error {
+	const op = "postgres.SetEmailVerified"
+
+	result, err := s.db.Exec(ctx, `UPDATE users SET email_verified = TRUE WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+	}
+
+	return nil
+}
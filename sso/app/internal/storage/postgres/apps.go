@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+const appColumns = `id, name, secret, COALESCE(previous_secret, ''), previous_secret_expires_at, created_at, disabled_at`
+
+// App returns an enabled app, disabled apps are reported as not found.
+func (s *Storage) App(ctx context.Context, appID int) (models.App, error) {
+	const op = "postgres.App"
+
+	query := `SELECT ` + appColumns + ` FROM apps WHERE id = $1 AND disabled_at IS NULL`
+
+	app, err := scanApp(s.db.QueryRow(ctx, query, appID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.App{}, fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+		}
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return app, nil
+}
+
+// Apps returns all apps including disabled ones.
+func (s *Storage) Apps(ctx context.Context) ([]models.App, error) {
+	const op = "postgres.Apps"
+
+	rows, err := s.db.Query(ctx, `SELECT `+appColumns+` FROM apps ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	apps := make([]models.App, 0)
+	for rows.Next() {
+		app, err := scanApp(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		apps = append(apps, app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return apps, nil
+}
+
+func (s *Storage) SaveApp(ctx context.Context, name, secret string) (models.App, error) {
+	const op = "postgres.SaveApp"
+
+	query := `INSERT INTO apps(name, secret) VALUES($1, $2) RETURNING ` + appColumns
+
+	app, err := scanApp(s.db.QueryRow(ctx, query, name, secret))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return models.App{}, fmt.Errorf("%s: %w", op, storage.ErrAppExists)
+		}
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return app, nil
+}
+
+// RotateAppSecret replaces the secret of the app, the current one becomes the previous secret
+// valid until previousExpiresAt. A secret left from an earlier rotation is dropped.
+func (s *Storage) RotateAppSecret(ctx context.Context, appID int64, secret string, previousExpiresAt time.Time) (models.App, error) {
+	const op = "postgres.RotateAppSecret"
+
+	query := `UPDATE apps
+		SET previous_secret = secret, previous_secret_expires_at = $3, secret = $2
+		WHERE id = $1
+		RETURNING ` + appColumns
+
+	app, err := scanApp(s.db.QueryRow(ctx, query, appID, secret, previousExpiresAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.App{}, fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+		}
+		return models.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return app, nil
+}
+
+// SetAppDisabled disables or enables the app. Disabling an already disabled app keeps the original time.
+func (s *Storage) SetAppDisabled(ctx context.Context, appID int64, disabled bool) error {
+	const op = "postgres.SetAppDisabled"
+
+	query := `UPDATE apps
+		SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, NOW()) END
+		WHERE id = $1`
+
+	tag, err := s.db.Exec(ctx, query, appID, disabled)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+	}
+
+	return nil
+}
+
+func scanApp(row pgx.Row) (models.App, error) {
+	var (
+		app                           models.App
+		previousExpiresAt, disabledAt *time.Time
+	)
+
+	err := row.Scan(&app.ID, &app.Name, &app.Secret, &app.PreviousSecret, &previousExpiresAt, &app.CreatedAt, &disabledAt)
+	if err != nil {
+		return models.App{}, err
+	}
+
+	if previousExpiresAt != nil {
+		app.PreviousSecretExpiresAt = *previousExpiresAt
+	}
+	if disabledAt != nil {
+		app.DisabledAt = *disabledAt
+	}
+
+	return app, nil
+}
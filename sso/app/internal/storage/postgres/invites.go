@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SaveInvite stores an invite and returns it with the id and the creation time set.
+// Every role of the invite must exist.
+func (s *Storage) SaveInvite(ctx context.Context, invite models.Invite, codeHash string) (models.Invite, error) {
+	const op = "postgres.SaveInvite"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.Invite{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	roles := slices.Compact(slices.Sorted(slices.Values(invite.Roles)))
+
+	var found int
+	if err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM roles WHERE name = ANY($1)`, roles).Scan(&found); err != nil {
+		return models.Invite{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if found != len(roles) {
+		return models.Invite{}, fmt.Errorf("%s: %w", op, storage.ErrRoleNotFound)
+	}
+
+	query := `INSERT INTO invitations(code_hash, email, roles, created_by, expires_at)
+		VALUES($1, NULLIF($2, ''), $3, NULLIF($4, 0), $5)
+		RETURNING id, created_at`
+	err = tx.QueryRow(ctx, query, codeHash, invite.Email, roles, invite.CreatedBy, invite.ExpiresAt).
+		Scan(&invite.ID, &invite.CreatedAt)
+	if err != nil {
+		return models.Invite{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Invite{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invite.Roles = roles
+
+	return invite, nil
+}
+
+// Invites returns all invites, the latest first.
+func (s *Storage) Invites(ctx context.Context) ([]models.Invite, error) {
+	const op = "postgres.Invites"
+
+	query := `SELECT id, COALESCE(email, ''), roles, COALESCE(created_by, 0), created_at, expires_at,
+			COALESCE(used_by, 0), used_at
+		FROM invitations
+		ORDER BY id DESC`
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	invites := make([]models.Invite, 0)
+	for rows.Next() {
+		var (
+			invite models.Invite
+			usedAt *time.Time
+		)
+		err := rows.Scan(&invite.ID, &invite.Email, &invite.Roles, &invite.CreatedBy, &invite.CreatedAt,
+			&invite.ExpiresAt, &invite.UsedBy, &usedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if usedAt != nil {
+			invite.UsedAt = *usedAt
+		}
+		invites = append(invites, invite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invites, nil
+}
+
+// DeleteInvite deletes an invite that hasn't been used yet.
+func (s *Storage) DeleteInvite(ctx context.Context, id int64) error {
+	const op = "postgres.DeleteInvite"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM invitations WHERE id = $1 AND used_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrInviteNotFound)
+	}
+
+	return nil
+}
+
+// SaveInvitedUser registers a user with the roles of an unused, unexpired invite and marks the invite used.
+// An email delivered invite also confirms the email.
+func (s *Storage) SaveInvitedUser(ctx context.Context, email string, passHash []byte, codeHash string) (int64, error) {
+	const op = "postgres.SaveInvitedUser"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var invite models.Invite
+
+	inviteQuery := `SELECT id, COALESCE(email, ''), roles FROM invitations
+		WHERE code_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		FOR UPDATE`
+	err = tx.QueryRow(ctx, inviteQuery, codeHash).Scan(&invite.ID, &invite.Email, &invite.Roles)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrInviteNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if invite.Email != "" && !strings.EqualFold(invite.Email, email) {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrInviteEmailMismatch)
+	}
+
+	var exists bool
+	if err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`, email).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if exists {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrUserExists)
+	}
+
+	var userID int64
+	insertQuery := `INSERT INTO users(email, passhash, email_verified) VALUES($1, $2, $3) RETURNING id`
+	if err = tx.QueryRow(ctx, insertQuery, email, passHash, invite.Email != "").Scan(&userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, role := range invite.Roles {
+		if _, err = tx.Exec(ctx, `INSERT INTO user_roles(user_id, role) VALUES($1, $2)`, userID, role); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+				// the role was deleted after the invite was issued
+				return 0, fmt.Errorf("%s: %w", op, storage.ErrRoleNotFound)
+			}
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	useQuery := `UPDATE invitations SET used_by = $2, used_at = NOW() WHERE id = $1`
+	if _, err = tx.Exec(ctx, useQuery, invite.ID, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+const uniqueViolation = "23505"
+
+// SigningKeys returns the active key of the app and the keys retired after retiredSince, newest first.
+func (s *Storage) SigningKeys(ctx context.Context, appID int64, retiredSince time.Time) ([]models.SigningKey, error) {
+	const op = "postgres.SigningKeys"
+
+	query := `SELECT kid, app_id, algorithm, private_key, public_key, created_at, retired_at
+		FROM signing_keys
+		WHERE app_id = $1 AND (retired_at IS NULL OR retired_at > $2)
+		ORDER BY created_at DESC`
+	rows, err := s.db.Query(ctx, query, appID, retiredSince)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []models.SigningKey
+	for rows.Next() {
+		var (
+			key             models.SigningKey
+			private, public []byte
+		)
+		if err := rows.Scan(&key.ID, &key.AppID, &key.Algorithm, &private, &public, &key.CreatedAt, &key.RetiredAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if key.PrivateKey, key.PublicKey, err = parseKeyPair(private, public); err != nil {
+			return nil, fmt.Errorf("%s: key %s: %w", op, key.ID, err)
+		}
+
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// SaveSigningKey retires the active key of the app and stores key as the new active one.
+// ErrSigningKeyConflict means another instance has rotated the key at the same time.
+func (s *Storage) SaveSigningKey(ctx context.Context, key models.SigningKey) error {
+	const op = "postgres.SaveSigningKey"
+
+	private, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(key.PublicKey)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	retireQuery := `UPDATE signing_keys SET retired_at = NOW() WHERE app_id = $1 AND retired_at IS NULL`
+	if _, err = tx.Exec(ctx, retireQuery, key.AppID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	insertQuery := `INSERT INTO signing_keys(kid, app_id, algorithm, private_key, public_key, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)`
+	_, err = tx.Exec(ctx, insertQuery, key.ID, key.AppID, key.Algorithm, private, public, key.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrSigningKeyConflict)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrSigningKeyConflict)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteRetiredSigningKeys removes keys retired before the given time.
+func (s *Storage) DeleteRetiredSigningKeys(ctx context.Context, retiredBefore time.Time) (int64, error) {
+	const op = "postgres.DeleteRetiredSigningKeys"
+
+	query := `DELETE FROM signing_keys WHERE retired_at < $1`
+	result, err := s.db.Exec(ctx, query, retiredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func parseKeyPair(private, public []byte) (crypto.Signer, crypto.PublicKey, error) {
+	privateKey, err := x509.ParsePKCS8PrivateKey(private)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(public)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signer, publicKey, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// LoginLockedUntil returns the latest lock among the keys, zero time if none of them is locked.
+func (s *Storage) LoginLockedUntil(ctx context.Context, keys []string) (time.Time, error) {
+	const op = "postgres.LoginLockedUntil"
+
+	query := `SELECT MAX(locked_until) FROM login_failures WHERE key = ANY($1) AND locked_until > NOW()`
+
+	var until *time.Time
+	if err := s.db.QueryRow(ctx, query, keys).Scan(&until); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if until == nil {
+		return time.Time{}, nil
+	}
+
+	return *until, nil
+}
+
+// RegisterLoginFailure counts a failed login for the key and returns the number of failures
+// since the given time; earlier failures are forgotten.
+func (s *Storage) RegisterLoginFailure(ctx context.Context, key string, since time.Time) (int, error) {
+	const op = "postgres.RegisterLoginFailure"
+
+	query := `INSERT INTO login_failures(key, failures, last_failure_at)
+		VALUES($1, 1, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_failures.last_failure_at < $2 THEN 1 ELSE login_failures.failures + 1 END,
+			last_failure_at = NOW()
+		RETURNING failures`
+
+	var failures int
+	if err := s.db.QueryRow(ctx, query, key, since).Scan(&failures); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failures, nil
+}
+
+func (s *Storage) LockLogin(ctx context.Context, key string, until time.Time) error {
+	const op = "postgres.LockLogin"
+
+	if _, err := s.db.Exec(ctx, `UPDATE login_failures SET locked_until = $2 WHERE key = $1`, key, until); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetLoginFailures forgets the failures and locks of the keys and returns how many were reset.
+func (s *Storage) ResetLoginFailures(ctx context.Context, keys []string) (int64, error) {
+	const op = "postgres.ResetLoginFailures"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM login_failures WHERE key = ANY($1)`, keys)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (s *Storage) SaveLoginAttempt(ctx context.Context, attempt models.LoginAttempt) error {
+	const op = "postgres.SaveLoginAttempt"
+
+	var userID *int64
+	if attempt.UserID != 0 {
+		userID = &attempt.UserID
+	}
+
+	query := `INSERT INTO login_audit(email, ip, user_id, reason) VALUES($1, $2, $3, $4)`
+	if _, err := s.db.Exec(ctx, query, attempt.Email, attempt.IP, userID, attempt.Reason); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteStaleLoginData deletes unlocked counters without failures since failuresBefore
+// and audit records older than auditBefore.
+func (s *Storage) DeleteStaleLoginData(ctx context.Context, failuresBefore, auditBefore time.Time) (int64, error) {
+	const op = "postgres.DeleteStaleLoginData"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	failures, err := tx.Exec(ctx, `DELETE FROM login_failures
+		WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < NOW())`, failuresBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	audit, err := tx.Exec(ctx, `DELETE FROM login_audit WHERE created_at < $1`, auditBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failures.RowsAffected() + audit.RowsAffected(), nil
+}
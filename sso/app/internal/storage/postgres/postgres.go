@@ -8,9 +8,13 @@ import (
 	"github.com/Muaz717/sso/app/internal/domain/models"
 	"github.com/Muaz717/sso/app/internal/storage"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 )
 
+const foreignKeyViolation = "23503"
+
 type Storage struct {
 	db *pgxpool.Pool
 }
@@ -76,11 +80,14 @@ func (s *Storage) SaveUser(
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	queryInsertRole := `INSERT INTO user_roles(user_id, role) VALUES($1, $2)`
-	_, err = tx.Exec(ctx, queryInsertRole, userId, role)
-	if err != nil {
-		_ = tx.Rollback(ctx)
-		return 0, fmt.Errorf("%s: %w", op, err)
+	// with open registration disabled self-registered users get no role
+	if role != "" {
+		queryInsertRole := `INSERT INTO user_roles(user_id, role) VALUES($1, $2)`
+		_, err = tx.Exec(ctx, queryInsertRole, userId, role)
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -93,56 +100,75 @@ func (s *Storage) SaveUser(
 func (s *Storage) User(ctx context.Context, email string) (models.User, []string, error) {
 	const op = "postgres.User"
 
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	user, roles, err := s.user(ctx, `SELECT id, email, passhash, token_version, email_verified FROM users WHERE email = $1`, email)
+	if err != nil {
+		return models.User{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, roles, nil
+}
+
+func (s *Storage) UserByID(ctx context.Context, userID int64) (models.User, []string, error) {
+	const op = "postgres.UserByID"
+
+	user, roles, err := s.user(ctx, `SELECT id, email, passhash, token_version, email_verified FROM users WHERE id = $1`, userID)
 	if err != nil {
 		return models.User{}, nil, fmt.Errorf("%s: %w", op, err)
 	}
+
+	return user, roles, nil
+}
+
+// user loads a single user selected by selectUserQuery together with the roles.
+func (s *Storage) user(ctx context.Context, selectUserQuery string, arg any) (models.User, []string, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.User{}, nil, err
+	}
 	defer tx.Rollback(ctx)
 
-	selectUserQuery := `SELECT id, email, passhash FROM users WHERE email = $1`
-	row := tx.QueryRow(ctx, selectUserQuery, email)
+	row := tx.QueryRow(ctx, selectUserQuery, arg)
 
 	var user models.User
-	err = row.Scan(&user.ID, &user.Email, &user.PassHash)
+	err = row.Scan(&user.ID, &user.Email, &user.PassHash, &user.TokenVersion, &user.EmailVerified)
 	if err != nil {
-		_ = tx.Rollback(ctx)
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.User{}, nil, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+			return models.User{}, nil, storage.ErrUserNotFound
 		}
-		return models.User{}, nil, fmt.Errorf("%s: %w", op, err)
+		return models.User{}, nil, err
 	}
 
 	var roles []string
 	selectRolesQuery := `SELECT role FROM user_roles WHERE user_id = $1`
 	rows, err := tx.Query(ctx, selectRolesQuery, user.ID)
 	if err != nil {
-		_ = tx.Rollback(ctx)
-		return models.User{}, nil, fmt.Errorf("%s: %w", op, err)
+		return models.User{}, nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var role string
 		if err := rows.Scan(&role); err != nil {
-			return models.User{}, nil, fmt.Errorf("%s: %w", op, err)
+			return models.User{}, nil, err
 		}
 		roles = append(roles, role)
 	}
 
-	return user, roles, nil
+	return user, roles, rows.Err()
 }
 
 func (s *Storage) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 	const op = "postgres.IsAdmin"
 
-	query := `SELECT is_admin FROM users WHERE id = $1`
-	row := s.db.QueryRow(ctx, query, userID)
+	query := `SELECT EXISTS(SELECT 1 FROM user_roles WHERE user_id = u.id AND role = $2)
+		FROM users u WHERE u.id = $1`
+	row := s.db.QueryRow(ctx, query, userID, models.RoleAdmin)
 
 	var isAdmin bool
 	err := row.Scan(&isAdmin)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return false, fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+			return false, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
 		}
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
@@ -150,29 +176,37 @@ func (s *Storage) IsAdmin(ctx context.Context, userID int64) (bool, error) {
 	return isAdmin, nil
 }
 
-func (s *Storage) App(ctx context.Context, appID int) (models.App, error) {
-	const op = "postgres.App"
+// RevokeToken stores the token id until the token expires.
+func (s *Storage) RevokeToken(ctx context.Context, jti string, userID int64, expiresAt time.Time) error {
+	const op = "postgres.RevokeToken"
 
-	query := `SELECT id, name, secret FROM apps WHERE id = $1`
-	row := s.db.QueryRow(ctx, query, appID)
-
-	var app models.App
-	err := row.Scan(&app.ID, &app.Name, &app.Secret)
+	query := `INSERT INTO revoked_tokens(jti, user_id, expires_at) VALUES($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING`
+	_, err := s.db.Exec(ctx, query, jti, userID, expiresAt)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.App{}, fmt.Errorf("%s: %w", op, storage.ErrAppNotFound)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
 		}
-		return models.App{}, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return app, nil
+	return nil
 }
 
-func (s *Storage) Logout(ctx context.Context, email string) error {
-	const op = "postgres.Logout"
+// RevokeAllTokens bumps the user's token version, so every token issued before becomes invalid,
+// and revokes all refresh sessions of the user.
+func (s *Storage) RevokeAllTokens(ctx context.Context, userID int64) error {
+	const op = "postgres.RevokeAllTokens"
 
-	query := `DELETE FROM users WHERE email = $1`
-	result, err := s.db.Exec(ctx, query, email)
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users SET token_version = token_version + 1 WHERE id = $1`
+	result, err := tx.Exec(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -181,5 +215,184 @@ func (s *Storage) Logout(ctx context.Context, email string) error {
 		return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
 	}
 
+	revokeQuery := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err = tx.Exec(ctx, revokeQuery, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// TokenState returns the current token version of the user and whether the token id
+// or its refresh session was revoked.
+func (s *Storage) TokenState(ctx context.Context, userID int64, jti string, sessionID string) (int, bool, error) {
+	const op = "postgres.TokenState"
+
+	query := `SELECT u.token_version,
+			EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $2)
+			OR EXISTS(SELECT 1 FROM refresh_tokens WHERE session_id = $3 AND revoked_at IS NOT NULL)
+		FROM users u WHERE u.id = $1`
+	row := s.db.QueryRow(ctx, query, userID, jti, sessionID)
+
+	var (
+		version int
+		revoked bool
+	)
+	err := row.Scan(&version, &revoked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, revoked, nil
+}
+
+// DeleteExpiredTokens removes revoked token ids, refresh tokens and one-time tokens that have already expired.
+func (s *Storage) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	const op = "postgres.DeleteExpiredTokens"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	revoked, err := tx.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refresh, err := tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	oneTime, err := tx.Exec(ctx, `DELETE FROM one_time_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revoked.RowsAffected() + refresh.RowsAffected() + oneTime.RowsAffected(), nil
+}
+
+// SaveRefreshToken stores a refresh token issued on login.
+func (s *Storage) SaveRefreshToken(ctx context.Context, token models.RefreshToken) error {
+	const op = "postgres.SaveRefreshToken"
+
+	query := `INSERT INTO refresh_tokens(session_id, user_id, app_id, token_hash, device, expires_at)
+		VALUES($1, $2, $3, $4, $5, $6)`
+	_, err := s.db.Exec(ctx, query, token.SessionID, token.UserID, token.AppID, token.TokenHash, token.Device, token.ExpiresAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken marks the token with the given hash as used and stores next in the same session.
+// A token presented again within reuseInterval after its first use is a concurrent refresh of the same
+// client and is rotated once more. A later reuse means the token leaked: the whole session is revoked and
+// ErrRefreshTokenReused is returned together with the presented token.
+func (s *Storage) RotateRefreshToken(
+	ctx context.Context,
+	hash string,
+	appID int64,
+	next models.RefreshToken,
+	reuseInterval time.Duration,
+) (models.RefreshToken, error) {
+	const op = "postgres.RotateRefreshToken"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	selectQuery := `SELECT id, session_id, user_id, app_id, token_hash, device, expires_at,
+			used_at IS NOT NULL, used_at IS NOT NULL AND used_at > $3
+		FROM refresh_tokens
+		WHERE token_hash = $1 AND app_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+		FOR UPDATE`
+	row := tx.QueryRow(ctx, selectQuery, hash, appID, time.Now().Add(-reuseInterval))
+
+	var (
+		current      models.RefreshToken
+		used, recent bool
+	)
+	err = row.Scan(
+		&current.ID,
+		&current.SessionID,
+		&current.UserID,
+		&current.AppID,
+		&current.TokenHash,
+		&current.Device,
+		&current.ExpiresAt,
+		&used,
+		&recent,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RefreshToken{}, fmt.Errorf("%s: %w", op, storage.ErrRefreshTokenNotFound)
+		}
+		return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if used && !recent {
+		revokeQuery := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE session_id = $1 AND revoked_at IS NULL`
+		if _, err = tx.Exec(ctx, revokeQuery, current.SessionID); err != nil {
+			return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err = tx.Commit(ctx); err != nil {
+			return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return current, fmt.Errorf("%s: %w", op, storage.ErrRefreshTokenReused)
+	}
+
+	// the interval counts from the first use, repeats don't extend it
+	if !used {
+		if _, err = tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1`, current.ID); err != nil {
+			return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	insertQuery := `INSERT INTO refresh_tokens(session_id, user_id, app_id, token_hash, device, expires_at)
+		VALUES($1, $2, $3, $4, $5, $6)`
+	_, err = tx.Exec(ctx, insertQuery, current.SessionID, current.UserID, current.AppID, next.TokenHash, current.Device, next.ExpiresAt)
+	if err != nil {
+		return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return current, nil
+}
+
+// RevokeSession revokes all refresh tokens of one login session.
+func (s *Storage) RevokeSession(ctx context.Context, sessionID string) error {
+	const op = "postgres.RevokeSession"
+
+	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE session_id = $1 AND revoked_at IS NULL`
+	if _, err := s.db.Exec(ctx, query, sessionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (s *Storage) Users(ctx context.Context, filter models.UserFilter) ([]models.UserInfo, int64, error) {
+	const op = "postgres.Users"
+
+	pattern := "%" + filter.Email + "%"
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM users WHERE email ILIKE $1`
+	if err := s.db.QueryRow(ctx, countQuery, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `SELECT u.id, u.email, COALESCE(array_agg(ur.role ORDER BY ur.role) FILTER (WHERE ur.role IS NOT NULL), '{}')
+		FROM users u
+		LEFT JOIN user_roles ur ON ur.user_id = u.id
+		WHERE u.email ILIKE $1
+		GROUP BY u.id
+		ORDER BY u.id
+		LIMIT $2 OFFSET $3`
+	rows, err := s.db.Query(ctx, query, pattern, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := make([]models.UserInfo, 0)
+	for rows.Next() {
+		var user models.UserInfo
+		if err := rows.Scan(&user.ID, &user.Email, &user.Roles); err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, total, nil
+}
+
+func (s *Storage) Roles(ctx context.Context) ([]models.Role, error) {
+	const op = "postgres.Roles"
+
+	query := `SELECT r.name, r.description,
+			COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		GROUP BY r.name
+		ORDER BY r.name`
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.Name, &role.Description, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// AssignRole grants the role to the user. Tokens issued before carry the old roles,
+// so the user's token version is bumped and the client has to refresh them.
+func (s *Storage) AssignRole(ctx context.Context, userID int64, role string) error {
+	const op = "postgres.AssignRole"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `INSERT INTO user_roles(user_id, role) VALUES($1, $2) ON CONFLICT DO NOTHING`
+	result, err := tx.Exec(ctx, query, userID, role)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			if pgErr.ConstraintName == "fk_user_roles_role" {
+				return fmt.Errorf("%s: %w", op, storage.ErrRoleNotFound)
+			}
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	if err = bumpTokenVersion(ctx, tx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RevokeRole takes the role away from the user. With keepHolder set, the last holder
+// of the role can't lose it, so the system is never left without an administrator.
+func (s *Storage) RevokeRole(ctx context.Context, userID int64, role string, keepHolder bool) error {
+	const op = "postgres.RevokeRole"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+	}
+
+	if keepHolder {
+		// locks the holders so two admins can't revoke each other at the same time
+		rows, err := tx.Query(ctx, `SELECT user_id FROM user_roles WHERE role = $1 FOR UPDATE`, role)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		holders, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if len(holders) == 1 && holders[0] == userID {
+			return fmt.Errorf("%s: %w", op, storage.ErrLastRoleHolder)
+		}
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, userID, role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	if err = bumpTokenVersion(ctx, tx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// HasPermission reports whether one of the user's roles grants the permission.
+func (s *Storage) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	const op = "postgres.HasPermission"
+
+	query := `SELECT EXISTS(
+			SELECT 1 FROM user_roles ur
+			JOIN role_permissions rp ON rp.role = ur.role
+			WHERE ur.user_id = u.id AND rp.permission IN ($2, $3)
+		)
+		FROM users u WHERE u.id = $1`
+
+	var allowed bool
+	err := s.db.QueryRow(ctx, query, userID, permission, models.PermissionAll).Scan(&allowed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return allowed, nil
+}
+
+// Permissions returns the permissions granted by all roles of the user.
+func (s *Storage) Permissions(ctx context.Context, userID int64) ([]string, error) {
+	const op = "postgres.Permissions"
+
+	query := `SELECT DISTINCT rp.permission
+		FROM user_roles ur
+		JOIN role_permissions rp ON rp.role = ur.role
+		WHERE ur.user_id = $1
+		ORDER BY rp.permission`
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	permissions, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return permissions, nil
+}
+
+func bumpTokenVersion(ctx context.Context, tx pgx.Tx, userID int64) error {
+	_, err := tx.Exec(ctx, `UPDATE users SET token_version = token_version + 1 WHERE id = $1`, userID)
+	return err
+}
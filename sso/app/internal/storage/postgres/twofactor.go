@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Muaz717/sso/app/internal/domain/models"
+	"github.com/Muaz717/sso/app/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (s *Storage) TOTP(ctx context.Context, userID int64) (models.TOTP, error) {
+	const op = "postgres.TOTP"
+
+	totp := models.TOTP{UserID: userID}
+
+	query := `SELECT secret, enabled, last_step FROM user_totp WHERE user_id = $1`
+	err := s.db.QueryRow(ctx, query, userID).Scan(&totp.Secret, &totp.Enabled, &totp.LastStep)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TOTP{}, fmt.Errorf("%s: %w", op, storage.ErrTOTPNotFound)
+		}
+		return models.TOTP{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return totp, nil
+}
+
+// SaveTOTPSecret stores a new unconfirmed secret, replacing an earlier unconfirmed one.
+// An enabled secret is never replaced, ErrTOTPNotFound is returned then.
+func (s *Storage) SaveTOTPSecret(ctx context.Context, userID int64, secret string) error {
+	const op = "postgres.SaveTOTPSecret"
+
+	query := `INSERT INTO user_totp(user_id, secret) VALUES($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_step = 0, created_at = NOW()
+		WHERE user_totp.enabled = FALSE`
+	result, err := s.db.Exec(ctx, query, userID, secret)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTOTPNotFound)
+	}
+
+	return nil
+}
+
+// EnableTOTP confirms the unconfirmed secret with the step of a valid code
+// and replaces the recovery codes of the user.
+func (s *Storage) EnableTOTP(ctx context.Context, userID int64, step int64, recoveryHashes []string) error {
+	const op = "postgres.EnableTOTP"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE user_totp SET enabled = TRUE, enabled_at = NOW(), last_step = $2
+		WHERE user_id = $1 AND enabled = FALSE AND last_step < $2`
+	result, err := tx.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTOTPNotFound)
+	}
+
+	if err = replaceRecoveryCodes(ctx, tx, userID, recoveryHashes); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the step of an accepted code. It fails with ErrTOTPCodeUsed
+// if a code of this or a later step was already accepted.
+func (s *Storage) UseTOTPStep(ctx context.Context, userID int64, step int64) error {
+	const op = "postgres.UseTOTPStep"
+
+	query := `UPDATE user_totp SET last_step = $2 WHERE user_id = $1 AND enabled = TRUE AND last_step < $2`
+	result, err := s.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTOTPCodeUsed)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode consumes a recovery code of the user.
+func (s *Storage) UseRecoveryCode(ctx context.Context, userID int64, hash string) error {
+	const op = "postgres.UseRecoveryCode"
+
+	result, err := s.db.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1 AND code_hash = $2`, userID, hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrRecoveryCodeNotFound)
+	}
+
+	return nil
+}
+
+// DeleteTOTP turns 2FA off: the secret and the recovery codes are deleted.
+func (s *Storage) DeleteTOTP(ctx context.Context, userID int64) error {
+	const op = "postgres.DeleteTOTP"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = replaceRecoveryCodes(ctx, tx, userID, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID int64, hashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		if _, err := tx.Exec(ctx, `INSERT INTO recovery_codes(code_hash, user_id) VALUES($1, $2)`, hash, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
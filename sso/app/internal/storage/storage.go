@@ -6,4 +6,22 @@ var (
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
 	ErrAppNotFound  = errors.New("app not found")
+	ErrAppExists    = errors.New("app already exists")
+
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token reused")
+
+	ErrSigningKeyConflict = errors.New("signing key was rotated concurrently")
+
+	ErrRoleNotFound   = errors.New("role not found")
+	ErrLastRoleHolder = errors.New("user is the last holder of the role")
+
+	ErrOneTimeTokenNotFound = errors.New("one-time token not found")
+
+	ErrTOTPNotFound         = errors.New("totp not found")
+	ErrTOTPCodeUsed         = errors.New("totp code already used")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+
+	ErrInviteNotFound      = errors.New("invite not found")
+	ErrInviteEmailMismatch = errors.New("invite was issued for another email")
 )
@@ -293,6 +293,10 @@ func (m *RegisterRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for InviteCode
+
+	// no validation rules for WithoutRoles
+
 	if len(errors) > 0 {
 		return RegisterRequestMultiError(errors)
 	}
@@ -579,6 +583,19 @@ func (m *LoginRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if utf8.RuneCountInString(m.GetDevice()) > 255 {
+		err := LoginRequestValidationError{
+			field:  "Device",
+			reason: "value length must be at most 255 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Ip
+
 	if len(errors) > 0 {
 		return LoginRequestMultiError(errors)
 	}
@@ -730,6 +747,16 @@ func (m *LoginResponse) validate(all bool) error {
 
 	// no validation rules for Token
 
+	// no validation rules for RefreshToken
+
+	// no validation rules for ExpiresIn
+
+	// no validation rules for RefreshExpiresIn
+
+	// no validation rules for TwoFactorRequired
+
+	// no validation rules for Challenge
+
 	if len(errors) > 0 {
 		return LoginResponseMultiError(errors)
 	}
@@ -808,31 +835,42 @@ var _ interface {
 	ErrorName() string
 } = LoginResponseValidationError{}
 
-// Validate checks the field values on LogoutRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *LogoutRequest) Validate() error {
+// Validate checks the field values on LoginTwoFactorRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *LoginTwoFactorRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LogoutRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LogoutRequestMultiError, or
-// nil if none found.
-func (m *LogoutRequest) ValidateAll() error {
+// ValidateAll checks the field values on LoginTwoFactorRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LoginTwoFactorRequestMultiError, or nil if none found.
+func (m *LoginTwoFactorRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LogoutRequest) validate(all bool) error {
+func (m *LoginTwoFactorRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetToken()) < 1 {
-		err := LogoutRequestValidationError{
-			field:  "Token",
+	if utf8.RuneCountInString(m.GetChallenge()) < 1 {
+		err := LoginTwoFactorRequestValidationError{
+			field:  "Challenge",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCode()) < 1 {
+		err := LoginTwoFactorRequestValidationError{
+			field:  "Code",
 			reason: "value length must be at least 1 runes",
 		}
 		if !all {
@@ -842,7 +880,7 @@ func (m *LogoutRequest) validate(all bool) error {
 	}
 
 	if m.GetAppId() <= 0 {
-		err := LogoutRequestValidationError{
+		err := LoginTwoFactorRequestValidationError{
 			field:  "AppId",
 			reason: "value must be greater than 0",
 		}
@@ -852,20 +890,33 @@ func (m *LogoutRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if utf8.RuneCountInString(m.GetDevice()) > 255 {
+		err := LoginTwoFactorRequestValidationError{
+			field:  "Device",
+			reason: "value length must be at most 255 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Ip
+
 	if len(errors) > 0 {
-		return LogoutRequestMultiError(errors)
+		return LoginTwoFactorRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// LogoutRequestMultiError is an error wrapping multiple validation errors
-// returned by LogoutRequest.ValidateAll() if the designated constraints
-// aren't met.
-type LogoutRequestMultiError []error
+// LoginTwoFactorRequestMultiError is an error wrapping multiple validation
+// errors returned by LoginTwoFactorRequest.ValidateAll() if the designated
+// constraints aren't met.
+type LoginTwoFactorRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LogoutRequestMultiError) Error() string {
+func (m LoginTwoFactorRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -874,11 +925,11 @@ func (m LogoutRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LogoutRequestMultiError) AllErrors() []error { return m }
+func (m LoginTwoFactorRequestMultiError) AllErrors() []error { return m }
 
-// LogoutRequestValidationError is the validation error returned by
-// LogoutRequest.Validate if the designated constraints aren't met.
-type LogoutRequestValidationError struct {
+// LoginTwoFactorRequestValidationError is the validation error returned by
+// LoginTwoFactorRequest.Validate if the designated constraints aren't met.
+type LoginTwoFactorRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -886,22 +937,24 @@ type LogoutRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e LogoutRequestValidationError) Field() string { return e.field }
+func (e LoginTwoFactorRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LogoutRequestValidationError) Reason() string { return e.reason }
+func (e LoginTwoFactorRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LogoutRequestValidationError) Cause() error { return e.cause }
+func (e LoginTwoFactorRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LogoutRequestValidationError) Key() bool { return e.key }
+func (e LoginTwoFactorRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LogoutRequestValidationError) ErrorName() string { return "LogoutRequestValidationError" }
+func (e LoginTwoFactorRequestValidationError) ErrorName() string {
+	return "LoginTwoFactorRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e LogoutRequestValidationError) Error() string {
+func (e LoginTwoFactorRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -913,14 +966,14 @@ func (e LogoutRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLogoutRequest.%s: %s%s",
+		"invalid %sLoginTwoFactorRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LogoutRequestValidationError{}
+var _ error = LoginTwoFactorRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -928,46 +981,66 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LogoutRequestValidationError{}
+} = LoginTwoFactorRequestValidationError{}
 
-// Validate checks the field values on LogoutResponse with the rules defined in
+// Validate checks the field values on RefreshRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *LogoutResponse) Validate() error {
+func (m *RefreshRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LogoutResponse with the rules defined
+// ValidateAll checks the field values on RefreshRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LogoutResponseMultiError,
+// result is a list of violation errors wrapped in RefreshRequestMultiError,
 // or nil if none found.
-func (m *LogoutResponse) ValidateAll() error {
+func (m *RefreshRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LogoutResponse) validate(all bool) error {
+func (m *RefreshRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Success
+	if utf8.RuneCountInString(m.GetRefreshToken()) < 1 {
+		err := RefreshRequestValidationError{
+			field:  "RefreshToken",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAppId() <= 0 {
+		err := RefreshRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return LogoutResponseMultiError(errors)
+		return RefreshRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// LogoutResponseMultiError is an error wrapping multiple validation errors
-// returned by LogoutResponse.ValidateAll() if the designated constraints
+// RefreshRequestMultiError is an error wrapping multiple validation errors
+// returned by RefreshRequest.ValidateAll() if the designated constraints
 // aren't met.
-type LogoutResponseMultiError []error
+type RefreshRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LogoutResponseMultiError) Error() string {
+func (m RefreshRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -976,11 +1049,11 @@ func (m LogoutResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LogoutResponseMultiError) AllErrors() []error { return m }
+func (m RefreshRequestMultiError) AllErrors() []error { return m }
 
-// LogoutResponseValidationError is the validation error returned by
-// LogoutResponse.Validate if the designated constraints aren't met.
-type LogoutResponseValidationError struct {
+// RefreshRequestValidationError is the validation error returned by
+// RefreshRequest.Validate if the designated constraints aren't met.
+type RefreshRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -988,22 +1061,22 @@ type LogoutResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e LogoutResponseValidationError) Field() string { return e.field }
+func (e RefreshRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LogoutResponseValidationError) Reason() string { return e.reason }
+func (e RefreshRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LogoutResponseValidationError) Cause() error { return e.cause }
+func (e RefreshRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LogoutResponseValidationError) Key() bool { return e.key }
+func (e RefreshRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LogoutResponseValidationError) ErrorName() string { return "LogoutResponseValidationError" }
+func (e RefreshRequestValidationError) ErrorName() string { return "RefreshRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e LogoutResponseValidationError) Error() string {
+func (e RefreshRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1015,14 +1088,14 @@ func (e LogoutResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLogoutResponse.%s: %s%s",
+		"invalid %sRefreshRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LogoutResponseValidationError{}
+var _ error = RefreshRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1030,66 +1103,52 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LogoutResponseValidationError{}
+} = RefreshRequestValidationError{}
 
-// Validate checks the field values on CheckTokenRequest with the rules defined
+// Validate checks the field values on RefreshResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *CheckTokenRequest) Validate() error {
+func (m *RefreshResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CheckTokenRequest with the rules
+// ValidateAll checks the field values on RefreshResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CheckTokenRequestMultiError, or nil if none found.
-func (m *CheckTokenRequest) ValidateAll() error {
+// RefreshResponseMultiError, or nil if none found.
+func (m *RefreshResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CheckTokenRequest) validate(all bool) error {
+func (m *RefreshResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetToken()) < 1 {
-		err := CheckTokenRequestValidationError{
-			field:  "Token",
-			reason: "value length must be at least 1 runes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for Token
 
-	if m.GetAppId() <= 0 {
-		err := CheckTokenRequestValidationError{
-			field:  "AppId",
-			reason: "value must be greater than 0",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for RefreshToken
+
+	// no validation rules for ExpiresIn
+
+	// no validation rules for RefreshExpiresIn
 
 	if len(errors) > 0 {
-		return CheckTokenRequestMultiError(errors)
+		return RefreshResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// CheckTokenRequestMultiError is an error wrapping multiple validation errors
-// returned by CheckTokenRequest.ValidateAll() if the designated constraints
+// RefreshResponseMultiError is an error wrapping multiple validation errors
+// returned by RefreshResponse.ValidateAll() if the designated constraints
 // aren't met.
-type CheckTokenRequestMultiError []error
+type RefreshResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CheckTokenRequestMultiError) Error() string {
+func (m RefreshResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1098,11 +1157,11 @@ func (m CheckTokenRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CheckTokenRequestMultiError) AllErrors() []error { return m }
+func (m RefreshResponseMultiError) AllErrors() []error { return m }
 
-// CheckTokenRequestValidationError is the validation error returned by
-// CheckTokenRequest.Validate if the designated constraints aren't met.
-type CheckTokenRequestValidationError struct {
+// RefreshResponseValidationError is the validation error returned by
+// RefreshResponse.Validate if the designated constraints aren't met.
+type RefreshResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1110,24 +1169,22 @@ type CheckTokenRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e CheckTokenRequestValidationError) Field() string { return e.field }
+func (e RefreshResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CheckTokenRequestValidationError) Reason() string { return e.reason }
+func (e RefreshResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CheckTokenRequestValidationError) Cause() error { return e.cause }
+func (e RefreshResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CheckTokenRequestValidationError) Key() bool { return e.key }
+func (e RefreshResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CheckTokenRequestValidationError) ErrorName() string {
-	return "CheckTokenRequestValidationError"
-}
+func (e RefreshResponseValidationError) ErrorName() string { return "RefreshResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e CheckTokenRequestValidationError) Error() string {
+func (e RefreshResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1139,14 +1196,14 @@ func (e CheckTokenRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCheckTokenRequest.%s: %s%s",
+		"invalid %sRefreshResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CheckTokenRequestValidationError{}
+var _ error = RefreshResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1154,50 +1211,68 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CheckTokenRequestValidationError{}
+} = RefreshResponseValidationError{}
 
-// Validate checks the field values on CheckTokenResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *CheckTokenResponse) Validate() error {
+// Validate checks the field values on LogoutRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogoutRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CheckTokenResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// CheckTokenResponseMultiError, or nil if none found.
-func (m *CheckTokenResponse) ValidateAll() error {
+// ValidateAll checks the field values on LogoutRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogoutRequestMultiError, or
+// nil if none found.
+func (m *LogoutRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CheckTokenResponse) validate(all bool) error {
+func (m *LogoutRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := LogoutRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-	// no validation rules for IsValid
+	if m.GetAppId() <= 0 {
+		err := LogoutRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-	// no validation rules for Email
+	// no validation rules for AllSessions
 
 	if len(errors) > 0 {
-		return CheckTokenResponseMultiError(errors)
+		return LogoutRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// CheckTokenResponseMultiError is an error wrapping multiple validation errors
-// returned by CheckTokenResponse.ValidateAll() if the designated constraints
+// LogoutRequestMultiError is an error wrapping multiple validation errors
+// returned by LogoutRequest.ValidateAll() if the designated constraints
 // aren't met.
-type CheckTokenResponseMultiError []error
+type LogoutRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CheckTokenResponseMultiError) Error() string {
+func (m LogoutRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1206,11 +1281,11 @@ func (m CheckTokenResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CheckTokenResponseMultiError) AllErrors() []error { return m }
+func (m LogoutRequestMultiError) AllErrors() []error { return m }
 
-// CheckTokenResponseValidationError is the validation error returned by
-// CheckTokenResponse.Validate if the designated constraints aren't met.
-type CheckTokenResponseValidationError struct {
+// LogoutRequestValidationError is the validation error returned by
+// LogoutRequest.Validate if the designated constraints aren't met.
+type LogoutRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1218,24 +1293,22 @@ type CheckTokenResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e CheckTokenResponseValidationError) Field() string { return e.field }
+func (e LogoutRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CheckTokenResponseValidationError) Reason() string { return e.reason }
+func (e LogoutRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CheckTokenResponseValidationError) Cause() error { return e.cause }
+func (e LogoutRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CheckTokenResponseValidationError) Key() bool { return e.key }
+func (e LogoutRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CheckTokenResponseValidationError) ErrorName() string {
-	return "CheckTokenResponseValidationError"
-}
+func (e LogoutRequestValidationError) ErrorName() string { return "LogoutRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e CheckTokenResponseValidationError) Error() string {
+func (e LogoutRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1247,14 +1320,14 @@ func (e CheckTokenResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCheckTokenResponse.%s: %s%s",
+		"invalid %sLogoutRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CheckTokenResponseValidationError{}
+var _ error = LogoutRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1262,4 +1335,6304 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CheckTokenResponseValidationError{}
+} = LogoutRequestValidationError{}
+
+// Validate checks the field values on LogoutResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogoutResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogoutResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogoutResponseMultiError,
+// or nil if none found.
+func (m *LogoutResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogoutResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return LogoutResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogoutResponseMultiError is an error wrapping multiple validation errors
+// returned by LogoutResponse.ValidateAll() if the designated constraints
+// aren't met.
+type LogoutResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogoutResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogoutResponseMultiError) AllErrors() []error { return m }
+
+// LogoutResponseValidationError is the validation error returned by
+// LogoutResponse.Validate if the designated constraints aren't met.
+type LogoutResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogoutResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogoutResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogoutResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogoutResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogoutResponseValidationError) ErrorName() string { return "LogoutResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogoutResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogoutResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogoutResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogoutResponseValidationError{}
+
+// Validate checks the field values on CheckTokenRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CheckTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CheckTokenRequestMultiError, or nil if none found.
+func (m *CheckTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := CheckTokenRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAppId() <= 0 {
+		err := CheckTokenRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CheckTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckTokenRequestMultiError is an error wrapping multiple validation errors
+// returned by CheckTokenRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CheckTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckTokenRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckTokenRequestMultiError) AllErrors() []error { return m }
+
+// CheckTokenRequestValidationError is the validation error returned by
+// CheckTokenRequest.Validate if the designated constraints aren't met.
+type CheckTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckTokenRequestValidationError) ErrorName() string {
+	return "CheckTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckTokenRequestValidationError{}
+
+// Validate checks the field values on CheckTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CheckTokenResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CheckTokenResponseMultiError, or nil if none found.
+func (m *CheckTokenResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckTokenResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for IsValid
+
+	// no validation rules for Email
+
+	if len(errors) > 0 {
+		return CheckTokenResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckTokenResponseMultiError is an error wrapping multiple validation errors
+// returned by CheckTokenResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CheckTokenResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckTokenResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckTokenResponseMultiError) AllErrors() []error { return m }
+
+// CheckTokenResponseValidationError is the validation error returned by
+// CheckTokenResponse.Validate if the designated constraints aren't met.
+type CheckTokenResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckTokenResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckTokenResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckTokenResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckTokenResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckTokenResponseValidationError) ErrorName() string {
+	return "CheckTokenResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckTokenResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckTokenResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckTokenResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckTokenResponseValidationError{}
+
+// Validate checks the field values on GetJWKSRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetJWKSRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetJWKSRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetJWKSRequestMultiError,
+// or nil if none found.
+func (m *GetJWKSRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetJWKSRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAppId() <= 0 {
+		err := GetJWKSRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetJWKSRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetJWKSRequestMultiError is an error wrapping multiple validation errors
+// returned by GetJWKSRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetJWKSRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetJWKSRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetJWKSRequestMultiError) AllErrors() []error { return m }
+
+// GetJWKSRequestValidationError is the validation error returned by
+// GetJWKSRequest.Validate if the designated constraints aren't met.
+type GetJWKSRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetJWKSRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetJWKSRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetJWKSRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetJWKSRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetJWKSRequestValidationError) ErrorName() string { return "GetJWKSRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetJWKSRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetJWKSRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetJWKSRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetJWKSRequestValidationError{}
+
+// Validate checks the field values on JWK with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *JWK) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JWK with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in JWKMultiError, or nil if none found.
+func (m *JWK) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JWK) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Kty
+
+	// no validation rules for Kid
+
+	// no validation rules for Alg
+
+	// no validation rules for Use
+
+	// no validation rules for Crv
+
+	// no validation rules for X
+
+	// no validation rules for N
+
+	// no validation rules for E
+
+	if len(errors) > 0 {
+		return JWKMultiError(errors)
+	}
+
+	return nil
+}
+
+// JWKMultiError is an error wrapping multiple validation errors returned by
+// JWK.ValidateAll() if the designated constraints aren't met.
+type JWKMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JWKMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JWKMultiError) AllErrors() []error { return m }
+
+// JWKValidationError is the validation error returned by JWK.Validate if the
+// designated constraints aren't met.
+type JWKValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JWKValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JWKValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JWKValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JWKValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JWKValidationError) ErrorName() string { return "JWKValidationError" }
+
+// Error satisfies the builtin error interface
+func (e JWKValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJWK.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JWKValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JWKValidationError{}
+
+// Validate checks the field values on GetJWKSResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetJWKSResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetJWKSResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetJWKSResponseMultiError, or nil if none found.
+func (m *GetJWKSResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetJWKSResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetKeys() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetJWKSResponseValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetJWKSResponseValidationError{
+						field:  fmt.Sprintf("Keys[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetJWKSResponseValidationError{
+					field:  fmt.Sprintf("Keys[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetJWKSResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetJWKSResponseMultiError is an error wrapping multiple validation errors
+// returned by GetJWKSResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetJWKSResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetJWKSResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetJWKSResponseMultiError) AllErrors() []error { return m }
+
+// GetJWKSResponseValidationError is the validation error returned by
+// GetJWKSResponse.Validate if the designated constraints aren't met.
+type GetJWKSResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetJWKSResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetJWKSResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetJWKSResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetJWKSResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetJWKSResponseValidationError) ErrorName() string { return "GetJWKSResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetJWKSResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetJWKSResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetJWKSResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetJWKSResponseValidationError{}
+
+// Validate checks the field values on UserInfo with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserInfoMultiError, or nil
+// if none found.
+func (m *UserInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Email
+
+	if len(errors) > 0 {
+		return UserInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserInfoMultiError is an error wrapping multiple validation errors returned
+// by UserInfo.ValidateAll() if the designated constraints aren't met.
+type UserInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserInfoMultiError) AllErrors() []error { return m }
+
+// UserInfoValidationError is the validation error returned by
+// UserInfo.Validate if the designated constraints aren't met.
+type UserInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserInfoValidationError) ErrorName() string { return "UserInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserInfoValidationError{}
+
+// Validate checks the field values on ListUsersRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUsersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUsersRequestMultiError, or nil if none found.
+func (m *ListUsersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUsersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Email
+
+	if val := m.GetLimit(); val < 0 || val > 500 {
+		err := ListUsersRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 500]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetOffset() < 0 {
+		err := ListUsersRequestValidationError{
+			field:  "Offset",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListUsersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUsersRequestMultiError is an error wrapping multiple validation errors
+// returned by ListUsersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListUsersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUsersRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUsersRequestMultiError) AllErrors() []error { return m }
+
+// ListUsersRequestValidationError is the validation error returned by
+// ListUsersRequest.Validate if the designated constraints aren't met.
+type ListUsersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUsersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUsersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUsersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUsersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUsersRequestValidationError) ErrorName() string { return "ListUsersRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListUsersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUsersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUsersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUsersRequestValidationError{}
+
+// Validate checks the field values on ListUsersResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUsersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUsersResponseMultiError, or nil if none found.
+func (m *ListUsersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUsersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUsersResponseValidationError{
+					field:  fmt.Sprintf("Users[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListUsersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUsersResponseMultiError is an error wrapping multiple validation errors
+// returned by ListUsersResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListUsersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUsersResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUsersResponseMultiError) AllErrors() []error { return m }
+
+// ListUsersResponseValidationError is the validation error returned by
+// ListUsersResponse.Validate if the designated constraints aren't met.
+type ListUsersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUsersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUsersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUsersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUsersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUsersResponseValidationError) ErrorName() string {
+	return "ListUsersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUsersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUsersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUsersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUsersResponseValidationError{}
+
+// Validate checks the field values on Role with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Role) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Role with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RoleMultiError, or nil if none found.
+func (m *Role) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Role) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Description
+
+	if len(errors) > 0 {
+		return RoleMultiError(errors)
+	}
+
+	return nil
+}
+
+// RoleMultiError is an error wrapping multiple validation errors returned by
+// Role.ValidateAll() if the designated constraints aren't met.
+type RoleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RoleMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RoleMultiError) AllErrors() []error { return m }
+
+// RoleValidationError is the validation error returned by Role.Validate if the
+// designated constraints aren't met.
+type RoleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RoleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RoleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RoleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RoleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RoleValidationError) ErrorName() string { return "RoleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RoleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRole.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RoleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RoleValidationError{}
+
+// Validate checks the field values on ListRolesRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListRolesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRolesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRolesRequestMultiError, or nil if none found.
+func (m *ListRolesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRolesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListRolesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListRolesRequestMultiError is an error wrapping multiple validation errors
+// returned by ListRolesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListRolesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRolesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRolesRequestMultiError) AllErrors() []error { return m }
+
+// ListRolesRequestValidationError is the validation error returned by
+// ListRolesRequest.Validate if the designated constraints aren't met.
+type ListRolesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRolesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRolesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRolesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRolesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRolesRequestValidationError) ErrorName() string { return "ListRolesRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListRolesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRolesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRolesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRolesRequestValidationError{}
+
+// Validate checks the field values on ListRolesResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListRolesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListRolesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListRolesResponseMultiError, or nil if none found.
+func (m *ListRolesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListRolesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRoles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListRolesResponseValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListRolesResponseValidationError{
+						field:  fmt.Sprintf("Roles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListRolesResponseValidationError{
+					field:  fmt.Sprintf("Roles[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListRolesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListRolesResponseMultiError is an error wrapping multiple validation errors
+// returned by ListRolesResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListRolesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListRolesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListRolesResponseMultiError) AllErrors() []error { return m }
+
+// ListRolesResponseValidationError is the validation error returned by
+// ListRolesResponse.Validate if the designated constraints aren't met.
+type ListRolesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListRolesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListRolesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListRolesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListRolesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListRolesResponseValidationError) ErrorName() string {
+	return "ListRolesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListRolesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListRolesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListRolesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListRolesResponseValidationError{}
+
+// Validate checks the field values on AssignRoleRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AssignRoleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AssignRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AssignRoleRequestMultiError, or nil if none found.
+func (m *AssignRoleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AssignRoleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := AssignRoleRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetRole()) < 1 {
+		err := AssignRoleRequestValidationError{
+			field:  "Role",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return AssignRoleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// AssignRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by AssignRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AssignRoleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AssignRoleRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AssignRoleRequestMultiError) AllErrors() []error { return m }
+
+// AssignRoleRequestValidationError is the validation error returned by
+// AssignRoleRequest.Validate if the designated constraints aren't met.
+type AssignRoleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AssignRoleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AssignRoleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AssignRoleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AssignRoleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AssignRoleRequestValidationError) ErrorName() string {
+	return "AssignRoleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AssignRoleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAssignRoleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AssignRoleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AssignRoleRequestValidationError{}
+
+// Validate checks the field values on AssignRoleResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AssignRoleResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AssignRoleResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AssignRoleResponseMultiError, or nil if none found.
+func (m *AssignRoleResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AssignRoleResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return AssignRoleResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// AssignRoleResponseMultiError is an error wrapping multiple validation errors
+// returned by AssignRoleResponse.ValidateAll() if the designated constraints
+// aren't met.
+type AssignRoleResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AssignRoleResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AssignRoleResponseMultiError) AllErrors() []error { return m }
+
+// AssignRoleResponseValidationError is the validation error returned by
+// AssignRoleResponse.Validate if the designated constraints aren't met.
+type AssignRoleResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AssignRoleResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AssignRoleResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AssignRoleResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AssignRoleResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AssignRoleResponseValidationError) ErrorName() string {
+	return "AssignRoleResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AssignRoleResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAssignRoleResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AssignRoleResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AssignRoleResponseValidationError{}
+
+// Validate checks the field values on RevokeRoleRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RevokeRoleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeRoleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeRoleRequestMultiError, or nil if none found.
+func (m *RevokeRoleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeRoleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := RevokeRoleRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetRole()) < 1 {
+		err := RevokeRoleRequestValidationError{
+			field:  "Role",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeRoleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeRoleRequestMultiError is an error wrapping multiple validation errors
+// returned by RevokeRoleRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RevokeRoleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeRoleRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeRoleRequestMultiError) AllErrors() []error { return m }
+
+// RevokeRoleRequestValidationError is the validation error returned by
+// RevokeRoleRequest.Validate if the designated constraints aren't met.
+type RevokeRoleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeRoleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeRoleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeRoleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeRoleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeRoleRequestValidationError) ErrorName() string {
+	return "RevokeRoleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeRoleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeRoleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeRoleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeRoleRequestValidationError{}
+
+// Validate checks the field values on RevokeRoleResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeRoleResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeRoleResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeRoleResponseMultiError, or nil if none found.
+func (m *RevokeRoleResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeRoleResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RevokeRoleResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeRoleResponseMultiError is an error wrapping multiple validation errors
+// returned by RevokeRoleResponse.ValidateAll() if the designated constraints
+// aren't met.
+type RevokeRoleResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeRoleResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeRoleResponseMultiError) AllErrors() []error { return m }
+
+// RevokeRoleResponseValidationError is the validation error returned by
+// RevokeRoleResponse.Validate if the designated constraints aren't met.
+type RevokeRoleResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeRoleResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeRoleResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeRoleResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeRoleResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeRoleResponseValidationError) ErrorName() string {
+	return "RevokeRoleResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeRoleResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeRoleResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeRoleResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeRoleResponseValidationError{}
+
+// Validate checks the field values on CheckPermissionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CheckPermissionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckPermissionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CheckPermissionRequestMultiError, or nil if none found.
+func (m *CheckPermissionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckPermissionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetUserId() <= 0 {
+		err := CheckPermissionRequestValidationError{
+			field:  "UserId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPermission()) < 1 {
+		err := CheckPermissionRequestValidationError{
+			field:  "Permission",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CheckPermissionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckPermissionRequestMultiError is an error wrapping multiple validation
+// errors returned by CheckPermissionRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CheckPermissionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckPermissionRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckPermissionRequestMultiError) AllErrors() []error { return m }
+
+// CheckPermissionRequestValidationError is the validation error returned by
+// CheckPermissionRequest.Validate if the designated constraints aren't met.
+type CheckPermissionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckPermissionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckPermissionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckPermissionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckPermissionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckPermissionRequestValidationError) ErrorName() string {
+	return "CheckPermissionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckPermissionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckPermissionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckPermissionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckPermissionRequestValidationError{}
+
+// Validate checks the field values on CheckPermissionResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CheckPermissionResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckPermissionResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CheckPermissionResponseMultiError, or nil if none found.
+func (m *CheckPermissionResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckPermissionResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Allowed
+
+	if len(errors) > 0 {
+		return CheckPermissionResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckPermissionResponseMultiError is an error wrapping multiple validation
+// errors returned by CheckPermissionResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CheckPermissionResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckPermissionResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckPermissionResponseMultiError) AllErrors() []error { return m }
+
+// CheckPermissionResponseValidationError is the validation error returned by
+// CheckPermissionResponse.Validate if the designated constraints aren't met.
+type CheckPermissionResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckPermissionResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckPermissionResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckPermissionResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckPermissionResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckPermissionResponseValidationError) ErrorName() string {
+	return "CheckPermissionResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckPermissionResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckPermissionResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckPermissionResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckPermissionResponseValidationError{}
+
+// Validate checks the field values on UnlockLoginRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UnlockLoginRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnlockLoginRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnlockLoginRequestMultiError, or nil if none found.
+func (m *UnlockLoginRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnlockLoginRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Email
+
+	// no validation rules for Ip
+
+	if len(errors) > 0 {
+		return UnlockLoginRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnlockLoginRequestMultiError is an error wrapping multiple validation errors
+// returned by UnlockLoginRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UnlockLoginRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnlockLoginRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnlockLoginRequestMultiError) AllErrors() []error { return m }
+
+// UnlockLoginRequestValidationError is the validation error returned by
+// UnlockLoginRequest.Validate if the designated constraints aren't met.
+type UnlockLoginRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnlockLoginRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnlockLoginRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnlockLoginRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnlockLoginRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnlockLoginRequestValidationError) ErrorName() string {
+	return "UnlockLoginRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnlockLoginRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnlockLoginRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnlockLoginRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnlockLoginRequestValidationError{}
+
+// Validate checks the field values on UnlockLoginResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UnlockLoginResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnlockLoginResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnlockLoginResponseMultiError, or nil if none found.
+func (m *UnlockLoginResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnlockLoginResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Unlocked
+
+	if len(errors) > 0 {
+		return UnlockLoginResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnlockLoginResponseMultiError is an error wrapping multiple validation
+// errors returned by UnlockLoginResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UnlockLoginResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnlockLoginResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnlockLoginResponseMultiError) AllErrors() []error { return m }
+
+// UnlockLoginResponseValidationError is the validation error returned by
+// UnlockLoginResponse.Validate if the designated constraints aren't met.
+type UnlockLoginResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnlockLoginResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnlockLoginResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnlockLoginResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnlockLoginResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnlockLoginResponseValidationError) ErrorName() string {
+	return "UnlockLoginResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnlockLoginResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnlockLoginResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnlockLoginResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnlockLoginResponseValidationError{}
+
+// Validate checks the field values on Invite with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Invite) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Invite with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in InviteMultiError, or nil if none found.
+func (m *Invite) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Invite) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Email
+
+	// no validation rules for CreatedBy
+
+	// no validation rules for CreatedAt
+
+	// no validation rules for ExpiresAt
+
+	// no validation rules for UsedBy
+
+	// no validation rules for UsedAt
+
+	if len(errors) > 0 {
+		return InviteMultiError(errors)
+	}
+
+	return nil
+}
+
+// InviteMultiError is an error wrapping multiple validation errors returned by
+// Invite.ValidateAll() if the designated constraints aren't met.
+type InviteMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m InviteMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m InviteMultiError) AllErrors() []error { return m }
+
+// InviteValidationError is the validation error returned by Invite.Validate if
+// the designated constraints aren't met.
+type InviteValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e InviteValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e InviteValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e InviteValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e InviteValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e InviteValidationError) ErrorName() string { return "InviteValidationError" }
+
+// Error satisfies the builtin error interface
+func (e InviteValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInvite.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = InviteValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = InviteValidationError{}
+
+// Validate checks the field values on CreateInviteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateInviteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateInviteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateInviteRequestMultiError, or nil if none found.
+func (m *CreateInviteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateInviteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Email
+
+	if len(m.GetRoles()) < 1 {
+		err := CreateInviteRequestValidationError{
+			field:  "Roles",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateInviteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateInviteRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateInviteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateInviteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateInviteRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateInviteRequestMultiError) AllErrors() []error { return m }
+
+// CreateInviteRequestValidationError is the validation error returned by
+// CreateInviteRequest.Validate if the designated constraints aren't met.
+type CreateInviteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateInviteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateInviteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateInviteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateInviteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateInviteRequestValidationError) ErrorName() string {
+	return "CreateInviteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateInviteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateInviteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateInviteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateInviteRequestValidationError{}
+
+// Validate checks the field values on CreateInviteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateInviteResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateInviteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateInviteResponseMultiError, or nil if none found.
+func (m *CreateInviteResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateInviteResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetInvite()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateInviteResponseValidationError{
+					field:  "Invite",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateInviteResponseValidationError{
+					field:  "Invite",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInvite()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateInviteResponseValidationError{
+				field:  "Invite",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Code
+
+	if len(errors) > 0 {
+		return CreateInviteResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateInviteResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateInviteResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateInviteResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateInviteResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateInviteResponseMultiError) AllErrors() []error { return m }
+
+// CreateInviteResponseValidationError is the validation error returned by
+// CreateInviteResponse.Validate if the designated constraints aren't met.
+type CreateInviteResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateInviteResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateInviteResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateInviteResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateInviteResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateInviteResponseValidationError) ErrorName() string {
+	return "CreateInviteResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateInviteResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateInviteResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateInviteResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateInviteResponseValidationError{}
+
+// Validate checks the field values on ListInvitesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListInvitesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListInvitesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListInvitesRequestMultiError, or nil if none found.
+func (m *ListInvitesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListInvitesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListInvitesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListInvitesRequestMultiError is an error wrapping multiple validation errors
+// returned by ListInvitesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListInvitesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListInvitesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListInvitesRequestMultiError) AllErrors() []error { return m }
+
+// ListInvitesRequestValidationError is the validation error returned by
+// ListInvitesRequest.Validate if the designated constraints aren't met.
+type ListInvitesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListInvitesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListInvitesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListInvitesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListInvitesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListInvitesRequestValidationError) ErrorName() string {
+	return "ListInvitesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListInvitesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListInvitesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListInvitesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListInvitesRequestValidationError{}
+
+// Validate checks the field values on ListInvitesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListInvitesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListInvitesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListInvitesResponseMultiError, or nil if none found.
+func (m *ListInvitesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListInvitesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetInvites() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListInvitesResponseValidationError{
+						field:  fmt.Sprintf("Invites[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListInvitesResponseValidationError{
+						field:  fmt.Sprintf("Invites[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListInvitesResponseValidationError{
+					field:  fmt.Sprintf("Invites[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListInvitesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListInvitesResponseMultiError is an error wrapping multiple validation
+// errors returned by ListInvitesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListInvitesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListInvitesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListInvitesResponseMultiError) AllErrors() []error { return m }
+
+// ListInvitesResponseValidationError is the validation error returned by
+// ListInvitesResponse.Validate if the designated constraints aren't met.
+type ListInvitesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListInvitesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListInvitesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListInvitesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListInvitesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListInvitesResponseValidationError) ErrorName() string {
+	return "ListInvitesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListInvitesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListInvitesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListInvitesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListInvitesResponseValidationError{}
+
+// Validate checks the field values on RevokeInviteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeInviteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeInviteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeInviteRequestMultiError, or nil if none found.
+func (m *RevokeInviteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeInviteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetInviteId() <= 0 {
+		err := RevokeInviteRequestValidationError{
+			field:  "InviteId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeInviteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeInviteRequestMultiError is an error wrapping multiple validation
+// errors returned by RevokeInviteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeInviteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeInviteRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeInviteRequestMultiError) AllErrors() []error { return m }
+
+// RevokeInviteRequestValidationError is the validation error returned by
+// RevokeInviteRequest.Validate if the designated constraints aren't met.
+type RevokeInviteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeInviteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeInviteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeInviteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeInviteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeInviteRequestValidationError) ErrorName() string {
+	return "RevokeInviteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeInviteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeInviteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeInviteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeInviteRequestValidationError{}
+
+// Validate checks the field values on RevokeInviteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeInviteResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeInviteResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeInviteResponseMultiError, or nil if none found.
+func (m *RevokeInviteResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeInviteResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RevokeInviteResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeInviteResponseMultiError is an error wrapping multiple validation
+// errors returned by RevokeInviteResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeInviteResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeInviteResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeInviteResponseMultiError) AllErrors() []error { return m }
+
+// RevokeInviteResponseValidationError is the validation error returned by
+// RevokeInviteResponse.Validate if the designated constraints aren't met.
+type RevokeInviteResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeInviteResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeInviteResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeInviteResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeInviteResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeInviteResponseValidationError) ErrorName() string {
+	return "RevokeInviteResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeInviteResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeInviteResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeInviteResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeInviteResponseValidationError{}
+
+// Validate checks the field values on App with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *App) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on App with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in AppMultiError, or nil if none found.
+func (m *App) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *App) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for CreatedAt
+
+	// no validation rules for PreviousSecretExpiresAt
+
+	// no validation rules for DisabledAt
+
+	if len(errors) > 0 {
+		return AppMultiError(errors)
+	}
+
+	return nil
+}
+
+// AppMultiError is an error wrapping multiple validation errors returned by
+// App.ValidateAll() if the designated constraints aren't met.
+type AppMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AppMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AppMultiError) AllErrors() []error { return m }
+
+// AppValidationError is the validation error returned by App.Validate if the
+// designated constraints aren't met.
+type AppValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AppValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AppValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AppValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AppValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AppValidationError) ErrorName() string { return "AppValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AppValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sApp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AppValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AppValidationError{}
+
+// Validate checks the field values on CreateAppRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateAppRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAppRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAppRequestMultiError, or nil if none found.
+func (m *CreateAppRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAppRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetName()) < 1 {
+		err := CreateAppRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateAppRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateAppRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateAppRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateAppRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAppRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAppRequestMultiError) AllErrors() []error { return m }
+
+// CreateAppRequestValidationError is the validation error returned by
+// CreateAppRequest.Validate if the designated constraints aren't met.
+type CreateAppRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAppRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAppRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAppRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAppRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAppRequestValidationError) ErrorName() string { return "CreateAppRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CreateAppRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAppRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAppRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAppRequestValidationError{}
+
+// Validate checks the field values on CreateAppResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateAppResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAppResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAppResponseMultiError, or nil if none found.
+func (m *CreateAppResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAppResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetApp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateAppResponseValidationError{
+					field:  "App",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateAppResponseValidationError{
+					field:  "App",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetApp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateAppResponseValidationError{
+				field:  "App",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Secret
+
+	if len(errors) > 0 {
+		return CreateAppResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateAppResponseMultiError is an error wrapping multiple validation errors
+// returned by CreateAppResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CreateAppResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAppResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAppResponseMultiError) AllErrors() []error { return m }
+
+// CreateAppResponseValidationError is the validation error returned by
+// CreateAppResponse.Validate if the designated constraints aren't met.
+type CreateAppResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAppResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAppResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAppResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAppResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAppResponseValidationError) ErrorName() string {
+	return "CreateAppResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateAppResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAppResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAppResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAppResponseValidationError{}
+
+// Validate checks the field values on ListAppsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListAppsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAppsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAppsRequestMultiError, or nil if none found.
+func (m *ListAppsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAppsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListAppsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAppsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListAppsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListAppsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAppsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAppsRequestMultiError) AllErrors() []error { return m }
+
+// ListAppsRequestValidationError is the validation error returned by
+// ListAppsRequest.Validate if the designated constraints aren't met.
+type ListAppsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAppsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAppsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAppsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAppsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAppsRequestValidationError) ErrorName() string { return "ListAppsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListAppsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAppsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAppsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAppsRequestValidationError{}
+
+// Validate checks the field values on ListAppsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListAppsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAppsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAppsResponseMultiError, or nil if none found.
+func (m *ListAppsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAppsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetApps() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAppsResponseValidationError{
+						field:  fmt.Sprintf("Apps[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAppsResponseValidationError{
+						field:  fmt.Sprintf("Apps[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAppsResponseValidationError{
+					field:  fmt.Sprintf("Apps[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAppsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAppsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListAppsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListAppsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAppsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAppsResponseMultiError) AllErrors() []error { return m }
+
+// ListAppsResponseValidationError is the validation error returned by
+// ListAppsResponse.Validate if the designated constraints aren't met.
+type ListAppsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAppsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAppsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAppsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAppsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAppsResponseValidationError) ErrorName() string { return "ListAppsResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListAppsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAppsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAppsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAppsResponseValidationError{}
+
+// Validate checks the field values on RotateAppSecretRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RotateAppSecretRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RotateAppSecretRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RotateAppSecretRequestMultiError, or nil if none found.
+func (m *RotateAppSecretRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RotateAppSecretRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAppId() <= 0 {
+		err := RotateAppSecretRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetGracePeriodSeconds() < 0 {
+		err := RotateAppSecretRequestValidationError{
+			field:  "GracePeriodSeconds",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RotateAppSecretRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RotateAppSecretRequestMultiError is an error wrapping multiple validation
+// errors returned by RotateAppSecretRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RotateAppSecretRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RotateAppSecretRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RotateAppSecretRequestMultiError) AllErrors() []error { return m }
+
+// RotateAppSecretRequestValidationError is the validation error returned by
+// RotateAppSecretRequest.Validate if the designated constraints aren't met.
+type RotateAppSecretRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RotateAppSecretRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RotateAppSecretRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RotateAppSecretRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RotateAppSecretRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RotateAppSecretRequestValidationError) ErrorName() string {
+	return "RotateAppSecretRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RotateAppSecretRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRotateAppSecretRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RotateAppSecretRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RotateAppSecretRequestValidationError{}
+
+// Validate checks the field values on RotateAppSecretResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RotateAppSecretResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RotateAppSecretResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RotateAppSecretResponseMultiError, or nil if none found.
+func (m *RotateAppSecretResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RotateAppSecretResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetApp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RotateAppSecretResponseValidationError{
+					field:  "App",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RotateAppSecretResponseValidationError{
+					field:  "App",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetApp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RotateAppSecretResponseValidationError{
+				field:  "App",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Secret
+
+	if len(errors) > 0 {
+		return RotateAppSecretResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RotateAppSecretResponseMultiError is an error wrapping multiple validation
+// errors returned by RotateAppSecretResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RotateAppSecretResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RotateAppSecretResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RotateAppSecretResponseMultiError) AllErrors() []error { return m }
+
+// RotateAppSecretResponseValidationError is the validation error returned by
+// RotateAppSecretResponse.Validate if the designated constraints aren't met.
+type RotateAppSecretResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RotateAppSecretResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RotateAppSecretResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RotateAppSecretResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RotateAppSecretResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RotateAppSecretResponseValidationError) ErrorName() string {
+	return "RotateAppSecretResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RotateAppSecretResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRotateAppSecretResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RotateAppSecretResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RotateAppSecretResponseValidationError{}
+
+// Validate checks the field values on DisableAppRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DisableAppRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DisableAppRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DisableAppRequestMultiError, or nil if none found.
+func (m *DisableAppRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DisableAppRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAppId() <= 0 {
+		err := DisableAppRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DisableAppRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DisableAppRequestMultiError is an error wrapping multiple validation errors
+// returned by DisableAppRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DisableAppRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DisableAppRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DisableAppRequestMultiError) AllErrors() []error { return m }
+
+// DisableAppRequestValidationError is the validation error returned by
+// DisableAppRequest.Validate if the designated constraints aren't met.
+type DisableAppRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DisableAppRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DisableAppRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DisableAppRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DisableAppRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DisableAppRequestValidationError) ErrorName() string {
+	return "DisableAppRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DisableAppRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDisableAppRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DisableAppRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DisableAppRequestValidationError{}
+
+// Validate checks the field values on DisableAppResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DisableAppResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DisableAppResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DisableAppResponseMultiError, or nil if none found.
+func (m *DisableAppResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DisableAppResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DisableAppResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DisableAppResponseMultiError is an error wrapping multiple validation errors
+// returned by DisableAppResponse.ValidateAll() if the designated constraints
+// aren't met.
+type DisableAppResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DisableAppResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DisableAppResponseMultiError) AllErrors() []error { return m }
+
+// DisableAppResponseValidationError is the validation error returned by
+// DisableAppResponse.Validate if the designated constraints aren't met.
+type DisableAppResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DisableAppResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DisableAppResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DisableAppResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DisableAppResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DisableAppResponseValidationError) ErrorName() string {
+	return "DisableAppResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DisableAppResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDisableAppResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DisableAppResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DisableAppResponseValidationError{}
+
+// Validate checks the field values on EnableAppRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *EnableAppRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EnableAppRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EnableAppRequestMultiError, or nil if none found.
+func (m *EnableAppRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EnableAppRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAppId() <= 0 {
+		err := EnableAppRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return EnableAppRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// EnableAppRequestMultiError is an error wrapping multiple validation errors
+// returned by EnableAppRequest.ValidateAll() if the designated constraints
+// aren't met.
+type EnableAppRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EnableAppRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EnableAppRequestMultiError) AllErrors() []error { return m }
+
+// EnableAppRequestValidationError is the validation error returned by
+// EnableAppRequest.Validate if the designated constraints aren't met.
+type EnableAppRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EnableAppRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EnableAppRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EnableAppRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EnableAppRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EnableAppRequestValidationError) ErrorName() string { return "EnableAppRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EnableAppRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEnableAppRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EnableAppRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EnableAppRequestValidationError{}
+
+// Validate checks the field values on EnableAppResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *EnableAppResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EnableAppResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EnableAppResponseMultiError, or nil if none found.
+func (m *EnableAppResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EnableAppResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return EnableAppResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EnableAppResponseMultiError is an error wrapping multiple validation errors
+// returned by EnableAppResponse.ValidateAll() if the designated constraints
+// aren't met.
+type EnableAppResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EnableAppResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EnableAppResponseMultiError) AllErrors() []error { return m }
+
+// EnableAppResponseValidationError is the validation error returned by
+// EnableAppResponse.Validate if the designated constraints aren't met.
+type EnableAppResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EnableAppResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EnableAppResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EnableAppResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EnableAppResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EnableAppResponseValidationError) ErrorName() string {
+	return "EnableAppResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EnableAppResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEnableAppResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EnableAppResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EnableAppResponseValidationError{}
+
+// Validate checks the field values on ChangePasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ChangePasswordRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ChangePasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ChangePasswordRequestMultiError, or nil if none found.
+func (m *ChangePasswordRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ChangePasswordRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := ChangePasswordRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAppId() <= 0 {
+		err := ChangePasswordRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCurrentPassword()) < 1 {
+		err := ChangePasswordRequestValidationError{
+			field:  "CurrentPassword",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetNewPassword()) < 6 {
+		err := ChangePasswordRequestValidationError{
+			field:  "NewPassword",
+			reason: "value length must be at least 6 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ChangePasswordRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ChangePasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by ChangePasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ChangePasswordRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ChangePasswordRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ChangePasswordRequestMultiError) AllErrors() []error { return m }
+
+// ChangePasswordRequestValidationError is the validation error returned by
+// ChangePasswordRequest.Validate if the designated constraints aren't met.
+type ChangePasswordRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ChangePasswordRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ChangePasswordRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ChangePasswordRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ChangePasswordRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ChangePasswordRequestValidationError) ErrorName() string {
+	return "ChangePasswordRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ChangePasswordRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sChangePasswordRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ChangePasswordRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ChangePasswordRequestValidationError{}
+
+// Validate checks the field values on ChangePasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ChangePasswordResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ChangePasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ChangePasswordResponseMultiError, or nil if none found.
+func (m *ChangePasswordResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ChangePasswordResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ChangePasswordResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ChangePasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by ChangePasswordResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ChangePasswordResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ChangePasswordResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ChangePasswordResponseMultiError) AllErrors() []error { return m }
+
+// ChangePasswordResponseValidationError is the validation error returned by
+// ChangePasswordResponse.Validate if the designated constraints aren't met.
+type ChangePasswordResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ChangePasswordResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ChangePasswordResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ChangePasswordResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ChangePasswordResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ChangePasswordResponseValidationError) ErrorName() string {
+	return "ChangePasswordResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ChangePasswordResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sChangePasswordResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ChangePasswordResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ChangePasswordResponseValidationError{}
+
+// Validate checks the field values on RequestPasswordResetRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestPasswordResetRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestPasswordResetRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestPasswordResetRequestMultiError, or nil if none found.
+func (m *RequestPasswordResetRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestPasswordResetRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateEmail(m.GetEmail()); err != nil {
+		err = RequestPasswordResetRequestValidationError{
+			field:  "Email",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RequestPasswordResetRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RequestPasswordResetRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RequestPasswordResetRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// RequestPasswordResetRequestMultiError is an error wrapping multiple
+// validation errors returned by RequestPasswordResetRequest.ValidateAll() if
+// the designated constraints aren't met.
+type RequestPasswordResetRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestPasswordResetRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestPasswordResetRequestMultiError) AllErrors() []error { return m }
+
+// RequestPasswordResetRequestValidationError is the validation error returned
+// by RequestPasswordResetRequest.Validate if the designated constraints
+// aren't met.
+type RequestPasswordResetRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestPasswordResetRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestPasswordResetRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestPasswordResetRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestPasswordResetRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestPasswordResetRequestValidationError) ErrorName() string {
+	return "RequestPasswordResetRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestPasswordResetRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestPasswordResetRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestPasswordResetRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestPasswordResetRequestValidationError{}
+
+// Validate checks the field values on RequestPasswordResetResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestPasswordResetResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RequestPasswordResetResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RequestPasswordResetResponseMultiError, or nil if none found.
+func (m *RequestPasswordResetResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RequestPasswordResetResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RequestPasswordResetResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RequestPasswordResetResponseMultiError is an error wrapping multiple
+// validation errors returned by RequestPasswordResetResponse.ValidateAll() if
+// the designated constraints aren't met.
+type RequestPasswordResetResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RequestPasswordResetResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RequestPasswordResetResponseMultiError) AllErrors() []error { return m }
+
+// RequestPasswordResetResponseValidationError is the validation error returned
+// by RequestPasswordResetResponse.Validate if the designated constraints
+// aren't met.
+type RequestPasswordResetResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RequestPasswordResetResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RequestPasswordResetResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RequestPasswordResetResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RequestPasswordResetResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RequestPasswordResetResponseValidationError) ErrorName() string {
+	return "RequestPasswordResetResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RequestPasswordResetResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRequestPasswordResetResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RequestPasswordResetResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RequestPasswordResetResponseValidationError{}
+
+// Validate checks the field values on ResetPasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResetPasswordRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResetPasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResetPasswordRequestMultiError, or nil if none found.
+func (m *ResetPasswordRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResetPasswordRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := ResetPasswordRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetNewPassword()) < 6 {
+		err := ResetPasswordRequestValidationError{
+			field:  "NewPassword",
+			reason: "value length must be at least 6 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ResetPasswordRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResetPasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by ResetPasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ResetPasswordRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResetPasswordRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResetPasswordRequestMultiError) AllErrors() []error { return m }
+
+// ResetPasswordRequestValidationError is the validation error returned by
+// ResetPasswordRequest.Validate if the designated constraints aren't met.
+type ResetPasswordRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResetPasswordRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResetPasswordRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResetPasswordRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResetPasswordRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResetPasswordRequestValidationError) ErrorName() string {
+	return "ResetPasswordRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResetPasswordRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResetPasswordRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResetPasswordRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResetPasswordRequestValidationError{}
+
+// Validate checks the field values on ResetPasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResetPasswordResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResetPasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResetPasswordResponseMultiError, or nil if none found.
+func (m *ResetPasswordResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResetPasswordResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ResetPasswordResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResetPasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by ResetPasswordResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ResetPasswordResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResetPasswordResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResetPasswordResponseMultiError) AllErrors() []error { return m }
+
+// ResetPasswordResponseValidationError is the validation error returned by
+// ResetPasswordResponse.Validate if the designated constraints aren't met.
+type ResetPasswordResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResetPasswordResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResetPasswordResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResetPasswordResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResetPasswordResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResetPasswordResponseValidationError) ErrorName() string {
+	return "ResetPasswordResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResetPasswordResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResetPasswordResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResetPasswordResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResetPasswordResponseValidationError{}
+
+// Validate checks the field values on VerifyEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyEmailRequestMultiError, or nil if none found.
+func (m *VerifyEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := VerifyEmailRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyEmailRequestMultiError is an error wrapping multiple validation errors
+// returned by VerifyEmailRequest.ValidateAll() if the designated constraints
+// aren't met.
+type VerifyEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyEmailRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyEmailRequestMultiError) AllErrors() []error { return m }
+
+// VerifyEmailRequestValidationError is the validation error returned by
+// VerifyEmailRequest.Validate if the designated constraints aren't met.
+type VerifyEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyEmailRequestValidationError) ErrorName() string {
+	return "VerifyEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyEmailRequestValidationError{}
+
+// Validate checks the field values on VerifyEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyEmailResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyEmailResponseMultiError, or nil if none found.
+func (m *VerifyEmailResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyEmailResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return VerifyEmailResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyEmailResponseMultiError is an error wrapping multiple validation
+// errors returned by VerifyEmailResponse.ValidateAll() if the designated
+// constraints aren't met.
+type VerifyEmailResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyEmailResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyEmailResponseMultiError) AllErrors() []error { return m }
+
+// VerifyEmailResponseValidationError is the validation error returned by
+// VerifyEmailResponse.Validate if the designated constraints aren't met.
+type VerifyEmailResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyEmailResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyEmailResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyEmailResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyEmailResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyEmailResponseValidationError) ErrorName() string {
+	return "VerifyEmailResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyEmailResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyEmailResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyEmailResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyEmailResponseValidationError{}
+
+// Validate checks the field values on ResendVerificationEmailRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResendVerificationEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResendVerificationEmailRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ResendVerificationEmailRequestMultiError, or nil if none found.
+func (m *ResendVerificationEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResendVerificationEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateEmail(m.GetEmail()); err != nil {
+		err = ResendVerificationEmailRequestValidationError{
+			field:  "Email",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ResendVerificationEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ResendVerificationEmailRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ResendVerificationEmailRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// ResendVerificationEmailRequestMultiError is an error wrapping multiple
+// validation errors returned by ResendVerificationEmailRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ResendVerificationEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResendVerificationEmailRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResendVerificationEmailRequestMultiError) AllErrors() []error { return m }
+
+// ResendVerificationEmailRequestValidationError is the validation error
+// returned by ResendVerificationEmailRequest.Validate if the designated
+// constraints aren't met.
+type ResendVerificationEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResendVerificationEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResendVerificationEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResendVerificationEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResendVerificationEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResendVerificationEmailRequestValidationError) ErrorName() string {
+	return "ResendVerificationEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResendVerificationEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResendVerificationEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResendVerificationEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResendVerificationEmailRequestValidationError{}
+
+// Validate checks the field values on ResendVerificationEmailResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResendVerificationEmailResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResendVerificationEmailResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ResendVerificationEmailResponseMultiError, or nil if none found.
+func (m *ResendVerificationEmailResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResendVerificationEmailResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ResendVerificationEmailResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResendVerificationEmailResponseMultiError is an error wrapping multiple
+// validation errors returned by ResendVerificationEmailResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ResendVerificationEmailResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResendVerificationEmailResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResendVerificationEmailResponseMultiError) AllErrors() []error { return m }
+
+// ResendVerificationEmailResponseValidationError is the validation error
+// returned by ResendVerificationEmailResponse.Validate if the designated
+// constraints aren't met.
+type ResendVerificationEmailResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResendVerificationEmailResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResendVerificationEmailResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResendVerificationEmailResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResendVerificationEmailResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResendVerificationEmailResponseValidationError) ErrorName() string {
+	return "ResendVerificationEmailResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResendVerificationEmailResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResendVerificationEmailResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResendVerificationEmailResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResendVerificationEmailResponseValidationError{}
+
+// Validate checks the field values on EnrollTOTPRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *EnrollTOTPRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EnrollTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EnrollTOTPRequestMultiError, or nil if none found.
+func (m *EnrollTOTPRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EnrollTOTPRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := EnrollTOTPRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAppId() <= 0 {
+		err := EnrollTOTPRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return EnrollTOTPRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// EnrollTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by EnrollTOTPRequest.ValidateAll() if the designated constraints
+// aren't met.
+type EnrollTOTPRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EnrollTOTPRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EnrollTOTPRequestMultiError) AllErrors() []error { return m }
+
+// EnrollTOTPRequestValidationError is the validation error returned by
+// EnrollTOTPRequest.Validate if the designated constraints aren't met.
+type EnrollTOTPRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EnrollTOTPRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EnrollTOTPRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EnrollTOTPRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EnrollTOTPRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EnrollTOTPRequestValidationError) ErrorName() string {
+	return "EnrollTOTPRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EnrollTOTPRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEnrollTOTPRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EnrollTOTPRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EnrollTOTPRequestValidationError{}
+
+// Validate checks the field values on EnrollTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EnrollTOTPResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EnrollTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EnrollTOTPResponseMultiError, or nil if none found.
+func (m *EnrollTOTPResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EnrollTOTPResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Secret
+
+	// no validation rules for OtpauthUri
+
+	if len(errors) > 0 {
+		return EnrollTOTPResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EnrollTOTPResponseMultiError is an error wrapping multiple validation errors
+// returned by EnrollTOTPResponse.ValidateAll() if the designated constraints
+// aren't met.
+type EnrollTOTPResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EnrollTOTPResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EnrollTOTPResponseMultiError) AllErrors() []error { return m }
+
+// EnrollTOTPResponseValidationError is the validation error returned by
+// EnrollTOTPResponse.Validate if the designated constraints aren't met.
+type EnrollTOTPResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EnrollTOTPResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EnrollTOTPResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EnrollTOTPResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EnrollTOTPResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EnrollTOTPResponseValidationError) ErrorName() string {
+	return "EnrollTOTPResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EnrollTOTPResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEnrollTOTPResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EnrollTOTPResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EnrollTOTPResponseValidationError{}
+
+// Validate checks the field values on ConfirmTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmTOTPRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmTOTPRequestMultiError, or nil if none found.
+func (m *ConfirmTOTPRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmTOTPRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := ConfirmTOTPRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAppId() <= 0 {
+		err := ConfirmTOTPRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCode()) != 6 {
+		err := ConfirmTOTPRequestValidationError{
+			field:  "Code",
+			reason: "value length must be 6 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+
+	}
+
+	if len(errors) > 0 {
+		return ConfirmTOTPRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by ConfirmTOTPRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ConfirmTOTPRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmTOTPRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmTOTPRequestMultiError) AllErrors() []error { return m }
+
+// ConfirmTOTPRequestValidationError is the validation error returned by
+// ConfirmTOTPRequest.Validate if the designated constraints aren't met.
+type ConfirmTOTPRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmTOTPRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmTOTPRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmTOTPRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmTOTPRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmTOTPRequestValidationError) ErrorName() string {
+	return "ConfirmTOTPRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfirmTOTPRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmTOTPRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmTOTPRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmTOTPRequestValidationError{}
+
+// Validate checks the field values on ConfirmTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmTOTPResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmTOTPResponseMultiError, or nil if none found.
+func (m *ConfirmTOTPResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmTOTPResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ConfirmTOTPResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmTOTPResponseMultiError is an error wrapping multiple validation
+// errors returned by ConfirmTOTPResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ConfirmTOTPResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmTOTPResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmTOTPResponseMultiError) AllErrors() []error { return m }
+
+// ConfirmTOTPResponseValidationError is the validation error returned by
+// ConfirmTOTPResponse.Validate if the designated constraints aren't met.
+type ConfirmTOTPResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmTOTPResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmTOTPResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmTOTPResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmTOTPResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmTOTPResponseValidationError) ErrorName() string {
+	return "ConfirmTOTPResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfirmTOTPResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmTOTPResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmTOTPResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmTOTPResponseValidationError{}
+
+// Validate checks the field values on DisableTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DisableTOTPRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DisableTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DisableTOTPRequestMultiError, or nil if none found.
+func (m *DisableTOTPRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DisableTOTPRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) < 1 {
+		err := DisableTOTPRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAppId() <= 0 {
+		err := DisableTOTPRequestValidationError{
+			field:  "AppId",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCode()) < 1 {
+		err := DisableTOTPRequestValidationError{
+			field:  "Code",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DisableTOTPRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DisableTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by DisableTOTPRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DisableTOTPRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DisableTOTPRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DisableTOTPRequestMultiError) AllErrors() []error { return m }
+
+// DisableTOTPRequestValidationError is the validation error returned by
+// DisableTOTPRequest.Validate if the designated constraints aren't met.
+type DisableTOTPRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DisableTOTPRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DisableTOTPRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DisableTOTPRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DisableTOTPRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DisableTOTPRequestValidationError) ErrorName() string {
+	return "DisableTOTPRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DisableTOTPRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDisableTOTPRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DisableTOTPRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DisableTOTPRequestValidationError{}
+
+// Validate checks the field values on DisableTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DisableTOTPResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DisableTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DisableTOTPResponseMultiError, or nil if none found.
+func (m *DisableTOTPResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DisableTOTPResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DisableTOTPResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DisableTOTPResponseMultiError is an error wrapping multiple validation
+// errors returned by DisableTOTPResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DisableTOTPResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DisableTOTPResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DisableTOTPResponseMultiError) AllErrors() []error { return m }
+
+// DisableTOTPResponseValidationError is the validation error returned by
+// DisableTOTPResponse.Validate if the designated constraints aren't met.
+type DisableTOTPResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DisableTOTPResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DisableTOTPResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DisableTOTPResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DisableTOTPResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DisableTOTPResponseValidationError) ErrorName() string {
+	return "DisableTOTPResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DisableTOTPResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDisableTOTPResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DisableTOTPResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DisableTOTPResponseValidationError{}
@@ -1,11 +1,18 @@
 package tests
 
 import (
-	ssov1 "github.com/Muaz717/protos_sso/gen/go/sso"
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"math/big"
 	"sso/tests/suite"
 	"testing"
 	"time"
@@ -14,7 +21,8 @@ import (
 const (
 	emptyAppID = 0
 	appID      = 1
-	appSecret  = "test_secret"
+	// appSecret is the secret of the test app from migrations/1_init_apps.up.sql
+	appSecret = "test-secret"
 
 	passDefaultLen = 10
 )
@@ -44,9 +52,12 @@ func TestRegisterLogin_Login_HappyPath(t *testing.T) {
 	token := respLogin.GetToken()
 	assert.NotEmpty(t, token)
 
+	// Tokens are signed with the app key published in JWKS, the "kid" header names the key
 	tokenParsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		return []byte(appSecret), nil
+		kid, _ := token.Header["kid"].(string)
+		return jwksKey(ctx, st, kid)
 	})
+	require.NoError(t, err)
 
 	claims, ok := tokenParsed.Claims.(jwt.MapClaims)
 	assert.True(t, ok)
@@ -60,6 +71,45 @@ func TestRegisterLogin_Login_HappyPath(t *testing.T) {
 	assert.InDelta(t, loginTime.Add(st.Cfg.TokenTTL).Unix(), int64(claims["exp"].(float64)), deltaSeconds)
 }
 
+// jwksKey returns the public key with the given id from the app's JWKS.
+func jwksKey(ctx context.Context, st *suite.Suite, kid string) (interface{}, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-app-secret", appSecret)
+
+	resp, err := st.AuthClient.GetJWKS(ctx, &ssov1.GetJWKSRequest{AppId: appID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, jwk := range resp.GetKeys() {
+		if jwk.GetKid() != kid {
+			continue
+		}
+
+		switch jwk.GetKty() {
+		case "OKP":
+			x, err := base64.RawURLEncoding.DecodeString(jwk.GetX())
+			if err != nil {
+				return nil, err
+			}
+			return ed25519.PublicKey(x), nil
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(jwk.GetN())
+			if err != nil {
+				return nil, err
+			}
+			e, err := base64.RawURLEncoding.DecodeString(jwk.GetE())
+			if err != nil {
+				return nil, err
+			}
+			return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+		default:
+			return nil, fmt.Errorf("unsupported key type %q", jwk.GetKty())
+		}
+	}
+
+	return nil, fmt.Errorf("key %q not found in JWKS", kid)
+}
+
 func randomFakePassword() string {
 	return gofakeit.Password(true, true, true, true, false, passDefaultLen)
 }
@@ -2,12 +2,11 @@ package suite
 
 import (
 	"context"
-	ssov1 "github.com/Muaz717/protos_sso/gen/go/sso"
+	"github.com/Muaz717/sso/app/internal/config"
+	ssov1 "github.com/Muaz717/sso/app/pkg/sso"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"net"
-	"sso/app/intenal/config"
-	"strconv"
 	"testing"
 )
 
@@ -25,7 +24,7 @@ func New(t *testing.T) (context.Context, *Suite) {
 	t.Helper()
 	t.Parallel()
 
-	cfg := config.MustLoadByPath("../config/local.yml")
+	cfg := config.MustLoadByPath("../config/local.yaml")
 
 	ctx, cancelCtx := context.WithTimeout(context.Background(), cfg.GRPC.Timeout)
 	t.Cleanup(func() {
@@ -49,5 +48,5 @@ func New(t *testing.T) (context.Context, *Suite) {
 }
 
 func grpcAddress(cfg *config.Config) string {
-	return net.JoinHostPort(gRPCHost, strconv.Itoa(cfg.GRPC.Port))
+	return net.JoinHostPort(gRPCHost, cfg.GRPC.Port)
 }